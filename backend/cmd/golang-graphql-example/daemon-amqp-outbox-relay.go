@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"
+
+	"github.com/samber/lo"
+)
+
+var amqpOutboxRelayDaemon = &daemonDefinition{
+	Run: amqpOutboxRelayDaemonRun,
+}
+
+func amqpOutboxRelayDaemonRun(ctx context.Context, targets []string, sv *services) {
+	// Relay only with server target as other targets are one shot database operations
+	if !lo.Contains(targets, "server") && !lo.Contains(targets, "all") {
+		return
+	}
+
+	// Check if amqp outbox is enabled
+	if sv.amqpOutboxSvc == nil || sv.cfgManager.GetConfig().AMQP.Outbox == nil {
+		return
+	}
+
+	sv.logger.Info("Starting amqp outbox relay")
+	// Run relay until context is done
+	err := sv.amqpOutboxSvc.Run(ctx)
+	// Check error
+	if err != nil {
+		sv.logger.Fatal(err)
+	}
+}
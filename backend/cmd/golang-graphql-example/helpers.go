@@ -1,21 +1,51 @@
 package main
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/config"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/server"
 )
 
 func GenerateInternalServer(sv *services) (*server.InternalServer, error) {
 	intSvr := server.NewInternalServer(sv.logger, sv.cfgManager, sv.metricsSvc, sv.signalHandlerSvc)
 
-	// Add checker for database
-	intSvr.AddChecker(&server.CheckerInput{
-		Name:     "database",
-		CheckFn:  sv.db.Ping,
-		Interval: 2 * time.Second, //nolint:mnd // Won't do a const for that
-		Timeout:  time.Second,
-	})
+	// Add checkers for each database connection
+	for _, name := range sv.dbRegistry.GetConnectionNames() {
+		// Get database
+		db, err := sv.dbRegistry.Get(name)
+		// Check error
+		if err != nil {
+			return nil, err
+		}
+
+		// Main connection keep historical checker names
+		prefix := "database"
+		// Check if it isn't the main connection
+		if name != config.MainDatabaseConnectionName {
+			prefix = "database-" + name
+		}
+
+		// Add checker for database
+		intSvr.AddChecker(&server.CheckerInput{
+			Name:     prefix,
+			CheckFn:  db.Ping,
+			Interval: 2 * time.Second, //nolint:mnd // Won't do a const for that
+			Timeout:  time.Second,
+		})
+		// Add health checker for each database replica
+		for i := range sv.cfgManager.GetConfig().GetDatabaseConfig(name).ReplicaConnectionURLs {
+			intSvr.AddChecker(&server.CheckerInput{
+				Name:     fmt.Sprintf("%s-replica-%d", prefix, i),
+				CheckFn:  func() error { return db.CheckReplica(i) },
+				Interval: 2 * time.Second, //nolint:mnd // Won't do a const for that
+				Timeout:  time.Second,
+				// Lagging replicas are ejected from reads, so they mustn't make the application unready
+				HealthOnly: true,
+			})
+		}
+	}
 	// Add checker for email service
 	intSvr.AddChecker(&server.CheckerInput{
 		Name:    "email",
@@ -13,12 +13,14 @@ import (
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/authx/authentication"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/authx/authorization"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/migration"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/config"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/email"
 	lockdistributor "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/lockdistributor/sql"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/log"
 	amqpbusmessage "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/messagebus/amqp"
+	amqpoutbox "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/messagebus/amqp/outbox"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/metrics"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/signalhandler"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/tracing"
@@ -33,11 +35,13 @@ type services struct {
 	// Basics
 	metricsSvc        metrics.Service
 	tracingSvc        tracing.Service
+	dbRegistry        database.Registry
 	db                database.DB
 	mailSvc           email.Service
 	ldSvc             lockdistributor.Service
 	signalHandlerSvc  signalhandler.Service
 	amqpSvc           amqpbusmessage.Service
+	amqpOutboxSvc     amqpoutbox.Service
 	authorizationSvc  authorization.Service
 	authenticationSvc authentication.Service
 	// Extra
@@ -47,13 +51,18 @@ type services struct {
 
 var targetDefinitionsMap = map[string]*targetDefinition{
 	// Basics
-	"migrate-db": migrateDBTarget,
-	"server":     serverTarget,
+	"check-db-schema": checkDBSchemaTarget,
+	"migrate-db":      migrateDBTarget,
+	"seed-db":         seedDBTarget,
+	"server":          serverTarget,
 	// Extra
 }
 
-// Those definitions are saving daemon definitions that will be launched with every target.
-var daemonDefinitions = []*daemonDefinition{}
+// Those definitions are saving daemon definitions that will be launched with every target after primary ones.
+// Daemons must check targets to know if they have to run.
+var daemonDefinitions = []*daemonDefinition{
+	amqpOutboxRelayDaemon,
+}
 
 // WaitGroup is used to wait for the program to finish goroutines.
 var (
@@ -87,6 +96,32 @@ func main() {
 		config.DefaultMainConfigFolderPath,
 		"Represents the configuration folder path",
 	)
+	// Create migrate db flags
+	flag.StringVar(
+		&migrateDBOpts.mode,
+		"migrate-db-mode",
+		string(migration.UpMode),
+		"Represents the migrate-db target mode (possible values:"+strings.Join(migrateDBModes, ",")+")",
+	)
+	flag.StringVar(
+		&migrateDBOpts.targetID,
+		"migrate-db-target-id",
+		"",
+		"Represents the migration id used by up-to and rollback-to migrate-db modes",
+	)
+	flag.BoolVar(
+		&migrateDBOpts.dryRun,
+		"migrate-db-dry-run",
+		false,
+		"Only print migrations that would be executed by the migrate-db target",
+	)
+	// Create seed db flags
+	flag.StringVar(
+		&seedDBOpts.environment,
+		"seed-db-environment",
+		defaultSeedDBEnvironment,
+		"Represents the fixture set environment loaded by the seed-db target",
+	)
 	// Parse flags
 	flag.Parse()
 
@@ -197,12 +232,19 @@ func main() {
 		}
 	}
 
+	// Start all primary targets
+	for _, tDef := range primaryList {
+		// Run
+		tDef.Run(targets, sv)
+	}
+
 	// Add count for daemon wait group
 	daemonWg.Add(len(daemonDefinitions))
 
 	// Create cancellable daemon context
 	dCtx, dCancel := context.WithCancel(context.TODO())
 
+	// Start daemons after primary targets to have a migrated database
 	for _, dDef := range daemonDefinitions {
 		go func(dDef *daemonDefinition) {
 			// Inform routine is completed
@@ -213,12 +255,6 @@ func main() {
 		}(dDef)
 	}
 
-	// Start all primary targets
-	for _, tDef := range primaryList {
-		// Run
-		tDef.Run(targets, sv)
-	}
-
 	// Add count of other targets for waiting group
 	wg.Add(len(otherList))
 
@@ -14,6 +14,7 @@ import (
 	lockdistributor "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/lockdistributor/sql"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/log"
 	amqpbusmessage "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/messagebus/amqp"
+	amqpoutbox "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/messagebus/amqp/outbox"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/metrics"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/signalhandler"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/tracing"
@@ -24,7 +25,7 @@ func setupExtraServices(_ []string, _ *services) {}
 
 func setupBusinessServices(_ []string, sv *services) {
 	// Create business services
-	busServices := business.NewServices(sv.logger, sv.db, sv.authorizationSvc)
+	busServices := business.NewServices(sv.logger, sv.db, sv.authorizationSvc, sv.ldSvc)
 	// Save
 	sv.busServices = busServices
 }
@@ -80,30 +81,36 @@ func setupBasicsServices(_ []string, sv *services) {
 	// Save
 	sv.tracingSvc = tracingSvc
 
-	// Create database service
-	db := database.NewDatabase("main", cfgManager, logger, metricsSvc, tracingSvc)
-	// Connect to engine
-	err = db.Connect()
+	// Create database registry
+	dbRegistry := database.NewRegistry(cfgManager, logger, metricsSvc, tracingSvc)
+	// Connect to all engines
+	err = dbRegistry.Connect()
 	// Check error
 	if err != nil {
 		logger.Fatal(err)
 	}
-	// Add configuration reload hook
-	cfgManager.AddOnChangeHook(&config.HookDefinition{
-		Hook:              db.Reconnect,
-		RetryCount:        3,                      //nolint:mnd
-		RetryWaitDuration: 200 * time.Millisecond, //nolint:mnd
-	})
+	// Add configuration reload hook per connection
+	for _, name := range dbRegistry.GetConnectionNames() {
+		cfgManager.AddOnChangeHook(&config.HookDefinition{
+			Hook:              func() error { return dbRegistry.Reload(name) },
+			RetryCount:        3,                      //nolint:mnd
+			RetryWaitDuration: 200 * time.Millisecond, //nolint:mnd
+		})
+	}
 
 	// Register closing database connections on system stop
 	signalHandlerSvc.OnExit(func() {
-		err = db.Close()
+		err = dbRegistry.Close()
 		// Check error
 		if err != nil {
 			logger.Fatal(err)
 		}
 	})
 	// Save
+	sv.dbRegistry = dbRegistry
+	// Main database is used by all services by default
+	db := dbRegistry.GetMain()
+	// Save
 	sv.db = db
 
 	// Create new mail service
@@ -189,6 +196,12 @@ func setupBasicsServices(_ []string, sv *services) {
 	// Save
 	sv.amqpSvc = amqpSvc
 
+	// Check if amqp have configuration set
+	if amqpSvc != nil {
+		// Create amqp outbox service
+		sv.amqpOutboxSvc = amqpoutbox.NewService(logger, cfgManager, db, amqpSvc, ld)
+	}
+
 	// Create authentication service
 	authoSvc := authorization.NewService(cfgManager)
 	// Save
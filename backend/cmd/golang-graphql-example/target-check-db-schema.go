@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"emperror.dev/errors"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/schemacheck"
+)
+
+// Check isn't part of "all" target as it exits in error on drift.
+var checkDBSchemaTarget = &targetDefinition{
+	Run:         checkDBSchemaTargetRun,
+	Primary:     true,
+	InAllTarget: false,
+}
+
+func checkDBSchemaTargetRun(_ []string, sv *services) {
+	// Add trace
+	ctx, trace := sv.tracingSvc.StartTrace(context.TODO(), "check-db-schema")
+	// Defer
+	defer trace.Finish()
+
+	// Check schema
+	report, err := sv.busServices.CheckDBSchema(ctx)
+	if err != nil {
+		// trace
+		trace.AddAndMarkError(err)
+
+		sv.logger.Fatal(err)
+	}
+
+	// Print machine readable report on standard output
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	// Encode
+	err = enc.Encode(report)
+	// Check error
+	if err != nil {
+		sv.logger.Fatal(errors.WithStack(err))
+	}
+
+	// Check if schema is valid
+	if !report.Valid {
+		err = errors.Wrapf(schemacheck.ErrSchemaDrift, "%d issue(s) found", len(report.Issues))
+		// trace
+		trace.AddAndMarkError(err)
+
+		sv.logger.Fatal(err)
+	}
+
+	sv.logger.Info("Database schema matches models")
+}
@@ -2,9 +2,34 @@ package main
 
 import (
 	"context"
+	"strings"
 	"time"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/migration"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/common/tenant"
 )
 
+// Status mode isn't a migration run mode as it is only displaying migrations.
+const migrateDBStatusMode = "status"
+
+// Possible migrate db modes.
+var migrateDBModes = []string{
+	string(migration.UpMode),
+	migrateDBStatusMode,
+	string(migration.UpToMode),
+	string(migration.RollbackLastMode),
+	string(migration.RollbackToMode),
+}
+
+type migrateDBOptions struct {
+	mode     string
+	targetID string
+	dryRun   bool
+}
+
+// Options filled by flags.
+var migrateDBOpts = &migrateDBOptions{}
+
 var migrateDBTarget = &targetDefinition{
 	Run:         migrateDBTargetRun,
 	Primary:     true,
@@ -24,13 +49,68 @@ func migrateDBTargetRun(targets []string, sv *services) {
 		}
 	}()
 
-	sv.logger.Info("Starting database migration")
+	// Check if it is a status request
+	if migrateDBOpts.mode == migrateDBStatusMode {
+		// Get status
+		list, err := sv.busServices.GetDBMigrationStatus(ctx)
+		// Check error
+		if err != nil {
+			// trace
+			trace.AddAndMarkError(err)
+
+			sv.logger.Fatal(err)
+		}
+
+		// Print status
+		for _, st := range list {
+			// Compute status
+			status := "pending"
+			if st.Applied {
+				status = "applied"
+			}
+
+			// Check if applied file have been modified
+			if st.ChecksumMismatch {
+				sv.logger.Warnf("Migration %s: %s, file have been modified since it was applied", st.ID, status)
+
+				continue
+			}
+
+			sv.logger.Infof("Migration %s: %s", st.ID, status)
+		}
+
+		return
+	}
+
+	// Run as default tenant to move existing rows of models that become tenant scoped
+	ctx = tenant.SetInContext(ctx, sv.cfgManager.GetConfig().Tenancy.DefaultTenant)
+
+	sv.logger.Infof("Starting database migration with mode %s", migrateDBOpts.mode)
 	// Migrate database
-	err := sv.busServices.MigrateDB(ctx)
+	ids, err := sv.busServices.RunDBMigration(ctx, &migration.RunInput{
+		Mode:     migration.Mode(migrateDBOpts.mode),
+		TargetID: migrateDBOpts.targetID,
+		DryRun:   migrateDBOpts.dryRun,
+	})
 	if err != nil {
 		// trace
 		trace.AddAndMarkError(err)
 
 		sv.logger.Fatal(err)
 	}
+
+	// Compute list
+	idList := "none"
+	if len(ids) != 0 {
+		idList = strings.Join(ids, ", ")
+	}
+
+	// Check if it is a dry run
+	if migrateDBOpts.dryRun {
+		sv.logger.Infof("Dry run, migrations that would be executed: %s", idList)
+
+		return
+	}
+
+	sv.logger.Infof("Database migration done, executed migrations: %s", idList)
 }
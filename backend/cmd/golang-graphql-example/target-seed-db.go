@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"time"
+)
+
+const defaultSeedDBEnvironment = "development"
+
+type seedDBOptions struct {
+	environment string
+}
+
+// Options filled by flags.
+var seedDBOpts = &seedDBOptions{}
+
+// Seed isn't part of "all" target to avoid loading fixtures in production.
+var seedDBTarget = &targetDefinition{
+	Run:         seedDBTargetRun,
+	Primary:     true,
+	InAllTarget: false,
+}
+
+func seedDBTargetRun(targets []string, sv *services) {
+	// Add trace
+	ctx, trace := sv.tracingSvc.StartTrace(context.TODO(), "seed-db")
+	// Defer
+	defer func() {
+		trace.Finish()
+		// Check targets
+		if len(targets) == 1 {
+			// Wait
+			time.Sleep(5 * time.Second) //nolint:mnd
+		}
+	}()
+
+	sv.logger.Infof("Starting database seed with environment %s", seedDBOpts.environment)
+	// Seed database
+	_, err := sv.busServices.SeedDB(ctx, seedDBOpts.environment)
+	if err != nil {
+		// trace
+		trace.AddAndMarkError(err)
+
+		sv.logger.Fatal(err)
+	}
+
+	sv.logger.Info("Database seed done")
+}
@@ -18,6 +18,7 @@ import (
 
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/authx/models"
 	cerrors "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/common/errors"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/common/tenant"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/common/utils"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/config"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/log"
@@ -356,10 +357,46 @@ func (s *service) Middleware(unauthorizedPathRegexList []*regexp.Regexp) gin.Han
 			return
 		}
 
+		// Check if tenant claim is configured
+		if cfg.OIDCAuthentication.TenantClaim != "" {
+			// Get all claims
+			var claims map[string]any
+			// Parse
+			err = idToken.Claims(&claims)
+			// Check error
+			if err != nil {
+				logger.Error(errors.WithStack(err))
+				// Flush potential cookie
+				flushAuthCookie(c, cfg)
+
+				redirectOrUnauthorized(c, unauthorizedPathRegexList)
+
+				return
+			}
+
+			// Get tenant
+			ouser.Tenant, err = getTenantFromClaims(claims, cfg.OIDCAuthentication.TenantClaim)
+			// Check error
+			if err != nil {
+				logger.Error(err)
+				utils.AnswerWithError(c, err)
+
+				return
+			}
+		} else if cfg.Tenancy != nil {
+			// Use default tenant as identity provider doesn't manage tenants
+			ouser.Tenant = cfg.Tenancy.DefaultTenant
+		}
+
+		// Get request context
+		ctx := SetAuthenticatedUserToContext(c.Request.Context(), &ouser)
+		// Check if tenant is set
+		if ouser.Tenant != "" {
+			ctx = tenant.SetInContext(ctx, ouser.Tenant)
+		}
+
 		// Create new request with new context
-		c.Request = c.Request.WithContext(
-			SetAuthenticatedUserToContext(c.Request.Context(), &ouser),
-		)
+		c.Request = c.Request.WithContext(ctx)
 		// Add it to gin context
 		SetAuthenticatedUserToGin(c, &ouser)
 
@@ -368,6 +405,17 @@ func (s *service) Middleware(unauthorizedPathRegexList []*regexp.Regexp) gin.Han
 	}
 }
 
+func getTenantFromClaims(claims map[string]any, claimName string) (string, error) {
+	// Get claim
+	v, ok := claims[claimName].(string)
+	// Check if claim exists and is valid
+	if !ok || v == "" {
+		return "", cerrors.NewForbiddenError(fmt.Sprintf("tenant claim %s not found or invalid in token", claimName))
+	}
+
+	return v, nil
+}
+
 func flushAuthCookie(c *gin.Context, cfg *config.Config) {
 	http.SetCookie(c.Writer, &http.Cookie{
 		Expires:  time.Unix(0, 0),
@@ -311,3 +311,49 @@ func Test_service_redirectOrUnauthorized(t *testing.T) {
 		})
 	}
 }
+
+func Test_getTenantFromClaims(t *testing.T) {
+	type args struct {
+		claims    map[string]any
+		claimName string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "claim found",
+			args: args{claims: map[string]any{"org": "tenant1"}, claimName: "org"},
+			want: "tenant1",
+		},
+		{
+			name:    "claim not found",
+			args:    args{claims: map[string]any{"email": "fake@fake.com"}, claimName: "org"},
+			wantErr: true,
+		},
+		{
+			name:    "claim empty",
+			args:    args{claims: map[string]any{"org": ""}, claimName: "org"},
+			wantErr: true,
+		},
+		{
+			name:    "claim not a string",
+			args:    args{claims: map[string]any{"org": []any{"tenant1"}}, claimName: "org"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getTenantFromClaims(tt.args.claims, tt.args.claimName)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getTenantFromClaims() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
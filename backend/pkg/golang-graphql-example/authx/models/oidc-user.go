@@ -7,6 +7,7 @@ type OIDCUser struct {
 	FamilyName        string `json:"family_name"`
 	Email             string `json:"email"`
 	OriginalToken     string `json:"-"`
+	Tenant            string `json:"-"`
 	EmailVerified     bool   `json:"email_verified"`
 }
 
@@ -0,0 +1,86 @@
+// Code generated by daogen, DO NOT EDIT.
+package daos
+
+import (
+	"context"
+	models0 "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/audits/models"
+	database "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
+	common "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/common"
+	helpers "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/helpers"
+	pagination "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/pagination"
+	"iter"
+)
+
+/* Interface */
+
+// Dao for structure AuditEvent
+type AuditEventStructureDao interface {
+	FindAuditEventByID(ctx context.Context, id string, projection *models0.Projection, opts ...helpers.GormOpt) (*models0.AuditEvent, error)
+	FindOneAuditEvent(ctx context.Context, sorts []*models0.SortOrder, filter *models0.Filter, projection *models0.Projection, opts ...helpers.GormOpt) (*models0.AuditEvent, error)
+	FindAuditEventWithPagination(ctx context.Context, page *pagination.PageInput, sorts []*models0.SortOrder, filter *models0.Filter, projection *models0.Projection, opts ...helpers.GormOpt) ([]*models0.AuditEvent, error)
+	FindAuditEventPaginated(ctx context.Context, page *pagination.PageInput, sorts []*models0.SortOrder, filter *models0.Filter, projection *models0.Projection, opts ...database.TransactionOption) ([]*models0.AuditEvent, *pagination.PageOutput, error)
+	FindAllAuditEvent(ctx context.Context, sorts []*models0.SortOrder, filter *models0.Filter, projection *models0.Projection, opts ...helpers.GormOpt) ([]*models0.AuditEvent, error)
+	IterateAuditEvent(ctx context.Context, batchSize int, sorts []*models0.SortOrder, filter *models0.Filter, projection *models0.Projection, opts ...database.TransactionOption) iter.Seq2[*models0.AuditEvent, error]
+	CountAuditEventPaginated(ctx context.Context, page *pagination.PageInput, filter *models0.Filter, opts ...helpers.GormOpt) (int64, error)
+	CountAuditEvent(ctx context.Context, filter *models0.Filter, opts ...helpers.GormOpt) (int64, error)
+	DistinctAuditEvent(ctx context.Context, page *pagination.PageInput, field string, filter *models0.Filter, search string, opts ...helpers.GormOpt) ([]*common.FieldValue, *pagination.PageOutput, error)
+}
+
+// General Dao
+type Dao interface {
+	AuditEventStructureDao
+}
+
+/* New */
+
+func NewDao(db database.DB) Dao {
+	return &dao{db: db}
+}
+
+/* Structure */
+
+type dao struct {
+	db database.DB
+}
+
+/* Functions */
+
+// Starting methods for AuditEvent structure
+
+func (d *dao) FindAuditEventByID(ctx context.Context, id string, projection *models0.Projection, opts ...helpers.GormOpt) (*models0.AuditEvent, error) {
+	return helpers.FindByID(ctx, &models0.AuditEvent{}, d.db, id, projection, opts...)
+}
+
+func (d *dao) FindOneAuditEvent(ctx context.Context, sorts []*models0.SortOrder, filter *models0.Filter, projection *models0.Projection, opts ...helpers.GormOpt) (*models0.AuditEvent, error) {
+	return helpers.FindOne(ctx, &models0.AuditEvent{}, d.db, sorts, filter, projection, opts...)
+}
+
+func (d *dao) FindAuditEventWithPagination(ctx context.Context, page *pagination.PageInput, sorts []*models0.SortOrder, filter *models0.Filter, projection *models0.Projection, opts ...helpers.GormOpt) ([]*models0.AuditEvent, error) {
+	return helpers.FindWithPagination(ctx, []*models0.AuditEvent{}, d.db, page, sorts, filter, projection, opts...)
+}
+
+func (d *dao) FindAuditEventPaginated(ctx context.Context, page *pagination.PageInput, sorts []*models0.SortOrder, filter *models0.Filter, projection *models0.Projection, opts ...database.TransactionOption) ([]*models0.AuditEvent, *pagination.PageOutput, error) {
+	return helpers.GetAllPaginated(ctx, []*models0.AuditEvent{}, d.db, page, sorts, filter, projection, opts...)
+}
+
+func (d *dao) FindAllAuditEvent(ctx context.Context, sorts []*models0.SortOrder, filter *models0.Filter, projection *models0.Projection, opts ...helpers.GormOpt) ([]*models0.AuditEvent, error) {
+	return helpers.Find(ctx, []*models0.AuditEvent{}, d.db, sorts, filter, projection, opts...)
+}
+
+func (d *dao) IterateAuditEvent(ctx context.Context, batchSize int, sorts []*models0.SortOrder, filter *models0.Filter, projection *models0.Projection, opts ...database.TransactionOption) iter.Seq2[*models0.AuditEvent, error] {
+	return helpers.Iterate[*models0.AuditEvent](ctx, d.db, batchSize, sorts, filter, projection, opts...)
+}
+
+func (d *dao) CountAuditEventPaginated(ctx context.Context, page *pagination.PageInput, filter *models0.Filter, opts ...helpers.GormOpt) (int64, error) {
+	return helpers.CountPaginated(ctx, d.db, &models0.AuditEvent{}, page, filter, opts...)
+}
+
+func (d *dao) CountAuditEvent(ctx context.Context, filter *models0.Filter, opts ...helpers.GormOpt) (int64, error) {
+	return helpers.Count(ctx, d.db, &models0.AuditEvent{}, filter, opts...)
+}
+
+func (d *dao) DistinctAuditEvent(ctx context.Context, page *pagination.PageInput, field string, filter *models0.Filter, search string, opts ...helpers.GormOpt) ([]*common.FieldValue, *pagination.PageOutput, error) {
+	return helpers.Distinct(ctx, d.db, &models0.AuditEvent{}, page, field, filter, search, opts...)
+}
+
+// Ending methods for AuditEvent structure
@@ -0,0 +1,3 @@
+package daos
+
+//go:generate mockgen -destination=./mocks/mock_Doa.go -package=mocks github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/audits/daos Dao
@@ -0,0 +1,228 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/audits/daos (interfaces: Dao)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock_Doa.go -package=mocks github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/audits/daos Dao
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	iter "iter"
+	reflect "reflect"
+
+	models "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/audits/models"
+	database "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
+	common "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/common"
+	databasehelpers "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/helpers"
+	pagination "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/pagination"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDao is a mock of Dao interface.
+type MockDao struct {
+	ctrl     *gomock.Controller
+	recorder *MockDaoMockRecorder
+	isgomock struct{}
+}
+
+// MockDaoMockRecorder is the mock recorder for MockDao.
+type MockDaoMockRecorder struct {
+	mock *MockDao
+}
+
+// NewMockDao creates a new mock instance.
+func NewMockDao(ctrl *gomock.Controller) *MockDao {
+	mock := &MockDao{ctrl: ctrl}
+	mock.recorder = &MockDaoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDao) EXPECT() *MockDaoMockRecorder {
+	return m.recorder
+}
+
+// CountAuditEvent mocks base method.
+func (m *MockDao) CountAuditEvent(ctx context.Context, filter *models.Filter, opts ...databasehelpers.GormOpt) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, filter}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CountAuditEvent", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAuditEvent indicates an expected call of CountAuditEvent.
+func (mr *MockDaoMockRecorder) CountAuditEvent(ctx, filter any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, filter}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAuditEvent", reflect.TypeOf((*MockDao)(nil).CountAuditEvent), varargs...)
+}
+
+// CountAuditEventPaginated mocks base method.
+func (m *MockDao) CountAuditEventPaginated(ctx context.Context, page *pagination.PageInput, filter *models.Filter, opts ...databasehelpers.GormOpt) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, page, filter}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CountAuditEventPaginated", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAuditEventPaginated indicates an expected call of CountAuditEventPaginated.
+func (mr *MockDaoMockRecorder) CountAuditEventPaginated(ctx, page, filter any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, page, filter}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAuditEventPaginated", reflect.TypeOf((*MockDao)(nil).CountAuditEventPaginated), varargs...)
+}
+
+// DistinctAuditEvent mocks base method.
+func (m *MockDao) DistinctAuditEvent(ctx context.Context, page *pagination.PageInput, field string, filter *models.Filter, search string, opts ...databasehelpers.GormOpt) ([]*common.FieldValue, *pagination.PageOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, page, field, filter, search}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistinctAuditEvent", varargs...)
+	ret0, _ := ret[0].([]*common.FieldValue)
+	ret1, _ := ret[1].(*pagination.PageOutput)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DistinctAuditEvent indicates an expected call of DistinctAuditEvent.
+func (mr *MockDaoMockRecorder) DistinctAuditEvent(ctx, page, field, filter, search any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, page, field, filter, search}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistinctAuditEvent", reflect.TypeOf((*MockDao)(nil).DistinctAuditEvent), varargs...)
+}
+
+// FindAllAuditEvent mocks base method.
+func (m *MockDao) FindAllAuditEvent(ctx context.Context, sorts []*models.SortOrder, filter *models.Filter, projection *models.Projection, opts ...databasehelpers.GormOpt) ([]*models.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, sorts, filter, projection}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FindAllAuditEvent", varargs...)
+	ret0, _ := ret[0].([]*models.AuditEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllAuditEvent indicates an expected call of FindAllAuditEvent.
+func (mr *MockDaoMockRecorder) FindAllAuditEvent(ctx, sorts, filter, projection any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, sorts, filter, projection}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllAuditEvent", reflect.TypeOf((*MockDao)(nil).FindAllAuditEvent), varargs...)
+}
+
+// FindAuditEventByID mocks base method.
+func (m *MockDao) FindAuditEventByID(ctx context.Context, id string, projection *models.Projection, opts ...databasehelpers.GormOpt) (*models.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, id, projection}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FindAuditEventByID", varargs...)
+	ret0, _ := ret[0].(*models.AuditEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAuditEventByID indicates an expected call of FindAuditEventByID.
+func (mr *MockDaoMockRecorder) FindAuditEventByID(ctx, id, projection any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, id, projection}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAuditEventByID", reflect.TypeOf((*MockDao)(nil).FindAuditEventByID), varargs...)
+}
+
+// FindAuditEventPaginated mocks base method.
+func (m *MockDao) FindAuditEventPaginated(ctx context.Context, page *pagination.PageInput, sorts []*models.SortOrder, filter *models.Filter, projection *models.Projection, opts ...database.TransactionOption) ([]*models.AuditEvent, *pagination.PageOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, page, sorts, filter, projection}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FindAuditEventPaginated", varargs...)
+	ret0, _ := ret[0].([]*models.AuditEvent)
+	ret1, _ := ret[1].(*pagination.PageOutput)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindAuditEventPaginated indicates an expected call of FindAuditEventPaginated.
+func (mr *MockDaoMockRecorder) FindAuditEventPaginated(ctx, page, sorts, filter, projection any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, page, sorts, filter, projection}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAuditEventPaginated", reflect.TypeOf((*MockDao)(nil).FindAuditEventPaginated), varargs...)
+}
+
+// FindAuditEventWithPagination mocks base method.
+func (m *MockDao) FindAuditEventWithPagination(ctx context.Context, page *pagination.PageInput, sorts []*models.SortOrder, filter *models.Filter, projection *models.Projection, opts ...databasehelpers.GormOpt) ([]*models.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, page, sorts, filter, projection}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FindAuditEventWithPagination", varargs...)
+	ret0, _ := ret[0].([]*models.AuditEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAuditEventWithPagination indicates an expected call of FindAuditEventWithPagination.
+func (mr *MockDaoMockRecorder) FindAuditEventWithPagination(ctx, page, sorts, filter, projection any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, page, sorts, filter, projection}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAuditEventWithPagination", reflect.TypeOf((*MockDao)(nil).FindAuditEventWithPagination), varargs...)
+}
+
+// FindOneAuditEvent mocks base method.
+func (m *MockDao) FindOneAuditEvent(ctx context.Context, sorts []*models.SortOrder, filter *models.Filter, projection *models.Projection, opts ...databasehelpers.GormOpt) (*models.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, sorts, filter, projection}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FindOneAuditEvent", varargs...)
+	ret0, _ := ret[0].(*models.AuditEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneAuditEvent indicates an expected call of FindOneAuditEvent.
+func (mr *MockDaoMockRecorder) FindOneAuditEvent(ctx, sorts, filter, projection any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, sorts, filter, projection}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneAuditEvent", reflect.TypeOf((*MockDao)(nil).FindOneAuditEvent), varargs...)
+}
+
+// IterateAuditEvent mocks base method.
+func (m *MockDao) IterateAuditEvent(ctx context.Context, batchSize int, sorts []*models.SortOrder, filter *models.Filter, projection *models.Projection, opts ...database.TransactionOption) iter.Seq2[*models.AuditEvent, error] {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, batchSize, sorts, filter, projection}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "IterateAuditEvent", varargs...)
+	ret0, _ := ret[0].(iter.Seq2[*models.AuditEvent, error])
+	return ret0
+}
+
+// IterateAuditEvent indicates an expected call of IterateAuditEvent.
+func (mr *MockDaoMockRecorder) IterateAuditEvent(ctx, batchSize, sorts, filter, projection any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, batchSize, sorts, filter, projection}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IterateAuditEvent", reflect.TypeOf((*MockDao)(nil).IterateAuditEvent), varargs...)
+}
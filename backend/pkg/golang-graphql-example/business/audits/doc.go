@@ -0,0 +1,3 @@
+package audits
+
+// This package will manage business of audit events
@@ -0,0 +1,57 @@
+package audits
+
+import (
+	"context"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/audits/daos"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/audits/models"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/pagination"
+)
+
+const AuditEventIDPrefix = "auditevents"
+
+//go:generate mockgen -destination=./mocks/mock_AuthorizationService.go -package=mocks github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/audits AuthorizationService
+type AuthorizationService interface {
+	CheckAuthorized(ctx context.Context, action, resource string) error
+}
+
+// EntityReadChecker will check that the entity can be read by the user in context.
+// An error must be returned when the entity doesn't exist or isn't readable.
+type EntityReadChecker func(ctx context.Context, id string) error
+
+// AuditedEntity declares an entity type which history can be read.
+type AuditedEntity struct {
+	// Check read authorization on entity
+	CheckRead EntityReadChecker
+	// Entity type (relay id prefix)
+	Type string
+	// Model name saved in audit events
+	ModelName string
+}
+
+//go:generate mockgen -destination=./mocks/mock_Service.go -package=mocks github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/audits Service
+type Service interface {
+	GetAllPaginated(
+		ctx context.Context,
+		page *pagination.PageInput,
+		entityType string,
+		entityID string,
+		sort []*models.SortOrder,
+		filter *models.Filter,
+		projection *models.Projection,
+	) ([]*models.AuditEvent, *pagination.PageOutput, error)
+}
+
+func NewService(db database.DB, authSvc AuthorizationService, entities []*AuditedEntity) Service {
+	// Create dao
+	dao := daos.NewDao(db)
+
+	// Index entities by type
+	entityMap := make(map[string]*AuditedEntity, len(entities))
+	for _, e := range entities {
+		entityMap[e.Type] = e
+	}
+
+	return &service{dao: dao, authSvc: authSvc, entities: entityMap}
+}
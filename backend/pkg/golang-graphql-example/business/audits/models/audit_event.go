@@ -0,0 +1,24 @@
+package models
+
+import (
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/audit"
+)
+
+//go:generate go run github.com/oxyno-zeta/golang-graphql-example/tools/generator/modeltagsgen github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/audits/models AuditEvent
+type AuditEvent struct {
+	database.BaseWithTenant
+	EntityID       string `gorm:"index"`
+	ModelName      string `gorm:"index"`
+	Action         string
+	UserIdentifier string
+	CorrelationID  string
+	// JSON object of changed fields values before change
+	Before string `gorm:"type:text"`
+	// JSON object of changed fields values after change
+	After string `gorm:"type:text"`
+}
+
+func (*AuditEvent) TableName() string {
+	return audit.TableName
+}
@@ -0,0 +1,430 @@
+// Code generated by ModelTags, DO NOT EDIT.
+package models
+
+import errors "emperror.dev/errors"
+
+// ErrAuditEventUnsupportedGormColumn will be thrown when an unsupported Gorm column will be found in transform function.
+var ErrAuditEventUnsupportedGormColumn = errors.Sentinel("unsupported gorm column")
+
+// ErrAuditEventUnsupportedJSONKey will be thrown when an unsupported JSON key will be found in transform function.
+var ErrAuditEventUnsupportedJSONKey = errors.Sentinel("unsupported json key")
+
+// ErrAuditEventUnsupportedStructKeyName will be thrown when an unsupported structure key will be found in transform function.
+var ErrAuditEventUnsupportedStructKeyName = errors.Sentinel("unsupported struct key")
+
+/* Gorm columns Names */
+// AuditEvent Action Gorm Column Name
+const AuditEventActionGormColumnName = "action"
+
+// AuditEvent After Gorm Column Name
+const AuditEventAfterGormColumnName = "after"
+
+// AuditEvent Before Gorm Column Name
+const AuditEventBeforeGormColumnName = "before"
+
+// AuditEvent CorrelationID Gorm Column Name
+const AuditEventCorrelationIDGormColumnName = "correlation_id"
+
+// AuditEvent CreatedAt Gorm Column Name
+const AuditEventCreatedAtGormColumnName = "created_at"
+
+// AuditEvent DeletedAt Gorm Column Name
+const AuditEventDeletedAtGormColumnName = "deleted_at"
+
+// AuditEvent EntityID Gorm Column Name
+const AuditEventEntityIDGormColumnName = "entity_id"
+
+// AuditEvent ID Gorm Column Name
+const AuditEventIDGormColumnName = "id"
+
+// AuditEvent ModelName Gorm Column Name
+const AuditEventModelNameGormColumnName = "model_name"
+
+// AuditEvent TenantID Gorm Column Name
+const AuditEventTenantIDGormColumnName = "tenant_id"
+
+// AuditEvent UpdatedAt Gorm Column Name
+const AuditEventUpdatedAtGormColumnName = "updated_at"
+
+// AuditEvent UserIdentifier Gorm Column Name
+const AuditEventUserIdentifierGormColumnName = "user_identifier"
+
+var AuditEventGormColumnNameList = []string{AuditEventActionGormColumnName, AuditEventAfterGormColumnName, AuditEventBeforeGormColumnName, AuditEventCorrelationIDGormColumnName, AuditEventCreatedAtGormColumnName, AuditEventDeletedAtGormColumnName, AuditEventEntityIDGormColumnName, AuditEventIDGormColumnName, AuditEventModelNameGormColumnName, AuditEventTenantIDGormColumnName, AuditEventUpdatedAtGormColumnName, AuditEventUserIdentifierGormColumnName}
+
+/* JSON Key Names */
+// AuditEvent Action JSON Key Name
+const AuditEventActionJSONKeyName = "Action"
+
+// AuditEvent After JSON Key Name
+const AuditEventAfterJSONKeyName = "After"
+
+// AuditEvent Before JSON Key Name
+const AuditEventBeforeJSONKeyName = "Before"
+
+// AuditEvent CorrelationID JSON Key Name
+const AuditEventCorrelationIDJSONKeyName = "CorrelationID"
+
+// AuditEvent CreatedAt JSON Key Name
+const AuditEventCreatedAtJSONKeyName = "createdAt"
+
+// AuditEvent DeletedAt JSON Key Name
+const AuditEventDeletedAtJSONKeyName = "deletedAt"
+
+// AuditEvent EntityID JSON Key Name
+const AuditEventEntityIDJSONKeyName = "EntityID"
+
+// AuditEvent ID JSON Key Name
+const AuditEventIDJSONKeyName = "id"
+
+// AuditEvent ModelName JSON Key Name
+const AuditEventModelNameJSONKeyName = "ModelName"
+
+// AuditEvent TenantID JSON Key Name
+const AuditEventTenantIDJSONKeyName = "tenantId"
+
+// AuditEvent UpdatedAt JSON Key Name
+const AuditEventUpdatedAtJSONKeyName = "updatedAt"
+
+// AuditEvent UserIdentifier JSON Key Name
+const AuditEventUserIdentifierJSONKeyName = "UserIdentifier"
+
+var AuditEventJSONKeyNameList = []string{AuditEventActionJSONKeyName, AuditEventAfterJSONKeyName, AuditEventBeforeJSONKeyName, AuditEventCorrelationIDJSONKeyName, AuditEventCreatedAtJSONKeyName, AuditEventDeletedAtJSONKeyName, AuditEventEntityIDJSONKeyName, AuditEventIDJSONKeyName, AuditEventModelNameJSONKeyName, AuditEventTenantIDJSONKeyName, AuditEventUpdatedAtJSONKeyName, AuditEventUserIdentifierJSONKeyName}
+
+/* Struct Key Names */
+// AuditEvent Action Struct Key Name
+const AuditEventActionStructKeyName = "Action"
+
+// AuditEvent After Struct Key Name
+const AuditEventAfterStructKeyName = "After"
+
+// AuditEvent Before Struct Key Name
+const AuditEventBeforeStructKeyName = "Before"
+
+// AuditEvent CorrelationID Struct Key Name
+const AuditEventCorrelationIDStructKeyName = "CorrelationID"
+
+// AuditEvent CreatedAt Struct Key Name
+const AuditEventCreatedAtStructKeyName = "CreatedAt"
+
+// AuditEvent DeletedAt Struct Key Name
+const AuditEventDeletedAtStructKeyName = "DeletedAt"
+
+// AuditEvent EntityID Struct Key Name
+const AuditEventEntityIDStructKeyName = "EntityID"
+
+// AuditEvent ID Struct Key Name
+const AuditEventIDStructKeyName = "ID"
+
+// AuditEvent ModelName Struct Key Name
+const AuditEventModelNameStructKeyName = "ModelName"
+
+// AuditEvent TenantID Struct Key Name
+const AuditEventTenantIDStructKeyName = "TenantID"
+
+// AuditEvent UpdatedAt Struct Key Name
+const AuditEventUpdatedAtStructKeyName = "UpdatedAt"
+
+// AuditEvent UserIdentifier Struct Key Name
+const AuditEventUserIdentifierStructKeyName = "UserIdentifier"
+
+var AuditEventStructKeyNameList = []string{AuditEventActionStructKeyName, AuditEventAfterStructKeyName, AuditEventBeforeStructKeyName, AuditEventCorrelationIDStructKeyName, AuditEventCreatedAtStructKeyName, AuditEventDeletedAtStructKeyName, AuditEventEntityIDStructKeyName, AuditEventIDStructKeyName, AuditEventModelNameStructKeyName, AuditEventTenantIDStructKeyName, AuditEventUpdatedAtStructKeyName, AuditEventUserIdentifierStructKeyName}
+
+// Transform AuditEvent Gorm Column To JSON Key
+func TransformAuditEventGormColumnToJSONKey(gormColumn string) (string, error) {
+	switch gormColumn {
+	case AuditEventActionGormColumnName:
+		return AuditEventActionJSONKeyName, nil
+	case AuditEventAfterGormColumnName:
+		return AuditEventAfterJSONKeyName, nil
+	case AuditEventBeforeGormColumnName:
+		return AuditEventBeforeJSONKeyName, nil
+	case AuditEventCorrelationIDGormColumnName:
+		return AuditEventCorrelationIDJSONKeyName, nil
+	case AuditEventCreatedAtGormColumnName:
+		return AuditEventCreatedAtJSONKeyName, nil
+	case AuditEventDeletedAtGormColumnName:
+		return AuditEventDeletedAtJSONKeyName, nil
+	case AuditEventEntityIDGormColumnName:
+		return AuditEventEntityIDJSONKeyName, nil
+	case AuditEventIDGormColumnName:
+		return AuditEventIDJSONKeyName, nil
+	case AuditEventModelNameGormColumnName:
+		return AuditEventModelNameJSONKeyName, nil
+	case AuditEventTenantIDGormColumnName:
+		return AuditEventTenantIDJSONKeyName, nil
+	case AuditEventUpdatedAtGormColumnName:
+		return AuditEventUpdatedAtJSONKeyName, nil
+	case AuditEventUserIdentifierGormColumnName:
+		return AuditEventUserIdentifierJSONKeyName, nil
+	default:
+		return "", errors.WithStack(ErrAuditEventUnsupportedGormColumn)
+	}
+}
+
+// Transform AuditEvent JSON Key To Gorm Column
+func TransformAuditEventJSONKeyToGormColumn(jsonKey string) (string, error) {
+	switch jsonKey {
+	case AuditEventActionJSONKeyName:
+		return AuditEventActionGormColumnName, nil
+	case AuditEventAfterJSONKeyName:
+		return AuditEventAfterGormColumnName, nil
+	case AuditEventBeforeJSONKeyName:
+		return AuditEventBeforeGormColumnName, nil
+	case AuditEventCorrelationIDJSONKeyName:
+		return AuditEventCorrelationIDGormColumnName, nil
+	case AuditEventCreatedAtJSONKeyName:
+		return AuditEventCreatedAtGormColumnName, nil
+	case AuditEventDeletedAtJSONKeyName:
+		return AuditEventDeletedAtGormColumnName, nil
+	case AuditEventEntityIDJSONKeyName:
+		return AuditEventEntityIDGormColumnName, nil
+	case AuditEventIDJSONKeyName:
+		return AuditEventIDGormColumnName, nil
+	case AuditEventModelNameJSONKeyName:
+		return AuditEventModelNameGormColumnName, nil
+	case AuditEventTenantIDJSONKeyName:
+		return AuditEventTenantIDGormColumnName, nil
+	case AuditEventUpdatedAtJSONKeyName:
+		return AuditEventUpdatedAtGormColumnName, nil
+	case AuditEventUserIdentifierJSONKeyName:
+		return AuditEventUserIdentifierGormColumnName, nil
+	default:
+		return "", errors.WithStack(ErrAuditEventUnsupportedJSONKey)
+	}
+}
+
+// Transform AuditEvent JSON Key map To Gorm Column map
+func TransformAuditEventJSONKeyMapToGormColumnMap(input map[string]any, ignoreUnsupportedError bool) (map[string]any, error) {
+	m := map[string]any{}
+
+	for k, v := range input {
+		r, err := TransformAuditEventJSONKeyToGormColumn(k)
+		if err != nil {
+			if ignoreUnsupportedError && errors.Is(err, ErrAuditEventUnsupportedJSONKey) {
+				continue
+			}
+			return nil, err
+		}
+		m[r] = v
+	}
+
+	return m, nil
+}
+
+// Transform AuditEvent Gorm Column map To JSON Key map
+func TransformAuditEventGormColumnMapToJSONKeyMap(input map[string]any, ignoreUnsupportedError bool) (map[string]any, error) {
+	m := map[string]any{}
+
+	for k, v := range input {
+		r, err := TransformAuditEventGormColumnToJSONKey(k)
+		if err != nil {
+			if ignoreUnsupportedError && errors.Is(err, ErrAuditEventUnsupportedGormColumn) {
+				continue
+			}
+			return nil, err
+		}
+		m[r] = v
+	}
+
+	return m, nil
+}
+
+// Transform AuditEvent Gorm Column To Struct Key Name
+func TransformAuditEventGormColumnToStructKeyName(gormColumn string) (string, error) {
+	switch gormColumn {
+	case AuditEventActionGormColumnName:
+		return AuditEventActionStructKeyName, nil
+	case AuditEventAfterGormColumnName:
+		return AuditEventAfterStructKeyName, nil
+	case AuditEventBeforeGormColumnName:
+		return AuditEventBeforeStructKeyName, nil
+	case AuditEventCorrelationIDGormColumnName:
+		return AuditEventCorrelationIDStructKeyName, nil
+	case AuditEventCreatedAtGormColumnName:
+		return AuditEventCreatedAtStructKeyName, nil
+	case AuditEventDeletedAtGormColumnName:
+		return AuditEventDeletedAtStructKeyName, nil
+	case AuditEventEntityIDGormColumnName:
+		return AuditEventEntityIDStructKeyName, nil
+	case AuditEventIDGormColumnName:
+		return AuditEventIDStructKeyName, nil
+	case AuditEventModelNameGormColumnName:
+		return AuditEventModelNameStructKeyName, nil
+	case AuditEventTenantIDGormColumnName:
+		return AuditEventTenantIDStructKeyName, nil
+	case AuditEventUpdatedAtGormColumnName:
+		return AuditEventUpdatedAtStructKeyName, nil
+	case AuditEventUserIdentifierGormColumnName:
+		return AuditEventUserIdentifierStructKeyName, nil
+	default:
+		return "", errors.WithStack(ErrAuditEventUnsupportedGormColumn)
+	}
+}
+
+// Transform AuditEvent Struct Key Name To Gorm Column
+func TransformAuditEventStructKeyNameToGormColumn(structKey string) (string, error) {
+	switch structKey {
+	case AuditEventActionStructKeyName:
+		return AuditEventActionGormColumnName, nil
+	case AuditEventAfterStructKeyName:
+		return AuditEventAfterGormColumnName, nil
+	case AuditEventBeforeStructKeyName:
+		return AuditEventBeforeGormColumnName, nil
+	case AuditEventCorrelationIDStructKeyName:
+		return AuditEventCorrelationIDGormColumnName, nil
+	case AuditEventCreatedAtStructKeyName:
+		return AuditEventCreatedAtGormColumnName, nil
+	case AuditEventDeletedAtStructKeyName:
+		return AuditEventDeletedAtGormColumnName, nil
+	case AuditEventEntityIDStructKeyName:
+		return AuditEventEntityIDGormColumnName, nil
+	case AuditEventIDStructKeyName:
+		return AuditEventIDGormColumnName, nil
+	case AuditEventModelNameStructKeyName:
+		return AuditEventModelNameGormColumnName, nil
+	case AuditEventTenantIDStructKeyName:
+		return AuditEventTenantIDGormColumnName, nil
+	case AuditEventUpdatedAtStructKeyName:
+		return AuditEventUpdatedAtGormColumnName, nil
+	case AuditEventUserIdentifierStructKeyName:
+		return AuditEventUserIdentifierGormColumnName, nil
+	default:
+		return "", errors.WithStack(ErrAuditEventUnsupportedStructKeyName)
+	}
+}
+
+// Transform AuditEvent Struct Key Name map To Gorm Column map
+func TransformAuditEventStructKeyNameMapToGormColumnMap(input map[string]any, ignoreUnsupportedError bool) (map[string]any, error) {
+	m := map[string]any{}
+
+	for k, v := range input {
+		r, err := TransformAuditEventStructKeyNameToGormColumn(k)
+		if err != nil {
+			if ignoreUnsupportedError && errors.Is(err, ErrAuditEventUnsupportedStructKeyName) {
+				continue
+			}
+			return nil, err
+		}
+		m[r] = v
+	}
+
+	return m, nil
+}
+
+// Transform AuditEvent Gorm Column map To Struct Key Name map
+func TransformAuditEventGormColumnMapToStructKeyNameMap(input map[string]any, ignoreUnsupportedError bool) (map[string]any, error) {
+	m := map[string]any{}
+
+	for k, v := range input {
+		r, err := TransformAuditEventGormColumnToStructKeyName(k)
+		if err != nil {
+			if ignoreUnsupportedError && errors.Is(err, ErrAuditEventUnsupportedGormColumn) {
+				continue
+			}
+			return nil, err
+		}
+		m[r] = v
+	}
+
+	return m, nil
+}
+
+// Transform AuditEvent JSON Key To Struct Key Name
+func TransformAuditEventJSONKeyToStructKeyName(jsonKey string) (string, error) {
+	switch jsonKey {
+	case AuditEventActionJSONKeyName:
+		return AuditEventActionStructKeyName, nil
+	case AuditEventAfterJSONKeyName:
+		return AuditEventAfterStructKeyName, nil
+	case AuditEventBeforeJSONKeyName:
+		return AuditEventBeforeStructKeyName, nil
+	case AuditEventCorrelationIDJSONKeyName:
+		return AuditEventCorrelationIDStructKeyName, nil
+	case AuditEventCreatedAtJSONKeyName:
+		return AuditEventCreatedAtStructKeyName, nil
+	case AuditEventDeletedAtJSONKeyName:
+		return AuditEventDeletedAtStructKeyName, nil
+	case AuditEventEntityIDJSONKeyName:
+		return AuditEventEntityIDStructKeyName, nil
+	case AuditEventIDJSONKeyName:
+		return AuditEventIDStructKeyName, nil
+	case AuditEventModelNameJSONKeyName:
+		return AuditEventModelNameStructKeyName, nil
+	case AuditEventTenantIDJSONKeyName:
+		return AuditEventTenantIDStructKeyName, nil
+	case AuditEventUpdatedAtJSONKeyName:
+		return AuditEventUpdatedAtStructKeyName, nil
+	case AuditEventUserIdentifierJSONKeyName:
+		return AuditEventUserIdentifierStructKeyName, nil
+	default:
+		return "", errors.WithStack(ErrAuditEventUnsupportedJSONKey)
+	}
+}
+
+// Transform AuditEvent Struct Key Name To JSON Key
+func TransformAuditEventStructKeyNameToJSONKey(structKey string) (string, error) {
+	switch structKey {
+	case AuditEventActionStructKeyName:
+		return AuditEventActionStructKeyName, nil
+	case AuditEventAfterStructKeyName:
+		return AuditEventAfterStructKeyName, nil
+	case AuditEventBeforeStructKeyName:
+		return AuditEventBeforeStructKeyName, nil
+	case AuditEventCorrelationIDStructKeyName:
+		return AuditEventCorrelationIDStructKeyName, nil
+	case AuditEventCreatedAtStructKeyName:
+		return AuditEventCreatedAtStructKeyName, nil
+	case AuditEventDeletedAtStructKeyName:
+		return AuditEventDeletedAtStructKeyName, nil
+	case AuditEventEntityIDStructKeyName:
+		return AuditEventEntityIDStructKeyName, nil
+	case AuditEventIDStructKeyName:
+		return AuditEventIDStructKeyName, nil
+	case AuditEventModelNameStructKeyName:
+		return AuditEventModelNameStructKeyName, nil
+	case AuditEventTenantIDStructKeyName:
+		return AuditEventTenantIDStructKeyName, nil
+	case AuditEventUpdatedAtStructKeyName:
+		return AuditEventUpdatedAtStructKeyName, nil
+	case AuditEventUserIdentifierStructKeyName:
+		return AuditEventUserIdentifierStructKeyName, nil
+	default:
+		return "", errors.WithStack(ErrAuditEventUnsupportedStructKeyName)
+	}
+}
+
+// Transform AuditEvent Struct Key Name map To JSON Key map
+func TransformAuditEventStructKeyNameMapToJSONKeyMap(input map[string]any, ignoreUnsupportedError bool) (map[string]any, error) {
+	m := map[string]any{}
+
+	for k, v := range input {
+		r, err := TransformAuditEventStructKeyNameToJSONKey(k)
+		if err != nil {
+			if ignoreUnsupportedError && errors.Is(err, ErrAuditEventUnsupportedStructKeyName) {
+				continue
+			}
+			return nil, err
+		}
+		m[r] = v
+	}
+
+	return m, nil
+}
+
+// Transform AuditEvent JSON Key map To Struct Key Name map
+func TransformAuditEventJSONKeyMapToStructKeyNameMap(input map[string]any, ignoreUnsupportedError bool) (map[string]any, error) {
+	m := map[string]any{}
+
+	for k, v := range input {
+		r, err := TransformAuditEventJSONKeyToStructKeyName(k)
+		if err != nil {
+			if ignoreUnsupportedError && errors.Is(err, ErrAuditEventUnsupportedJSONKey) {
+				continue
+			}
+			return nil, err
+		}
+		m[r] = v
+	}
+
+	return m, nil
+}
@@ -0,0 +1,3 @@
+package models
+
+// This package will manage audit event models
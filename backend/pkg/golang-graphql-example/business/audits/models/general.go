@@ -0,0 +1,32 @@
+package models
+
+import "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/common"
+
+type SortOrder struct {
+	CreatedAt *common.SortOrderEnum `dbfield:"created_at"`
+	ModelName *common.SortOrderEnum `dbfield:"model_name"`
+	Action    *common.SortOrderEnum `dbfield:"action"`
+}
+
+type Filter struct {
+	CreatedAt      *common.DateFilter    `dbfield:"created_at"`
+	EntityID       *common.GenericFilter `dbfield:"entity_id"`
+	ModelName      *common.GenericFilter `dbfield:"model_name"`
+	Action         *common.GenericFilter `dbfield:"action"`
+	UserIdentifier *common.GenericFilter `dbfield:"user_identifier"`
+	CorrelationID  *common.GenericFilter `dbfield:"correlation_id"`
+	AND            []*Filter
+	OR             []*Filter
+}
+
+type Projection struct {
+	ID             bool `dbfield:"id"              graphqlfield:"id"`
+	CreatedAt      bool `dbfield:"created_at"      graphqlfield:"createdAt"`
+	EntityID       bool `dbfield:"entity_id"       graphqlfield:"entityId"`
+	ModelName      bool `dbfield:"model_name"      graphqlfield:"modelName"`
+	Action         bool `dbfield:"action"          graphqlfield:"action"`
+	UserIdentifier bool `dbfield:"user_identifier" graphqlfield:"userIdentifier"`
+	CorrelationID  bool `dbfield:"correlation_id"  graphqlfield:"correlationId"`
+	Before         bool `dbfield:"before"          graphqlfield:"before"`
+	After          bool `dbfield:"after"           graphqlfield:"after"`
+}
@@ -0,0 +1,72 @@
+package audits
+
+import (
+	"context"
+	"fmt"
+
+	"emperror.dev/errors"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/audits/daos"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/audits/models"
+	cerrors "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/common/errors"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/common"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/pagination"
+)
+
+const mainAuthorizationPrefix = "audit"
+
+// ErrUnsupportedEntityType is returned when history is asked for an entity type that isn't audited.
+var ErrUnsupportedEntityType = errors.Sentinel("unsupported audited entity type")
+
+type service struct {
+	dao      daos.Dao
+	authSvc  AuthorizationService
+	entities map[string]*AuditedEntity
+}
+
+func (s *service) GetAllPaginated(
+	ctx context.Context,
+	page *pagination.PageInput,
+	entityType string,
+	entityID string,
+	sort []*models.SortOrder,
+	filter *models.Filter,
+	projection *models.Projection,
+) ([]*models.AuditEvent, *pagination.PageOutput, error) {
+	// Check authorization
+	err := s.authSvc.CheckAuthorized(
+		ctx,
+		fmt.Sprintf("%s:%s", mainAuthorizationPrefix, "List"),
+		fmt.Sprintf("%s:%s", mainAuthorizationPrefix, entityID),
+	)
+	// Check error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Get audited entity
+	entity, ok := s.entities[entityType]
+	// Check if it exists
+	if !ok {
+		return nil, nil, cerrors.NewInvalidInputError(ErrUnsupportedEntityType.Error())
+	}
+
+	// Check that entity can be read
+	err = entity.CheckRead(ctx, entityID)
+	// Check error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Scope filter on entity
+	f := &models.Filter{
+		EntityID:  &common.GenericFilter{Eq: entityID},
+		ModelName: &common.GenericFilter{Eq: entity.ModelName},
+	}
+	// Check if a filter is given
+	if filter != nil {
+		f.AND = []*models.Filter{filter}
+	}
+
+	return s.dao.FindAuditEventPaginated(ctx, page, sort, f, projection)
+}
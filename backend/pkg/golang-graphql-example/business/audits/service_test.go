@@ -0,0 +1,117 @@
+//go:build unit
+
+package audits
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	daomocks "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/audits/daos/mocks"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/audits/mocks"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/audits/models"
+	cerrors "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/common/errors"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/common"
+)
+
+func Test_service_GetAllPaginated(t *testing.T) {
+	readErr := cerrors.NewNotFoundError("entity not found")
+	authErr := cerrors.NewForbiddenError("forbidden")
+	inputFilter := &models.Filter{Action: &common.GenericFilter{Eq: "UPDATE"}}
+
+	tests := []struct {
+		name           string
+		entityType     string
+		filter         *models.Filter
+		authErr        error
+		readErr        error
+		expectedFilter *models.Filter
+		wantErr        error
+	}{
+		{
+			name:       "unsupported entity type",
+			entityType: "fake",
+			wantErr:    cerrors.NewInvalidInputError(ErrUnsupportedEntityType.Error()),
+		},
+		{
+			name:       "not authorized",
+			entityType: "todos",
+			authErr:    authErr,
+			wantErr:    authErr,
+		},
+		{
+			name:       "entity not readable",
+			entityType: "todos",
+			readErr:    readErr,
+			wantErr:    readErr,
+		},
+		{
+			name:       "without filter",
+			entityType: "todos",
+			expectedFilter: &models.Filter{
+				EntityID:  &common.GenericFilter{Eq: "id1"},
+				ModelName: &common.GenericFilter{Eq: "Todo"},
+			},
+		},
+		{
+			name:       "with filter",
+			entityType: "todos",
+			filter:     inputFilter,
+			expectedFilter: &models.Filter{
+				EntityID:  &common.GenericFilter{Eq: "id1"},
+				ModelName: &common.GenericFilter{Eq: "Todo"},
+				AND:       []*models.Filter{inputFilter},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			daoMock := daomocks.NewMockDao(ctrl)
+			authMock := mocks.NewMockAuthorizationService(ctrl)
+
+			ctx := context.TODO()
+
+			authMock.EXPECT().CheckAuthorized(ctx, "audit:List", "audit:id1").Return(tt.authErr)
+
+			readCalled := false
+			s := &service{
+				dao:     daoMock,
+				authSvc: authMock,
+				entities: map[string]*AuditedEntity{
+					"todos": {
+						Type:      "todos",
+						ModelName: "Todo",
+						CheckRead: func(_ context.Context, id string) error {
+							readCalled = true
+
+							assert.Equal(t, "id1", id)
+
+							return tt.readErr
+						},
+					},
+				},
+			}
+
+			if tt.expectedFilter != nil {
+				daoMock.EXPECT().FindAuditEventPaginated(ctx, nil, nil, tt.expectedFilter, nil).Return([]*models.AuditEvent{}, nil, nil)
+			}
+
+			got, _, err := s.GetAllPaginated(ctx, nil, tt.entityType, "id1", nil, tt.filter, nil)
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+				assert.Nil(t, got)
+				assert.Equal(t, tt.readErr != nil, readCalled)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, readCalled)
+			assert.Equal(t, []*models.AuditEvent{}, got)
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package fixtures
+
+// This package will load fixtures (seed data) in database.
+//
+// Fixture sets are folders of yaml or json files. Embedded sets are stored
+// in the sets folder, one folder per environment. Each file is a map of
+// kinds (see kinds.go) containing a map of named fixtures with their fields.
+// Fields are column or go field names of the model.
+//
+// A string value like "@todo1.id" is a reference to the field of another
+// fixture of the set, whatever the file or the declaration order. A value
+// starting with "@@" is escaped and will be saved with a single "@".
+//
+// Fixtures are upserted on primary key and a stable id is generated from the
+// fixture name when it isn't declared, so seeding many times is idempotent.
+//
+// Tenant scoped fixtures are saved with their declared tenant_id or with the
+// tenant in context when it isn't declared.
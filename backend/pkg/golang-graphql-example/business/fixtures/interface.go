@@ -0,0 +1,48 @@
+package fixtures
+
+import (
+	"context"
+	"io/fs"
+
+	"emperror.dev/errors"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
+)
+
+// ErrInvalidFixture is returned when a fixture file or a fixture cannot be loaded.
+var ErrInvalidFixture = errors.Sentinel("invalid fixture")
+
+// ErrFixtureSetNotFound is returned when an embedded fixture set doesn't exist for an environment.
+var ErrFixtureSetNotFound = errors.Sentinel("fixture set not found")
+
+// Result contains loaded fixtures by name.
+type Result struct {
+	objects map[string]any
+	ids     map[string]string
+}
+
+// Get will return the loaded object of a fixture or nil if it doesn't exist.
+func (r *Result) Get(name string) any {
+	return r.objects[name]
+}
+
+// GetID will return the primary key of a fixture or an empty string if it doesn't exist.
+func (r *Result) GetID(name string) string {
+	return r.ids[name]
+}
+
+// Service will represent the service that will load fixtures in database.
+type Service interface {
+	// Seed will load the embedded fixture set of an environment.
+	Seed(ctx context.Context, environment string) (*Result, error)
+	// Load will load the fixture set stored in a folder of a file system.
+	// All fixtures are loaded in a single transaction.
+	Load(ctx context.Context, fsys fs.FS, dir string) (*Result, error)
+}
+
+func New(dbSvc database.DB) Service {
+	return &service{
+		dbSvc: dbSvc,
+		setFS: embeddedSets,
+	}
+}
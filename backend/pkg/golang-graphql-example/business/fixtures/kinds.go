@@ -0,0 +1,38 @@
+package fixtures
+
+import (
+	"context"
+
+	tododaos "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/todos/daos"
+	todomodels "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/todos/models"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
+)
+
+// kindDefinition will describe how to create and save objects of a fixture kind.
+type kindDefinition struct {
+	newObject func() any
+	// Upsert object on primary key and only update given columns on conflict
+	upsert func(ctx context.Context, dbSvc database.DB, obj any, updateColumns []string) error
+}
+
+// Supported fixture kinds.
+var kindDefinitions = map[string]*kindDefinition{
+	"todos": newKindDefinition(
+		func(ctx context.Context, dbSvc database.DB, input []*todomodels.Todo, updateColumns []string) error {
+			_, err := tododaos.NewDao(dbSvc).UpsertTodo(ctx, input, 0, nil, updateColumns)
+
+			return err
+		},
+	),
+}
+
+func newKindDefinition[T any](
+	upsert func(ctx context.Context, dbSvc database.DB, input []*T, updateColumns []string) error,
+) *kindDefinition {
+	return &kindDefinition{
+		newObject: func() any { return new(T) },
+		upsert: func(ctx context.Context, dbSvc database.DB, obj any, updateColumns []string) error {
+			return upsert(ctx, dbSvc, []*T{obj.(*T)}, updateColumns) //nolint:forcetypeassert // Created by newObject
+		},
+	}
+}
@@ -0,0 +1,339 @@
+package fixtures
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path"
+	"reflect"
+	"regexp"
+	"slices"
+	"sync"
+
+	"emperror.dev/errors"
+	"github.com/gofrs/uuid/v5"
+	"github.com/samber/lo"
+	"go.yaml.in/yaml/v3"
+	"gorm.io/gorm/schema"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/common/tenant"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
+)
+
+// Embedded fixture sets, one folder per environment.
+//
+//go:embed sets
+var embeddedSets embed.FS
+
+const setsDir = "sets"
+
+// Columns always updated on conflict to refresh and restore rows.
+var alwaysUpdatedColumns = []string{"updated_at", "deleted_at"}
+
+var referenceRegexp = regexp.MustCompile(`^@([A-Za-z0-9_-]+)\.([A-Za-z0-9_]+)$`)
+
+// Namespace used to generate stable ids from fixture names.
+var idNamespace = uuid.NewV5(uuid.NamespaceURL, "golang-graphql-example/fixtures")
+
+type service struct {
+	dbSvc database.DB
+	setFS fs.FS
+}
+
+// fixture is a named fixture of a set.
+type fixture struct {
+	// Loaded object
+	object any
+	fields map[string]any
+	kind   string
+	name   string
+	id     string
+	// Used to detect circular references
+	loading bool
+}
+
+// loader will load fixtures of a set and resolve references between them.
+type loader struct {
+	dbSvc       database.DB
+	fixtures    map[string]*fixture
+	schemaCache *sync.Map
+}
+
+func (s *service) Seed(ctx context.Context, environment string) (*Result, error) {
+	// Check that environment is a single folder name
+	if environment == "." || !fs.ValidPath(environment) {
+		return nil, errors.Wrapf(ErrFixtureSetNotFound, "environment %q", environment)
+	}
+
+	// Get set directory
+	dir := path.Join(setsDir, environment)
+
+	// Check that set exists
+	st, err := fs.Stat(s.setFS, dir)
+	// Check error
+	if err != nil || !st.IsDir() {
+		return nil, errors.Wrapf(ErrFixtureSetNotFound, "environment %q", environment)
+	}
+
+	return s.Load(ctx, s.setFS, dir)
+}
+
+func (s *service) Load(ctx context.Context, fsys fs.FS, dir string) (*Result, error) {
+	// Read fixtures
+	fixtures, err := readFixtures(fsys, dir)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	l := &loader{
+		dbSvc:       s.dbSvc,
+		fixtures:    fixtures,
+		schemaCache: &sync.Map{},
+	}
+
+	// Load all fixtures in a transaction
+	err = s.dbSvc.ExecuteTransaction(ctx, func(ctx context.Context) error {
+		// Loop over fixtures in a stable order
+		for _, name := range slices.Sorted(maps.Keys(fixtures)) {
+			// Load
+			err := l.load(ctx, fixtures[name])
+			// Check error
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		objects: lo.MapValues(fixtures, func(f *fixture, _ string) any { return f.object }),
+		ids:     lo.MapValues(fixtures, func(f *fixture, _ string) string { return f.id }),
+	}, nil
+}
+
+// readFixtures will read yaml and json files of a folder and return fixtures by name.
+func readFixtures(fsys fs.FS, dir string) (map[string]*fixture, error) {
+	// Read directory
+	entries, err := fs.ReadDir(fsys, dir)
+	// Check error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	res := map[string]*fixture{}
+	// Loop over entries
+	for _, entry := range entries {
+		// Get extension
+		ext := path.Ext(entry.Name())
+		// Ignore directories and other files
+		if entry.IsDir() || !slices.Contains([]string{".yaml", ".yml", ".json"}, ext) {
+			continue
+		}
+
+		// Read file
+		b, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		// Check error
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		// Fixtures by kind and name
+		var content map[string]map[string]map[string]any
+		// Decode
+		if ext == ".json" {
+			err = json.Unmarshal(b, &content)
+		} else {
+			err = yaml.Unmarshal(b, &content)
+		}
+		// Check error
+		if err != nil {
+			return nil, errors.Wrapf(ErrInvalidFixture, "file %s cannot be decoded: %s", entry.Name(), err)
+		}
+
+		// Loop over kinds
+		for kind, list := range content {
+			// Check that kind is supported
+			if kindDefinitions[kind] == nil {
+				return nil, errors.Wrapf(ErrInvalidFixture, "file %s contains unsupported kind %q", entry.Name(), kind)
+			}
+
+			// Loop over fixtures
+			for name, fields := range list {
+				// Check that name is unique in set
+				if res[name] != nil {
+					return nil, errors.Wrapf(ErrInvalidFixture, "fixture %q is duplicated", name)
+				}
+
+				res[name] = &fixture{kind: kind, name: name, fields: fields}
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// load will upsert a fixture after having loaded the fixtures it references.
+func (l *loader) load(ctx context.Context, f *fixture) error {
+	// Check if it is already loaded
+	if f.object != nil {
+		return nil
+	}
+
+	// Check circular references
+	if f.loading {
+		return errors.Wrapf(ErrInvalidFixture, "fixture %q has a circular reference", f.name)
+	}
+
+	f.loading = true
+
+	// Get kind definition
+	kd := kindDefinitions[f.kind]
+	// Create object
+	obj := kd.newObject()
+
+	// Parse schema
+	sch, err := schema.Parse(obj, l.schemaCache, l.dbSvc.GetGormDB().NamingStrategy)
+	// Check error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	rv := reflect.ValueOf(obj)
+	updateColumns := []string{}
+
+	// Loop over fields in a stable order
+	for _, key := range slices.Sorted(maps.Keys(f.fields)) {
+		// Get field
+		field := sch.LookUpField(key)
+		// Check if it exists
+		if field == nil {
+			return errors.Wrapf(ErrInvalidFixture, "fixture %q have an unknown field %q", f.name, key)
+		}
+
+		// Resolve value
+		v, err := l.resolve(ctx, f.fields[key])
+		// Check error
+		if err != nil {
+			return err
+		}
+
+		// Set value
+		err = field.Set(ctx, rv, v)
+		// Check error
+		if err != nil {
+			return errors.Wrapf(ErrInvalidFixture, "fixture %q field %q cannot be set: %s", f.name, key, err)
+		}
+
+		// Primary keys are the conflict target
+		if !field.PrimaryKey {
+			updateColumns = append(updateColumns, field.DBName)
+		}
+	}
+
+	// Add columns always updated when model have them
+	for _, c := range alwaysUpdatedColumns {
+		// Check if column exists and isn't already added
+		if sch.LookUpField(c) != nil && !slices.Contains(updateColumns, c) {
+			updateColumns = append(updateColumns, c)
+		}
+	}
+
+	// Get primary key
+	pf := sch.PrioritizedPrimaryField
+	// Check if it exists
+	if pf == nil {
+		return errors.Wrapf(ErrInvalidFixture, "fixture %q kind %q doesn't have a primary key", f.name, f.kind)
+	}
+
+	// Generate a stable id when it isn't declared
+	if _, zero := pf.ValueOf(ctx, rv); zero && pf.DataType == schema.String {
+		err = pf.Set(ctx, rv, uuid.NewV5(idNamespace, f.kind+"/"+f.name).String())
+		// Check error
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	// Run as the declared tenant to allow loading objects of several tenants
+	if ts, ok := obj.(database.TenantScoped); ok && ts.GetTenantID() != "" {
+		ctx = tenant.SetInContext(ctx, ts.GetTenantID())
+	}
+
+	// Upsert
+	err = kd.upsert(ctx, l.dbSvc, obj, updateColumns)
+	// Check error
+	if err != nil {
+		return err
+	}
+
+	// Save
+	pk, _ := pf.ValueOf(ctx, rv)
+	f.id = fmt.Sprint(pk)
+	f.object = obj
+	f.loading = false
+
+	return nil
+}
+
+// resolve will return the referenced value when the input is a reference.
+func (l *loader) resolve(ctx context.Context, v any) (any, error) {
+	// Check if it is a string
+	s, ok := v.(string)
+	if !ok {
+		return v, nil
+	}
+
+	// Check if it is escaped
+	if len(s) > 1 && s[:2] == "@@" {
+		return s[1:], nil
+	}
+
+	// Parse reference
+	matches := referenceRegexp.FindStringSubmatch(s)
+	// Check if it is a reference
+	if matches == nil {
+		return v, nil
+	}
+
+	// Get referenced fixture
+	target := l.fixtures[matches[1]]
+	// Check if it exists
+	if target == nil {
+		return nil, errors.Wrapf(ErrInvalidFixture, "reference %s targets an unknown fixture", s)
+	}
+
+	// Load it
+	err := l.load(ctx, target)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse schema
+	sch, err := schema.Parse(target.object, l.schemaCache, l.dbSvc.GetGormDB().NamingStrategy)
+	// Check error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	// Get field
+	field := sch.LookUpField(matches[2])
+	// Check if it exists
+	if field == nil {
+		return nil, errors.Wrapf(ErrInvalidFixture, "reference %s targets an unknown field", s)
+	}
+
+	// Get value
+	res, _ := field.ValueOf(ctx, reflect.ValueOf(target.object))
+
+	return res, nil
+}
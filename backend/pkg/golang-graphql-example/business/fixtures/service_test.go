@@ -0,0 +1,216 @@
+//go:build unit
+
+package fixtures
+
+import (
+	"context"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+
+	todomodels "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/todos/models"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/common/tenant"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/dbtest"
+	dbmocks "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/mocks"
+)
+
+func setupTestService(t *testing.T) (*service, *gorm.DB) {
+	t.Helper()
+
+	db := dbtest.NewSQLiteDB(t, &todomodels.Todo{})
+
+	ctrl := gomock.NewController(t)
+
+	dbSvc := dbmocks.NewMockDB(ctrl)
+	dbSvc.EXPECT().GetGormDB().AnyTimes().Return(db)
+	dbSvc.EXPECT().GetTransactionalOrDefaultGormDB(gomock.Any()).AnyTimes().Return(db)
+	dbSvc.EXPECT().ExecuteTransaction(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(ctx context.Context, cb func(context.Context) error, _ ...any) error {
+			return cb(ctx)
+		},
+	)
+
+	return &service{dbSvc: dbSvc, setFS: embeddedSets}, db
+}
+
+func TestService_Load(t *testing.T) {
+	s, db := setupTestService(t)
+	ctx := tenant.SetInContext(context.TODO(), "tenant1")
+
+	fsys := fstest.MapFS{
+		"fixtures/a.yaml": {Data: []byte(`
+todos:
+  todo2:
+    text: "@todo1.id"
+  todo3:
+    text: "@@todo1.id"
+    done: "@todo1.done"
+`)},
+		"fixtures/b.json":    {Data: []byte(`{"todos": {"todo1": {"Text": "first", "done": true}}}`)},
+		"fixtures/README.md": {Data: []byte("ignored")},
+	}
+
+	res, err := s.Load(ctx, fsys, "fixtures")
+	require.NoError(t, err)
+
+	todo1, ok := res.Get("todo1").(*todomodels.Todo)
+	require.True(t, ok)
+	assert.Equal(t, "first", todo1.Text)
+	assert.True(t, todo1.Done)
+	assert.Equal(t, todo1.ID, res.GetID("todo1"))
+	assert.NotEmpty(t, todo1.ID)
+
+	todo2, ok := res.Get("todo2").(*todomodels.Todo)
+	require.True(t, ok)
+	assert.Equal(t, todo1.ID, todo2.Text)
+
+	todo3, ok := res.Get("todo3").(*todomodels.Todo)
+	require.True(t, ok)
+	assert.Equal(t, "@todo1.id", todo3.Text)
+	assert.True(t, todo3.Done)
+
+	assert.Nil(t, res.Get("fake"))
+	assert.Empty(t, res.GetID("fake"))
+
+	// Modify and soft delete to check that seeding again restores fixtures
+	require.NoError(t, db.Model(&todomodels.Todo{}).Where("id = ?", todo1.ID).Update("text", "modified").Error)
+	require.NoError(t, db.Delete(&todomodels.Todo{}, "id = ?", todo2.ID).Error)
+
+	res2, err := s.Load(ctx, fsys, "fixtures")
+	require.NoError(t, err)
+	assert.Equal(t, todo1.ID, res2.GetID("todo1"))
+
+	var count int64
+
+	require.NoError(t, db.Model(&todomodels.Todo{}).Count(&count).Error)
+	assert.Equal(t, int64(3), count)
+
+	var got1, got2 todomodels.Todo
+
+	require.NoError(t, db.First(&got1, "id = ?", todo1.ID).Error)
+	assert.Equal(t, "first", got1.Text)
+	assert.Equal(t, todo1.CreatedAt.Unix(), got1.CreatedAt.Unix())
+
+	require.NoError(t, db.First(&got2, "id = ?", todo2.ID).Error)
+	assert.Equal(t, todo1.ID, got2.Text)
+}
+
+func TestService_Load_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+	}{
+		{
+			name:  "unsupported kind",
+			files: map[string]string{"a.yaml": "fake:\n  f1:\n    text: a\n"},
+		},
+		{
+			name:  "duplicated name",
+			files: map[string]string{"a.yaml": "todos:\n  f1:\n    text: a\n", "b.yml": "todos:\n  f1:\n    text: b\n"},
+		},
+		{
+			name:  "invalid file",
+			files: map[string]string{"a.json": "{"},
+		},
+		{
+			name:  "unknown field",
+			files: map[string]string{"a.yaml": "todos:\n  f1:\n    fake: a\n"},
+		},
+		{
+			name:  "unknown fixture reference",
+			files: map[string]string{"a.yaml": "todos:\n  f1:\n    text: \"@f2.id\"\n"},
+		},
+		{
+			name:  "unknown field reference",
+			files: map[string]string{"a.yaml": "todos:\n  f1:\n    text: \"@f2.fake\"\n  f2:\n    text: a\n"},
+		},
+		{
+			name:  "circular reference",
+			files: map[string]string{"a.yaml": "todos:\n  f1:\n    text: \"@f2.text\"\n  f2:\n    text: \"@f1.text\"\n"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := setupTestService(t)
+
+			fsys := fstest.MapFS{}
+			for k, v := range tt.files {
+				fsys["fixtures/"+k] = &fstest.MapFile{Data: []byte(v)}
+			}
+
+			_, err := s.Load(tenant.SetInContext(context.TODO(), "tenant1"), fsys, "fixtures")
+			require.ErrorIs(t, err, ErrInvalidFixture)
+		})
+	}
+}
+
+func TestService_Load_Tenant(t *testing.T) {
+	s, db := setupTestService(t)
+
+	fsys := fstest.MapFS{
+		"fixtures/a.yaml": {Data: []byte(`
+todos:
+  todo1:
+    text: context tenant
+  todo2:
+    text: declared tenant
+    tenant_id: tenant2
+`)},
+	}
+
+	res, err := s.Load(tenant.SetInContext(context.TODO(), "tenant1"), fsys, "fixtures")
+	require.NoError(t, err)
+
+	var got1, got2 todomodels.Todo
+
+	require.NoError(t, db.First(&got1, "id = ?", res.GetID("todo1")).Error)
+	assert.Equal(t, "tenant1", got1.TenantID)
+
+	require.NoError(t, db.First(&got2, "id = ?", res.GetID("todo2")).Error)
+	assert.Equal(t, "tenant2", got2.TenantID)
+
+	// Tenant is required when it isn't declared
+	_, err = s.Load(context.TODO(), fsys, "fixtures")
+	require.EqualError(t, err, "no tenant found in context")
+}
+
+func TestService_Seed(t *testing.T) {
+	s, db := setupTestService(t)
+	ctx := context.TODO()
+
+	// Unknown environments
+	for _, env := range []string{"fake", ".", "../sets", ""} {
+		_, err := s.Seed(ctx, env)
+		require.ErrorIs(t, err, ErrFixtureSetNotFound, env)
+	}
+
+	// All embedded sets can be loaded
+	entries, err := fs.ReadDir(embeddedSets, setsDir)
+	require.NoError(t, err)
+	require.NotEmpty(t, entries)
+
+	for _, entry := range entries {
+		_, err = s.Seed(ctx, entry.Name())
+		require.NoError(t, err, entry.Name())
+	}
+
+	// Seeding is idempotent
+	var count int64
+
+	require.NoError(t, db.Model(&todomodels.Todo{}).Count(&count).Error)
+
+	for _, entry := range entries {
+		_, err = s.Seed(ctx, entry.Name())
+		require.NoError(t, err, entry.Name())
+	}
+
+	var count2 int64
+
+	require.NoError(t, db.Model(&todomodels.Todo{}).Count(&count2).Error)
+	assert.Equal(t, count, count2)
+}
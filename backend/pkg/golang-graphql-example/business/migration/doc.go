@@ -4,3 +4,8 @@ package migration
 // a migration tool and a dedicated database to store migration status.
 // This service should be the only service knowing that database exists
 // and how to request it. Otherwise, this should be done in a dao.
+//
+// Migrations are declared as go sequences (see sequences package) or as
+// sql files in the sql folder. Sql files are executed as a single statement
+// list, so MySQL connections must allow multi statements to use files with
+// multiple statements.
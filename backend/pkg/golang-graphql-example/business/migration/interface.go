@@ -3,15 +3,78 @@ package migration
 import (
 	"context"
 
+	"emperror.dev/errors"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/migration/sequences"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
+	lockdistributor "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/lockdistributor/sql"
+)
+
+// Mode is the migration run mode.
+type Mode string
+
+const (
+	// UpMode will apply all pending migrations.
+	UpMode Mode = "up"
+	// UpToMode will apply pending migrations until the target one (included).
+	UpToMode Mode = "up-to"
+	// RollbackLastMode will roll back the last applied migration.
+	RollbackLastMode Mode = "rollback-last"
+	// RollbackToMode will roll back applied migrations until the target one (excluded).
+	RollbackToMode Mode = "rollback-to"
 )
 
+// ErrUnsupportedMode is returned when a migration mode isn't supported.
+var ErrUnsupportedMode = errors.Sentinel("unsupported migration mode")
+
+// ErrMigrationNotFound is returned when a target migration doesn't exist.
+var ErrMigrationNotFound = errors.Sentinel("migration not found")
+
+// ErrRollbackNotDefined is returned when a migration to roll back doesn't have a rollback function.
+var ErrRollbackNotDefined = errors.Sentinel("migration doesn't have a rollback function")
+
+// ErrDuplicatedMigration is returned when a sql migration file and a go sequence have the same id.
+var ErrDuplicatedMigration = errors.Sentinel("duplicated migration id")
+
+// ErrChecksumMismatch is returned when an applied sql migration file have been modified.
+var ErrChecksumMismatch = errors.Sentinel("applied sql migration file have been modified")
+
+// RunInput is the migration run input.
+type RunInput struct {
+	Mode Mode
+	// Migration id used by up-to and rollback-to modes
+	TargetID string
+	// Only compute migrations that would be applied or rolled back
+	DryRun bool
+}
+
+// Status is the status of a migration.
+type Status struct {
+	ID      string
+	Applied bool
+	// Applied sql migration file have been modified
+	ChecksumMismatch bool
+}
+
 // Service will represent the service that will migrate database.
+// Go sequences and embedded sql migration files are ordered together by id.
 type Service interface {
-	// Migrate.
+	// Migrate will apply all pending migrations.
 	Migrate(ctx context.Context) error
+	// Run will apply or roll back migrations depending on the mode.
+	// Migration ids applied or rolled back are returned in execution order.
+	// Runs are guarded by a distributed lock, nothing is executed on dry run.
+	Run(ctx context.Context, input *RunInput) ([]string, error)
+	// Status will return the status of all migrations in execution order.
+	Status(ctx context.Context) ([]*Status, error)
 }
 
-func New(dbSvc database.DB) Service {
-	return &service{dbSvc: dbSvc}
+func New(dbSvc database.DB, ldSvc lockdistributor.Service) Service {
+	return &service{
+		dbSvc:        dbSvc,
+		ldSvc:        ldSvc,
+		goMigrations: sequences.GetAll(),
+		sqlFS:        embeddedSQLFiles,
+		sqlDir:       sqlFilesDir,
+	}
 }
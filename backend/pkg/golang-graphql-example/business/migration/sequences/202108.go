@@ -35,7 +35,11 @@ var Seq202108List = []*gormigrate.Migration{
 			return tx.AutoMigrate(&Todo{})
 		},
 		Rollback: func(tx *gorm.DB) error {
-			return tx.Raw(`ALTER TABLE "todos" ALTER COLUMN "text" TYPE varchar(200)`).Error
+			type Todo struct {
+				Text string `gorm:"type:varchar(200)"`
+			}
+
+			return tx.Migrator().AlterColumn(&Todo{}, "Text")
 		},
 	},
 }
@@ -0,0 +1,202 @@
+package sequences
+
+import (
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/migrationhelpers"
+)
+
+var Seq202610List = []*gormigrate.Migration{
+	// Add todos version for optimistic concurrency control
+	{
+		ID: "202610181000",
+		Migrate: func(tx *gorm.DB) error {
+			type Todo struct {
+				Version int `gorm:"not null;default:1"`
+			}
+
+			return tx.AutoMigrate(&Todo{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn("todos", "version")
+		},
+	},
+	// Add audit events
+	{
+		ID: "202610181100",
+		Migrate: func(tx *gorm.DB) error {
+			type AuditEvent struct {
+				database.Base
+				EntityID       string `gorm:"index"`
+				ModelName      string `gorm:"index"`
+				Action         string
+				UserIdentifier string
+				CorrelationID  string
+				Before         string `gorm:"type:text"`
+				After          string `gorm:"type:text"`
+			}
+
+			return tx.AutoMigrate(&AuditEvent{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable("audit_events")
+		},
+	},
+	// Add amqp outbox messages
+	{
+		ID: "202610181200",
+		Migrate: func(tx *gorm.DB) error {
+			type AmqpOutboxMessage struct {
+				DeliveredAt *time.Time `gorm:"index"`
+				database.Base
+				Exchange   string
+				RoutingKey string
+				LastError  string
+				Publishing []byte `gorm:"not null"`
+				Timeout    time.Duration
+				RetryDelay time.Duration
+				Attempts   int `gorm:"not null;default:0"`
+				Mandatory  bool
+				Immediate  bool
+			}
+
+			return tx.AutoMigrate(&AmqpOutboxMessage{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable("amqp_outbox_messages")
+		},
+	},
+	// Add todos text full text search index
+	{
+		ID: "202610181300",
+		Migrate: func(tx *gorm.DB) error {
+			return migrationhelpers.CreateFullTextSearchIndex(tx, "todos", "text")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return migrationhelpers.DropFullTextSearchIndex(tx, "todos", "text")
+		},
+	},
+	// Fix todos indexes dropped by the sqlite table recreation of 202108201849
+	// Other databases already have them, so this is a no-op for them
+	{
+		ID: "202610191000",
+		Migrate: func(tx *gorm.DB) error {
+			type Todo struct {
+				database.Base
+			}
+
+			return tx.AutoMigrate(&Todo{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			// Indexes were only missing on sqlite
+			if tx.Dialector.Name() != "sqlite" {
+				return nil
+			}
+
+			type Todo struct {
+				database.Base
+			}
+
+			// Drop restored indexes
+			for _, f := range []string{"CreatedAt", "UpdatedAt", "DeletedAt"} {
+				err := tx.Migrator().DropIndex(&Todo{}, f)
+				// Check error
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	},
+	// Add audit events tenant
+	{
+		ID: "202610201000",
+		Migrate: func(tx *gorm.DB) error {
+			type AuditEvent struct {
+				// Default value is set for existing rows
+				TenantID string `gorm:"index;not null;default:''"`
+			}
+
+			return tx.AutoMigrate(&AuditEvent{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn("audit_events", "tenant_id")
+		},
+	},
+	// Add amqp outbox messages retry backoff and dead letter
+	{
+		ID: "202610201100",
+		Migrate: func(tx *gorm.DB) error {
+			type AmqpOutboxMessage struct {
+				NextAttemptAt  *time.Time `gorm:"index"`
+				DeadLetteredAt *time.Time `gorm:"index"`
+			}
+
+			return tx.AutoMigrate(&AmqpOutboxMessage{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			err := tx.Migrator().DropColumn("amqp_outbox_messages", "next_attempt_at")
+			// Check error
+			if err != nil {
+				return err
+			}
+
+			return tx.Migrator().DropColumn("amqp_outbox_messages", "dead_lettered_at")
+		},
+	},
+	// Add todos tenant for row-level multi-tenancy
+	{
+		ID: "202610201200",
+		Migrate: func(tx *gorm.DB) error {
+			type Todo struct {
+				// Default value is set for existing rows
+				TenantID string `gorm:"index;not null;default:''"`
+			}
+
+			err := tx.AutoMigrate(&Todo{})
+			// Check error
+			if err != nil {
+				return err
+			}
+
+			// Existing todos must be moved in a tenant as empty tenant is rejected by tenant scoped requests
+			var count int64
+			// Count them
+			err = tx.Table("todos").Where("tenant_id = ?", "").Count(&count).Error
+			// Check error
+			if err != nil {
+				return errors.WithStack(err)
+			}
+
+			// Check if there is something to move
+			if count == 0 {
+				return nil
+			}
+
+			// Get default tenant set in context by migration caller
+			tenantID, err := database.GetTenantFromContext(tx.Statement.Context)
+			// Check error
+			if err != nil {
+				return errors.WithMessage(err, "default tenant is required to migrate existing todos")
+			}
+
+			// Move them in default tenant
+			err = tx.Table("todos").Where("tenant_id = ?", "").Update("tenant_id", tenantID).Error
+			// Check error
+			if err != nil {
+				return errors.WithStack(err)
+			}
+
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn("todos", "tenant_id")
+		},
+	},
+}
@@ -0,0 +1,23 @@
+package sequences
+
+import "github.com/go-gormigrate/gormigrate/v2"
+
+// GetAll will return all migrations in execution order.
+// Each migration should declare a rollback function to allow rollback of a bad deploy.
+func GetAll() []*gormigrate.Migration {
+	// Create array of sequences
+	sequencesList := [][]*gormigrate.Migration{
+		Seq201608List,
+		Seq202108List,
+		Seq202610List,
+	}
+
+	// Create migration list
+	res := []*gormigrate.Migration{}
+	// Loop over list
+	for _, k := range sequencesList {
+		res = append(res, k...)
+	}
+
+	return res
+}
@@ -0,0 +1,76 @@
+//go:build unit
+
+package sequences
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/common/tenant"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/dbtest"
+)
+
+func TestGetAll(t *testing.T) {
+	list := GetAll()
+
+	assert.Len(t, list, len(Seq201608List)+len(Seq202108List)+len(Seq202610List))
+
+	ids := map[string]bool{}
+	// Loop over migrations
+	for _, m := range list {
+		assert.False(t, ids[m.ID], "migration %s is duplicated", m.ID)
+		assert.NotNil(t, m.Rollback, "migration %s must have a rollback function", m.ID)
+
+		ids[m.ID] = true
+	}
+}
+
+func TestTodosIndexesFix(t *testing.T) {
+	db := dbtest.NewSQLiteDB(t)
+	indexes := []string{"idx_todos_created_at", "idx_todos_updated_at", "idx_todos_deleted_at"}
+
+	m := gormigrate.New(db, &gormigrate.Options{UseTransaction: true}, GetAll())
+	require.NoError(t, m.MigrateTo("202610191000"))
+
+	for _, idx := range indexes {
+		assert.True(t, db.Migrator().HasIndex("todos", idx), "index %s must exist", idx)
+	}
+
+	require.NoError(t, m.RollbackLast())
+
+	for _, idx := range indexes {
+		assert.False(t, db.Migrator().HasIndex("todos", idx), "index %s must be dropped", idx)
+	}
+}
+
+func TestTodosTenant(t *testing.T) {
+	db := dbtest.NewSQLiteDB(t)
+
+	m := gormigrate.New(db, &gormigrate.Options{UseTransaction: true}, GetAll())
+	require.NoError(t, m.MigrateTo("202610201100"))
+
+	// Create todo before multi-tenancy
+	require.NoError(t, db.Exec(
+		"INSERT INTO todos (id, created_at, updated_at, text, done) VALUES (?, ?, ?, ?, ?)",
+		"todo1", time.Now(), time.Now(), "text", false,
+	).Error)
+
+	// Existing todos cannot be moved without default tenant
+	err := gormigrate.New(db, &gormigrate.Options{UseTransaction: true}, GetAll()).MigrateTo("202610201200")
+	require.ErrorContains(t, err, "default tenant is required to migrate existing todos")
+	assert.False(t, db.Migrator().HasColumn("todos", "tenant_id"))
+
+	ctx := tenant.SetInContext(context.TODO(), "default")
+	m = gormigrate.New(db.WithContext(ctx), &gormigrate.Options{UseTransaction: true}, GetAll())
+	require.NoError(t, m.MigrateTo("202610201200"))
+
+	var tenantID string
+
+	require.NoError(t, db.Table("todos").Where("id = ?", "todo1").Pluck("tenant_id", &tenantID).Error)
+	assert.Equal(t, "default", tenantID)
+}
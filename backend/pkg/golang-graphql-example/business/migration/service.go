@@ -2,47 +2,327 @@ package migration
 
 import (
 	"context"
+	"io/fs"
+	"slices"
+	"strings"
 
 	"emperror.dev/errors"
 	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
 
-	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/migration/sequences"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
+	lockdistributor "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/lockdistributor/sql"
 )
 
+const migrationLockName = "database-migration"
+
 type service struct {
-	dbSvc database.DB
+	dbSvc        database.DB
+	ldSvc        lockdistributor.Service
+	sqlFS        fs.FS
+	goMigrations []*gormigrate.Migration
+	sqlDir       string
+}
+
+// migrationList is the list of all migrations with their status.
+type migrationList struct {
+	// Applied migration ids
+	applied    map[string]bool
+	migrations []*gormigrate.Migration
+	// Applied sql migration ids with a modified file
+	mismatches []string
 }
 
 func (s *service) Migrate(ctx context.Context) error {
-	// Get gorm database
-	db := s.dbSvc.GetGormDB()
+	_, err := s.Run(ctx, &RunInput{Mode: UpMode})
+
+	return err
+}
+
+func (s *service) Status(ctx context.Context) ([]*Status, error) {
+	// Get migrations
+	ml, err := s.getMigrationList(ctx)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.Map(ml.migrations, func(m *gormigrate.Migration, _ int) *Status {
+		return &Status{
+			ID:               m.ID,
+			Applied:          ml.applied[m.ID],
+			ChecksumMismatch: slices.Contains(ml.mismatches, m.ID),
+		}
+	}), nil
+}
 
-	// Add context
-	db = db.WithContext(ctx)
+func (s *service) Run(ctx context.Context, input *RunInput) (ids []string, err error) {
+	// Compute migrations
+	ids, _, err = s.computeMigrationIDs(ctx, input)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
 
-	// Create array of sequences
-	sequencesList := [][]*gormigrate.Migration{
-		sequences.Seq201608List,
-		sequences.Seq202108List,
+	// Check if it is a dry run or if there is nothing to do
+	if input.DryRun || len(ids) == 0 {
+		return ids, nil
 	}
 
-	// Create migrationSequences
-	migrationSequences := []*gormigrate.Migration{}
-	// Loot of list
-	for _, k := range sequencesList {
-		migrationSequences = append(migrationSequences, k...)
+	// Acquire lock to ensure that only one instance is migrating
+	lock := s.ldSvc.GetLock(migrationLockName)
+	// Acquire
+	err = lock.AcquireWithContext(ctx)
+	// Check error
+	if err != nil {
+		return nil, errors.WithMessage(err, "cannot acquire migration lock, another migration may be running")
+	}
+	// Release lock at the end
+	defer func() {
+		// Release
+		err2 := lock.Release()
+		// Check error
+		if err2 != nil && err == nil {
+			ids = nil
+			err = err2
+		}
+	}()
+
+	// Compute migrations again as another instance may have migrated while waiting for the lock
+	ids, ml, err := s.computeMigrationIDs(ctx, input)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if there is nothing to do
+	if len(ids) == 0 {
+		return ids, nil
 	}
 
 	// Create migration sequence
+	// Migrations can be long, so statement timeout is disabled
 	m := gormigrate.New(
-		db,
+		s.dbSvc.GetGormDB().WithContext(database.WithoutStatementTimeout(ctx)),
 		// Due to #76, force transaction to have a rollback
 		// https://github.com/go-gormigrate/gormigrate/issues/76
 		&gormigrate.Options{UseTransaction: true},
-		migrationSequences,
+		ml.migrations,
 	)
 
-	// Start migration
-	return errors.WithStack(m.Migrate())
+	// Run
+	switch input.Mode {
+	case UpToMode:
+		err = m.MigrateTo(input.TargetID)
+	case RollbackLastMode:
+		err = m.RollbackLast()
+	case RollbackToMode:
+		err = m.RollbackTo(input.TargetID)
+	default:
+		err = m.Migrate()
+	}
+	// Check error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return ids, nil
+}
+
+// computeMigrationIDs will compute the migration ids that will be applied or rolled back in execution order.
+func (s *service) computeMigrationIDs(ctx context.Context, input *RunInput) ([]string, *migrationList, error) {
+	// Get migrations
+	ml, err := s.getMigrationList(ctx)
+	// Check error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Check that applied files haven't been modified
+	if len(ml.mismatches) != 0 {
+		return nil, nil, errors.Wrapf(ErrChecksumMismatch, "migrations %s", strings.Join(ml.mismatches, ", "))
+	}
+
+	// Select migrations
+	ids, err := selectMigrationIDs(ml, input)
+	// Check error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ids, ml, nil
+}
+
+func selectMigrationIDs(ml *migrationList, input *RunInput) ([]string, error) {
+	// Get pending ids
+	getPendingIDs := func(list []*gormigrate.Migration) []string {
+		return lo.FilterMap(list, func(m *gormigrate.Migration, _ int) (string, bool) {
+			return m.ID, !ml.applied[m.ID]
+		})
+	}
+
+	switch input.Mode {
+	case UpMode, "":
+		return getPendingIDs(ml.migrations), nil
+	case UpToMode:
+		// Get target index
+		idx, err := getMigrationIndex(ml.migrations, input.TargetID)
+		// Check error
+		if err != nil {
+			return nil, err
+		}
+
+		return getPendingIDs(ml.migrations[:idx+1]), nil
+	case RollbackLastMode:
+		// Get last applied migration
+		for i := len(ml.migrations) - 1; i >= 0; i-- {
+			// Check if it is applied
+			if ml.applied[ml.migrations[i].ID] {
+				return getRollbackIDs(ml.migrations[i : i+1])
+			}
+		}
+
+		// Nothing have been applied
+		return []string{}, nil
+	case RollbackToMode:
+		// Get target index
+		idx, err := getMigrationIndex(ml.migrations, input.TargetID)
+		// Check error
+		if err != nil {
+			return nil, err
+		}
+
+		// Get applied migrations after target in reverse order
+		list := lo.Filter(ml.migrations[idx+1:], func(m *gormigrate.Migration, _ int) bool { return ml.applied[m.ID] })
+		slices.Reverse(list)
+
+		return getRollbackIDs(list)
+	default:
+		return nil, errors.Wrapf(ErrUnsupportedMode, "mode %q", input.Mode)
+	}
+}
+
+// getMigrationList will return go sequences and sql migrations ordered by id with their status.
+func (s *service) getMigrationList(ctx context.Context) (*migrationList, error) {
+	// Get gorm database
+	db := s.dbSvc.GetGormDB().WithContext(ctx)
+
+	// Load sql migrations for current driver
+	sqlMigrations, err := loadSQLMigrations(s.sqlFS, s.sqlDir, db.Dialector.Name())
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	// Initialize list with go sequences
+	migrations := slices.Clone(s.goMigrations)
+	checksums := map[string]string{}
+	// Loop over sql migrations
+	for _, sm := range sqlMigrations {
+		// Check that id isn't already used by a go sequence
+		if slices.ContainsFunc(s.goMigrations, func(m *gormigrate.Migration) bool { return m.ID == sm.id }) {
+			return nil, errors.Wrapf(ErrDuplicatedMigration, "migration %s", sm.id)
+		}
+
+		// Save
+		migrations = append(migrations, sm.toGormigrate())
+		checksums[sm.id] = sm.checksum
+	}
+
+	// Sort all migrations together
+	slices.SortStableFunc(migrations, func(a, b *gormigrate.Migration) int { return strings.Compare(a.ID, b.ID) })
+
+	// Get applied migrations
+	applied, err := getAppliedIDs(db)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	// Get modified files
+	mismatches, err := getChecksumMismatches(db, checksums)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	return &migrationList{
+		applied:    applied,
+		migrations: migrations,
+		mismatches: mismatches,
+	}, nil
+}
+
+func getMigrationIndex(migrations []*gormigrate.Migration, id string) (int, error) {
+	// Find index
+	idx := slices.IndexFunc(migrations, func(m *gormigrate.Migration) bool { return m.ID == id })
+	// Check if it exists
+	if idx == -1 {
+		return 0, errors.Wrapf(ErrMigrationNotFound, "migration %q", id)
+	}
+
+	return idx, nil
+}
+
+// getAppliedIDs will return the set of applied migration ids.
+func getAppliedIDs(db *gorm.DB) (map[string]bool, error) {
+	// Check if migration table exists
+	if !db.Migrator().HasTable(gormigrate.DefaultOptions.TableName) {
+		return map[string]bool{}, nil
+	}
+
+	var ids []string
+	// Get ids
+	err := db.Table(gormigrate.DefaultOptions.TableName).
+		Pluck(gormigrate.DefaultOptions.IDColumnName, &ids).
+		Error
+	// Check error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return lo.SliceToMap(ids, func(id string) (string, bool) { return id, true }), nil
+}
+
+// getChecksumMismatches will return ids of applied sql migrations whose up file have been modified.
+func getChecksumMismatches(db *gorm.DB, checksums map[string]string) ([]string, error) {
+	// Check if checksum table exists
+	if !db.Migrator().HasTable(checksumTableName) {
+		return []string{}, nil
+	}
+
+	var list []*migrationChecksum
+	// Get checksums
+	err := db.Order("id").Find(&list).Error
+	// Check error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	res := []string{}
+	// Loop over applied checksums
+	for _, mc := range list {
+		// Get current checksum
+		checksum, ok := checksums[mc.ID]
+		// Check if file still exists and if it has been modified
+		if ok && checksum != mc.Checksum {
+			res = append(res, mc.ID)
+		}
+	}
+
+	return res, nil
+}
+
+// getRollbackIDs will return ids of migrations after having checked that they can be rolled back.
+func getRollbackIDs(list []*gormigrate.Migration) ([]string, error) {
+	// Loop over migrations
+	for _, m := range list {
+		// Check rollback function
+		if m.Rollback == nil {
+			return nil, errors.Wrapf(ErrRollbackNotDefined, "migration %q", m.ID)
+		}
+	}
+
+	return lo.Map(list, func(m *gormigrate.Migration, _ int) string { return m.ID }), nil
 }
@@ -0,0 +1,181 @@
+//go:build unit
+
+package migration
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	dbmocks "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/mocks"
+	ldmocks "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/lockdistributor/sql/mocks"
+)
+
+func newTestMigration(id string) *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: id,
+		Migrate: func(tx *gorm.DB) error {
+			return tx.Exec("CREATE TABLE t" + id + " (id TEXT)").Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable("t" + id)
+		},
+	}
+}
+
+func setupTestService(t *testing.T) (*service, *gorm.DB, *ldmocks.MockLock) {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "migration.db")), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+
+	dbSvc := dbmocks.NewMockDB(ctrl)
+	dbSvc.EXPECT().GetGormDB().AnyTimes().Return(db)
+
+	lock := ldmocks.NewMockLock(ctrl)
+	ldSvc := ldmocks.NewMockService(ctrl)
+	ldSvc.EXPECT().GetLock(migrationLockName).AnyTimes().Return(lock)
+
+	return &service{
+		dbSvc:        dbSvc,
+		ldSvc:        ldSvc,
+		goMigrations: []*gormigrate.Migration{newTestMigration("1"), newTestMigration("2"), newTestMigration("3")},
+		sqlFS: fstest.MapFS{
+			"sql/25_create_t25.up.sql":        {Data: []byte("FAIL")},
+			"sql/25_create_t25.sqlite.up.sql": {Data: []byte("CREATE TABLE t25 (id TEXT);")},
+			"sql/25_create_t25.down.sql":      {Data: []byte("DROP TABLE t25;")},
+		},
+		sqlDir: "sql",
+	}, db, lock
+}
+
+func TestService_Run(t *testing.T) {
+	s, db, lock := setupTestService(t)
+	ctx := context.TODO()
+
+	// Dry run doesn't need lock
+	ids, err := s.Run(ctx, &RunInput{Mode: UpMode, DryRun: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2", "25", "3"}, ids)
+	assert.False(t, db.Migrator().HasTable("t1"))
+
+	// Unknown target
+	_, err = s.Run(ctx, &RunInput{Mode: UpToMode, TargetID: "4"})
+	require.ErrorIs(t, err, ErrMigrationNotFound)
+
+	// Unknown mode
+	_, err = s.Run(ctx, &RunInput{Mode: "fake"})
+	require.ErrorIs(t, err, ErrUnsupportedMode)
+
+	// Up to
+	lock.EXPECT().AcquireWithContext(gomock.Any()).Return(nil)
+	lock.EXPECT().Release().Return(nil)
+
+	ids, err = s.Run(ctx, &RunInput{Mode: UpToMode, TargetID: "2"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, ids)
+	assert.True(t, db.Migrator().HasTable("t2"))
+	assert.False(t, db.Migrator().HasTable("t3"))
+
+	// Status
+	status, err := s.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []*Status{{ID: "1", Applied: true}, {ID: "2", Applied: true}, {ID: "25"}, {ID: "3"}}, status)
+
+	// Up
+	lock.EXPECT().AcquireWithContext(gomock.Any()).Return(nil)
+	lock.EXPECT().Release().Return(nil)
+
+	ids, err = s.Run(ctx, &RunInput{Mode: UpMode})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"25", "3"}, ids)
+	// Driver variant is used
+	assert.True(t, db.Migrator().HasTable("t25"))
+
+	// Nothing to do doesn't need lock
+	ids, err = s.Run(ctx, &RunInput{Mode: UpMode})
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+
+	// Rollback to dry run
+	ids, err = s.Run(ctx, &RunInput{Mode: RollbackToMode, TargetID: "1", DryRun: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"3", "25", "2"}, ids)
+
+	// Rollback last
+	lock.EXPECT().AcquireWithContext(gomock.Any()).Return(nil)
+	lock.EXPECT().Release().Return(nil)
+
+	ids, err = s.Run(ctx, &RunInput{Mode: RollbackLastMode})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"3"}, ids)
+	assert.False(t, db.Migrator().HasTable("t3"))
+
+	// Rollback to
+	lock.EXPECT().AcquireWithContext(gomock.Any()).Return(nil)
+	lock.EXPECT().Release().Return(nil)
+
+	ids, err = s.Run(ctx, &RunInput{Mode: RollbackToMode, TargetID: "1"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"25", "2"}, ids)
+	assert.True(t, db.Migrator().HasTable("t1"))
+	assert.False(t, db.Migrator().HasTable("t2"))
+	assert.False(t, db.Migrator().HasTable("t25"))
+
+	// Migration without rollback function
+	s.goMigrations[0].Rollback = nil
+
+	_, err = s.Run(ctx, &RunInput{Mode: RollbackLastMode})
+	require.ErrorIs(t, err, ErrRollbackNotDefined)
+}
+
+func TestService_Run_LockNotAcquired(t *testing.T) {
+	s, db, lock := setupTestService(t)
+
+	lock.EXPECT().AcquireWithContext(gomock.Any()).Return(context.DeadlineExceeded)
+
+	_, err := s.Run(context.TODO(), &RunInput{Mode: UpMode})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.False(t, db.Migrator().HasTable("t1"))
+}
+
+func TestService_Run_ChecksumMismatch(t *testing.T) {
+	s, _, lock := setupTestService(t)
+	ctx := context.TODO()
+
+	lock.EXPECT().AcquireWithContext(gomock.Any()).Return(nil)
+	lock.EXPECT().Release().Return(nil)
+
+	_, err := s.Run(ctx, &RunInput{Mode: UpMode})
+	require.NoError(t, err)
+
+	// Modify applied file
+	s.sqlFS.(fstest.MapFS)["sql/25_create_t25.sqlite.up.sql"].Data = []byte("CREATE TABLE t25 (id TEXT, name TEXT);")
+
+	status, err := s.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, &Status{ID: "25", Applied: true, ChecksumMismatch: true}, status[2])
+
+	_, err = s.Run(ctx, &RunInput{Mode: UpMode, DryRun: true})
+	require.ErrorIs(t, err, ErrChecksumMismatch)
+}
+
+func TestService_Run_DuplicatedMigration(t *testing.T) {
+	s, _, _ := setupTestService(t)
+
+	s.sqlFS.(fstest.MapFS)["sql/2_duplicated.up.sql"] = &fstest.MapFile{Data: []byte("SELECT 1;")}
+
+	_, err := s.Run(context.TODO(), &RunInput{Mode: UpMode, DryRun: true})
+	require.ErrorIs(t, err, ErrDuplicatedMigration)
+}
@@ -0,0 +1,205 @@
+package migration
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"io/fs"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// Embedded sql migration files.
+// Files must be named "<id>_<description>[.<driver>].<up|down>.sql".
+// Driver is optional and is one of "postgres", "sqlite" or "mysql", it takes precedence over generic files.
+//
+//go:embed sql/*.sql
+var embeddedSQLFiles embed.FS
+
+const (
+	sqlFilesDir       = "sql"
+	checksumTableName = "migration_checksums"
+	upDirection       = "up"
+	downDirection     = "down"
+)
+
+var sqlFileNameRegexp = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)(?:\.(postgres|sqlite|mysql))?\.(up|down)\.sql$`)
+
+// ErrInvalidSQLMigrationFile is returned when a sql migration file cannot be loaded.
+var ErrInvalidSQLMigrationFile = errors.Sentinel("invalid sql migration file")
+
+// migrationChecksum stores checksums of applied sql migration files.
+type migrationChecksum struct {
+	ID       string `gorm:"primaryKey;size:255"`
+	Checksum string `gorm:"size:64;not null"`
+}
+
+func (*migrationChecksum) TableName() string {
+	return checksumTableName
+}
+
+type sqlMigrationFiles struct {
+	// Files by direction and driver, empty driver is the generic one
+	files map[string]map[string]string
+	id    string
+}
+
+// sqlMigration is a loaded sql migration for a driver.
+type sqlMigration struct {
+	id       string
+	up       string
+	down     string
+	checksum string
+	hasDown  bool
+}
+
+// loadSQLMigrations will load sql migrations of a driver ordered by id.
+func loadSQLMigrations(fsys fs.FS, dir, driver string) ([]*sqlMigration, error) {
+	// Read directory
+	entries, err := fs.ReadDir(fsys, dir)
+	// Check error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	// Group files by migration id
+	filesByID := map[string]*sqlMigrationFiles{}
+	// Loop over entries
+	for _, entry := range entries {
+		// Ignore directories
+		if entry.IsDir() {
+			continue
+		}
+
+		// Parse name
+		matches := sqlFileNameRegexp.FindStringSubmatch(entry.Name())
+		// Check if it is matching
+		if matches == nil {
+			return nil, errors.Wrapf(ErrInvalidSQLMigrationFile, "file %s doesn't respect naming", entry.Name())
+		}
+
+		id, fileDriver, direction := matches[1], matches[3], matches[4]
+
+		// Get migration files
+		mf := filesByID[id]
+		// Check if it exists
+		if mf == nil {
+			mf = &sqlMigrationFiles{id: id, files: map[string]map[string]string{}}
+			filesByID[id] = mf
+		}
+
+		// Check if direction exists
+		if mf.files[direction] == nil {
+			mf.files[direction] = map[string]string{}
+		}
+
+		// Check duplicates as description can be different
+		if _, ok := mf.files[direction][fileDriver]; ok {
+			return nil, errors.Wrapf(ErrInvalidSQLMigrationFile, "file %s is duplicated", entry.Name())
+		}
+
+		// Read file
+		b, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		// Check error
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		// Save
+		mf.files[direction][fileDriver] = string(b)
+	}
+
+	// Build migrations
+	res := make([]*sqlMigration, 0, len(filesByID))
+	// Loop over migrations
+	for _, mf := range filesByID {
+		// Get up file
+		up, ok := mf.getFile(upDirection, driver)
+		// Check if it exists
+		if !ok {
+			return nil, errors.Wrapf(ErrInvalidSQLMigrationFile, "migration %s doesn't have an up file for driver %s", mf.id, driver)
+		}
+
+		// Get down file
+		down, hasDown := mf.getFile(downDirection, driver)
+
+		// Compute checksum
+		sum := sha256.Sum256([]byte(up))
+
+		res = append(res, &sqlMigration{
+			id:       mf.id,
+			up:       up,
+			down:     down,
+			hasDown:  hasDown,
+			checksum: hex.EncodeToString(sum[:]),
+		})
+	}
+
+	// Sort by id
+	slices.SortFunc(res, func(a, b *sqlMigration) int { return strings.Compare(a.id, b.id) })
+
+	return res, nil
+}
+
+// getFile will return the file of driver or the generic one.
+func (mf *sqlMigrationFiles) getFile(direction, driver string) (string, bool) {
+	// Get driver file
+	content, ok := mf.files[direction][driver]
+	// Check if it exists
+	if ok {
+		return content, true
+	}
+
+	// Get generic file
+	content, ok = mf.files[direction][""]
+
+	return content, ok
+}
+
+// toGormigrate will create the gormigrate migration that will apply sql files and save checksum.
+func (sm *sqlMigration) toGormigrate() *gormigrate.Migration {
+	m := &gormigrate.Migration{
+		ID: sm.id,
+		Migrate: func(tx *gorm.DB) error {
+			// Execute file
+			err := tx.Exec(sm.up).Error
+			// Check error
+			if err != nil {
+				return errors.WithStack(err)
+			}
+
+			// Create checksum table if needed
+			err = tx.AutoMigrate(&migrationChecksum{})
+			// Check error
+			if err != nil {
+				return errors.WithStack(err)
+			}
+
+			// Save checksum
+			return errors.WithStack(tx.Save(&migrationChecksum{ID: sm.id, Checksum: sm.checksum}).Error)
+		},
+	}
+
+	// Check if there is a down file
+	if sm.hasDown {
+		m.Rollback = func(tx *gorm.DB) error {
+			// Execute file
+			err := tx.Exec(sm.down).Error
+			// Check error
+			if err != nil {
+				return errors.WithStack(err)
+			}
+
+			// Delete checksum
+			return errors.WithStack(tx.Delete(&migrationChecksum{ID: sm.id}).Error)
+		}
+	}
+
+	return m
+}
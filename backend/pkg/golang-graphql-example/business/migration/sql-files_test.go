@@ -0,0 +1,101 @@
+//go:build unit
+
+package migration
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_loadSQLMigrations(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   fstest.MapFS
+		driver  string
+		want    []*sqlMigration
+		wantErr bool
+	}{
+		{
+			name: "generic and driver variants",
+			files: fstest.MapFS{
+				"2_second.up.sql":           {Data: []byte("up2")},
+				"1_first.up.sql":            {Data: []byte("up1")},
+				"1_first.postgres.up.sql":   {Data: []byte("up1-pg")},
+				"1_first.down.sql":          {Data: []byte("down1")},
+				"1_first.sqlite.down.sql":   {Data: []byte("down1-sqlite")},
+				"2_second.mysql.down.sql":   {Data: []byte("down2-mysql")},
+				"3_third.postgres.up.sql":   {Data: []byte("up3-pg")},
+				"3_third.sqlite.up.sql":     {Data: []byte("up3-sqlite")},
+				"3_third.postgres.down.sql": {Data: []byte("down3-pg")},
+			},
+			driver: "postgres",
+			want: []*sqlMigration{
+				{id: "1", up: "up1-pg", down: "down1", hasDown: true},
+				{id: "2", up: "up2"},
+				{id: "3", up: "up3-pg", down: "down3-pg", hasDown: true},
+			},
+		},
+		{
+			name:    "invalid name",
+			files:   fstest.MapFS{"1-first.up.sql": {Data: []byte("up1")}},
+			driver:  "postgres",
+			wantErr: true,
+		},
+		{
+			name:    "unknown driver variant",
+			files:   fstest.MapFS{"1_first.oracle.up.sql": {Data: []byte("up1")}},
+			driver:  "postgres",
+			wantErr: true,
+		},
+		{
+			name:    "missing up file for driver",
+			files:   fstest.MapFS{"1_first.sqlite.up.sql": {Data: []byte("up1")}},
+			driver:  "postgres",
+			wantErr: true,
+		},
+		{
+			name: "duplicated file",
+			files: fstest.MapFS{
+				"1_first.up.sql":  {Data: []byte("up1")},
+				"1_second.up.sql": {Data: []byte("up1")},
+			},
+			driver:  "postgres",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := loadSQLMigrations(tt.files, ".", tt.driver)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidSQLMigrationFile)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, got, len(tt.want))
+
+			for i, w := range tt.want {
+				assert.Equal(t, w.id, got[i].id)
+				assert.Equal(t, w.up, got[i].up)
+				assert.Equal(t, w.down, got[i].down)
+				assert.Equal(t, w.hasDown, got[i].hasDown)
+				assert.Len(t, got[i].checksum, 64)
+			}
+		})
+	}
+}
+
+func Test_loadSQLMigrations_Embedded(t *testing.T) {
+	for _, driver := range []string{"postgres", "sqlite", "mysql"} {
+		got, err := loadSQLMigrations(embeddedSQLFiles, sqlFilesDir, driver)
+		require.NoError(t, err)
+
+		for _, sm := range got {
+			assert.True(t, sm.hasDown, "migration %s must have a down file for %s", sm.id, driver)
+		}
+	}
+}
@@ -0,0 +1,2 @@
+-- MySQL does not support partial indexes
+CREATE INDEX idx_todos_done ON todos (done);
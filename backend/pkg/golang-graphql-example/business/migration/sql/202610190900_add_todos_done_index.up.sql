@@ -0,0 +1,2 @@
+-- Index todos by status, only on not deleted rows
+CREATE INDEX idx_todos_done ON todos (done) WHERE deleted_at IS NULL;
@@ -0,0 +1,9 @@
+package schemacheck
+
+// This package will detect schema drifts between gorm models and the
+// connected database.
+//
+// Database is introspected with the gorm migrator of the driver (Postgres
+// catalog, SQLite pragma or MySQL information schema) and compared to the
+// gorm schema of registered models (see models.go). Indexes only declared
+// by migrations aren't reported as those aren't known by models.
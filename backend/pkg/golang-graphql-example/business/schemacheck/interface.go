@@ -0,0 +1,74 @@
+package schemacheck
+
+import (
+	"context"
+	"fmt"
+
+	"emperror.dev/errors"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
+)
+
+// IssueType is the type of a schema drift.
+type IssueType string
+
+const (
+	MissingTableIssue              IssueType = "missing_table"
+	MissingColumnIssue             IssueType = "missing_column"
+	UnexpectedColumnIssue          IssueType = "unexpected_column"
+	ColumnTypeMismatchIssue        IssueType = "column_type_mismatch"
+	ColumnNullabilityMismatchIssue IssueType = "column_nullability_mismatch"
+	MissingIndexIssue              IssueType = "missing_index"
+	IndexMismatchIssue             IssueType = "index_mismatch"
+)
+
+// ErrSchemaDrift is returned when database schema doesn't match models.
+var ErrSchemaDrift = errors.Sentinel("database schema drift detected")
+
+// Report is the schema check report.
+type Report struct {
+	Issues []*Issue `json:"issues"`
+	Valid  bool     `json:"valid"`
+}
+
+// Issue is a schema drift.
+type Issue struct {
+	Type     IssueType `json:"type"`
+	Table    string    `json:"table"`
+	Column   string    `json:"column,omitempty"`
+	Index    string    `json:"index,omitempty"`
+	Expected string    `json:"expected,omitempty"`
+	Actual   string    `json:"actual,omitempty"`
+}
+
+func (i *Issue) String() string {
+	// Build target
+	target := i.Table
+	if i.Column != "" {
+		target += "." + i.Column
+	} else if i.Index != "" {
+		target += "." + i.Index
+	}
+
+	// Check if values are present
+	if i.Expected == "" && i.Actual == "" {
+		return fmt.Sprintf("%s %s", i.Type, target)
+	}
+
+	return fmt.Sprintf("%s %s (expected %q, actual %q)", i.Type, target, i.Expected, i.Actual)
+}
+
+// Service will represent the service that will check database schema.
+type Service interface {
+	// Check will compare database schema with models and return the report.
+	Check(ctx context.Context) (*Report, error)
+	// CheckDrift will return an ErrSchemaDrift error listing issues when schema doesn't match models.
+	CheckDrift(ctx context.Context) error
+}
+
+func New(dbSvc database.DB) Service {
+	return &service{
+		dbSvc:  dbSvc,
+		models: registeredModels,
+	}
+}
@@ -0,0 +1,13 @@
+package schemacheck
+
+import (
+	auditmodels "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/audits/models"
+	todomodels "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/todos/models"
+)
+
+// Models checked against database schema.
+// New business models must be added here.
+var registeredModels = []any{
+	&todomodels.Todo{},
+	&auditmodels.AuditEvent{},
+}
@@ -0,0 +1,265 @@
+package schemacheck
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"emperror.dev/errors"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
+)
+
+const (
+	notNullValue = "NOT NULL"
+	nullValue    = "NULL"
+	uniqueValue  = "UNIQUE"
+)
+
+var dataTypeSizeRegexp = regexp.MustCompile(`\((\d+)\)`)
+
+type service struct {
+	dbSvc  database.DB
+	models []any
+}
+
+func (s *service) Check(ctx context.Context) (*Report, error) {
+	// Get gorm database
+	db := s.dbSvc.GetGormDB().WithContext(ctx)
+
+	issues := []*Issue{}
+	cache := &sync.Map{}
+	// Loop over models
+	for _, model := range s.models {
+		// Parse schema
+		sch, err := schema.Parse(model, cache, db.NamingStrategy)
+		// Check error
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		// Check model
+		res, err := checkModel(db, model, sch)
+		// Check error
+		if err != nil {
+			return nil, err
+		}
+
+		issues = append(issues, res...)
+	}
+
+	return &Report{Issues: issues, Valid: len(issues) == 0}, nil
+}
+
+func (s *service) CheckDrift(ctx context.Context) error {
+	// Check
+	report, err := s.Check(ctx)
+	// Check error
+	if err != nil {
+		return err
+	}
+
+	// Check if schema is valid
+	if report.Valid {
+		return nil
+	}
+
+	return errors.Wrapf(
+		ErrSchemaDrift,
+		"%s",
+		strings.Join(lo.Map(report.Issues, func(i *Issue, _ int) string { return i.String() }), ", "),
+	)
+}
+
+func checkModel(db *gorm.DB, model any, sch *schema.Schema) ([]*Issue, error) {
+	// Get migrator
+	m := db.Migrator()
+
+	// Check table
+	if !m.HasTable(model) {
+		return []*Issue{{Type: MissingTableIssue, Table: sch.Table}}, nil
+	}
+
+	// Get columns
+	columnTypes, err := m.ColumnTypes(model)
+	// Check error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	// Get indexes
+	indexes, err := m.GetIndexes(model)
+	// Check error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	issues := checkColumns(db, sch, columnTypes)
+	issues = append(issues, checkIndexes(sch, indexes)...)
+
+	return issues, nil
+}
+
+func checkColumns(db *gorm.DB, sch *schema.Schema, columnTypes []gorm.ColumnType) []*Issue {
+	// Index columns by name
+	columns := lo.SliceToMap(columnTypes, func(ct gorm.ColumnType) (string, gorm.ColumnType) {
+		return strings.ToLower(ct.Name()), ct
+	})
+
+	issues := []*Issue{}
+	// Loop over model columns
+	for _, dbName := range sch.DBNames {
+		// Get field
+		field := sch.FieldsByDBName[dbName]
+		// Ignore fields not managed by migrations
+		if field.IgnoreMigration {
+			continue
+		}
+
+		// Get column
+		ct, ok := columns[strings.ToLower(dbName)]
+		// Check if it exists
+		if !ok {
+			issues = append(issues, &Issue{Type: MissingColumnIssue, Table: sch.Table, Column: dbName})
+
+			continue
+		}
+
+		// Check type
+		expected := strings.ToLower(db.Dialector.DataTypeOf(field))
+		if !isSameDataType(db, expected, ct) {
+			issues = append(issues, &Issue{
+				Type:     ColumnTypeMismatchIssue,
+				Table:    sch.Table,
+				Column:   dbName,
+				Expected: expected,
+				Actual:   getDataType(ct),
+			})
+		}
+
+		// Check nullability, primary keys nullability depends on driver
+		nullable, ok := ct.Nullable()
+		if ok && !field.PrimaryKey && nullable == field.NotNull {
+			issues = append(issues, &Issue{
+				Type:     ColumnNullabilityMismatchIssue,
+				Table:    sch.Table,
+				Column:   dbName,
+				Expected: getNullability(!field.NotNull),
+				Actual:   getNullability(nullable),
+			})
+		}
+	}
+
+	// Loop over database columns
+	for _, ct := range columnTypes {
+		// Check if column is declared in model
+		if !slices.ContainsFunc(sch.DBNames, func(n string) bool { return strings.EqualFold(n, ct.Name()) }) {
+			issues = append(issues, &Issue{Type: UnexpectedColumnIssue, Table: sch.Table, Column: ct.Name()})
+		}
+	}
+
+	return issues
+}
+
+// isSameDataType will compare types like gorm auto migration.
+func isSameDataType(db *gorm.DB, expected string, ct gorm.ColumnType) bool {
+	// Get database type
+	actual := strings.ToLower(ct.DatabaseTypeName())
+
+	// Check type or type aliases
+	if !strings.HasPrefix(expected, actual) &&
+		!slices.ContainsFunc(db.Migrator().GetTypeAliases(actual), func(a string) bool { return strings.HasPrefix(expected, a) }) {
+		return false
+	}
+
+	// Get expected size
+	matches := dataTypeSizeRegexp.FindStringSubmatch(expected)
+	// Get database size
+	length, ok := ct.Length()
+
+	return matches == nil || !ok || length <= 0 || matches[1] == strconv.FormatInt(length, 10)
+}
+
+func getDataType(ct gorm.ColumnType) string {
+	// Get database type
+	res := strings.ToLower(ct.DatabaseTypeName())
+	// Add length if present
+	if length, ok := ct.Length(); ok && length > 0 && !strings.Contains(res, "(") {
+		res += "(" + strconv.FormatInt(length, 10) + ")"
+	}
+
+	return res
+}
+
+func getNullability(nullable bool) string {
+	if nullable {
+		return nullValue
+	}
+
+	return notNullValue
+}
+
+func checkIndexes(sch *schema.Schema, indexes []gorm.Index) []*Issue {
+	// Index database indexes by name
+	actualIndexes := lo.SliceToMap(indexes, func(i gorm.Index) (string, gorm.Index) { return i.Name(), i })
+
+	issues := []*Issue{}
+	// Loop over model indexes
+	for _, idx := range sch.ParseIndexes() {
+		// Get database index
+		ai, ok := actualIndexes[idx.Name]
+		// Check if it exists
+		if !ok {
+			issues = append(issues, &Issue{Type: MissingIndexIssue, Table: sch.Table, Index: idx.Name})
+
+			continue
+		}
+
+		// Check columns
+		expectedColumns := lo.Map(idx.Fields, func(o schema.IndexOption, _ int) string {
+			// Check if it is an expression index
+			if o.Expression != "" {
+				return o.Expression
+			}
+
+			return o.DBName
+		})
+		if !slices.Equal(expectedColumns, ai.Columns()) {
+			issues = append(issues, &Issue{
+				Type:     IndexMismatchIssue,
+				Table:    sch.Table,
+				Index:    idx.Name,
+				Expected: strings.Join(expectedColumns, ","),
+				Actual:   strings.Join(ai.Columns(), ","),
+			})
+		}
+
+		// Check uniqueness
+		unique, ok := ai.Unique()
+		if ok && unique != (idx.Class == uniqueValue) {
+			issues = append(issues, &Issue{
+				Type:     IndexMismatchIssue,
+				Table:    sch.Table,
+				Index:    idx.Name,
+				Expected: getUniqueness(idx.Class == uniqueValue),
+				Actual:   getUniqueness(unique),
+			})
+		}
+	}
+
+	return issues
+}
+
+func getUniqueness(unique bool) string {
+	if unique {
+		return uniqueValue
+	}
+
+	return "NOT " + uniqueValue
+}
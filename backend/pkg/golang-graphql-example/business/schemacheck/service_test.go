@@ -0,0 +1,107 @@
+//go:build unit
+
+package schemacheck
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/migration/sequences"
+	dbmocks "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/mocks"
+)
+
+type schemaCheckTestModel struct {
+	ID    string `gorm:"primaryKey"`
+	Name  string `gorm:"type:varchar(100);not null;uniqueIndex"`
+	Label string `gorm:"index:idx_value_label,priority:2"`
+	Note  string
+	Value int `gorm:"index:idx_value_label,priority:1"`
+}
+
+type schemaCheckMissingModel struct {
+	ID string `gorm:"primaryKey"`
+}
+
+func setupTestService(t *testing.T, models []any) (*service, *gorm.DB) {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "schemacheck.db")), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+
+	dbSvc := dbmocks.NewMockDB(ctrl)
+	dbSvc.EXPECT().GetGormDB().AnyTimes().Return(db)
+
+	return &service{dbSvc: dbSvc, models: models}, db
+}
+
+func TestService_Check_Migrations(t *testing.T) {
+	s, db := setupTestService(t, registeredModels)
+
+	// Migrations must create the schema expected by models
+	require.NoError(t, gormigrate.New(db, &gormigrate.Options{UseTransaction: true}, sequences.GetAll()).Migrate())
+
+	report, err := s.Check(context.TODO())
+	require.NoError(t, err)
+	assert.Empty(t, report.Issues)
+	assert.True(t, report.Valid)
+
+	require.NoError(t, s.CheckDrift(context.TODO()))
+}
+
+func TestService_Check_Drift(t *testing.T) {
+	s, db := setupTestService(t, []any{&schemaCheckTestModel{}, &schemaCheckMissingModel{}})
+
+	// Valid schema
+	require.NoError(t, db.AutoMigrate(&schemaCheckTestModel{}))
+
+	report, err := s.Check(context.TODO())
+	require.NoError(t, err)
+	assert.False(t, report.Valid)
+	assert.Equal(t, []*Issue{{Type: MissingTableIssue, Table: "schema_check_missing_models"}}, report.Issues)
+
+	// Drifted schema
+	require.NoError(t, db.Migrator().DropTable(&schemaCheckTestModel{}))
+	require.NoError(t, db.Exec(
+		"CREATE TABLE schema_check_test_models (id text, name varchar(50), label text, value integer NOT NULL, extra text, PRIMARY KEY (id))",
+	).Error)
+	require.NoError(t, db.Exec("CREATE INDEX idx_schema_check_test_models_name ON schema_check_test_models (name)").Error)
+	require.NoError(t, db.Exec("CREATE INDEX idx_value_label ON schema_check_test_models (label, value)").Error)
+
+	report, err = s.Check(context.TODO())
+	require.NoError(t, err)
+	assert.False(t, report.Valid)
+	assert.Equal(t, []*Issue{
+		{Type: ColumnTypeMismatchIssue, Table: "schema_check_test_models", Column: "name", Expected: "varchar(100)", Actual: "varchar(50)"},
+		{Type: ColumnNullabilityMismatchIssue, Table: "schema_check_test_models", Column: "name", Expected: "NOT NULL", Actual: "NULL"},
+		{Type: MissingColumnIssue, Table: "schema_check_test_models", Column: "note"},
+		{Type: ColumnNullabilityMismatchIssue, Table: "schema_check_test_models", Column: "value", Expected: "NULL", Actual: "NOT NULL"},
+		{Type: UnexpectedColumnIssue, Table: "schema_check_test_models", Column: "extra"},
+		{Type: IndexMismatchIssue, Table: "schema_check_test_models", Index: "idx_schema_check_test_models_name", Expected: "UNIQUE", Actual: "NOT UNIQUE"},
+		{Type: IndexMismatchIssue, Table: "schema_check_test_models", Index: "idx_value_label", Expected: "value,label", Actual: "label,value"},
+		{Type: MissingTableIssue, Table: "schema_check_missing_models"},
+	}, report.Issues)
+
+	// Drift error
+	err = s.CheckDrift(context.TODO())
+	require.ErrorIs(t, err, ErrSchemaDrift)
+	assert.Contains(t, err.Error(), `column_type_mismatch schema_check_test_models.name (expected "varchar(100)", actual "varchar(50)")`)
+	assert.Contains(t, err.Error(), "missing_table schema_check_missing_models")
+
+	// Missing index
+	require.NoError(t, db.Exec("DROP INDEX idx_value_label").Error)
+
+	report, err = s.Check(context.TODO())
+	require.NoError(t, err)
+	assert.Contains(t, report.Issues, &Issue{Type: MissingIndexIssue, Table: "schema_check_test_models", Index: "idx_value_label"})
+}
@@ -2,34 +2,97 @@ package business
 
 import (
 	"context"
+	"io/fs"
 
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/authx/authorization"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/audits"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/fixtures"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/migration"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/schemacheck"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/todos"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
+	lockdistributor "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/lockdistributor/sql"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/log"
 )
 
 type Services struct {
 	db           database.DB
+	ldSvc        lockdistributor.Service
 	systemLogger log.Logger
 	TodoSvc      todos.Service
+	AuditSvc     audits.Service
 }
 
 func (s *Services) MigrateDB(ctx context.Context) error {
 	// Create migration service
-	migrationSvc := migration.New(s.db)
+	migrationSvc := migration.New(s.db, s.ldSvc)
 
 	return migrationSvc.Migrate(ctx)
 }
 
-func NewServices(systemLogger log.Logger, db database.DB, authSvc authorization.Service) *Services {
+// RunDBMigration will apply or roll back database migrations depending on input mode.
+func (s *Services) RunDBMigration(ctx context.Context, input *migration.RunInput) ([]string, error) {
+	// Create migration service
+	migrationSvc := migration.New(s.db, s.ldSvc)
+
+	return migrationSvc.Run(ctx, input)
+}
+
+// GetDBMigrationStatus will return the status of all database migrations.
+func (s *Services) GetDBMigrationStatus(ctx context.Context) ([]*migration.Status, error) {
+	// Create migration service
+	migrationSvc := migration.New(s.db, s.ldSvc)
+
+	return migrationSvc.Status(ctx)
+}
+
+// CheckDBSchema will compare database schema with business models and return the report.
+func (s *Services) CheckDBSchema(ctx context.Context) (*schemacheck.Report, error) {
+	// Create schema check service
+	schemaCheckSvc := schemacheck.New(s.db)
+
+	return schemaCheckSvc.Check(ctx)
+}
+
+// SeedDB will load the embedded fixture set of an environment in database.
+func (s *Services) SeedDB(ctx context.Context, environment string) (*fixtures.Result, error) {
+	// Create fixtures service
+	fixturesSvc := fixtures.New(s.db)
+
+	return fixturesSvc.Seed(ctx, environment)
+}
+
+// LoadDBFixtures will load the fixture set stored in a folder of a file system in database.
+// This is useful to prepare datasets in tests.
+func (s *Services) LoadDBFixtures(ctx context.Context, fsys fs.FS, dir string) (*fixtures.Result, error) {
+	// Create fixtures service
+	fixturesSvc := fixtures.New(s.db)
+
+	return fixturesSvc.Load(ctx, fsys, dir)
+}
+
+func NewServices(
+	systemLogger log.Logger,
+	db database.DB,
+	authSvc authorization.Service,
+	ldSvc lockdistributor.Service,
+) *Services {
 	// Create todos service
 	todoSvc := todos.NewService(db, authSvc)
+	// Create audits service
+	auditSvc := audits.NewService(db, authSvc, []*audits.AuditedEntity{
+		{
+			Type:      todos.TodoIDPrefix,
+			ModelName: todos.TodoModelName,
+			CheckRead: todoSvc.CheckReadable,
+		},
+	})
 
 	return &Services{
 		db:           db,
+		ldSvc:        ldSvc,
 		systemLogger: systemLogger,
 		TodoSvc:      todoSvc,
+		AuditSvc:     auditSvc,
 	}
 }
@@ -5,8 +5,10 @@ import (
 	"context"
 	models0 "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/todos/models"
 	database "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
+	common "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/common"
 	helpers "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/helpers"
 	pagination "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/pagination"
+	"iter"
 )
 
 /* Interface */
@@ -18,12 +20,19 @@ type TodoStructureDao interface {
 	FindTodoWithPagination(ctx context.Context, page *pagination.PageInput, sorts []*models0.SortOrder, filter *models0.Filter, projection *models0.Projection, opts ...helpers.GormOpt) ([]*models0.Todo, error)
 	FindTodoPaginated(ctx context.Context, page *pagination.PageInput, sorts []*models0.SortOrder, filter *models0.Filter, projection *models0.Projection, opts ...database.TransactionOption) ([]*models0.Todo, *pagination.PageOutput, error)
 	FindAllTodo(ctx context.Context, sorts []*models0.SortOrder, filter *models0.Filter, projection *models0.Projection, opts ...helpers.GormOpt) ([]*models0.Todo, error)
+	IterateTodo(ctx context.Context, batchSize int, sorts []*models0.SortOrder, filter *models0.Filter, projection *models0.Projection, opts ...database.TransactionOption) iter.Seq2[*models0.Todo, error]
 	CountTodoPaginated(ctx context.Context, page *pagination.PageInput, filter *models0.Filter, opts ...helpers.GormOpt) (int64, error)
 	CountTodo(ctx context.Context, filter *models0.Filter, opts ...helpers.GormOpt) (int64, error)
+	AggregateTodo(ctx context.Context, filter *models0.Filter, groupBy *models0.GroupBy, metrics *common.AggregationMetrics, opts ...helpers.GormOpt) ([]*common.AggregationBucket, error)
+	DistinctTodo(ctx context.Context, page *pagination.PageInput, field string, filter *models0.Filter, search string, opts ...helpers.GormOpt) ([]*common.FieldValue, *pagination.PageOutput, error)
 	CreateOrUpdateTodo(ctx context.Context, input *models0.Todo, opts ...helpers.GormOpt) (*models0.Todo, error)
+	BulkCreateTodo(ctx context.Context, input []*models0.Todo, batchSize int, opts ...helpers.GormOpt) ([]*models0.Todo, error)
+	UpsertTodo(ctx context.Context, input []*models0.Todo, batchSize int, conflictColumns []string, updateColumns []string, opts ...helpers.GormOpt) ([]*models0.Todo, error)
 	PermanentDeleteTodo(ctx context.Context, input *models0.Todo, opts ...helpers.GormOpt) (*models0.Todo, error)
 	PermanentDeleteTodoByID(ctx context.Context, id string, opts ...helpers.GormOpt) (*models0.Todo, error)
 	PermanentDeleteTodoFiltered(ctx context.Context, filter *models0.Filter, opts ...helpers.GormOpt) error
+	SoftDeleteTodoByID(ctx context.Context, id string, opts ...helpers.GormOpt) (*models0.Todo, error)
+	RestoreTodoByID(ctx context.Context, id string, opts ...helpers.GormOpt) (*models0.Todo, error)
 	PatchUpdateTodo(ctx context.Context, input *models0.Todo, patch map[string]any, opts ...helpers.GormOpt) (*models0.Todo, error)
 	PatchUpdateTodoByID(ctx context.Context, id string, patch map[string]any, opts ...helpers.GormOpt) (*models0.Todo, error)
 	PatchUpdateTodoFiltered(ctx context.Context, filter *models0.Filter, patch map[string]any, opts ...helpers.GormOpt) error
@@ -70,6 +79,10 @@ func (d *dao) FindAllTodo(ctx context.Context, sorts []*models0.SortOrder, filte
 	return helpers.Find(ctx, []*models0.Todo{}, d.db, sorts, filter, projection, opts...)
 }
 
+func (d *dao) IterateTodo(ctx context.Context, batchSize int, sorts []*models0.SortOrder, filter *models0.Filter, projection *models0.Projection, opts ...database.TransactionOption) iter.Seq2[*models0.Todo, error] {
+	return helpers.Iterate[*models0.Todo](ctx, d.db, batchSize, sorts, filter, projection, opts...)
+}
+
 func (d *dao) CountTodoPaginated(ctx context.Context, page *pagination.PageInput, filter *models0.Filter, opts ...helpers.GormOpt) (int64, error) {
 	return helpers.CountPaginated(ctx, d.db, &models0.Todo{}, page, filter, opts...)
 }
@@ -78,10 +91,26 @@ func (d *dao) CountTodo(ctx context.Context, filter *models0.Filter, opts ...hel
 	return helpers.Count(ctx, d.db, &models0.Todo{}, filter, opts...)
 }
 
+func (d *dao) AggregateTodo(ctx context.Context, filter *models0.Filter, groupBy *models0.GroupBy, metrics *common.AggregationMetrics, opts ...helpers.GormOpt) ([]*common.AggregationBucket, error) {
+	return helpers.Aggregate(ctx, d.db, &models0.Todo{}, filter, groupBy, metrics, opts...)
+}
+
+func (d *dao) DistinctTodo(ctx context.Context, page *pagination.PageInput, field string, filter *models0.Filter, search string, opts ...helpers.GormOpt) ([]*common.FieldValue, *pagination.PageOutput, error) {
+	return helpers.Distinct(ctx, d.db, &models0.Todo{}, page, field, filter, search, opts...)
+}
+
 func (d *dao) CreateOrUpdateTodo(ctx context.Context, input *models0.Todo, opts ...helpers.GormOpt) (*models0.Todo, error) {
 	return helpers.CreateOrUpdate(ctx, input, d.db, opts...)
 }
 
+func (d *dao) BulkCreateTodo(ctx context.Context, input []*models0.Todo, batchSize int, opts ...helpers.GormOpt) ([]*models0.Todo, error) {
+	return helpers.CreateInBatches(ctx, input, batchSize, d.db, opts...)
+}
+
+func (d *dao) UpsertTodo(ctx context.Context, input []*models0.Todo, batchSize int, conflictColumns []string, updateColumns []string, opts ...helpers.GormOpt) ([]*models0.Todo, error) {
+	return helpers.Upsert(ctx, input, batchSize, conflictColumns, updateColumns, d.db, opts...)
+}
+
 func (d *dao) PermanentDeleteTodo(ctx context.Context, input *models0.Todo, opts ...helpers.GormOpt) (*models0.Todo, error) {
 	return helpers.PermanentDelete(ctx, input, d.db, opts...)
 }
@@ -93,6 +122,20 @@ func (d *dao) PermanentDeleteTodoByID(ctx context.Context, id string, opts ...he
 	return helpers.PermanentDelete(ctx, input, d.db, opts...)
 }
 
+func (d *dao) SoftDeleteTodoByID(ctx context.Context, id string, opts ...helpers.GormOpt) (*models0.Todo, error) {
+	input := &models0.Todo{}
+	input.ID = id
+
+	return helpers.SoftDelete(ctx, input, d.db, opts...)
+}
+
+func (d *dao) RestoreTodoByID(ctx context.Context, id string, opts ...helpers.GormOpt) (*models0.Todo, error) {
+	input := &models0.Todo{}
+	input.ID = id
+
+	return helpers.Restore(ctx, input, d.db, opts...)
+}
+
 func (d *dao) PermanentDeleteTodoFiltered(ctx context.Context, filter *models0.Filter, opts ...helpers.GormOpt) error {
 	return helpers.PermanentDeleteFiltered(ctx, &models0.Todo{}, filter, d.db, opts...)
 }
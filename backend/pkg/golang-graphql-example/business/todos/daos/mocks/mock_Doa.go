@@ -11,10 +11,12 @@ package mocks
 
 import (
 	context "context"
+	iter "iter"
 	reflect "reflect"
 
 	models "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/todos/models"
 	database "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
+	common "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/common"
 	databasehelpers "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/helpers"
 	pagination "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/pagination"
 	gomock "go.uber.org/mock/gomock"
@@ -44,6 +46,46 @@ func (m *MockDao) EXPECT() *MockDaoMockRecorder {
 	return m.recorder
 }
 
+// AggregateTodo mocks base method.
+func (m *MockDao) AggregateTodo(ctx context.Context, filter *models.Filter, groupBy *models.GroupBy, metrics *common.AggregationMetrics, opts ...databasehelpers.GormOpt) ([]*common.AggregationBucket, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, filter, groupBy, metrics}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AggregateTodo", varargs...)
+	ret0, _ := ret[0].([]*common.AggregationBucket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AggregateTodo indicates an expected call of AggregateTodo.
+func (mr *MockDaoMockRecorder) AggregateTodo(ctx, filter, groupBy, metrics any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, filter, groupBy, metrics}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AggregateTodo", reflect.TypeOf((*MockDao)(nil).AggregateTodo), varargs...)
+}
+
+// BulkCreateTodo mocks base method.
+func (m *MockDao) BulkCreateTodo(ctx context.Context, input []*models.Todo, batchSize int, opts ...databasehelpers.GormOpt) ([]*models.Todo, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, input, batchSize}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BulkCreateTodo", varargs...)
+	ret0, _ := ret[0].([]*models.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkCreateTodo indicates an expected call of BulkCreateTodo.
+func (mr *MockDaoMockRecorder) BulkCreateTodo(ctx, input, batchSize any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, input, batchSize}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkCreateTodo", reflect.TypeOf((*MockDao)(nil).BulkCreateTodo), varargs...)
+}
+
 // CountTodo mocks base method.
 func (m *MockDao) CountTodo(ctx context.Context, filter *models.Filter, opts ...databasehelpers.GormOpt) (int64, error) {
 	m.ctrl.T.Helper()
@@ -104,6 +146,27 @@ func (mr *MockDaoMockRecorder) CreateOrUpdateTodo(ctx, input any, opts ...any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdateTodo", reflect.TypeOf((*MockDao)(nil).CreateOrUpdateTodo), varargs...)
 }
 
+// DistinctTodo mocks base method.
+func (m *MockDao) DistinctTodo(ctx context.Context, page *pagination.PageInput, field string, filter *models.Filter, search string, opts ...databasehelpers.GormOpt) ([]*common.FieldValue, *pagination.PageOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, page, field, filter, search}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistinctTodo", varargs...)
+	ret0, _ := ret[0].([]*common.FieldValue)
+	ret1, _ := ret[1].(*pagination.PageOutput)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DistinctTodo indicates an expected call of DistinctTodo.
+func (mr *MockDaoMockRecorder) DistinctTodo(ctx, page, field, filter, search any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, page, field, filter, search}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistinctTodo", reflect.TypeOf((*MockDao)(nil).DistinctTodo), varargs...)
+}
+
 // FindAllTodo mocks base method.
 func (m *MockDao) FindAllTodo(ctx context.Context, sorts []*models.SortOrder, filter *models.Filter, projection *models.Projection, opts ...databasehelpers.GormOpt) ([]*models.Todo, error) {
 	m.ctrl.T.Helper()
@@ -205,6 +268,25 @@ func (mr *MockDaoMockRecorder) FindTodoWithPagination(ctx, page, sorts, filter,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTodoWithPagination", reflect.TypeOf((*MockDao)(nil).FindTodoWithPagination), varargs...)
 }
 
+// IterateTodo mocks base method.
+func (m *MockDao) IterateTodo(ctx context.Context, batchSize int, sorts []*models.SortOrder, filter *models.Filter, projection *models.Projection, opts ...database.TransactionOption) iter.Seq2[*models.Todo, error] {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, batchSize, sorts, filter, projection}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "IterateTodo", varargs...)
+	ret0, _ := ret[0].(iter.Seq2[*models.Todo, error])
+	return ret0
+}
+
+// IterateTodo indicates an expected call of IterateTodo.
+func (mr *MockDaoMockRecorder) IterateTodo(ctx, batchSize, sorts, filter, projection any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, batchSize, sorts, filter, projection}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IterateTodo", reflect.TypeOf((*MockDao)(nil).IterateTodo), varargs...)
+}
+
 // PatchUpdateTodo mocks base method.
 func (m *MockDao) PatchUpdateTodo(ctx context.Context, input *models.Todo, patch map[string]any, opts ...databasehelpers.GormOpt) (*models.Todo, error) {
 	m.ctrl.T.Helper()
@@ -322,3 +404,63 @@ func (mr *MockDaoMockRecorder) PermanentDeleteTodoFiltered(ctx, filter any, opts
 	varargs := append([]any{ctx, filter}, opts...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PermanentDeleteTodoFiltered", reflect.TypeOf((*MockDao)(nil).PermanentDeleteTodoFiltered), varargs...)
 }
+
+// RestoreTodoByID mocks base method.
+func (m *MockDao) RestoreTodoByID(ctx context.Context, id string, opts ...databasehelpers.GormOpt) (*models.Todo, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, id}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RestoreTodoByID", varargs...)
+	ret0, _ := ret[0].(*models.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreTodoByID indicates an expected call of RestoreTodoByID.
+func (mr *MockDaoMockRecorder) RestoreTodoByID(ctx, id any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, id}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTodoByID", reflect.TypeOf((*MockDao)(nil).RestoreTodoByID), varargs...)
+}
+
+// SoftDeleteTodoByID mocks base method.
+func (m *MockDao) SoftDeleteTodoByID(ctx context.Context, id string, opts ...databasehelpers.GormOpt) (*models.Todo, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, id}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SoftDeleteTodoByID", varargs...)
+	ret0, _ := ret[0].(*models.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SoftDeleteTodoByID indicates an expected call of SoftDeleteTodoByID.
+func (mr *MockDaoMockRecorder) SoftDeleteTodoByID(ctx, id any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, id}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDeleteTodoByID", reflect.TypeOf((*MockDao)(nil).SoftDeleteTodoByID), varargs...)
+}
+
+// UpsertTodo mocks base method.
+func (m *MockDao) UpsertTodo(ctx context.Context, input []*models.Todo, batchSize int, conflictColumns, updateColumns []string, opts ...databasehelpers.GormOpt) ([]*models.Todo, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, input, batchSize, conflictColumns, updateColumns}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpsertTodo", varargs...)
+	ret0, _ := ret[0].([]*models.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertTodo indicates an expected call of UpsertTodo.
+func (mr *MockDaoMockRecorder) UpsertTodo(ctx, input, batchSize, conflictColumns, updateColumns any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, input, batchSize, conflictColumns, updateColumns}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertTodo", reflect.TypeOf((*MockDao)(nil).UpsertTodo), varargs...)
+}
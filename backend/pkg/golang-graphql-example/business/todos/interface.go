@@ -6,11 +6,15 @@ import (
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/todos/daos"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/todos/models"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/common"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/pagination"
 )
 
 const TodoIDPrefix = "todos"
 
+// TodoModelName is the todo model name saved in audit events.
+const TodoModelName = "Todo"
+
 //go:generate mockgen -destination=./mocks/mock_AuthorizationService.go -package=mocks github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/todos AuthorizationService
 type AuthorizationService interface {
 	CheckAuthorized(ctx context.Context, action, resource string) error
@@ -31,10 +35,28 @@ type Service interface {
 		filter *models.Filter,
 		projection *models.Projection,
 	) ([]*models.Todo, *pagination.PageOutput, error)
+	Aggregate(
+		ctx context.Context,
+		filter *models.Filter,
+		groupBy *models.GroupBy,
+	) ([]*common.AggregationBucket, error)
+	// FieldValues will return distinct values of a field for todos matching filter.
+	// Search is a case insensitive prefix only supported on text field.
+	FieldValues(
+		ctx context.Context,
+		page *pagination.PageInput,
+		field models.Field,
+		filter *models.Filter,
+		search string,
+	) ([]*common.FieldValue, *pagination.PageOutput, error)
 	FindByID(ctx context.Context, id string, projection *models.Projection) (*models.Todo, error)
+	// CheckReadable will return an error if todo doesn't exist or cannot be read by user in context.
+	CheckReadable(ctx context.Context, id string) error
 	Create(ctx context.Context, inp *InputCreateTodo) (*models.Todo, error)
 	Update(ctx context.Context, inp *InputUpdateTodo) (*models.Todo, error)
 	Close(ctx context.Context, id string, projection *models.Projection) (*models.Todo, error)
+	Delete(ctx context.Context, id string, projection *models.Projection) (*models.Todo, error)
+	Restore(ctx context.Context, id string, projection *models.Projection) (*models.Todo, error)
 }
 
 type InputCreateTodo struct {
@@ -42,8 +64,10 @@ type InputCreateTodo struct {
 }
 
 type InputUpdateTodo struct {
-	ID   string
-	Text string
+	// Expected version, current one is used when nil
+	Version *int
+	ID      string
+	Text    string
 }
 
 func NewService(db database.DB, authSvc AuthorizationService) Service {
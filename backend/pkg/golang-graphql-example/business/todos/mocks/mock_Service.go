@@ -15,6 +15,7 @@ import (
 
 	todos "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/todos"
 	models "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/todos/models"
+	common "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/common"
 	pagination "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/pagination"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -43,6 +44,35 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 	return m.recorder
 }
 
+// Aggregate mocks base method.
+func (m *MockService) Aggregate(ctx context.Context, filter *models.Filter, groupBy *models.GroupBy) ([]*common.AggregationBucket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Aggregate", ctx, filter, groupBy)
+	ret0, _ := ret[0].([]*common.AggregationBucket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Aggregate indicates an expected call of Aggregate.
+func (mr *MockServiceMockRecorder) Aggregate(ctx, filter, groupBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Aggregate", reflect.TypeOf((*MockService)(nil).Aggregate), ctx, filter, groupBy)
+}
+
+// CheckReadable mocks base method.
+func (m *MockService) CheckReadable(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckReadable", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckReadable indicates an expected call of CheckReadable.
+func (mr *MockServiceMockRecorder) CheckReadable(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckReadable", reflect.TypeOf((*MockService)(nil).CheckReadable), ctx, id)
+}
+
 // Close mocks base method.
 func (m *MockService) Close(ctx context.Context, id string, projection *models.Projection) (*models.Todo, error) {
 	m.ctrl.T.Helper()
@@ -73,6 +103,37 @@ func (mr *MockServiceMockRecorder) Create(ctx, inp any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockService)(nil).Create), ctx, inp)
 }
 
+// Delete mocks base method.
+func (m *MockService) Delete(ctx context.Context, id string, projection *models.Projection) (*models.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, projection)
+	ret0, _ := ret[0].(*models.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockServiceMockRecorder) Delete(ctx, id, projection any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockService)(nil).Delete), ctx, id, projection)
+}
+
+// FieldValues mocks base method.
+func (m *MockService) FieldValues(ctx context.Context, page *pagination.PageInput, field models.Field, filter *models.Filter, search string) ([]*common.FieldValue, *pagination.PageOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FieldValues", ctx, page, field, filter, search)
+	ret0, _ := ret[0].([]*common.FieldValue)
+	ret1, _ := ret[1].(*pagination.PageOutput)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FieldValues indicates an expected call of FieldValues.
+func (mr *MockServiceMockRecorder) FieldValues(ctx, page, field, filter, search any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FieldValues", reflect.TypeOf((*MockService)(nil).FieldValues), ctx, page, field, filter, search)
+}
+
 // Find mocks base method.
 func (m *MockService) Find(ctx context.Context, sort []*models.SortOrder, filter *models.Filter, projection *models.Projection) ([]*models.Todo, error) {
 	m.ctrl.T.Helper()
@@ -119,6 +180,21 @@ func (mr *MockServiceMockRecorder) GetAllPaginated(ctx, page, sort, filter, proj
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllPaginated", reflect.TypeOf((*MockService)(nil).GetAllPaginated), ctx, page, sort, filter, projection)
 }
 
+// Restore mocks base method.
+func (m *MockService) Restore(ctx context.Context, id string, projection *models.Projection) (*models.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id, projection)
+	ret0, _ := ret[0].(*models.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockServiceMockRecorder) Restore(ctx, id, projection any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockService)(nil).Restore), ctx, id, projection)
+}
+
 // Update mocks base method.
 func (m *MockService) Update(ctx context.Context, inp *todos.InputUpdateTodo) (*models.Todo, error) {
 	m.ctrl.T.Helper()
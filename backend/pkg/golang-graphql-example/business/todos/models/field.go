@@ -0,0 +1,66 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"emperror.dev/errors"
+)
+
+// Field is a todo field on which distinct values can be listed.
+type Field string
+
+var (
+	FieldText Field = "TEXT"
+	FieldDone Field = "DONE"
+)
+
+var AllField = []Field{
+	FieldText,
+	FieldDone,
+}
+
+func (e Field) IsValid() bool {
+	switch e {
+	case FieldText, FieldDone:
+		return true
+	}
+
+	return false
+}
+
+func (e Field) String() string {
+	return string(e)
+}
+
+// DBField will return the database column of the field.
+// Result is empty when field isn't valid.
+func (e Field) DBField() string {
+	switch e {
+	case FieldText:
+		return TodoTextGormColumnName
+	case FieldDone:
+		return TodoDoneGormColumnName
+	}
+
+	return ""
+}
+
+func (e *Field) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return errors.New("enums must be strings")
+	}
+
+	*e = Field(str)
+	if !e.IsValid() {
+		return errors.Errorf("%s is not a valid TodoField", str)
+	}
+
+	return nil
+}
+
+func (e Field) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
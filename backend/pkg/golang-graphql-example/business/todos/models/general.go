@@ -2,11 +2,22 @@ package models
 
 import "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/common"
 
+// TextLengthSortExpressionName is the computed sort expression on text length.
+var TextLengthSortExpressionName = common.RegisterSortExpression("todo_text_length", &common.SortExpression{
+	SQL: "char_length(text)",
+	Dialects: map[string]string{
+		"sqlite": "length(text)",
+	},
+})
+
 type SortOrder struct {
-	CreatedAt *common.SortOrderEnum `dbfield:"created_at"`
-	UpdatedAt *common.SortOrderEnum `dbfield:"updated_at"`
-	Text      *common.SortOrderEnum `dbfield:"text"`
-	Done      *common.SortOrderEnum `dbfield:"done"`
+	CreatedAt           *common.SortOrderEnum `dbfield:"created_at"`
+	UpdatedAt           *common.SortOrderEnum `dbfield:"updated_at"`
+	Text                *common.SortOrderEnum `dbfield:"text"`
+	TextCaseInsensitive *common.SortOrderEnum `dbfield:"text;caseInsensitive"`
+	TextLength          *common.SortOrderEnum `dbfield:"todo_text_length;computed"`
+	Done                *common.SortOrderEnum `dbfield:"done"`
+	Relevance           *common.SortOrderEnum `dbfield:"text;relevance"`
 }
 
 type Filter struct {
@@ -20,9 +31,16 @@ type Filter struct {
 }
 
 type Projection struct {
-	ID        bool `dbfield:"id"         graphqlfield:"id"`
-	CreatedAt bool `dbfield:"created_at" graphqlfield:"createdAt"`
-	UpdatedAt bool `dbfield:"updated_at" graphqlfield:"updatedAt"`
-	Text      bool `dbfield:"text"       graphqlfield:"text"`
-	Done      bool `dbfield:"done"       graphqlfield:"done"`
+	ID        bool `dbfield:"id"                  graphqlfield:"id"`
+	CreatedAt bool `dbfield:"created_at"          graphqlfield:"createdAt"`
+	UpdatedAt bool `dbfield:"updated_at"          graphqlfield:"updatedAt"`
+	Text      bool `dbfield:"text"                graphqlfield:"text"`
+	Done      bool `dbfield:"done"                graphqlfield:"done"`
+	Version   bool `dbfield:"version;alwaysFetch" graphqlfield:"version"`
+}
+
+type GroupBy struct {
+	CreatedAt *common.DateTruncEnum `dbfield:"created_at"`
+	UpdatedAt *common.DateTruncEnum `dbfield:"updated_at"`
+	Done      *bool                 `dbfield:"done"`
 }
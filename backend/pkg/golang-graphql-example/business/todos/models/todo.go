@@ -4,7 +4,12 @@ import "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/
 
 //go:generate go run github.com/oxyno-zeta/golang-graphql-example/tools/generator/modeltagsgen github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/todos/models Todo
 type Todo struct {
-	database.Base
+	database.BaseWithTenantAndVersion
 	Text string `gorm:"type:varchar(2000)"`
 	Done bool
 }
+
+// Audited will enable audit trail on todos.
+func (*Todo) Audited() bool {
+	return true
+}
@@ -25,13 +25,19 @@ const TodoDoneGormColumnName = "done"
 // Todo ID Gorm Column Name
 const TodoIDGormColumnName = "id"
 
+// Todo TenantID Gorm Column Name
+const TodoTenantIDGormColumnName = "tenant_id"
+
 // Todo Text Gorm Column Name
 const TodoTextGormColumnName = "text"
 
 // Todo UpdatedAt Gorm Column Name
 const TodoUpdatedAtGormColumnName = "updated_at"
 
-var TodoGormColumnNameList = []string{TodoCreatedAtGormColumnName, TodoDeletedAtGormColumnName, TodoDoneGormColumnName, TodoIDGormColumnName, TodoTextGormColumnName, TodoUpdatedAtGormColumnName}
+// Todo Version Gorm Column Name
+const TodoVersionGormColumnName = "version"
+
+var TodoGormColumnNameList = []string{TodoCreatedAtGormColumnName, TodoDeletedAtGormColumnName, TodoDoneGormColumnName, TodoIDGormColumnName, TodoTenantIDGormColumnName, TodoTextGormColumnName, TodoUpdatedAtGormColumnName, TodoVersionGormColumnName}
 
 /* JSON Key Names */
 // Todo CreatedAt JSON Key Name
@@ -46,13 +52,19 @@ const TodoDoneJSONKeyName = "Done"
 // Todo ID JSON Key Name
 const TodoIDJSONKeyName = "id"
 
+// Todo TenantID JSON Key Name
+const TodoTenantIDJSONKeyName = "tenantId"
+
 // Todo Text JSON Key Name
 const TodoTextJSONKeyName = "Text"
 
 // Todo UpdatedAt JSON Key Name
 const TodoUpdatedAtJSONKeyName = "updatedAt"
 
-var TodoJSONKeyNameList = []string{TodoCreatedAtJSONKeyName, TodoDeletedAtJSONKeyName, TodoDoneJSONKeyName, TodoIDJSONKeyName, TodoTextJSONKeyName, TodoUpdatedAtJSONKeyName}
+// Todo Version JSON Key Name
+const TodoVersionJSONKeyName = "version"
+
+var TodoJSONKeyNameList = []string{TodoCreatedAtJSONKeyName, TodoDeletedAtJSONKeyName, TodoDoneJSONKeyName, TodoIDJSONKeyName, TodoTenantIDJSONKeyName, TodoTextJSONKeyName, TodoUpdatedAtJSONKeyName, TodoVersionJSONKeyName}
 
 /* Struct Key Names */
 // Todo CreatedAt Struct Key Name
@@ -67,13 +79,19 @@ const TodoDoneStructKeyName = "Done"
 // Todo ID Struct Key Name
 const TodoIDStructKeyName = "ID"
 
+// Todo TenantID Struct Key Name
+const TodoTenantIDStructKeyName = "TenantID"
+
 // Todo Text Struct Key Name
 const TodoTextStructKeyName = "Text"
 
 // Todo UpdatedAt Struct Key Name
 const TodoUpdatedAtStructKeyName = "UpdatedAt"
 
-var TodoStructKeyNameList = []string{TodoCreatedAtStructKeyName, TodoDeletedAtStructKeyName, TodoDoneStructKeyName, TodoIDStructKeyName, TodoTextStructKeyName, TodoUpdatedAtStructKeyName}
+// Todo Version Struct Key Name
+const TodoVersionStructKeyName = "Version"
+
+var TodoStructKeyNameList = []string{TodoCreatedAtStructKeyName, TodoDeletedAtStructKeyName, TodoDoneStructKeyName, TodoIDStructKeyName, TodoTenantIDStructKeyName, TodoTextStructKeyName, TodoUpdatedAtStructKeyName, TodoVersionStructKeyName}
 
 // Transform Todo Gorm Column To JSON Key
 func TransformTodoGormColumnToJSONKey(gormColumn string) (string, error) {
@@ -86,10 +104,14 @@ func TransformTodoGormColumnToJSONKey(gormColumn string) (string, error) {
 		return TodoDoneJSONKeyName, nil
 	case TodoIDGormColumnName:
 		return TodoIDJSONKeyName, nil
+	case TodoTenantIDGormColumnName:
+		return TodoTenantIDJSONKeyName, nil
 	case TodoTextGormColumnName:
 		return TodoTextJSONKeyName, nil
 	case TodoUpdatedAtGormColumnName:
 		return TodoUpdatedAtJSONKeyName, nil
+	case TodoVersionGormColumnName:
+		return TodoVersionJSONKeyName, nil
 	default:
 		return "", errors.WithStack(ErrTodoUnsupportedGormColumn)
 	}
@@ -106,10 +128,14 @@ func TransformTodoJSONKeyToGormColumn(jsonKey string) (string, error) {
 		return TodoDoneGormColumnName, nil
 	case TodoIDJSONKeyName:
 		return TodoIDGormColumnName, nil
+	case TodoTenantIDJSONKeyName:
+		return TodoTenantIDGormColumnName, nil
 	case TodoTextJSONKeyName:
 		return TodoTextGormColumnName, nil
 	case TodoUpdatedAtJSONKeyName:
 		return TodoUpdatedAtGormColumnName, nil
+	case TodoVersionJSONKeyName:
+		return TodoVersionGormColumnName, nil
 	default:
 		return "", errors.WithStack(ErrTodoUnsupportedJSONKey)
 	}
@@ -162,10 +188,14 @@ func TransformTodoGormColumnToStructKeyName(gormColumn string) (string, error) {
 		return TodoDoneStructKeyName, nil
 	case TodoIDGormColumnName:
 		return TodoIDStructKeyName, nil
+	case TodoTenantIDGormColumnName:
+		return TodoTenantIDStructKeyName, nil
 	case TodoTextGormColumnName:
 		return TodoTextStructKeyName, nil
 	case TodoUpdatedAtGormColumnName:
 		return TodoUpdatedAtStructKeyName, nil
+	case TodoVersionGormColumnName:
+		return TodoVersionStructKeyName, nil
 	default:
 		return "", errors.WithStack(ErrTodoUnsupportedGormColumn)
 	}
@@ -182,10 +212,14 @@ func TransformTodoStructKeyNameToGormColumn(structKey string) (string, error) {
 		return TodoDoneGormColumnName, nil
 	case TodoIDStructKeyName:
 		return TodoIDGormColumnName, nil
+	case TodoTenantIDStructKeyName:
+		return TodoTenantIDGormColumnName, nil
 	case TodoTextStructKeyName:
 		return TodoTextGormColumnName, nil
 	case TodoUpdatedAtStructKeyName:
 		return TodoUpdatedAtGormColumnName, nil
+	case TodoVersionStructKeyName:
+		return TodoVersionGormColumnName, nil
 	default:
 		return "", errors.WithStack(ErrTodoUnsupportedStructKeyName)
 	}
@@ -238,10 +272,14 @@ func TransformTodoJSONKeyToStructKeyName(jsonKey string) (string, error) {
 		return TodoDoneStructKeyName, nil
 	case TodoIDJSONKeyName:
 		return TodoIDStructKeyName, nil
+	case TodoTenantIDJSONKeyName:
+		return TodoTenantIDStructKeyName, nil
 	case TodoTextJSONKeyName:
 		return TodoTextStructKeyName, nil
 	case TodoUpdatedAtJSONKeyName:
 		return TodoUpdatedAtStructKeyName, nil
+	case TodoVersionJSONKeyName:
+		return TodoVersionStructKeyName, nil
 	default:
 		return "", errors.WithStack(ErrTodoUnsupportedJSONKey)
 	}
@@ -258,10 +296,14 @@ func TransformTodoStructKeyNameToJSONKey(structKey string) (string, error) {
 		return TodoDoneStructKeyName, nil
 	case TodoIDStructKeyName:
 		return TodoIDStructKeyName, nil
+	case TodoTenantIDStructKeyName:
+		return TodoTenantIDStructKeyName, nil
 	case TodoTextStructKeyName:
 		return TodoTextStructKeyName, nil
 	case TodoUpdatedAtStructKeyName:
 		return TodoUpdatedAtStructKeyName, nil
+	case TodoVersionStructKeyName:
+		return TodoVersionStructKeyName, nil
 	default:
 		return "", errors.WithStack(ErrTodoUnsupportedStructKeyName)
 	}
@@ -6,7 +6,9 @@ import (
 
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/todos/daos"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/todos/models"
+	cerrors "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/common/errors"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/common"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/pagination"
 )
 
@@ -44,6 +46,21 @@ func (s *service) FindByID(
 	return res, nil
 }
 
+func (s *service) CheckReadable(ctx context.Context, id string) error {
+	// Find by id
+	res, err := s.FindByID(ctx, id, &models.Projection{ID: true})
+	// Check error
+	if err != nil {
+		return err
+	}
+	// Check if it exists
+	if res == nil {
+		return cerrors.NewNotFoundError("todo not found")
+	}
+
+	return nil
+}
+
 func (s *service) Find(
 	ctx context.Context,
 	sort []*models.SortOrder,
@@ -74,6 +91,51 @@ func (s *service) GetAllPaginated(
 	return s.dao.FindTodoPaginated(ctx, page, sort, filter, projection)
 }
 
+func (s *service) Aggregate(
+	ctx context.Context,
+	filter *models.Filter,
+	groupBy *models.GroupBy,
+) ([]*common.AggregationBucket, error) {
+	// Check authorization
+	err := s.authSvc.CheckAuthorized(
+		ctx,
+		fmt.Sprintf("%s:%s", mainAuthorizationPrefix, "List"),
+		"",
+	)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	return s.dao.AggregateTodo(ctx, filter, groupBy, nil)
+}
+
+func (s *service) FieldValues(
+	ctx context.Context,
+	page *pagination.PageInput,
+	field models.Field,
+	filter *models.Filter,
+	search string,
+) ([]*common.FieldValue, *pagination.PageOutput, error) {
+	// Check authorization
+	err := s.authSvc.CheckAuthorized(
+		ctx,
+		fmt.Sprintf("%s:%s", mainAuthorizationPrefix, "List"),
+		"",
+	)
+	// Check error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Check field
+	if !field.IsValid() {
+		return nil, nil, cerrors.NewInvalidInputError(fmt.Sprintf("%s is not a valid TodoField", field.String()))
+	}
+
+	return s.dao.DistinctTodo(ctx, page, field.DBField(), filter, search)
+}
+
 func (s *service) Create(ctx context.Context, inp *InputCreateTodo) (*models.Todo, error) {
 	// Check authorization
 	err := s.authSvc.CheckAuthorized(
@@ -110,6 +172,14 @@ func (s *service) Update(ctx context.Context, inp *InputUpdateTodo) (*models.Tod
 	if err != nil {
 		return nil, err
 	}
+	// Check if it exists in current tenant
+	if tt == nil {
+		return nil, cerrors.NewNotFoundError("todo not found")
+	}
+	// Check if an expected version is given
+	if inp.Version != nil {
+		tt.Version = *inp.Version
+	}
 	// Update text in existing result
 	tt.Text = inp.Text
 	// Save
@@ -143,6 +213,10 @@ func (s *service) Close(
 		if err2 != nil {
 			return err2
 		}
+		// Check if it exists
+		if tt == nil {
+			return cerrors.NewNotFoundError("todo not found")
+		}
 		// Save
 		res, err2 = s.dao.PatchUpdateTodo(
 			ctx,
@@ -159,3 +233,104 @@ func (s *service) Close(
 
 	return res, nil
 }
+
+func (s *service) Delete(
+	ctx context.Context,
+	id string,
+	projection *models.Projection,
+) (*models.Todo, error) {
+	// Check authorization
+	err := s.authSvc.CheckAuthorized(
+		ctx,
+		fmt.Sprintf("%s:%s", mainAuthorizationPrefix, "Delete"),
+		fmt.Sprintf("%s:%s", mainAuthorizationPrefix, id),
+	)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	// Prepare result
+	var res *models.Todo
+
+	// Create transaction
+	err = s.dbSvc.ExecuteTransaction(ctx, func(ctx context.Context) error {
+		// Search by id first
+		tt, err2 := s.dao.FindTodoByID(ctx, id, projection)
+		// Check error
+		if err2 != nil {
+			return err2
+		}
+		// Check if it exists
+		if tt == nil {
+			return cerrors.NewNotFoundError("todo not found")
+		}
+
+		// Soft delete
+		_, err2 = s.dao.SoftDeleteTodoByID(ctx, id)
+		// Check error
+		if err2 != nil {
+			return err2
+		}
+
+		// Save result
+		res = tt
+
+		return nil
+	})
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *service) Restore(
+	ctx context.Context,
+	id string,
+	projection *models.Projection,
+) (*models.Todo, error) {
+	// Check authorization
+	err := s.authSvc.CheckAuthorized(
+		ctx,
+		fmt.Sprintf("%s:%s", mainAuthorizationPrefix, "Restore"),
+		fmt.Sprintf("%s:%s", mainAuthorizationPrefix, id),
+	)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	// Prepare result
+	var res *models.Todo
+
+	// Create transaction
+	err = s.dbSvc.ExecuteTransaction(ctx, func(ctx context.Context) error {
+		// Restore
+		_, err2 := s.dao.RestoreTodoByID(ctx, id)
+		// Check error
+		if err2 != nil {
+			return err2
+		}
+
+		// Search by id to get restored todo
+		res, err2 = s.dao.FindTodoByID(ctx, id, projection)
+		// Check error
+		if err2 != nil {
+			return err2
+		}
+		// Check if it exists
+		if res == nil {
+			return cerrors.NewNotFoundError("todo not found")
+		}
+
+		return nil
+	})
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
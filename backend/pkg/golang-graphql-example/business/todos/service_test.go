@@ -0,0 +1,139 @@
+//go:build unit
+
+package todos_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/todos"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/todos/mocks"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/common/tenant"
+	dbmocks "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/mocks"
+)
+
+func Test_service_Tenant(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name              string
+		tenantID          string
+		run               func(ctx context.Context, s todos.Service) error
+		authorizationCall string
+		expectedSQLQuery  string
+		expectedSQLArgs   []driver.Value
+		expectedSQLExec   bool
+		errorString       string
+	}{
+		{
+			name:              "read is scoped on tenant",
+			tenantID:          "tenant1",
+			authorizationCall: "todo:Get",
+			run: func(ctx context.Context, s todos.Service) error {
+				return s.CheckReadable(ctx, "other-tenant-id")
+			},
+			expectedSQLQuery: `SELECT "id","version" FROM "todos" WHERE "todos"."tenant_id" = $1 AND id = $2 AND "todos"."deleted_at" IS NULL ORDER BY "todos"."id" LIMIT $3`,
+			expectedSQLArgs:  []driver.Value{"tenant1", "other-tenant-id", 1},
+			errorString:      "todo not found",
+		},
+		{
+			name:              "update is scoped on tenant",
+			tenantID:          "tenant1",
+			authorizationCall: "todo:Update",
+			run: func(ctx context.Context, s todos.Service) error {
+				_, err := s.Update(ctx, &todos.InputUpdateTodo{ID: "other-tenant-id", Text: "text"})
+
+				return err
+			},
+			expectedSQLQuery: `SELECT * FROM "todos" WHERE "todos"."tenant_id" = $1 AND id = $2 AND "todos"."deleted_at" IS NULL ORDER BY "todos"."id" LIMIT $3`,
+			expectedSQLArgs:  []driver.Value{"tenant1", "other-tenant-id", 1},
+			errorString:      "todo not found",
+		},
+		{
+			name:              "create in tenant",
+			tenantID:          "tenant1",
+			authorizationCall: "todo:Create",
+			run: func(ctx context.Context, s todos.Service) error {
+				res, err := s.Create(ctx, &todos.InputCreateTodo{Text: "text"})
+				if err == nil {
+					assert.Equal(t, "tenant1", res.TenantID)
+				}
+
+				return err
+			},
+			expectedSQLQuery: `INSERT INTO "todos" ("created_at","updated_at","deleted_at","id","tenant_id","version","text","done") VALUES ($1,$2,$3,$4,$5,$6,$7,$8)`,
+			expectedSQLArgs:  []driver.Value{now, now, nil, sqlmock.AnyArg(), "tenant1", 1, "text", false},
+			expectedSQLExec:  true,
+		},
+		{
+			name:              "reject without tenant",
+			authorizationCall: "todo:Create",
+			run: func(ctx context.Context, s todos.Service) error {
+				_, err := s.Create(ctx, &todos.InputCreateTodo{Text: "text"})
+
+				return err
+			},
+			errorString: "no tenant found in context",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Error(err)
+
+				return
+			}
+			defer sqlDB.Close()
+
+			db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Discard, NowFunc: func() time.Time {
+				return now
+			}})
+			if err != nil {
+				t.Error(err)
+
+				return
+			}
+
+			ctrl := gomock.NewController(t)
+			dbSvc := dbmocks.NewMockDB(ctrl)
+			dbSvc.EXPECT().GetTransactionalOrDefaultGormDB(gomock.Any()).AnyTimes().Return(db)
+
+			authMock := mocks.NewMockAuthorizationService(ctrl)
+			authMock.EXPECT().CheckAuthorized(gomock.Any(), tt.authorizationCall, gomock.Any()).Return(nil)
+
+			if tt.expectedSQLExec {
+				mock.ExpectBegin()
+				mock.ExpectExec(tt.expectedSQLQuery).
+					WithArgs(tt.expectedSQLArgs...).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			} else if tt.expectedSQLQuery != "" {
+				// Other tenant rows aren't returned
+				mock.ExpectQuery(tt.expectedSQLQuery).
+					WithArgs(tt.expectedSQLArgs...).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			}
+
+			ctx := tenant.SetInContext(context.TODO(), tt.tenantID)
+
+			err = tt.run(ctx, todos.NewService(dbSvc, authMock))
+			if tt.errorString != "" {
+				assert.EqualError(t, err, tt.errorString)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
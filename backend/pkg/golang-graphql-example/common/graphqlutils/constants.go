@@ -4,5 +4,6 @@ const (
 	DefaultMaxPageSize     = 50
 	DefaultDefaultPageSize = 10
 	PaginationIDPrefix     = "paginate"
+	KeysetPaginationPrefix = "keyset"
 	RelayIDSplitSize       = 2
 )
@@ -1,7 +1,9 @@
 package graphqlutils
 
 import (
+	"bytes"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
@@ -18,34 +20,91 @@ func ToRelayID(prefix, id string) string {
 }
 
 func FromRelayID(prefix, relayID string) (string, error) {
+	// Split
+	sp, err := splitRelayID(relayID)
+	// Check error
+	if err != nil {
+		return "", err
+	}
+	// Check that first item of split is a good
+	if sp[0] != prefix {
+		return "", errors.NewInvalidInputError("invalid relay prefix")
+	}
+
+	return sp[1], nil
+}
+
+// GetPrefixAndIDFromRelayID will return the prefix and the id contained in a relay id.
+// Prefix must be validated by caller.
+func GetPrefixAndIDFromRelayID(relayID string) (string, string, error) {
+	// Split
+	sp, err := splitRelayID(relayID)
+	// Check error
+	if err != nil {
+		return "", "", err
+	}
+
+	return sp[0], sp[1], nil
+}
+
+func splitRelayID(relayID string) ([]string, error) {
 	// Base64 decode
 	idBb, err := base64.StdEncoding.DecodeString(relayID)
 	// Check error
 	if err != nil {
-		return "", errors.NewInvalidInputErrorWithError(err)
+		return nil, errors.NewInvalidInputErrorWithError(err)
 	}
 
 	// Validate utf8
 	if !utf8.Valid(idBb) {
-		return "", errors.NewInvalidInputError("not utf8 compatible")
+		return nil, errors.NewInvalidInputError("not utf8 compatible")
 	}
 
 	idContent := string(idBb)
 	// Split
 	sp := strings.Split(idContent, ":")
 	if len(sp) != RelayIDSplitSize {
-		return "", errors.NewInvalidInputError("format error on relay token")
-	}
-	// Check that first item of split is a good
-	if sp[0] != prefix {
-		return "", errors.NewInvalidInputError("invalid relay prefix")
+		return nil, errors.NewInvalidInputError("format error on relay token")
 	}
 
-	return sp[1], nil
+	return sp, nil
 }
 
 func GetPaginateCursor(tableIndex, skip int) string {
-	return ToRelayID(strconv.Itoa(tableIndex+skip+1), PaginationIDPrefix)
+	return ToRelayID(PaginationIDPrefix, strconv.Itoa(tableIndex+skip+1))
+}
+
+// GetKeysetPaginateCursor will generate a keyset cursor from sort columns values.
+func GetKeysetPaginateCursor(values []any) (string, error) {
+	// Marshal values
+	bb, err := json.Marshal(values)
+	// Check error
+	if err != nil {
+		return "", goerrors.WithStack(err)
+	}
+
+	return ToRelayID(KeysetPaginationPrefix, base64.RawURLEncoding.EncodeToString(bb)), nil
+}
+
+// GetConnectionCursor will generate the cursor of the element at the table index
+// depending on the pagination mode used.
+func GetConnectionCursor(tableIndex int, p *pagination.PageOutput) (string, error) {
+	// Check if keyset mode was used
+	if p.Keysets != nil {
+		return GetKeysetPaginateCursor(p.Keysets[tableIndex])
+	}
+
+	return GetPaginateCursor(tableIndex, p.Skip), nil
+}
+
+// GetTotalCount will return total count if it was computed.
+func GetTotalCount(p *pagination.PageOutput) *int {
+	// Check if count was computed
+	if p.CountMode == pagination.CountModeNone {
+		return nil
+	}
+
+	return &p.TotalRecord
 }
 
 func GetPageInfo(startCursor, endCursor string, p *pagination.PageOutput) *PageInfo {
@@ -86,12 +145,51 @@ func GetPageInput(
 	)
 }
 
+// GetKeysetPageInput will generate a keyset page input from relay arguments.
+func GetKeysetPageInput(
+	after *string,
+	before *string,
+	first *int,
+	last *int,
+) (*pagination.PageInput, error) {
+	return GetKeysetPageInputCustomized(
+		after,
+		before,
+		first,
+		last,
+		DefaultMaxPageSize,
+		DefaultDefaultPageSize,
+	)
+}
+
 func GetPageInputCustomized(
 	after *string,
 	before *string,
 	first *int,
 	last *int,
 	maxPageSize, defaultPageSize int,
+) (*pagination.PageInput, error) {
+	return getPageInput(after, before, first, last, maxPageSize, defaultPageSize, false)
+}
+
+// GetKeysetPageInputCustomized will generate a keyset page input from relay arguments with custom page sizes.
+func GetKeysetPageInputCustomized(
+	after *string,
+	before *string,
+	first *int,
+	last *int,
+	maxPageSize, defaultPageSize int,
+) (*pagination.PageInput, error) {
+	return getPageInput(after, before, first, last, maxPageSize, defaultPageSize, true)
+}
+
+func getPageInput(
+	after *string,
+	before *string,
+	first *int,
+	last *int,
+	maxPageSize, defaultPageSize int,
+	keyset bool,
 ) (*pagination.PageInput, error) {
 	// Check if all cursors are present together
 	if after != nil && before != nil {
@@ -131,8 +229,39 @@ func GetPageInputCustomized(
 	// Create parginator input
 	var res pagination.PageInput
 
+	// Check if keyset mode is enabled
+	if keyset {
+		// Initialize keyset input
+		res.Keyset = &pagination.KeysetInput{}
+
+		// Before case
+		if before != nil && *before != "" {
+			vals, err := parseKeysetPaginateCursor(*before)
+			// Check error
+			if err != nil {
+				return nil, err
+			}
+
+			res.Keyset.Values = vals
+			res.Keyset.Backward = true
+			res.Limit = *last
+		}
+
+		// After case
+		if after != nil && *after != "" {
+			vals, err := parseKeysetPaginateCursor(*after)
+			// Check error
+			if err != nil {
+				return nil, err
+			}
+
+			res.Keyset.Values = vals
+			res.Limit = *first
+		}
+	}
+
 	// Before case
-	if before != nil && *before != "" {
+	if !keyset && before != nil && *before != "" {
 		i, err := parsePaginateCursor(*before)
 		// Check error
 		if err != nil {
@@ -149,7 +278,7 @@ func GetPageInputCustomized(
 	}
 
 	// After case
-	if after != nil && *after != "" {
+	if !keyset && after != nil && *after != "" {
 		i, err := parsePaginateCursor(*after)
 		// Check error
 		if err != nil {
@@ -202,3 +331,35 @@ func parsePaginateCursor(cursorB64 string) (int, error) {
 
 	return res, nil
 }
+
+func parseKeysetPaginateCursor(cursorB64 string) ([]any, error) {
+	val, err := FromRelayID(KeysetPaginationPrefix, cursorB64)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+	// Decode values
+	bb, err := base64.RawURLEncoding.DecodeString(val)
+	// Check error
+	if err != nil {
+		return nil, errors.NewInvalidInputErrorWithError(err)
+	}
+
+	// Create decoder keeping numbers to avoid float approximations
+	dec := json.NewDecoder(bytes.NewReader(bb))
+	dec.UseNumber()
+
+	var res []any
+	// Decode
+	err = dec.Decode(&res)
+	// Check error
+	if err != nil {
+		return nil, errors.NewInvalidInputErrorWithError(err)
+	}
+	// Check that cursor isn't empty
+	if len(res) == 0 {
+		return nil, errors.NewInvalidInputError("keyset cursor mustn't be empty")
+	}
+
+	return res, nil
+}
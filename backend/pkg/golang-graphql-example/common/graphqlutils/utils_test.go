@@ -4,6 +4,7 @@ package graphqlutils
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"reflect"
 	"testing"
 
@@ -258,6 +259,133 @@ func TestGetPageInput(t *testing.T) {
 	}
 }
 
+func TestGetKeysetPageInput(t *testing.T) {
+	toStarString := func(s string) *string { return &s }
+	toStarInt := func(i int) *int { return &i }
+	cursor := base64.StdEncoding.EncodeToString(
+		[]byte("keyset:" + base64.RawURLEncoding.EncodeToString([]byte(`["2024-01-02T03:04:05Z",1]`))),
+	)
+	type args struct {
+		after  *string
+		before *string
+		first  *int
+		last   *int
+	}
+	tests := []struct {
+		name        string
+		args        args
+		want        *pagination.PageInput
+		wantErr     bool
+		errorString string
+	}{
+		{
+			name: "empty",
+			args: args{},
+			want: &pagination.PageInput{
+				Limit:  10,
+				Keyset: &pagination.KeysetInput{},
+			},
+		},
+		{
+			name: "after",
+			args: args{
+				after: toStarString(cursor),
+				first: toStarInt(5),
+			},
+			want: &pagination.PageInput{
+				Limit: 5,
+				Keyset: &pagination.KeysetInput{
+					Values: []any{"2024-01-02T03:04:05Z", json.Number("1")},
+				},
+			},
+		},
+		{
+			name: "before",
+			args: args{
+				before: toStarString(cursor),
+				last:   toStarInt(5),
+			},
+			want: &pagination.PageInput{
+				Limit: 5,
+				Keyset: &pagination.KeysetInput{
+					Values:   []any{"2024-01-02T03:04:05Z", json.Number("1")},
+					Backward: true,
+				},
+			},
+		},
+		{
+			name: "offset cursor not supported",
+			args: args{
+				after: toStarString(base64.StdEncoding.EncodeToString([]byte("paginate:1"))),
+				first: toStarInt(5),
+			},
+			wantErr:     true,
+			errorString: "invalid relay prefix",
+		},
+		{
+			name: "empty cursor values",
+			args: args{
+				after: toStarString(base64.StdEncoding.EncodeToString(
+					[]byte("keyset:" + base64.RawURLEncoding.EncodeToString([]byte(`[]`))),
+				)),
+				first: toStarInt(5),
+			},
+			wantErr:     true,
+			errorString: "keyset cursor mustn't be empty",
+		},
+	}
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetKeysetPageInput(tt.args.after, tt.args.before, tt.args.first, tt.args.last)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetKeysetPageInput() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && err.Error() != tt.errorString {
+				t.Errorf("GetKeysetPageInput() error = %v, wantErr %v", err, tt.errorString)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetKeysetPageInput() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_GetConnectionCursor(t *testing.T) {
+	tests := []struct {
+		name string
+		p    *pagination.PageOutput
+		want string
+	}{
+		{
+			name: "offset",
+			p:    &pagination.PageOutput{Skip: 5},
+			want: base64.StdEncoding.EncodeToString([]byte("paginate:7")),
+		},
+		{
+			name: "keyset",
+			p:    &pagination.PageOutput{Keysets: [][]any{{"a", 1}, {"b", 2}}},
+			want: base64.StdEncoding.EncodeToString(
+				[]byte("keyset:" + base64.RawURLEncoding.EncodeToString([]byte(`["b",2]`))),
+			),
+		},
+	}
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetConnectionCursor(1, tt.p)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GetConnectionCursor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_parsePaginateCursor(t *testing.T) {
 	type args struct {
 		cursorB64 string
@@ -0,0 +1,3 @@
+package tenant
+
+// This package is responsible of saving the current tenant in context.
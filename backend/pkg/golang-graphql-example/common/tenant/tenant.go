@@ -0,0 +1,27 @@
+package tenant
+
+import (
+	"context"
+)
+
+type contextKey struct {
+	name string
+}
+
+var tenantCtxKey = &contextKey{name: "tenant"}
+
+func GetFromContext(ctx context.Context) string {
+	res, _ := ctx.Value(tenantCtxKey).(string)
+
+	return res
+}
+
+func SetInContext(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantCtxKey, tenantID)
+}
+
+// RunAs will run the given function as the given tenant.
+// This must be used in AMQP consumers or daemons that aren't linked to an authenticated request.
+func RunAs(ctx context.Context, tenantID string, fn func(ctx context.Context) error) error {
+	return fn(SetInContext(ctx, tenantID))
+}
@@ -1,5 +1,11 @@
 package config
 
+import (
+	"slices"
+
+	"github.com/samber/lo"
+)
+
 // DefaultLogLevel Default log level.
 const DefaultLogLevel = "info"
 
@@ -27,9 +33,25 @@ var DefaultOIDCScopes = []string{"openid", "email", "profile"}
 // Default cookie name.
 const DefaultCookieName = "oidc"
 
+// Default tenant used when no tenant claim is configured and for rows created before multi-tenancy.
+const DefaultTenant = "default"
+
 // Default Database driver.
 const DefaultDatabaseDriver = "POSTGRES"
 
+// Main database connection name.
+// This connection is configured with the database key.
+const MainDatabaseConnectionName = "main"
+
+// Default database window during which reads are done on primary after a write.
+const DefaultDatabaseReplicaReadYourWritesWindow = "5s"
+
+// Default database maximum replication lag before ejecting a replica.
+const DefaultDatabaseReplicaMaxLag = "10s"
+
+// Default database duration above which queries are considered as slow.
+const DefaultDatabaseSlowQueryThreshold = "1s"
+
 // Default tracing type.
 const (
 	DefaultTracingType  = TracingOtelHTTPType
@@ -45,16 +67,41 @@ const (
 
 // Config Configuration object.
 type Config struct {
-	Log                    *LogConfig              `mapstructure:"log"                    json:"log,omitempty"`
-	Tracing                *TracingConfig          `mapstructure:"tracing"                json:"tracing,omitempty"`
-	Server                 *ServerConfig           `mapstructure:"server"                 json:"server,omitempty"`
-	InternalServer         *ServerConfig           `mapstructure:"internalServer"         json:"internalServer,omitempty"`
-	Database               *DatabaseConfig         `mapstructure:"database"               json:"database,omitempty"               validate:"required"`
-	LockDistributor        *LockDistributorConfig  `mapstructure:"lockDistributor"        json:"lockDistributor,omitempty"        validate:"required"`
-	OIDCAuthentication     *OIDCAuthConfig         `mapstructure:"oidcAuthentication"     json:"oidcAuthentication,omitempty"`
-	OPAServerAuthorization *OPAServerAuthorization `mapstructure:"opaServerAuthorization" json:"opaServerAuthorization,omitempty"`
-	SMTP                   *SMTPConfig             `mapstructure:"smtp"                   json:"smtp,omitempty"                   validate:"omitempty"`
-	AMQP                   *AMQPConfig             `mapstructure:"amqp"                   json:"amqp,omitempty"                   validate:"omitempty"`
+	Log                    *LogConfig                 `mapstructure:"log"                    json:"log,omitempty"`
+	Tracing                *TracingConfig             `mapstructure:"tracing"                json:"tracing,omitempty"`
+	Server                 *ServerConfig              `mapstructure:"server"                 json:"server,omitempty"`
+	InternalServer         *ServerConfig              `mapstructure:"internalServer"         json:"internalServer,omitempty"`
+	Database               *DatabaseConfig            `mapstructure:"database"               json:"database,omitempty"               validate:"required"`
+	Databases              map[string]*DatabaseConfig `mapstructure:"databases"              json:"databases,omitempty"              validate:"omitempty,dive,required"`
+	LockDistributor        *LockDistributorConfig     `mapstructure:"lockDistributor"        json:"lockDistributor,omitempty"        validate:"required"`
+	Tenancy                *TenancyConfig             `mapstructure:"tenancy"                json:"tenancy,omitempty"`
+	OIDCAuthentication     *OIDCAuthConfig            `mapstructure:"oidcAuthentication"     json:"oidcAuthentication,omitempty"`
+	OPAServerAuthorization *OPAServerAuthorization    `mapstructure:"opaServerAuthorization" json:"opaServerAuthorization,omitempty"`
+	SMTP                   *SMTPConfig                `mapstructure:"smtp"                   json:"smtp,omitempty"                   validate:"omitempty"`
+	AMQP                   *AMQPConfig                `mapstructure:"amqp"                   json:"amqp,omitempty"                   validate:"omitempty"`
+}
+
+// GetDatabaseConfig will return the database configuration of a named connection.
+// Main connection is the one declared in database key, others are declared in databases key.
+// Nil is returned when connection doesn't exist.
+func (c *Config) GetDatabaseConfig(connectionName string) *DatabaseConfig {
+	// Check if it is the main connection
+	if connectionName == MainDatabaseConnectionName {
+		return c.Database
+	}
+
+	return c.Databases[connectionName]
+}
+
+// GetDatabaseConnectionNames will return all database connection names.
+// Main connection is always the first one, others are sorted.
+func (c *Config) GetDatabaseConnectionNames() []string {
+	// Get other connection names
+	others := lo.Keys(c.Databases)
+	// Sort them to have a stable order
+	slices.Sort(others)
+
+	return append([]string{MainDatabaseConnectionName}, others...)
 }
 
 // AMQPConfig AMQP Message Bus configuration.
@@ -64,6 +111,16 @@ type AMQPConfig struct {
 	Exchanges  []*AMQPExchangeConfig  `mapstructure:"exchanges"  validate:"required,dive,required" json:"exchanges,omitempty"`
 	Queues     []*AMQPQueueConfig     `mapstructure:"queues"     validate:"omitempty,dive"         json:"queues,omitempty"`
 	QueueBinds []*AMQPQueueBindConfig `mapstructure:"queueBinds" validate:"omitempty,dive"         json:"queueBinds,omitempty"`
+	Outbox     *AMQPOutboxConfig      `mapstructure:"outbox"     validate:"omitempty"              json:"outbox,omitempty"`
+}
+
+// AMQPOutboxConfig AMQP transactional outbox relay configuration.
+type AMQPOutboxConfig struct {
+	PollInterval string `mapstructure:"pollInterval"   validate:"omitempty" json:"pollInterval,omitempty"`
+	BatchSize    int    `mapstructure:"batchSize"      validate:"gte=0"     json:"batchSize,omitempty"`
+	// Attempts before dead lettering a failing message
+	MaxAttempts    int  `mapstructure:"maxAttempts"    validate:"gte=0"     json:"maxAttempts,omitempty"`
+	PurgeDelivered bool `mapstructure:"purgeDelivered"                      json:"purgeDelivered,omitempty"`
 }
 
 // AMQPChannelQosConfig AMQP Channel Qos Configuration.
@@ -121,6 +178,13 @@ type LockDistributorConfig struct {
 	HeartbeatFrequency string `mapstructure:"heartbeatFrequency" validate:"required" json:"heartbeatFrequency,omitempty"`
 }
 
+// TenancyConfig Tenancy configuration.
+type TenancyConfig struct {
+	// Tenant used for authenticated users when no tenant claim is configured
+	// and set on existing rows when a model becomes tenant scoped
+	DefaultTenant string `mapstructure:"defaultTenant" json:"defaultTenant,omitempty"`
+}
+
 // OIDCAuthConfig OpenID Connect authentication configurations.
 type OIDCAuthConfig struct {
 	ClientSecret      *CredentialConfig `mapstructure:"clientSecret"      validate:"omitempty"     json:"clientSecret,omitempty"`
@@ -130,6 +194,7 @@ type OIDCAuthConfig struct {
 	LogoutRedirectURL string            `mapstructure:"logoutRedirectUrl" validate:"omitempty,url" json:"logoutRedirectUrl,omitempty"`
 	State             string            `mapstructure:"state"             validate:"required"      json:"state,omitempty"`
 	CookieName        string            `mapstructure:"cookieName"                                 json:"cookieName,omitempty"`
+	TenantClaim       string            `mapstructure:"tenantClaim"                                json:"tenantClaim,omitempty"`
 	Scopes            []string          `mapstructure:"scopes"                                     json:"scopes,omitempty"`
 	EmailVerified     bool              `mapstructure:"emailVerified"                              json:"emailVerified,omitempty"`
 	CookieSecure      bool              `mapstructure:"cookieSecure"                               json:"cookieSecure,omitempty"`
@@ -209,15 +274,19 @@ type ServerCorsConfig struct {
 
 // DatabaseConfig Database configuration.
 type DatabaseConfig struct {
-	ConnectionURL                    *CredentialConfig   `mapstructure:"connectionUrl"                    validate:"required"                       json:"connectionUrl,omitempty"`
-	Driver                           string              `mapstructure:"driver"                           validate:"required,oneof=POSTGRES SQLITE" json:"driver,omitempty"`
-	SQLConnectionMaxLifetimeDuration string              `mapstructure:"sqlConnectionMaxLifetimeDuration"                                           json:"sqlConnectionMaxLifetimeDuration,omitempty"`
-	ReplicaConnectionURLs            []*CredentialConfig `mapstructure:"replicaConnectionUrls"                                                      json:"replicaConnectionUrls,omitempty"`
-	SQLMaxIdleConnections            int                 `mapstructure:"sqlMaxIdleConnections"                                                      json:"sqlMaxIdleConnections,omitempty"`
-	SQLMaxOpenConnections            int                 `mapstructure:"sqlMaxOpenConnections"                                                      json:"sqlMaxOpenConnections,omitempty"`
-	DisableForeignKeyWhenMigrating   bool                `mapstructure:"disableForeignKeyWhenMigrating"                                             json:"disableForeignKeyWhenMigrating,omitempty"`
-	AllowGlobalUpdate                bool                `mapstructure:"allowGlobalUpdate"                                                          json:"allowGlobalUpdate,omitempty"`
-	PrepareStatement                 bool                `mapstructure:"prepareStatement"                                                           json:"prepareStatement,omitempty"`
+	ConnectionURL                    *CredentialConfig   `mapstructure:"connectionUrl"                    validate:"required"                             json:"connectionUrl,omitempty"`
+	Driver                           string              `mapstructure:"driver"                           validate:"required,oneof=POSTGRES SQLITE MYSQL" json:"driver,omitempty"`
+	SQLConnectionMaxLifetimeDuration string              `mapstructure:"sqlConnectionMaxLifetimeDuration"                                                 json:"sqlConnectionMaxLifetimeDuration,omitempty"`
+	ReplicaConnectionURLs            []*CredentialConfig `mapstructure:"replicaConnectionUrls"                                                            json:"replicaConnectionUrls,omitempty"`
+	ReplicaReadYourWritesWindow      string              `mapstructure:"replicaReadYourWritesWindow"                                                      json:"replicaReadYourWritesWindow,omitempty"`
+	ReplicaMaxLag                    string              `mapstructure:"replicaMaxLag"                                                                    json:"replicaMaxLag,omitempty"`
+	StatementTimeout                 string              `mapstructure:"statementTimeout"                                                                 json:"statementTimeout,omitempty"`
+	SlowQueryThreshold               string              `mapstructure:"slowQueryThreshold"                                                               json:"slowQueryThreshold,omitempty"`
+	SQLMaxIdleConnections            int                 `mapstructure:"sqlMaxIdleConnections"                                                            json:"sqlMaxIdleConnections,omitempty"`
+	SQLMaxOpenConnections            int                 `mapstructure:"sqlMaxOpenConnections"                                                            json:"sqlMaxOpenConnections,omitempty"`
+	DisableForeignKeyWhenMigrating   bool                `mapstructure:"disableForeignKeyWhenMigrating"                                                   json:"disableForeignKeyWhenMigrating,omitempty"`
+	AllowGlobalUpdate                bool                `mapstructure:"allowGlobalUpdate"                                                                json:"allowGlobalUpdate,omitempty"`
+	PrepareStatement                 bool                `mapstructure:"prepareStatement"                                                                 json:"prepareStatement,omitempty"`
 }
 
 // SMTPConfig SMTP Configuration.
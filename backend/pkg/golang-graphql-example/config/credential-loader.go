@@ -6,6 +6,9 @@ import (
 	"emperror.dev/errors"
 )
 
+// Path key used to walk over all values of a map.
+const mapValuesPathKey = "*"
+
 // Load credential configs here.
 func (impl *managerimpl) loadAllCredentials(out *Config) ([]*CredentialConfig, error) {
 	return internalLoadAllCredentials(out, impl.credentialConfigPathList)
@@ -20,54 +23,97 @@ func internalLoadAllCredentials(
 
 	// Loop over credential config path list
 	for _, cPath := range credentialConfigPathList {
-		// Save path value
-		pVal := reflect.ValueOf(out).Elem()
-		// Save length
-		pLen := len(cPath)
-
-		// Loop over path
-		for i, p := range cPath {
-			// Get path value
-			pVal = pVal.FieldByName(p)
-			// Check if new value doesn't exist
-			if !pVal.IsValid() || (pVal.Kind() == reflect.Pointer && pVal.IsNil()) {
-				// Stop here
-				break
+		// Load credentials for path
+		res, err := internalLoadCredentialsFromPath(reflect.ValueOf(out).Elem(), cPath)
+		// Check error
+		if err != nil {
+			return nil, err
+		}
+		// Append result
+		result = append(result, res...)
+	}
+
+	return result, nil
+}
+
+func internalLoadCredentialsFromPath(pVal reflect.Value, cPath []string) ([]*CredentialConfig, error) {
+	// Save length
+	pLen := len(cPath)
+
+	// Loop over path
+	for i, p := range cPath {
+		// Check if it is a map values key
+		if p == mapValuesPathKey {
+			// Check if map exists
+			if pVal.Kind() != reflect.Map || pVal.IsNil() {
+				return nil, nil
 			}
 
-			// Check if it is a pointer and not the last key
-			if pVal.Kind() == reflect.Pointer && i != pLen-1 {
-				// Remove ptr
-				pVal = pVal.Elem()
+			// Initialize answer
+			result := make([]*CredentialConfig, 0)
+
+			// Loop over map values
+			iter := pVal.MapRange()
+			for iter.Next() {
+				// Get value
+				mVal := iter.Value()
+				// Check if value doesn't exist
+				if mVal.Kind() == reflect.Pointer && mVal.IsNil() {
+					continue
+				}
+
+				// Check if it is a pointer and not the last key
+				if mVal.Kind() == reflect.Pointer && i != pLen-1 {
+					// Remove ptr
+					mVal = mVal.Elem()
+				}
+
+				// Load credentials for rest of the path
+				res, err := internalLoadCredentialsFromPath(mVal, cPath[i+1:])
+				// Check error
+				if err != nil {
+					return nil, err
+				}
+				// Append result
+				result = append(result, res...)
 			}
+
+			return result, nil
 		}
 
-		// Check if value exists
-		if pVal.IsValid() && !pVal.IsNil() {
-			// Check if it is an array or slice
-			if pVal.Kind() == reflect.Array || pVal.Kind() == reflect.Slice {
-				// Loop over it
-				for i := 0; i < pVal.Len(); i++ {
-					// Get value
-					pVal2 := pVal.Index(i)
-					// Check if value exists
-					if pVal2.IsValid() && !pVal2.IsNil() {
-						// Get value
-						v := pVal2.Interface()
-
-						vv, err := internalLoadCredential(v)
-						// Check error
-						if err != nil {
-							return nil, err
-						}
-						// Append result
-						result = append(result, vv)
-					}
-				}
-			} else {
-				// Direct object case
+		// Get path value
+		pVal = pVal.FieldByName(p)
+		// Check if new value doesn't exist
+		if !pVal.IsValid() || (pVal.Kind() == reflect.Pointer && pVal.IsNil()) {
+			// Stop here
+			return nil, nil
+		}
+
+		// Check if it is a pointer and not the last key
+		if pVal.Kind() == reflect.Pointer && i != pLen-1 {
+			// Remove ptr
+			pVal = pVal.Elem()
+		}
+	}
+
+	// Check if value exists
+	if !pVal.IsValid() || pVal.IsNil() {
+		return nil, nil
+	}
+
+	// Initialize answer
+	result := make([]*CredentialConfig, 0)
+
+	// Check if it is an array or slice
+	if pVal.Kind() == reflect.Array || pVal.Kind() == reflect.Slice {
+		// Loop over it
+		for i := 0; i < pVal.Len(); i++ {
+			// Get value
+			pVal2 := pVal.Index(i)
+			// Check if value exists
+			if pVal2.IsValid() && !pVal2.IsNil() {
 				// Get value
-				v := pVal.Interface()
+				v := pVal2.Interface()
 
 				vv, err := internalLoadCredential(v)
 				// Check error
@@ -78,6 +124,18 @@ func internalLoadAllCredentials(
 				result = append(result, vv)
 			}
 		}
+	} else {
+		// Direct object case
+		// Get value
+		v := pVal.Interface()
+
+		vv, err := internalLoadCredential(v)
+		// Check error
+		if err != nil {
+			return nil, err
+		}
+		// Append result
+		result = append(result, vv)
 	}
 
 	return result, nil
@@ -122,6 +180,24 @@ func getRecursivelyCredentialConfigPathList(keys []string, r reflect.Type) ([][]
 			fieldType = fieldType.Elem()
 		}
 
+		// Get field name
+		fieldName := field.Name
+		// Initialize field keys
+		fieldKeys := []string{fieldName}
+
+		// Check if it is a map
+		if fieldType.Kind() == reflect.Map {
+			// Get map value
+			fieldType = fieldType.Elem()
+			// Check if it is a pointer
+			if fieldType.Kind() == reflect.Pointer {
+				// Remove pointer
+				fieldType = fieldType.Elem()
+			}
+			// Walk over all map values
+			fieldKeys = append(fieldKeys, mapValuesPathKey)
+		}
+
 		// Check if it is an array or slice
 		if fieldType.Kind() == reflect.Array || fieldType.Kind() == reflect.Slice {
 			// Get slice/array element
@@ -138,15 +214,12 @@ func getRecursivelyCredentialConfigPathList(keys []string, r reflect.Type) ([][]
 			continue
 		}
 
-		// Get field name
-		fieldName := field.Name
-
 		// Check if it is a CredentialConfig
 		// If yes, save path and continue
 		if fieldType.AssignableTo(credCfgType) {
 			// Force recreate a slice
 			inter := append([]string{}, keys...)
-			inter = append(inter, fieldName)
+			inter = append(inter, fieldKeys...)
 			// Save
 			res = append(res, inter)
 
@@ -157,7 +230,7 @@ func getRecursivelyCredentialConfigPathList(keys []string, r reflect.Type) ([][]
 		inter := append([]string{}, keys...)
 		// Analyze sub type
 		intRes, err := getRecursivelyCredentialConfigPathList(
-			append(inter, fieldName),
+			append(inter, fieldKeys...),
 			fieldType,
 		)
 		// Check error
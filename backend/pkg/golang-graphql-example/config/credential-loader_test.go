@@ -31,6 +31,13 @@ func Test_internalLoadAllCredentials(t *testing.T) {
 		}
 		C2 *CredentialConfig
 	}
+	type obj5 struct {
+		M1 map[string]*struct {
+			C1 *CredentialConfig
+			C2 []*CredentialConfig
+		}
+		M2 map[string]*CredentialConfig
+	}
 
 	type args struct {
 		out any
@@ -123,6 +130,32 @@ func Test_internalLoadAllCredentials(t *testing.T) {
 			},
 			want: []*CredentialConfig{{Value: "c1"}, {Value: "c2"}, {Value: "c3"}},
 		},
+		{
+			name: "fifth object without anything",
+			args: args{
+				out: &obj5{},
+			},
+			want: []*CredentialConfig{},
+		},
+		{
+			name: "fifth object with full content",
+			args: args{
+				out: &obj5{
+					M1: map[string]*struct {
+						C1 *CredentialConfig
+						C2 []*CredentialConfig
+					}{
+						"k1": {
+							C1: &CredentialConfig{Value: "c1"},
+							C2: []*CredentialConfig{{Value: "c2"}},
+						},
+						"k2": nil,
+					},
+					M2: map[string]*CredentialConfig{"k1": {Value: "c3"}},
+				},
+			},
+			want: []*CredentialConfig{{Value: "c1"}, {Value: "c2"}, {Value: "c3"}},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -208,6 +241,15 @@ func Test_getRecursivelyCredentialConfigPathList(t *testing.T) {
 		C2 []CredentialConfig
 		S1 string
 	}
+	type obj9 struct {
+		M1 map[string]*struct {
+			C1 *CredentialConfig
+			C2 []*CredentialConfig
+			S1 string
+		}
+		M2 map[string]*CredentialConfig
+		M3 map[string]string
+	}
 	type obj8 struct {
 		P1 *struct {
 			C1 *CredentialConfig
@@ -299,6 +341,15 @@ func Test_getRecursivelyCredentialConfigPathList(t *testing.T) {
 				{"C2"},
 			},
 		},
+		{
+			name:  "case 9",
+			input: obj9{},
+			want: [][]string{
+				{"M1", "*", "C1"},
+				{"M1", "*", "C2"},
+				{"M2", "*"},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -11,10 +11,14 @@ func (*managerimpl) loadDefaultConfigurationValues(vip *viper.Viper) {
 	vip.SetDefault("server.port", DefaultPort)
 	vip.SetDefault("internalServer.port", DefaultInternalPort)
 	vip.SetDefault("database.driver", DefaultDatabaseDriver)
+	vip.SetDefault("database.replicaReadYourWritesWindow", DefaultDatabaseReplicaReadYourWritesWindow)
+	vip.SetDefault("database.replicaMaxLag", DefaultDatabaseReplicaMaxLag)
+	vip.SetDefault("database.slowQueryThreshold", DefaultDatabaseSlowQueryThreshold)
 	vip.SetDefault("lockDistributor.tableName", DefaultLockDistributorTableName)
 	vip.SetDefault("lockDistributor.leaseDuration", DefaultLockDistributorLeaseDuration)
 	vip.SetDefault("lockDistributor.heartbeatFrequency", DefaultLockDistributionHeartbeatFrequency)
 	vip.SetDefault("tracing.type", DefaultTracingType)
+	vip.SetDefault("tenancy.defaultTenant", DefaultTenant)
 }
 
 // Load default values based on business rules.
@@ -36,6 +40,31 @@ func loadBusinessDefaultValues(out *Config) error {
 		out.OPAServerAuthorization.Tags = map[string]string{}
 	}
 
+	// Load default named database configurations
+	// Viper default values cannot be applied on map entries
+	for _, dbCfg := range out.Databases {
+		// Check if it exists
+		if dbCfg == nil {
+			continue
+		}
+		// Add default driver
+		if dbCfg.Driver == "" {
+			dbCfg.Driver = DefaultDatabaseDriver
+		}
+		// Add default read your writes window
+		if dbCfg.ReplicaReadYourWritesWindow == "" {
+			dbCfg.ReplicaReadYourWritesWindow = DefaultDatabaseReplicaReadYourWritesWindow
+		}
+		// Add default replica maximum lag
+		if dbCfg.ReplicaMaxLag == "" {
+			dbCfg.ReplicaMaxLag = DefaultDatabaseReplicaMaxLag
+		}
+		// Add default slow query threshold
+		if dbCfg.SlowQueryThreshold == "" {
+			dbCfg.SlowQueryThreshold = DefaultDatabaseSlowQueryThreshold
+		}
+	}
+
 	// Load default tracing configuration
 	if out.Tracing == nil {
 		out.Tracing = &TracingConfig{Enabled: false}
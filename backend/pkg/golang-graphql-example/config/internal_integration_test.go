@@ -83,6 +83,7 @@ database:
 					LeaseDuration:      "3s",
 					TableName:          "locks",
 				},
+				Tenancy: &TenancyConfig{DefaultTenant: "default"},
 			},
 		},
 	}
@@ -228,6 +229,7 @@ tracing:
 			LeaseDuration:      "3s",
 			TableName:          "locks",
 		},
+		Tenancy: &TenancyConfig{DefaultTenant: "default"},
 	}, res)
 
 	configs = map[string]string{
@@ -278,6 +280,7 @@ log:
 			LeaseDuration:      "3s",
 			TableName:          "locks",
 		},
+		Tenancy: &TenancyConfig{DefaultTenant: "default"},
 	}, res)
 	assert.True(t, reloadHookCalled)
 }
@@ -380,6 +383,7 @@ oidcAuthentication:
 			LeaseDuration:      "3s",
 			TableName:          "locks",
 		},
+		Tenancy: &TenancyConfig{DefaultTenant: "default"},
 		OIDCAuthentication: &OIDCAuthConfig{
 			ClientID: "client-with-secret",
 			ClientSecret: &CredentialConfig{
@@ -441,6 +445,7 @@ oidcAuthentication:
 			LeaseDuration:      "3s",
 			TableName:          "locks",
 		},
+		Tenancy: &TenancyConfig{DefaultTenant: "default"},
 		OIDCAuthentication: &OIDCAuthConfig{
 			ClientID: "client-with-secret",
 			ClientSecret: &CredentialConfig{
@@ -546,6 +551,7 @@ tracing:
 			LeaseDuration:      "3s",
 			TableName:          "locks",
 		},
+		Tenancy: &TenancyConfig{DefaultTenant: "default"},
 	}, res)
 
 	configs = map[string]string{
@@ -587,6 +593,7 @@ configuration with error
 			LeaseDuration:      "3s",
 			TableName:          "locks",
 		},
+		Tenancy: &TenancyConfig{DefaultTenant: "default"},
 	}, res)
 	assert.False(t, reloadHookCalled)
 }
@@ -689,6 +696,7 @@ opaServerAuthorization:
 			LeaseDuration:      "3s",
 			TableName:          "locks",
 		},
+		Tenancy: &TenancyConfig{DefaultTenant: "default"},
 		OPAServerAuthorization: &OPAServerAuthorization{
 			URL: "http://fake.com",
 			Tags: map[string]string{
@@ -746,6 +754,7 @@ opaServerAuthorization:
 			LeaseDuration:      "3s",
 			TableName:          "locks",
 		},
+		Tenancy: &TenancyConfig{DefaultTenant: "default"},
 		OPAServerAuthorization: &OPAServerAuthorization{
 			URL: "http://fake.com",
 			Tags: map[string]string{
@@ -844,6 +853,7 @@ database:
 			LeaseDuration:      "3s",
 			TableName:          "locks",
 		},
+		Tenancy: &TenancyConfig{DefaultTenant: "default"},
 	}, res)
 }
 
@@ -948,6 +958,7 @@ opaServerAuthorization:
 			LeaseDuration:      "3s",
 			TableName:          "locks",
 		},
+		Tenancy: &TenancyConfig{DefaultTenant: "default"},
 		OPAServerAuthorization: &OPAServerAuthorization{
 			URL: "http://fake.com",
 			Tags: map[string]string{
@@ -1005,6 +1016,7 @@ opaServerAuthorization:
 			LeaseDuration:      "3s",
 			TableName:          "locks",
 		},
+		Tenancy: &TenancyConfig{DefaultTenant: "default"},
 		OPAServerAuthorization: &OPAServerAuthorization{
 			URL: "http://fake.com",
 			Tags: map[string]string{
@@ -54,6 +54,35 @@ func Test_loadBusinessDefaultValues(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "databases",
+			args: args{
+				out: &Config{
+					Databases: map[string]*DatabaseConfig{
+						"analytics": {},
+						"reporting": {Driver: "SQLITE", ReplicaMaxLag: "1s", StatementTimeout: "0s"},
+					},
+				},
+			},
+			expectedCfg: &Config{
+				Tracing: &TracingConfig{Enabled: false},
+				Databases: map[string]*DatabaseConfig{
+					"analytics": {
+						Driver:                      DefaultDatabaseDriver,
+						ReplicaReadYourWritesWindow: DefaultDatabaseReplicaReadYourWritesWindow,
+						ReplicaMaxLag:               DefaultDatabaseReplicaMaxLag,
+						SlowQueryThreshold:          DefaultDatabaseSlowQueryThreshold,
+					},
+					"reporting": {
+						Driver:                      "SQLITE",
+						ReplicaReadYourWritesWindow: DefaultDatabaseReplicaReadYourWritesWindow,
+						ReplicaMaxLag:               "1s",
+						StatementTimeout:            "0s",
+						SlowQueryThreshold:          DefaultDatabaseSlowQueryThreshold,
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -66,3 +95,53 @@ func Test_loadBusinessDefaultValues(t *testing.T) {
 		})
 	}
 }
+
+func Test_validateBusinessConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *Config
+		wantErr bool
+	}{
+		{
+			name: "Empty",
+			cfg:  &Config{},
+		},
+		{
+			name: "named databases",
+			cfg: &Config{
+				Databases: map[string]*DatabaseConfig{"analytics": {}},
+			},
+		},
+		{
+			name: "main database declared in databases",
+			cfg: &Config{
+				Databases: map[string]*DatabaseConfig{MainDatabaseConnectionName: {}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateBusinessConfig(tt.cfg)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func TestConfig_GetDatabaseConfig(t *testing.T) {
+	mainCfg := &DatabaseConfig{Driver: "POSTGRES"}
+	analyticsCfg := &DatabaseConfig{Driver: "SQLITE"}
+
+	cfg := &Config{
+		Database: mainCfg,
+		Databases: map[string]*DatabaseConfig{
+			"reporting": {},
+			"analytics": analyticsCfg,
+		},
+	}
+
+	assert.Same(t, mainCfg, cfg.GetDatabaseConfig(MainDatabaseConnectionName))
+	assert.Same(t, analyticsCfg, cfg.GetDatabaseConfig("analytics"))
+	assert.Nil(t, cfg.GetDatabaseConfig("unknown"))
+	assert.Equal(t, []string{MainDatabaseConnectionName, "analytics", "reporting"}, cfg.GetDatabaseConnectionNames())
+}
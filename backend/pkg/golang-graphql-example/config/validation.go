@@ -1,7 +1,17 @@
 package config
 
+import "emperror.dev/errors"
+
 // Validate configuration in a business way.
-func validateBusinessConfig(_ *Config) error {
+func validateBusinessConfig(cfg *Config) error {
+	// Check that main database connection isn't declared twice
+	if _, ok := cfg.Databases[MainDatabaseConnectionName]; ok {
+		return errors.Errorf(
+			"database connection %s must be declared in database key and not in databases",
+			MainDatabaseConnectionName,
+		)
+	}
+
 	// TODO Validate configuration in a business way
 	return nil
 }
@@ -0,0 +1,3 @@
+package audit
+
+// This package will manage entity change audit trail recorded by a gorm plugin.
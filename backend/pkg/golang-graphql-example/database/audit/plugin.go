@@ -0,0 +1,575 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"emperror.dev/errors"
+	"github.com/gofrs/uuid/v5"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+	"gorm.io/plugin/dbresolver"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/authx/authentication"
+	correlationid "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/common/correlation-id"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/common/tenant"
+)
+
+// TableName is the audit events table name.
+const TableName = "audit_events"
+
+// Actions recorded in audit events.
+const (
+	ActionCreate = "CREATE"
+	ActionUpdate = "UPDATE"
+	ActionDelete = "DELETE"
+)
+
+const (
+	pluginName            = "audit"
+	tenantIDColumnName    = "tenant_id"
+	beforeRowsInstanceKey = "audit:before_rows"
+)
+
+// Auditable must be implemented by models that must be audited.
+type Auditable interface {
+	Audited() bool
+}
+
+type plugin struct{}
+
+// NewPlugin will create the audit gorm plugin.
+func NewPlugin() gorm.Plugin {
+	return &plugin{}
+}
+
+func (*plugin) Name() string {
+	return pluginName
+}
+
+func (p *plugin) Initialize(db *gorm.DB) error {
+	// Register create callbacks
+	// Before callback is needed for upserts as they can update existing rows
+	err := db.Callback().Create().
+		After("gorm:before_create").
+		Before("gorm:create").
+		Register("audit:before_create", p.beforeCreateCallback)
+	// Check error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	err = db.Callback().Create().
+		After("gorm:create").
+		Register("audit:after_create", p.afterCallback(ActionCreate))
+	// Check error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Register update callbacks
+	err = db.Callback().Update().
+		After("gorm:begin_transaction").
+		Before("gorm:update").
+		Register("audit:before_update", p.beforeCallback)
+	// Check error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	err = db.Callback().Update().
+		After("gorm:update").
+		Register("audit:after_update", p.afterCallback(ActionUpdate))
+	// Check error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Register delete callbacks
+	err = db.Callback().Delete().
+		After("gorm:begin_transaction").
+		Before("gorm:delete").
+		Register("audit:before_delete", p.beforeCallback)
+	// Check error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	err = db.Callback().Delete().
+		After("gorm:delete").
+		Register("audit:after_delete", p.afterCallback(ActionDelete))
+	// Check error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (*plugin) beforeCallback(db *gorm.DB) {
+	// Check if audit must be done
+	if !isAudited(db) {
+		return
+	}
+
+	// Get current rows
+	rows, err := findCurrentRows(db)
+	// Check error
+	if err != nil {
+		_ = db.AddError(err)
+
+		return
+	}
+
+	// Save them for after callback
+	db.InstanceSet(beforeRowsInstanceKey, rows)
+}
+
+func (*plugin) beforeCreateCallback(db *gorm.DB) {
+	// Check if audit must be done
+	if !isAudited(db) {
+		return
+	}
+
+	// Get rows that will be updated on conflict
+	rows, err := findConflictingRows(db)
+	// Check error
+	if err != nil {
+		_ = db.AddError(err)
+
+		return
+	}
+
+	// Save them for after callback
+	db.InstanceSet(beforeRowsInstanceKey, rows)
+}
+
+func (*plugin) afterCallback(action string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		// Check if audit must be done
+		if !isAudited(db) {
+			return
+		}
+
+		// Get primary field
+		pkField := db.Statement.Schema.PrioritizedPrimaryField
+		// Initialize before rows
+		var beforeRows []map[string]any
+		// Get them from instance
+		if v, ok := db.InstanceGet(beforeRowsInstanceKey); ok {
+			beforeRows, _ = v.([]map[string]any)
+		}
+
+		// Get ids from statement and before rows
+		ids := getPrimaryKeyValues(db.Statement)
+		// Loop over before rows
+		for _, r := range beforeRows {
+			// Check if it is already present
+			if !slices.Contains(ids, r[pkField.DBName]) {
+				ids = append(ids, r[pkField.DBName])
+			}
+		}
+
+		// Check if there is something to audit
+		if len(ids) == 0 {
+			return
+		}
+
+		// Get rows after modification
+		var afterRows []map[string]any
+		// Find them
+		err := newSession(db).
+			Where(clause.IN{Column: clause.Column{Table: clause.CurrentTable, Name: pkField.DBName}, Values: ids}).
+			Find(&afterRows).Error
+		// Check error
+		if err != nil {
+			_ = db.AddError(errors.WithStack(err))
+
+			return
+		}
+
+		// Build events
+		events, err := buildEvents(db, action, pkField, beforeRows, afterRows)
+		// Check error
+		if err != nil {
+			_ = db.AddError(err)
+
+			return
+		}
+
+		// Check if there is something to save
+		if len(events) == 0 {
+			return
+		}
+
+		// Save events
+		err = db.Session(&gorm.Session{NewDB: true}).
+			Clauses(dbresolver.Write).
+			Table(TableName).
+			Create(&events).Error
+		// Check error
+		if err != nil {
+			_ = db.AddError(errors.WithStack(err))
+		}
+	}
+}
+
+func buildEvents(
+	db *gorm.DB,
+	action string,
+	pkField *schema.Field,
+	beforeRows, afterRows []map[string]any,
+) ([]map[string]any, error) {
+	// Index rows by id
+	beforeByID := indexRows(pkField, beforeRows)
+	afterByID := indexRows(pkField, afterRows)
+
+	// Get ids in a stable order
+	ids := make([]string, 0, len(beforeByID)+len(afterByID))
+	// Loop over rows
+	for _, r := range slices.Concat(beforeRows, afterRows) {
+		// Get id
+		id := fmt.Sprint(r[pkField.DBName])
+		// Check if it is already present
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+
+	// Get context values
+	ctx := db.Statement.Context
+	userIdentifier := ""
+	// Get authenticated user
+	if user := authentication.GetAuthenticatedUserFromContext(ctx); user != nil {
+		userIdentifier = user.GetIdentifier()
+	}
+	correlationID := correlationid.GetFromContext(ctx)
+	ctxTenantID := tenant.GetFromContext(ctx)
+	now := db.NowFunc()
+
+	// Create result
+	res := make([]map[string]any, 0, len(ids))
+	// Loop over ids
+	for _, id := range ids {
+		// Compute diff
+		before, after := diffRows(beforeByID[id], afterByID[id])
+		// Ignore update without any change
+		if len(before) == 0 && len(after) == 0 {
+			continue
+		}
+
+		// Initialize event action
+		eventAction := action
+		// Check if it is an existing row updated by an upsert
+		if action == ActionCreate && beforeByID[id] != nil {
+			eventAction = ActionUpdate
+		}
+
+		// Marshal
+		beforeBb, err := json.Marshal(before)
+		// Check error
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		afterBb, err := json.Marshal(after)
+		// Check error
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		// Generate id
+		eventID, err := uuid.NewV7()
+		// Check error
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		// Save
+		res = append(res, map[string]any{
+			"id":              eventID.String(),
+			"created_at":      now,
+			"updated_at":      now,
+			"entity_id":       id,
+			"model_name":      db.Statement.Schema.Name,
+			"action":          eventAction,
+			"user_identifier": userIdentifier,
+			"correlation_id":  correlationID,
+			"tenant_id":       getTenantID(ctxTenantID, afterByID[id], beforeByID[id]),
+			"before":          string(beforeBb),
+			"after":           string(afterBb),
+		})
+	}
+
+	return res, nil
+}
+
+func diffRows(before, after map[string]any) (map[string]any, map[string]any) {
+	// Initialize
+	beforeRes := map[string]any{}
+	afterRes := map[string]any{}
+
+	// Get all keys
+	keys := make([]string, 0, len(before)+len(after))
+	for k := range before {
+		keys = append(keys, k)
+	}
+
+	for k := range after {
+		if _, ok := before[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+
+	// Loop over keys
+	for _, k := range keys {
+		// Get values
+		bv, bok := before[k]
+		av, aok := after[k]
+
+		// Check if value have changed
+		if bok == aok && isSameValue(bv, av) {
+			continue
+		}
+
+		// Save
+		if bok {
+			beforeRes[k] = bv
+		}
+
+		if aok {
+			afterRes[k] = av
+		}
+	}
+
+	return beforeRes, afterRes
+}
+
+func isSameValue(a, b any) bool {
+	// Marshal values to compare them whatever the driver type is
+	abb, aerr := json.Marshal(a)
+	bbb, berr := json.Marshal(b)
+
+	return aerr == nil && berr == nil && string(abb) == string(bbb)
+}
+
+// getTenantID will return the tenant of the entity rows.
+// Tenant in context is used for entities that aren't tenant scoped.
+func getTenantID(ctxTenantID string, rows ...map[string]any) string {
+	// Loop over rows
+	for _, r := range rows {
+		// Get tenant
+		if v, ok := r[tenantIDColumnName]; ok && v != nil {
+			return fmt.Sprint(v)
+		}
+	}
+
+	return ctxTenantID
+}
+
+func indexRows(pkField *schema.Field, rows []map[string]any) map[string]map[string]any {
+	// Create result
+	res := make(map[string]map[string]any, len(rows))
+	// Loop over rows
+	for _, r := range rows {
+		// Normalize values
+		for k, v := range r {
+			// Some drivers are returning strings as bytes
+			if bb, ok := v.([]byte); ok {
+				r[k] = string(bb)
+			}
+		}
+		// Save
+		res[fmt.Sprint(r[pkField.DBName])] = r
+	}
+
+	return res
+}
+
+func findCurrentRows(db *gorm.DB) ([]map[string]any, error) {
+	// Get statement
+	stmt := db.Statement
+	// Create session on model to get the query clauses that gorm will add to the statement, like the soft delete scope
+	tx := newSession(db).Model(reflect.New(stmt.Schema.ModelType).Interface())
+	// Check if statement is unscoped
+	if stmt.Unscoped {
+		tx = tx.Unscoped()
+	}
+	// Flag to know if a condition is applied to avoid reading all the table
+	hasCondition := false
+
+	// Reuse statement conditions
+	if c, ok := stmt.Clauses["WHERE"]; ok {
+		// Cast expression
+		if where, ok := c.Expression.(clause.Where); ok && len(where.Exprs) != 0 {
+			tx = tx.Clauses(where)
+			hasCondition = true
+		}
+	}
+
+	// Add primary keys from model
+	ids := getPrimaryKeyValues(stmt)
+	// Check if there are ids
+	if len(ids) != 0 {
+		tx = tx.Where(clause.IN{
+			Column: clause.Column{Table: clause.CurrentTable, Name: stmt.Schema.PrioritizedPrimaryField.DBName},
+			Values: ids,
+		})
+		hasCondition = true
+	}
+
+	// Check if condition exists
+	if !hasCondition {
+		return nil, nil
+	}
+
+	// Find
+	var rows []map[string]any
+	// Query
+	err := tx.Find(&rows).Error
+	// Check error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return rows, nil
+}
+
+// findConflictingRows will return the existing rows that an upsert can update.
+func findConflictingRows(db *gorm.DB) ([]map[string]any, error) {
+	// Get statement
+	stmt := db.Statement
+	// Get on conflict clause
+	c, ok := stmt.Clauses["ON CONFLICT"]
+	// Check if it exists
+	if !ok {
+		return nil, nil
+	}
+
+	// Cast expression
+	// Conflicting rows are also read when nothing is done to avoid auditing them as created
+	onConflict, ok := c.Expression.(clause.OnConflict)
+	// Check if it is valid
+	if !ok {
+		return nil, nil
+	}
+
+	// Get conflict fields
+	fields := make([]*schema.Field, 0, len(onConflict.Columns))
+	// Loop over columns
+	for _, col := range onConflict.Columns {
+		// Get field
+		if f := stmt.Schema.LookUpField(col.Name); f != nil {
+			fields = append(fields, f)
+		}
+	}
+	// Check if conflict target is the primary key
+	if len(onConflict.Columns) == 0 {
+		fields = stmt.Schema.PrimaryFields
+	}
+
+	// Build conditions
+	conds := make([]clause.Expression, 0)
+	// Get reflect value
+	rv := reflect.Indirect(stmt.ReflectValue)
+	// Build condition of an element
+	addCond := func(el reflect.Value) {
+		exprs := make([]clause.Expression, 0, len(fields))
+		// Loop over fields
+		for _, f := range fields {
+			// Get value
+			v, _ := f.ValueOf(stmt.Context, el)
+			exprs = append(exprs, clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: f.DBName}, Value: v})
+		}
+
+		conds = append(conds, clause.And(exprs...))
+	}
+
+	// Switch on kind
+	switch rv.Kind() { //nolint:exhaustive // Only struct and lists are managed
+	case reflect.Struct:
+		addCond(rv)
+	case reflect.Slice, reflect.Array:
+		// Loop over elements
+		for i := range rv.Len() {
+			addCond(reflect.Indirect(rv.Index(i)))
+		}
+	}
+
+	// Check if there is a condition to avoid reading all the table
+	if len(conds) == 0 || len(fields) == 0 {
+		return nil, nil
+	}
+
+	// Create session
+	// Session isn't scoped as soft deleted rows are also conflicting
+	tx := newSession(db).Where(clause.Or(conds...))
+	// Check if update is limited
+	if len(onConflict.Where.Exprs) != 0 {
+		tx = tx.Clauses(clause.Where{Exprs: onConflict.Where.Exprs})
+	}
+
+	// Find
+	var rows []map[string]any
+	// Query
+	err := tx.Find(&rows).Error
+	// Check error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return rows, nil
+}
+
+func getPrimaryKeyValues(stmt *gorm.Statement) []any {
+	// Get primary field
+	pkField := stmt.Schema.PrioritizedPrimaryField
+	// Initialize result
+	res := make([]any, 0)
+	// Get reflect value
+	rv := reflect.Indirect(stmt.ReflectValue)
+
+	// Switch on kind
+	switch rv.Kind() { //nolint:exhaustive // Only struct and lists are managed
+	case reflect.Struct:
+		// Get value
+		if v, zero := pkField.ValueOf(stmt.Context, rv); !zero {
+			res = append(res, v)
+		}
+	case reflect.Slice, reflect.Array:
+		// Loop over elements
+		for i := range rv.Len() {
+			// Get value
+			if v, zero := pkField.ValueOf(stmt.Context, reflect.Indirect(rv.Index(i))); !zero {
+				res = append(res, v)
+			}
+		}
+	}
+
+	return res
+}
+
+func newSession(db *gorm.DB) *gorm.DB {
+	return db.Session(&gorm.Session{NewDB: true}).
+		Clauses(dbresolver.Write).
+		Table(db.Statement.Table)
+}
+
+func isAudited(db *gorm.DB) bool {
+	// Get statement
+	stmt := db.Statement
+
+	// Check if audit can be done
+	if db.Error != nil || db.DryRun || stmt.Schema == nil ||
+		stmt.Schema.PrioritizedPrimaryField == nil || stmt.Table == TableName {
+		return false
+	}
+
+	// Check if model is auditable
+	a, ok := reflect.New(stmt.Schema.ModelType).Interface().(Auditable)
+
+	return ok && a.Audited()
+}
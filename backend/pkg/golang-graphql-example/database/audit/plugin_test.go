@@ -0,0 +1,233 @@
+//go:build unit
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/authx/authentication"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/authx/models"
+	correlationid "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/common/correlation-id"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/common/tenant"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/dbtest"
+)
+
+type auditedPeople struct {
+	ID        string `gorm:"primary_key"`
+	DeletedAt gorm.DeletedAt
+	Name      string
+	Age       int
+}
+
+func (*auditedPeople) Audited() bool { return true }
+
+type tenantAuditedPeople struct {
+	ID       string `gorm:"primary_key"`
+	TenantID string
+	Name     string
+}
+
+func (*tenantAuditedPeople) Audited() bool { return true }
+
+type notAuditedPeople struct {
+	ID   string `gorm:"primary_key"`
+	Name string
+}
+
+type auditEvent struct {
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	ID             string
+	EntityID       string
+	ModelName      string
+	Action         string
+	UserIdentifier string
+	CorrelationID  string
+	TenantID       string
+	Before         string
+	After          string
+}
+
+func (*auditEvent) TableName() string { return TableName }
+
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db := dbtest.NewSQLiteDB(t, &auditedPeople{}, &tenantAuditedPeople{}, &notAuditedPeople{}, &auditEvent{})
+	require.NoError(t, db.Use(NewPlugin()))
+
+	return db
+}
+
+func getEvents(t *testing.T, db *gorm.DB) []*auditEvent {
+	t.Helper()
+
+	var res []*auditEvent
+	require.NoError(t, db.Order("rowid").Find(&res).Error)
+
+	return res
+}
+
+func unmarshal(t *testing.T, s string) map[string]any {
+	t.Helper()
+
+	var res map[string]any
+	require.NoError(t, json.Unmarshal([]byte(s), &res))
+
+	return res
+}
+
+func TestPlugin(t *testing.T) {
+	db := newTestDB(t)
+
+	ctx := authentication.SetAuthenticatedUserToContext(
+		correlationid.SetInContext(context.TODO(), "corr-id"),
+		&models.OIDCUser{PreferredUsername: "user1"},
+	)
+	gdb := db.WithContext(ctx)
+
+	// Create
+	p := &auditedPeople{ID: "id1", Name: "name1", Age: 10}
+	require.NoError(t, gdb.Create(p).Error)
+	// Update
+	require.NoError(t, gdb.Model(p).Updates(map[string]any{"name": "name2"}).Error)
+	// Update without change
+	require.NoError(t, gdb.Model(p).Updates(map[string]any{"name": "name2"}).Error)
+	// Filtered update
+	require.NoError(t, gdb.Model(&auditedPeople{}).Where("age = ?", 10).Update("age", 11).Error)
+	// Soft delete
+	require.NoError(t, gdb.Delete(p).Error)
+	// Not audited
+	require.NoError(t, gdb.Create(&notAuditedPeople{ID: "id2", Name: "name"}).Error)
+
+	events := getEvents(t, db)
+	require.Len(t, events, 4)
+
+	for _, e := range events {
+		assert.Equal(t, "id1", e.EntityID)
+		assert.Equal(t, "auditedPeople", e.ModelName)
+		assert.Equal(t, "user1", e.UserIdentifier)
+		assert.Equal(t, "corr-id", e.CorrelationID)
+		assert.Equal(t, "", e.TenantID)
+	}
+
+	assert.Equal(t, ActionCreate, events[0].Action)
+	assert.Equal(t, map[string]any{}, unmarshal(t, events[0].Before))
+	assert.Equal(t, map[string]any{"id": "id1", "name": "name1", "age": float64(10), "deleted_at": nil}, unmarshal(t, events[0].After))
+
+	assert.Equal(t, ActionUpdate, events[1].Action)
+	assert.Equal(t, map[string]any{"name": "name1"}, unmarshal(t, events[1].Before))
+	assert.Equal(t, map[string]any{"name": "name2"}, unmarshal(t, events[1].After))
+
+	assert.Equal(t, ActionUpdate, events[2].Action)
+	assert.Equal(t, map[string]any{"age": float64(10)}, unmarshal(t, events[2].Before))
+	assert.Equal(t, map[string]any{"age": float64(11)}, unmarshal(t, events[2].After))
+
+	assert.Equal(t, ActionDelete, events[3].Action)
+	assert.Equal(t, map[string]any{"deleted_at": nil}, unmarshal(t, events[3].Before))
+	assert.Contains(t, unmarshal(t, events[3].After), "deleted_at")
+
+	// Permanent delete
+	require.NoError(t, gdb.Unscoped().Delete(p).Error)
+
+	events = getEvents(t, db)
+	require.Len(t, events, 5)
+	assert.Equal(t, ActionDelete, events[4].Action)
+	assert.Equal(t, map[string]any{}, unmarshal(t, events[4].After))
+	assert.Equal(t, "name2", unmarshal(t, events[4].Before)["name"])
+}
+
+func TestPlugin_Tenant(t *testing.T) {
+	db := newTestDB(t)
+
+	gdb := db.WithContext(tenant.SetInContext(context.TODO(), "tenant1"))
+
+	// Entity tenant is used
+	require.NoError(t, gdb.Create(&tenantAuditedPeople{ID: "id1", TenantID: "tenant2", Name: "name1"}).Error)
+	// Context tenant is used for entities that aren't tenant scoped
+	require.NoError(t, gdb.Create(&auditedPeople{ID: "id2", Name: "name2"}).Error)
+
+	events := getEvents(t, db)
+	require.Len(t, events, 2)
+	assert.Equal(t, "tenant2", events[0].TenantID)
+	assert.Equal(t, "tenant1", events[1].TenantID)
+}
+
+func TestPlugin_Upsert(t *testing.T) {
+	db := newTestDB(t)
+
+	require.NoError(t, db.Create(&auditedPeople{ID: "id1", Name: "name1", Age: 10}).Error)
+
+	// Upsert an existing row and a new one
+	require.NoError(t, db.Clauses(clause.OnConflict{UpdateAll: true}).Create([]*auditedPeople{
+		{ID: "id1", Name: "name2", Age: 10},
+		{ID: "id2", Name: "name3", Age: 20},
+	}).Error)
+	// Upsert without update mustn't be audited
+	require.NoError(t, db.Clauses(clause.OnConflict{DoNothing: true}).Create(&auditedPeople{ID: "id1", Name: "name4"}).Error)
+
+	events := getEvents(t, db)
+	require.Len(t, events, 3)
+
+	assert.Equal(t, ActionUpdate, events[1].Action)
+	assert.Equal(t, "id1", events[1].EntityID)
+	assert.Equal(t, map[string]any{"name": "name1"}, unmarshal(t, events[1].Before))
+	assert.Equal(t, map[string]any{"name": "name2"}, unmarshal(t, events[1].After))
+
+	assert.Equal(t, ActionCreate, events[2].Action)
+	assert.Equal(t, "id2", events[2].EntityID)
+	assert.Equal(t, map[string]any{}, unmarshal(t, events[2].Before))
+}
+
+func TestFindCurrentRows(t *testing.T) {
+	db := newTestDB(t)
+
+	require.NoError(t, db.Create([]*auditedPeople{
+		{ID: "id1", Name: "name1", Age: 10},
+		{ID: "id2", Name: "name2", Age: 10},
+	}).Error)
+	require.NoError(t, db.Delete(&auditedPeople{ID: "id2"}).Error)
+
+	tests := []struct {
+		name     string
+		unscoped bool
+		wantIDs  []any
+	}{
+		{
+			name:    "soft deleted rows are ignored like in the statement",
+			wantIDs: []any{"id1"},
+		},
+		{
+			name:     "soft deleted rows are included in unscoped statement",
+			unscoped: true,
+			wantIDs:  []any{"id1", "id2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := db.Model(&auditedPeople{}).Where("age = ?", 10)
+			if tt.unscoped {
+				tx = tx.Unscoped()
+			}
+			require.NoError(t, tx.Statement.Parse(&auditedPeople{}))
+
+			rows, err := findCurrentRows(tx)
+			require.NoError(t, err)
+
+			ids := make([]any, 0, len(rows))
+			for _, r := range rows {
+				ids = append(ids, r["id"])
+			}
+
+			assert.ElementsMatch(t, tt.wantIDs, ids)
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package database
+
+// BaseWithTenantAndVersion contains common columns for all tables isolated per tenant
+// with an optimistic concurrency version column.
+type BaseWithTenantAndVersion struct {
+	BaseWithTenant
+	Version int `gorm:"not null;default:1" json:"version"`
+}
+
+// GetVersion will return the current version.
+func (base *BaseWithTenantAndVersion) GetVersion() int {
+	return base.Version
+}
+
+// SetVersion will set the current version.
+func (base *BaseWithTenantAndVersion) SetVersion(version int) {
+	base.Version = version
+}
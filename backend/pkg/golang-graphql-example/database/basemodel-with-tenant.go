@@ -0,0 +1,24 @@
+package database
+
+// TenantScoped is implemented by models isolated per tenant.
+type TenantScoped interface {
+	GetTenantID() string
+	SetTenantID(tenantID string)
+}
+
+// BaseWithTenant contains common columns for all tables isolated per tenant.
+// Helpers will scope queries on the tenant found in context and will reject writes for another tenant.
+type BaseWithTenant struct {
+	Base
+	TenantID string `gorm:"index;not null" json:"tenantId"`
+}
+
+// GetTenantID will return the tenant id.
+func (base *BaseWithTenant) GetTenantID() string {
+	return base.TenantID
+}
+
+// SetTenantID will set the tenant id.
+func (base *BaseWithTenant) SetTenantID(tenantID string) {
+	base.TenantID = tenantID
+}
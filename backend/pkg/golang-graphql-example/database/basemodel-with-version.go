@@ -0,0 +1,24 @@
+package database
+
+// Versioned is implemented by models having an optimistic concurrency version column.
+type Versioned interface {
+	GetVersion() int
+	SetVersion(version int)
+}
+
+// BaseWithVersion contains common columns for all tables with an optimistic concurrency version column.
+// Helpers will check the version on update and will increment it.
+type BaseWithVersion struct {
+	Base
+	Version int `gorm:"not null;default:1" json:"version"`
+}
+
+// GetVersion will return the current version.
+func (base *BaseWithVersion) GetVersion() int {
+	return base.Version
+}
+
+// SetVersion will set the current version.
+func (base *BaseWithVersion) SetVersion(version int) {
+	base.Version = version
+}
@@ -10,10 +10,10 @@ import (
 
 // BaseWithoutAllIndexes contains common columns for all tables.
 type BaseWithoutAllIndexes struct {
-	CreatedAt time.Time  `json:"createdAt"`
-	UpdatedAt time.Time  `json:"updatedAt"`
-	DeletedAt *time.Time `json:"deletedAt,omitempty" gorm:"index"`
-	ID        string     `json:"id"                  gorm:"primary_key"`
+	CreatedAt time.Time      `json:"createdAt"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+	DeletedAt gorm.DeletedAt `json:"deletedAt,omitempty" gorm:"index"`
+	ID        string         `json:"id"                  gorm:"primary_key"`
 }
 
 // BeforeCreate will set a UUID rather than numeric ID.
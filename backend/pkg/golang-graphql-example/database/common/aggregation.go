@@ -0,0 +1,196 @@
+package common
+
+import (
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/common/errors"
+)
+
+// AggregationMetrics lists database columns on which metrics must be computed.
+// Count is always computed.
+type AggregationMetrics struct {
+	Sum []string
+	Min []string
+	Max []string
+}
+
+// AggregationBucket is the aggregation result of a group.
+type AggregationBucket struct {
+	// Group values indexed by database column
+	Keys map[string]any
+	// Sum values indexed by database column
+	Sum map[string]*float64
+	// Min values indexed by database column
+	Min map[string]any
+	// Max values indexed by database column
+	Max map[string]any
+	// Number of rows in group
+	Count int64
+}
+
+// GroupByColumn is a group by column extracted from a group by structure.
+type GroupByColumn struct {
+	// Date truncation applied, nil when column is grouped by value
+	DateTrunc *DateTruncEnum
+	Column    string
+}
+
+var (
+	supportedDateTruncType = reflect.TypeOf(new(DateTruncEnum))
+	supportedGroupByType   = reflect.TypeOf(new(bool))
+)
+
+// GetGroupByColumns will extract group by columns from a group by structure.
+// Fields must have a dbfield tag and be a *DateTruncEnum to group on a truncated date
+// or a *bool to group on value when set to true.
+// Example:
+//
+//	type GroupBy struct {
+//		CreatedAt *DateTruncEnum `dbfield:"created_at"`
+//		Done      *bool          `dbfield:"done"`
+//	}
+//
+// .
+func GetGroupByColumns(groupBy any) ([]*GroupByColumn, error) {
+	// Get reflect value of group by object
+	rVal := reflect.ValueOf(groupBy)
+	// Get kind of group by
+	rKind := rVal.Kind()
+	// Check if group by isn't nil
+	if rKind == reflect.Invalid || (rKind == reflect.Pointer && rVal.IsNil()) {
+		// Stop here
+		return nil, nil
+	}
+	// Indirect value
+	indirect := reflect.Indirect(rVal)
+	// Check if kind is supported
+	if indirect.Kind() != reflect.Struct {
+		return nil, errors.NewInvalidInputError("group by must be an object")
+	}
+
+	// Initialize result
+	res := make([]*GroupByColumn, 0)
+	// Get type of indirect value
+	typeOfIndi := indirect.Type()
+	// Loop over all num fields
+	for i := 0; i < indirect.NumField(); i++ {
+		// Get field type
+		fType := typeOfIndi.Field(i)
+		// Get tag on field
+		tagVal := fType.Tag.Get(dbColTagName)
+		// Check that field have a tag set and correct
+		if tagVal == "" || tagVal == "-" {
+			// Skip this value
+			continue
+		}
+		// Check that type is supported
+		if fType.Type != supportedDateTruncType && fType.Type != supportedGroupByType {
+			return nil, errors.NewInvalidInputError(
+				fmt.Sprintf("field %s with group by tag must be a *DateTruncEnum or a *bool", fType.Name),
+			)
+		}
+		// Get field value
+		fVal := indirect.Field(i)
+		// Test if field is nil
+		if fVal.IsNil() {
+			// Skip field because of nil
+			continue
+		}
+
+		// Check if it is a date truncation
+		if v, ok := fVal.Interface().(*DateTruncEnum); ok {
+			// Check value
+			if !v.IsValid() {
+				return nil, errors.NewInvalidInputError(
+					fmt.Sprintf("%s is not a valid DateTruncEnum", v.String()),
+				)
+			}
+
+			res = append(res, &GroupByColumn{Column: tagVal, DateTrunc: v})
+
+			continue
+		}
+
+		// Check if group by value is enabled
+		if *(fVal.Interface().(*bool)) {
+			res = append(res, &GroupByColumn{Column: tagVal})
+		}
+	}
+
+	return res, nil
+}
+
+// GetGroupByExpression will return the SQL expression used to group on column.
+func GetGroupByExpression(db *gorm.DB, col *GroupByColumn) (string, error) {
+	// Check if it is a date truncation
+	if col.DateTrunc == nil {
+		return col.Column, nil
+	}
+
+	switch db.Dialector.Name() {
+	case "postgres":
+		return fmt.Sprintf("date_trunc('%s', %s)", getPostgresDateTruncField(*col.DateTrunc), col.Column), nil
+	case "sqlite":
+		return getSqliteDateTruncExpression(*col.DateTrunc, col.Column), nil
+	case "mysql":
+		return getMySQLDateTruncExpression(*col.DateTrunc, col.Column), nil
+	default:
+		return "", errors.NewInvalidInputError("date truncation isn't supported by database driver")
+	}
+}
+
+func getPostgresDateTruncField(dt DateTruncEnum) string {
+	switch dt {
+	case DateTruncEnumHour:
+		return "hour"
+	case DateTruncEnumDay:
+		return "day"
+	case DateTruncEnumWeek:
+		return "week"
+	case DateTruncEnumMonth:
+		return "month"
+	case DateTruncEnumYear:
+		fallthrough
+	default:
+		return "year"
+	}
+}
+
+func getSqliteDateTruncExpression(dt DateTruncEnum, column string) string {
+	switch dt {
+	case DateTruncEnumHour:
+		return fmt.Sprintf("strftime('%%Y-%%m-%%d %%H:00:00', %s)", column)
+	case DateTruncEnumDay:
+		return fmt.Sprintf("strftime('%%Y-%%m-%%d 00:00:00', %s)", column)
+	case DateTruncEnumWeek:
+		// Go to next sunday (or stay on it) and go back to monday to have ISO weeks like postgres
+		return fmt.Sprintf("strftime('%%Y-%%m-%%d 00:00:00', %s, 'weekday 0', '-6 days')", column)
+	case DateTruncEnumMonth:
+		return fmt.Sprintf("strftime('%%Y-%%m-01 00:00:00', %s)", column)
+	case DateTruncEnumYear:
+		fallthrough
+	default:
+		return fmt.Sprintf("strftime('%%Y-01-01 00:00:00', %s)", column)
+	}
+}
+
+func getMySQLDateTruncExpression(dt DateTruncEnum, column string) string {
+	switch dt {
+	case DateTruncEnumHour:
+		return fmt.Sprintf("DATE_FORMAT(%s, '%%Y-%%m-%%d %%H:00:00')", column)
+	case DateTruncEnumDay:
+		return fmt.Sprintf("DATE_FORMAT(%s, '%%Y-%%m-%%d 00:00:00')", column)
+	case DateTruncEnumWeek:
+		// Go back to monday to have ISO weeks like postgres
+		return fmt.Sprintf("DATE_FORMAT(DATE_SUB(%s, INTERVAL WEEKDAY(%s) DAY), '%%Y-%%m-%%d 00:00:00')", column, column)
+	case DateTruncEnumMonth:
+		return fmt.Sprintf("DATE_FORMAT(%s, '%%Y-%%m-01 00:00:00')", column)
+	case DateTruncEnumYear:
+		fallthrough
+	default:
+		return fmt.Sprintf("DATE_FORMAT(%s, '%%Y-01-01 00:00:00')", column)
+	}
+}
@@ -0,0 +1,165 @@
+package common
+
+import (
+	"fmt"
+	"reflect"
+
+	gerrors "emperror.dev/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/common/errors"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
+)
+
+// Filter tag option to filter on a gorm association.
+// Tag value must be the gorm association name (structure field name in model) and field must be a filter object.
+//
+// Example:
+//
+//	type Filter struct {
+//		Project *ProjectFilter `dbfield:"Project;association"`
+//	}
+//
+// This will keep rows having at least one associated row matching the nested filter.
+const filterTagAssociationOption = "association"
+
+// ErrAssociationFilterWithoutModel is raised when an association filter is used in a query without model.
+var ErrAssociationFilterWithoutModel = gerrors.Sentinel("association filter cannot be used in a query without model")
+
+// Expression building an EXISTS subquery on association.
+// This is done at build time because the model schema is only known by gorm at this step.
+type associationFilterExpression struct {
+	name   string
+	filter any
+}
+
+func (e *associationFilterExpression) Build(builder clause.Builder) {
+	// Get statement
+	stmt, ok := builder.(*gorm.Statement)
+	// Check if it is a statement
+	if !ok {
+		return
+	}
+
+	// Check if schema is available
+	if stmt.Schema == nil {
+		_ = stmt.AddError(gerrors.WithStack(ErrAssociationFilterWithoutModel))
+
+		return
+	}
+
+	// Get relationship
+	rel, ok := stmt.Schema.Relationships.Relations[e.name]
+	// Check if it exists
+	if !ok {
+		_ = stmt.AddError(errors.NewInvalidInputError(
+			fmt.Sprintf("association %s not found on %s", e.name, stmt.Schema.Name),
+		))
+
+		return
+	}
+
+	// Build subquery
+	sub, err := e.buildSubQuery(stmt, rel)
+	// Check error
+	if err != nil {
+		_ = stmt.AddError(err)
+
+		return
+	}
+
+	builder.WriteString("EXISTS (")
+	builder.AddVar(builder, sub)
+	builder.WriteByte(')')
+}
+
+func (e *associationFilterExpression) buildSubQuery(stmt *gorm.Statement, rel *schema.Relationship) (*gorm.DB, error) {
+	// Compute alias for associated table
+	// Alias is computed from the current table to support self referencing and nested associations
+	alias := stmt.Table + "_" + stmt.NamingStrategy.ColumnName("", rel.Name)
+	// Create model instance to have soft delete and other query clauses managed by gorm
+	model := reflect.New(rel.FieldSchema.ModelType).Interface()
+
+	// Create clean db on associated model
+	sub := stmt.DB.Session(&gorm.Session{NewDB: true}).Model(model)
+	sub = sub.Table("? AS ?", clause.Table{Name: rel.FieldSchema.Table}, clause.Table{Name: alias})
+	// Force table name as alias is quoted
+	sub.Statement.Table = alias
+
+	// Scope associated rows on tenant in context when associated model is tenant scoped
+	sub, err := database.ApplyTenantScope(stmt.Context, sub, model)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply nested filter on a clean db to have pure groups
+	sub, err = manageFilter(e.filter, sub, false)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if it is a many to many relationship
+	if rel.JoinTable != nil {
+		// Compute join table alias
+		jtAlias := alias + "_join"
+		// Create join table subquery
+		jtSub := stmt.DB.Session(&gorm.Session{NewDB: true}).
+			Table("? AS ?", clause.Table{Name: rel.JoinTable.Table}, clause.Table{Name: jtAlias}).
+			Select("1").
+			Where(clause.And(getAssociationJoinConditions(rel, stmt.Table, alias, jtAlias)...))
+
+		sub = sub.Where("EXISTS (?)", jtSub)
+	} else {
+		sub = sub.Where(clause.And(getAssociationJoinConditions(rel, stmt.Table, alias, "")...))
+	}
+
+	// Build query in dry run mode to get errors
+	sub = sub.Session(&gorm.Session{DryRun: true, Logger: logger.Discard}).Select("1").Find(model)
+	// Check error
+	if sub.Error != nil {
+		return nil, sub.Error
+	}
+
+	return sub, nil
+}
+
+func getAssociationJoinConditions(rel *schema.Relationship, ownTable, relTable, joinTable string) []clause.Expression {
+	// Initialize result
+	res := make([]clause.Expression, 0, len(rel.References))
+
+	// Loop over references
+	for _, ref := range rel.References {
+		// Compute tables
+		// Without join table, primary key is on own table when relation is owning it, otherwise on associated table
+		// Foreign key is on the other one
+		pkTable, fkTable := relTable, ownTable
+		if ref.OwnPrimaryKey {
+			pkTable, fkTable = ownTable, relTable
+		}
+		// With join table, foreign keys are always in it
+		if joinTable != "" {
+			fkTable = joinTable
+		}
+
+		// Compute value
+		var value any
+		// Check if primary key is set, otherwise it is a polymorphic value
+		if ref.PrimaryKey != nil {
+			value = clause.Column{Table: pkTable, Name: ref.PrimaryKey.DBName}
+		} else {
+			value = ref.PrimaryValue
+		}
+
+		res = append(res, clause.Eq{
+			Column: clause.Column{Table: fkTable, Name: ref.ForeignKey.DBName},
+			Value:  value,
+		})
+	}
+
+	return res
+}
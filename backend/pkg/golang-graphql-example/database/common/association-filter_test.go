@@ -0,0 +1,276 @@
+//go:build unit
+
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/common/tenant"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
+)
+
+type assocTestProject struct {
+	DeletedAt gorm.DeletedAt
+	Parent    *assocTestProject
+	ParentID  *string
+	ID        string
+	Name      string
+	Todos     []*assocTestTodo `gorm:"foreignKey:ProjectID"`
+}
+
+func (*assocTestProject) TableName() string {
+	return "projects"
+}
+
+type assocTestTag struct {
+	ID   string
+	Name string
+}
+
+func (*assocTestTag) TableName() string {
+	return "tags"
+}
+
+type assocTestTodo struct {
+	Project   *assocTestProject
+	ID        string
+	Text      string
+	ProjectID string
+	Tags      []*assocTestTag `gorm:"many2many:todos_tags"`
+}
+
+func (*assocTestTodo) TableName() string {
+	return "todos"
+}
+
+type assocTestProjectFilter struct {
+	Name   *GenericFilter          `dbfield:"name"`
+	Parent *assocTestProjectFilter `dbfield:"Parent;association"`
+	Todos  *assocTestTodoFilter    `dbfield:"Todos;association"`
+}
+
+type assocTestTagFilter struct {
+	Name *GenericFilter `dbfield:"name"`
+}
+
+type assocTestTodoFilter struct {
+	Text    *GenericFilter          `dbfield:"text"`
+	Project *assocTestProjectFilter `dbfield:"Project;association"`
+	Tags    *assocTestTagFilter     `dbfield:"Tags;association"`
+	Fake    *assocTestTagFilter     `dbfield:"Fake;association"`
+	AND     []*assocTestTodoFilter
+	OR      []*assocTestTodoFilter
+}
+
+func Test_ManageFilter_Association_Postgres(t *testing.T) {
+	sqlDB, _, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer sqlDB.Close()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		// Use a new session as manage filter needs a cloneable db
+		res, err := ManageFilter(&assocTestTodoFilter{
+			Text:    &GenericFilter{Eq: "todo"},
+			Project: &assocTestProjectFilter{Name: &GenericFilter{Eq: "project"}},
+		}, tx.Session(&gorm.Session{}))
+		require.NoError(t, err)
+
+		return res.Find(&[]*assocTestTodo{})
+	})
+
+	assert.Equal(
+		t,
+		`SELECT * FROM "todos" WHERE text = 'todo' AND EXISTS (SELECT 1 FROM "projects" AS "todos_project" `+
+			`WHERE name = 'project' AND "todos"."project_id" = "todos_project"."id" AND "todos_project"."deleted_at" IS NULL)`,
+		sql,
+	)
+}
+
+func Test_ManageFilter_Association_Sqlite(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&assocTestProject{}, &assocTestTag{}, &assocTestTodo{}))
+
+	parentID := "p1"
+	require.NoError(t, db.Create([]*assocTestProject{
+		{ID: "p1", Name: "parent"},
+		{ID: "p2", Name: "child", ParentID: &parentID},
+		{ID: "p3", Name: "deleted"},
+	}).Error)
+	require.NoError(t, db.Delete(&assocTestProject{ID: "p3"}).Error)
+	require.NoError(t, db.Create([]*assocTestTodo{
+		{ID: "t1", Text: "first", ProjectID: "p1", Tags: []*assocTestTag{{ID: "tag1", Name: "urgent"}}},
+		{ID: "t2", Text: "second", ProjectID: "p2", Tags: []*assocTestTag{{ID: "tag2", Name: "later"}}},
+		{ID: "t3", Text: "third", ProjectID: "p3"},
+	}).Error)
+
+	tests := []struct {
+		name        string
+		filter      *assocTestTodoFilter
+		wantIDs     []string
+		wantErr     bool
+		errorString string
+	}{
+		{
+			name:    "belongs to",
+			filter:  &assocTestTodoFilter{Project: &assocTestProjectFilter{Name: &GenericFilter{Contains: "aren"}}},
+			wantIDs: []string{"t1"},
+		},
+		{
+			name:    "empty nested filter must keep rows with an association",
+			filter:  &assocTestTodoFilter{Project: &assocTestProjectFilter{}},
+			wantIDs: []string{"t1", "t2"},
+		},
+		{
+			name: "nested associations with self reference",
+			filter: &assocTestTodoFilter{Project: &assocTestProjectFilter{
+				Parent: &assocTestProjectFilter{Name: &GenericFilter{Eq: "parent"}},
+			}},
+			wantIDs: []string{"t2"},
+		},
+		{
+			name: "has many",
+			filter: &assocTestTodoFilter{Project: &assocTestProjectFilter{
+				Todos: &assocTestTodoFilter{Text: &GenericFilter{Eq: "second"}},
+			}},
+			wantIDs: []string{"t2"},
+		},
+		{
+			name:    "many to many",
+			filter:  &assocTestTodoFilter{Tags: &assocTestTagFilter{Name: &GenericFilter{Eq: "urgent"}}},
+			wantIDs: []string{"t1"},
+		},
+		{
+			name: "or between associations",
+			filter: &assocTestTodoFilter{OR: []*assocTestTodoFilter{
+				{Tags: &assocTestTagFilter{Name: &GenericFilter{Eq: "urgent"}}},
+				{Project: &assocTestProjectFilter{Name: &GenericFilter{Eq: "child"}}},
+			}},
+			wantIDs: []string{"t1", "t2"},
+		},
+		{
+			name:        "unknown association",
+			filter:      &assocTestTodoFilter{Fake: &assocTestTagFilter{}},
+			wantErr:     true,
+			errorString: "association Fake not found on assocTestTodo",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := ManageFilter(tt.filter, db)
+			require.NoError(t, err)
+
+			var list []*assocTestTodo
+
+			err = q.Order("id").Find(&list).Error
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Find() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if err != nil {
+				assert.Equal(t, tt.errorString, err.Error())
+
+				return
+			}
+
+			ids := make([]string, 0, len(list))
+			for _, it := range list {
+				ids = append(ids, it.ID)
+			}
+
+			assert.Equal(t, tt.wantIDs, ids)
+
+			// Count must apply the same filter
+			var count int64
+
+			q, err = ManageFilter(tt.filter, db)
+			require.NoError(t, err)
+			require.NoError(t, q.Model(&assocTestTodo{}).Count(&count).Error)
+			assert.Equal(t, int64(len(tt.wantIDs)), count)
+		})
+	}
+}
+
+type assocTestTenantProject struct {
+	database.BaseWithTenant
+	Name string
+}
+
+func (*assocTestTenantProject) TableName() string {
+	return "projects"
+}
+
+type assocTestTenantTodo struct {
+	Project   *assocTestTenantProject
+	ID        string
+	ProjectID string
+}
+
+func (*assocTestTenantTodo) TableName() string {
+	return "todos"
+}
+
+type assocTestTenantTodoFilter struct {
+	Project *assocTestProjectFilter `dbfield:"Project;association"`
+}
+
+func Test_ManageFilter_Association_Tenant(t *testing.T) {
+	sqlDB, _, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer sqlDB.Close()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+
+	filter := &assocTestTenantTodoFilter{
+		Project: &assocTestProjectFilter{Name: &GenericFilter{Eq: "project"}},
+	}
+
+	sql := db.WithContext(tenant.SetInContext(context.TODO(), "tenant1")).ToSQL(func(tx *gorm.DB) *gorm.DB {
+		// Use a new session as manage filter needs a cloneable db
+		res, err := ManageFilter(filter, tx.Session(&gorm.Session{}))
+		require.NoError(t, err)
+
+		return res.Find(&[]*assocTestTenantTodo{})
+	})
+
+	assert.Equal(
+		t,
+		`SELECT * FROM "todos" WHERE EXISTS (SELECT 1 FROM "projects" AS "todos_project" `+
+			`WHERE "todos_project"."tenant_id" = 'tenant1' AND name = 'project' AND "todos"."project_id" = "todos_project"."id" `+
+			`AND "todos_project"."deleted_at" IS NULL)`,
+		sql,
+	)
+
+	// Tenant is required
+	res, err := ManageFilter(filter, db.WithContext(context.TODO()).Session(&gorm.Session{DryRun: true}))
+	require.NoError(t, err)
+
+	err = res.Find(&[]*assocTestTenantTodo{}).Error
+	require.Error(t, err)
+	assert.Equal(t, "no tenant found in context", err.Error())
+}
+
+func Test_ManageFilter_Association_Errors(t *testing.T) {
+	type Filter struct {
+		Project *assocTestProjectFilter `dbfield:"Project;fake"`
+	}
+
+	_, err := ManageFilter(&Filter{Project: &assocTestProjectFilter{}}, &gorm.DB{})
+	require.Error(t, err)
+	assert.Equal(t, "field Project unsupported option in tag dbfield", err.Error())
+}
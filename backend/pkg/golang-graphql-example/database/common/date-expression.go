@@ -0,0 +1,192 @@
+package common
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	// Embed timezone database as runtime images may not have one.
+	_ "time/tzdata"
+
+	gerrors "emperror.dev/errors"
+)
+
+// ErrInvalidDateExpression is raised when a relative date expression cannot be parsed.
+var ErrInvalidDateExpression = gerrors.Sentinel("invalid date expression")
+
+// ErrInvalidTimezone is raised when a date filter timezone isn't a valid IANA timezone.
+var ErrInvalidTimezone = gerrors.Sentinel("invalid timezone")
+
+// Current time function, overridden in tests.
+var nowFunc = time.Now
+
+var (
+	// Anchors are "now", "startOf(<unit>)" and "endOf(<unit>)".
+	dateExpressionAnchorRegexp = regexp.MustCompile(`^(?:now|(startOf|endOf)\(([a-z]+)\))`)
+	// Operations are "+<n><unit>", "-<n><unit>" and "/<unit>" to round down to the start of unit.
+	dateExpressionOperationRegexp = regexp.MustCompile(`^(?:([+-])(\d+)|/)([smhdwMy])`)
+)
+
+// Units by name used in startOf and endOf anchors.
+var dateExpressionUnitNames = map[string]string{
+	"second": "s",
+	"minute": "m",
+	"hour":   "h",
+	"day":    "d",
+	"week":   "w",
+	"month":  "M",
+	"year":   "y",
+}
+
+// isDateExpression will return true when value is a relative date expression.
+func isDateExpression(s string) bool {
+	return dateExpressionAnchorRegexp.MatchString(strings.TrimSpace(s))
+}
+
+// parseDateExpression will evaluate a relative date expression.
+// Expressions are an anchor followed by operations, like "now-7d", "now/d" or "startOf(week)-1w".
+// Units are s (second), m (minute), h (hour), d (day), w (week), M (month) and y (year).
+// Weeks start on monday. Expression is evaluated in the location of now.
+func parseDateExpression(s string, now time.Time) (time.Time, error) {
+	// Initialize remaining expression
+	rest := strings.TrimSpace(s)
+
+	// Parse anchor
+	m := dateExpressionAnchorRegexp.FindStringSubmatch(rest)
+	// Check if it is matching
+	if m == nil {
+		return time.Time{}, gerrors.Wrapf(ErrInvalidDateExpression, "%q must start with now, startOf() or endOf()", s)
+	}
+
+	// Initialize result
+	res := now
+	// Check if it is a start or end of unit
+	if m[1] != "" {
+		// Get unit
+		unit, ok := dateExpressionUnitNames[m[2]]
+		// Check if it exists
+		if !ok {
+			return time.Time{}, gerrors.Wrapf(ErrInvalidDateExpression, "%q unit %s isn't supported", s, m[2])
+		}
+
+		res = startOfDateUnit(now, unit)
+		// Check if it is an end
+		// Databases store microseconds, a nanosecond precision would be rounded up to the next unit on POSTGRES
+		if m[1] == "endOf" {
+			res = addDateUnit(res, unit, 1).Add(-time.Microsecond)
+		}
+	}
+
+	rest = rest[len(m[0]):]
+
+	// Loop over operations
+	for rest != "" {
+		// Parse operation
+		om := dateExpressionOperationRegexp.FindStringSubmatch(rest)
+		// Check if it is matching
+		if om == nil {
+			return time.Time{}, gerrors.Wrapf(ErrInvalidDateExpression, "%q cannot parse %q", s, rest)
+		}
+
+		// Check if it is a rounding
+		if om[1] == "" {
+			res = startOfDateUnit(res, om[3])
+		} else {
+			// Parse number
+			n, err := strconv.Atoi(om[2])
+			// Check error
+			if err != nil {
+				return time.Time{}, gerrors.Wrapf(ErrInvalidDateExpression, "%q cannot parse number %s", s, om[2])
+			}
+			// Check sign
+			if om[1] == "-" {
+				n = -n
+			}
+
+			res = addDateUnit(res, om[3], n)
+		}
+
+		rest = rest[len(om[0]):]
+	}
+
+	return res, nil
+}
+
+// startOfDateUnit will round down time to the start of unit.
+func startOfDateUnit(t time.Time, unit string) time.Time {
+	y, mo, d := t.Date()
+	h, mi, sec := t.Clock()
+	loc := t.Location()
+
+	switch unit {
+	case "s":
+		return time.Date(y, mo, d, h, mi, sec, 0, loc)
+	case "m":
+		return time.Date(y, mo, d, h, mi, 0, 0, loc)
+	case "h":
+		return time.Date(y, mo, d, h, 0, 0, 0, loc)
+	case "w":
+		// Get number of days since monday
+		days := (int(t.Weekday()) + 6) % 7 //nolint:mnd // Days in a week
+
+		return time.Date(y, mo, d-days, 0, 0, 0, 0, loc)
+	case "M":
+		return time.Date(y, mo, 1, 0, 0, 0, 0, loc)
+	case "y":
+		return time.Date(y, time.January, 1, 0, 0, 0, 0, loc)
+	default:
+		return time.Date(y, mo, d, 0, 0, 0, 0, loc)
+	}
+}
+
+// addDateUnit will add n units to time.
+// Days, weeks, months and years are calendar ones in time location.
+func addDateUnit(t time.Time, unit string, n int) time.Time {
+	switch unit {
+	case "s":
+		return t.Add(time.Duration(n) * time.Second)
+	case "m":
+		return t.Add(time.Duration(n) * time.Minute)
+	case "h":
+		return t.Add(time.Duration(n) * time.Hour)
+	case "w":
+		return t.AddDate(0, 0, 7*n) //nolint:mnd // Days in a week
+	case "M":
+		return addMonths(t, n)
+	case "y":
+		return addMonths(t, 12*n) //nolint:mnd // Months in a year
+	default:
+		return t.AddDate(0, 0, n)
+	}
+}
+
+// addMonths will add n calendar months to time.
+// Day is clamped to the last day of the target month like moment and Grafana date math,
+// so one month before March 31 is the end of February and not March 3.
+func addMonths(t time.Time, n int) time.Time {
+	y, mo, d := t.Date()
+	h, mi, sec := t.Clock()
+	// Compute target month
+	target := mo + time.Month(n)
+	// Get last day of target month
+	lastDay := time.Date(y, target+1, 0, 0, 0, 0, 0, t.Location()).Day()
+
+	return time.Date(y, target, min(d, lastDay), h, mi, sec, t.Nanosecond(), t.Location())
+}
+
+// loadTimezone will load an IANA timezone, UTC is used when empty.
+func loadTimezone(tz string) (*time.Location, error) {
+	// Check if timezone is set
+	if tz == "" {
+		return time.UTC, nil
+	}
+
+	// Load
+	loc, err := time.LoadLocation(tz)
+	// Check error
+	if err != nil {
+		return nil, gerrors.Wrapf(ErrInvalidTimezone, "%q", tz)
+	}
+
+	return loc, nil
+}
@@ -0,0 +1,64 @@
+//go:build unit
+
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseDateExpression_MonthEnd(t *testing.T) {
+	tests := []struct {
+		name string
+		now  time.Time
+		expr string
+		want time.Time
+	}{
+		{
+			name: "one month before march 31",
+			now:  time.Date(2021, time.March, 31, 10, 0, 0, 0, time.UTC),
+			expr: "now-1M",
+			want: time.Date(2021, time.February, 28, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "one month before march 31 in leap year",
+			now:  time.Date(2020, time.March, 31, 10, 0, 0, 0, time.UTC),
+			expr: "now-1M",
+			want: time.Date(2020, time.February, 29, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "one month after january 31",
+			now:  time.Date(2021, time.January, 31, 10, 0, 0, 0, time.UTC),
+			expr: "now+1M",
+			want: time.Date(2021, time.February, 28, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "several months across years",
+			now:  time.Date(2021, time.May, 31, 10, 0, 0, 0, time.UTC),
+			expr: "now-15M",
+			want: time.Date(2020, time.February, 29, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "one year after february 29",
+			now:  time.Date(2020, time.February, 29, 10, 0, 0, 0, time.UTC),
+			expr: "now+1y",
+			want: time.Date(2021, time.February, 28, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "day isn't changed when it exists",
+			now:  time.Date(2021, time.March, 15, 10, 0, 0, 0, time.UTC),
+			expr: "now-1M",
+			want: time.Date(2021, time.February, 15, 10, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDateExpression(tt.expr, tt.now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package common
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"emperror.dev/errors"
+)
+
+// DateTruncEnum is used to group dates in aggregation buckets.
+// This must be used as a pointer in group by structures.
+// Moreover, a tag containing the database field must be declared.
+// Example:
+//
+//	type GroupBy struct {
+//		Field1 *DateTruncEnum `dbfield:"field_1"`
+//	}
+//
+// .
+type DateTruncEnum string
+
+var (
+	DateTruncEnumHour  DateTruncEnum = "HOUR"
+	DateTruncEnumDay   DateTruncEnum = "DAY"
+	DateTruncEnumWeek  DateTruncEnum = "WEEK"
+	DateTruncEnumMonth DateTruncEnum = "MONTH"
+	DateTruncEnumYear  DateTruncEnum = "YEAR"
+)
+
+var AllDateTruncEnum = []DateTruncEnum{
+	DateTruncEnumHour,
+	DateTruncEnumDay,
+	DateTruncEnumWeek,
+	DateTruncEnumMonth,
+	DateTruncEnumYear,
+}
+
+func (e DateTruncEnum) IsValid() bool {
+	switch e {
+	case DateTruncEnumHour, DateTruncEnumDay, DateTruncEnumWeek, DateTruncEnumMonth, DateTruncEnumYear:
+		return true
+	}
+
+	return false
+}
+
+func (e DateTruncEnum) String() string {
+	return string(e)
+}
+
+func (e *DateTruncEnum) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return errors.New("enums must be strings")
+	}
+
+	*e = DateTruncEnum(str)
+	if !e.IsValid() {
+		return errors.Errorf("%s is not a valid DateTruncEnum", str)
+	}
+
+	return nil
+}
+
+func (e DateTruncEnum) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
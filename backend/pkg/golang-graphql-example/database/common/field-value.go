@@ -0,0 +1,7 @@
+package common
+
+// FieldValue is a distinct value of a database column.
+type FieldValue struct {
+	// Value typed like the model field
+	Value any
+}
@@ -62,6 +62,16 @@ type GenericFilter struct {
 	In any `mapstructure:"in"`
 	// Allow to test if value isn't in array
 	NotIn any `mapstructure:"notIn"`
+	// Allow to test if value is between 2 values (included).
+	// Between must be an array of 2 values
+	Between any `mapstructure:"between"`
+	// Allow to test if value isn't between 2 values (included).
+	// NotBetween must be an array of 2 values
+	NotBetween any `mapstructure:"notBetween"`
+	// Allow to make a full text search on all words.
+	// This uses to_tsvector/plainto_tsquery on POSTGRES and FTS5 on SQLITE.
+	// Search must be a string
+	Search any `mapstructure:"search"`
 	// Allow to test if value is null
 	IsNull bool `mapstructure:"isNull"`
 	// Allow to test if value is not null
@@ -80,6 +90,8 @@ type GenericFilter struct {
 }
 
 // DateFilter is a structure that will handle filters for dates.
+// Values are RFC3339 dates or relative date expressions evaluated at query time
+// like "now-7d", "now/d" or "startOf(week)". See parseDateExpression for the syntax.
 // This must be used as a pointer in other structures to be used automatically in filters.
 // Moreover, a tag containing the database field must be declared.
 // Example:
@@ -116,6 +128,12 @@ type DateFilter struct {
 	In any `mapstructure:"in"`
 	// Allow to test if value isn't in array
 	NotIn any `mapstructure:"notIn"`
+	// Allow to test if value is between 2 dates (included)
+	Between any `mapstructure:"between"`
+	// Allow to test if value isn't between 2 dates (included)
+	NotBetween any `mapstructure:"notBetween"`
+	// IANA timezone used to evaluate relative date expressions, UTC is used when empty
+	Timezone string `mapstructure:"timezone"`
 	// Allow to test if value is null
 	IsNull bool `mapstructure:"isNull"`
 	// Allow to test if value is not null
@@ -135,10 +153,19 @@ func (d *DateFilter) GetGenericFilter() (*GenericFilter, error) {
 	// Create result
 	res := &GenericFilter{}
 
+	// Load timezone
+	loc, err := loadTimezone(d.Timezone)
+	// Check error
+	if err != nil {
+		return nil, errors.NewInvalidInputErrorWithError(err, errors.WithPublicError(err))
+	}
+	// Get current time in timezone for relative date expressions
+	now := nowFunc().In(loc)
+
 	// Eq case
 	if reflect.ValueOf(d.Eq).IsValid() {
 		// Parse time
-		t, err := parseOrGetTime(d.Eq)
+		t, err := parseOrGetTime(d.Eq, now)
 		// Check error
 		if err != nil {
 			return nil, err
@@ -150,7 +177,7 @@ func (d *DateFilter) GetGenericFilter() (*GenericFilter, error) {
 	// Not Eq case
 	if reflect.ValueOf(d.NotEq).IsValid() {
 		// Parse time
-		t, err := parseOrGetTime(d.NotEq)
+		t, err := parseOrGetTime(d.NotEq, now)
 		// Check error
 		if err != nil {
 			return nil, err
@@ -162,7 +189,7 @@ func (d *DateFilter) GetGenericFilter() (*GenericFilter, error) {
 	// Gte case
 	if reflect.ValueOf(d.Gte).IsValid() {
 		// Parse time
-		t, err := parseOrGetTime(d.Gte)
+		t, err := parseOrGetTime(d.Gte, now)
 		// Check error
 		if err != nil {
 			return nil, err
@@ -174,7 +201,7 @@ func (d *DateFilter) GetGenericFilter() (*GenericFilter, error) {
 	// Not Gte case
 	if reflect.ValueOf(d.NotGte).IsValid() {
 		// Parse time
-		t, err := parseOrGetTime(d.NotGte)
+		t, err := parseOrGetTime(d.NotGte, now)
 		// Check error
 		if err != nil {
 			return nil, err
@@ -186,7 +213,7 @@ func (d *DateFilter) GetGenericFilter() (*GenericFilter, error) {
 	// Gt case
 	if reflect.ValueOf(d.Gt).IsValid() {
 		// Parse time
-		t, err := parseOrGetTime(d.Gt)
+		t, err := parseOrGetTime(d.Gt, now)
 		// Check error
 		if err != nil {
 			return nil, err
@@ -198,7 +225,7 @@ func (d *DateFilter) GetGenericFilter() (*GenericFilter, error) {
 	// Not Gt case
 	if reflect.ValueOf(d.NotGt).IsValid() {
 		// Parse time
-		t, err := parseOrGetTime(d.NotGt)
+		t, err := parseOrGetTime(d.NotGt, now)
 		// Check error
 		if err != nil {
 			return nil, err
@@ -210,7 +237,7 @@ func (d *DateFilter) GetGenericFilter() (*GenericFilter, error) {
 	// Lte case
 	if reflect.ValueOf(d.Lte).IsValid() {
 		// Parse time
-		t, err := parseOrGetTime(d.Lte)
+		t, err := parseOrGetTime(d.Lte, now)
 		// Check error
 		if err != nil {
 			return nil, err
@@ -222,7 +249,7 @@ func (d *DateFilter) GetGenericFilter() (*GenericFilter, error) {
 	// Not Lte case
 	if reflect.ValueOf(d.NotLte).IsValid() {
 		// Parse time
-		t, err := parseOrGetTime(d.NotLte)
+		t, err := parseOrGetTime(d.NotLte, now)
 		// Check error
 		if err != nil {
 			return nil, err
@@ -234,7 +261,7 @@ func (d *DateFilter) GetGenericFilter() (*GenericFilter, error) {
 	// Lt case
 	if reflect.ValueOf(d.Lt).IsValid() {
 		// Parse time
-		t, err := parseOrGetTime(d.Lt)
+		t, err := parseOrGetTime(d.Lt, now)
 		// Check error
 		if err != nil {
 			return nil, err
@@ -246,7 +273,7 @@ func (d *DateFilter) GetGenericFilter() (*GenericFilter, error) {
 	// Not Lt case
 	if reflect.ValueOf(d.NotLt).IsValid() {
 		// Parse time
-		t, err := parseOrGetTime(d.NotLt)
+		t, err := parseOrGetTime(d.NotLt, now)
 		// Check error
 		if err != nil {
 			return nil, err
@@ -258,7 +285,7 @@ func (d *DateFilter) GetGenericFilter() (*GenericFilter, error) {
 	// In case
 	if reflect.ValueOf(d.In).IsValid() {
 		// Parse time
-		t, err := parseOrGetTimes(d.In)
+		t, err := parseOrGetTimes(d.In, now)
 		// Check error
 		if err != nil {
 			return nil, err
@@ -270,7 +297,7 @@ func (d *DateFilter) GetGenericFilter() (*GenericFilter, error) {
 	// Not In case
 	if reflect.ValueOf(d.NotIn).IsValid() {
 		// Parse time
-		t, err := parseOrGetTimes(d.NotIn)
+		t, err := parseOrGetTimes(d.NotIn, now)
 		// Check error
 		if err != nil {
 			return nil, err
@@ -279,6 +306,30 @@ func (d *DateFilter) GetGenericFilter() (*GenericFilter, error) {
 		res.NotIn = t
 	}
 
+	// Between case
+	if reflect.ValueOf(d.Between).IsValid() {
+		// Parse times
+		t, err := parseOrGetTimeRange(d.Between, now)
+		// Check error
+		if err != nil {
+			return nil, err
+		}
+
+		res.Between = t
+	}
+
+	// Not Between case
+	if reflect.ValueOf(d.NotBetween).IsValid() {
+		// Parse times
+		t, err := parseOrGetTimeRange(d.NotBetween, now)
+		// Check error
+		if err != nil {
+			return nil, err
+		}
+
+		res.NotBetween = t
+	}
+
 	// Apply is null
 	res.IsNull = d.IsNull
 	// Apply is not null
@@ -287,7 +338,7 @@ func (d *DateFilter) GetGenericFilter() (*GenericFilter, error) {
 	return res, nil
 }
 
-func parseOrGetTime(x any) (*time.Time, error) {
+func parseOrGetTime(x any, now time.Time) (*time.Time, error) {
 	// Get value in reflect mode
 	val := reflect.Indirect(reflect.ValueOf(x))
 	// Get interface value
@@ -296,8 +347,15 @@ func parseOrGetTime(x any) (*time.Time, error) {
 	// Switch on type
 	switch v := valInt.(type) {
 	case string:
+		// Initialize parse function
+		parseFn := func(s string) (time.Time, error) { return time.Parse(time.RFC3339Nano, s) }
+		// Check if it is a relative date expression
+		if isDateExpression(v) {
+			parseFn = func(s string) (time.Time, error) { return parseDateExpression(s, now) }
+		}
+
 		// Parse date
-		t, err := time.Parse(time.RFC3339Nano, v)
+		t, err := parseFn(v)
 		// Check error
 		if err != nil {
 			// In this particular case, display error in public message in order to help api user to detect the error
@@ -320,7 +378,7 @@ func parseOrGetTime(x any) (*time.Time, error) {
 	}
 }
 
-func parseOrGetTimes(x any) ([]*time.Time, error) {
+func parseOrGetTimes(x any, now time.Time) ([]*time.Time, error) {
 	// Prepare result
 	res := make([]*time.Time, 0)
 
@@ -337,7 +395,7 @@ func parseOrGetTimes(x any) ([]*time.Time, error) {
 		// Get value
 		v := val.Index(i).Interface()
 		// Parse time
-		t, err := parseOrGetTime(v)
+		t, err := parseOrGetTime(v, now)
 		// Check error
 		if err != nil {
 			return nil, err
@@ -348,3 +406,19 @@ func parseOrGetTimes(x any) ([]*time.Time, error) {
 
 	return res, nil
 }
+
+func parseOrGetTimeRange(x any, now time.Time) ([]*time.Time, error) {
+	// Parse times
+	res, err := parseOrGetTimes(x, now)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	// Check that it is a range
+	if len(res) != 2 { //nolint:mnd // Start and end
+		return nil, errors.NewInvalidInputError("date range must contain a start and an end dates")
+	}
+
+	return res, nil
+}
@@ -27,21 +27,32 @@ func TestDateFilter_GetGenericFilter(t *testing.T) {
 	date = date.UTC()
 	date2 = date2.UTC()
 
+	// Force current time for relative date expressions
+	now := time.Date(2020, time.September, 19, 23, 10, 35, 0, time.UTC)
+	oldNowFunc := nowFunc
+	nowFunc = func() time.Time { return now }
+	t.Cleanup(func() { nowFunc = oldNowFunc })
+
+	starTime := func(t time.Time) *time.Time { return &t }
+
 	type fields struct {
-		Eq        any
-		NotEq     any
-		Gte       any
-		NotGte    any
-		Gt        any
-		NotGt     any
-		Lte       any
-		NotLte    any
-		Lt        any
-		NotLt     any
-		In        any
-		NotIn     any
-		IsNull    bool
-		IsNotNull bool
+		Eq         any
+		NotEq      any
+		Gte        any
+		NotGte     any
+		Gt         any
+		NotGt      any
+		Lte        any
+		NotLte     any
+		Lt         any
+		NotLt      any
+		In         any
+		NotIn      any
+		Between    any
+		NotBetween any
+		Timezone   string
+		IsNull     bool
+		IsNotNull  bool
 	}
 	tests := []struct {
 		name    string
@@ -584,6 +595,102 @@ func TestDateFilter_GetGenericFilter(t *testing.T) {
 			fields: fields{NotIn: nil},
 			want:   &GenericFilter{},
 		},
+		{
+			name:   "Eq case (now)",
+			fields: fields{Eq: "now"},
+			want:   &GenericFilter{Eq: &now},
+		},
+		{
+			name:   "Eq case (now minus 7 days)",
+			fields: fields{Eq: "now-7d"},
+			want:   &GenericFilter{Eq: starTime(time.Date(2020, time.September, 12, 23, 10, 35, 0, time.UTC))},
+		},
+		{
+			name:   "Eq case (now rounded to day)",
+			fields: fields{Eq: "now/d"},
+			want:   &GenericFilter{Eq: starTime(time.Date(2020, time.September, 19, 0, 0, 0, 0, time.UTC))},
+		},
+		{
+			name:   "Gte case (start of week)",
+			fields: fields{Gte: "startOf(week)"},
+			want:   &GenericFilter{Gte: starTime(time.Date(2020, time.September, 14, 0, 0, 0, 0, time.UTC))},
+		},
+		{
+			name:   "Lte case (end of month)",
+			fields: fields{Lte: "endOf(month)"},
+			want:   &GenericFilter{Lte: starTime(time.Date(2020, time.September, 30, 23, 59, 59, 999999000, time.UTC))},
+		},
+		{
+			name:   "Lt case (start of year plus 1 month minus 1 day)",
+			fields: fields{Lt: "startOf(year)+1M-1d"},
+			want:   &GenericFilter{Lt: starTime(time.Date(2020, time.January, 31, 0, 0, 0, 0, time.UTC))},
+		},
+		{
+			name:   "In case (relative dates)",
+			fields: fields{In: []string{"now-1h", "now+30m"}},
+			want: &GenericFilter{In: []*time.Time{
+				starTime(time.Date(2020, time.September, 19, 22, 10, 35, 0, time.UTC)),
+				starTime(time.Date(2020, time.September, 19, 23, 40, 35, 0, time.UTC)),
+			}},
+		},
+		{
+			name:   "Eq case (now rounded to day with timezone)",
+			fields: fields{Eq: "now/d", Timezone: "Europe/Paris"},
+			want:   &GenericFilter{Eq: starTime(time.Date(2020, time.September, 19, 22, 0, 0, 0, time.UTC))},
+		},
+		{
+			name:   "Eq case (absolute date with timezone)",
+			fields: fields{Eq: dateStr, Timezone: "Europe/Paris"},
+			want:   &GenericFilter{Eq: &date},
+		},
+		{
+			name:    "Eq invalid relative date unit",
+			fields:  fields{Eq: "now-7x"},
+			wantErr: true,
+		},
+		{
+			name:    "Eq invalid relative date anchor unit",
+			fields:  fields{Eq: "startOf(decade)"},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid timezone",
+			fields:  fields{Eq: "now", Timezone: "Mars/Olympus"},
+			wantErr: true,
+		},
+		{
+			name:   "Between case",
+			fields: fields{Between: []string{dateStr, "now"}},
+			want:   &GenericFilter{Between: []*time.Time{&date, &now}},
+		},
+		{
+			name:   "Between case (pointer strings)",
+			fields: fields{Between: []*string{&dateStr, &dateStr2}},
+			want:   &GenericFilter{Between: []*time.Time{&date, &date2}},
+		},
+		{
+			name:    "Between with 1 date",
+			fields:  fields{Between: []string{dateStr}},
+			wantErr: true,
+		},
+		{
+			name:    "Between not a date",
+			fields:  fields{Between: []string{dateStr, notADate}},
+			wantErr: true,
+		},
+		{
+			name:   "NotBetween case",
+			fields: fields{NotBetween: []string{"startOf(day)", "endOf(day)"}},
+			want: &GenericFilter{NotBetween: []*time.Time{
+				starTime(time.Date(2020, time.September, 19, 0, 0, 0, 0, time.UTC)),
+				starTime(time.Date(2020, time.September, 19, 23, 59, 59, 999999000, time.UTC)),
+			}},
+		},
+		{
+			name:    "NotBetween with 3 dates",
+			fields:  fields{NotBetween: []string{dateStr, dateStr, dateStr}},
+			wantErr: true,
+		},
 		{
 			name:   "IsNull case",
 			fields: fields{IsNull: true},
@@ -598,20 +705,23 @@ func TestDateFilter_GetGenericFilter(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			d := &DateFilter{
-				Eq:        tt.fields.Eq,
-				NotEq:     tt.fields.NotEq,
-				Gte:       tt.fields.Gte,
-				NotGte:    tt.fields.NotGte,
-				Gt:        tt.fields.Gt,
-				NotGt:     tt.fields.NotGt,
-				Lte:       tt.fields.Lte,
-				NotLte:    tt.fields.NotLte,
-				Lt:        tt.fields.Lt,
-				NotLt:     tt.fields.NotLt,
-				In:        tt.fields.In,
-				NotIn:     tt.fields.NotIn,
-				IsNull:    tt.fields.IsNull,
-				IsNotNull: tt.fields.IsNotNull,
+				Eq:         tt.fields.Eq,
+				NotEq:      tt.fields.NotEq,
+				Gte:        tt.fields.Gte,
+				NotGte:     tt.fields.NotGte,
+				Gt:         tt.fields.Gt,
+				NotGt:      tt.fields.NotGt,
+				Lte:        tt.fields.Lte,
+				NotLte:     tt.fields.NotLte,
+				Lt:         tt.fields.Lt,
+				NotLt:      tt.fields.NotLt,
+				In:         tt.fields.In,
+				NotIn:      tt.fields.NotIn,
+				Between:    tt.fields.Between,
+				NotBetween: tt.fields.NotBetween,
+				Timezone:   tt.fields.Timezone,
+				IsNull:     tt.fields.IsNull,
+				IsNotNull:  tt.fields.IsNotNull,
 			}
 			got, err := d.GetGenericFilter()
 			if (err != nil) != tt.wantErr {
@@ -16,8 +16,32 @@ const andFieldName = "AND"
 // OR field.
 const orFieldName = "OR"
 
+// Escape LIKE wildcards with backslash.
+var likeValueEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
 func ManageFilter(filter any, db *gorm.DB) (*gorm.DB, error) {
-	return manageFilter(filter, db, false)
+	// Manage filter
+	res, err := manageFilter(filter, db, false)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	// Collect search terms for relevance sorts
+	terms := map[string]string{}
+	collectSearchTerms(filter, terms)
+	// Check if terms are found
+	if len(terms) != 0 {
+		res = res.Set(searchTermsSettingKey, terms)
+	}
+
+	return res, nil
+}
+
+// ManageColumnFilter will apply a generic filter on a database column.
+// Column must be validated by caller as it is used as is in queries.
+func ManageColumnFilter(dbCol string, filter *GenericFilter, db *gorm.DB) (*gorm.DB, error) {
+	return manageFilterRequest(dbCol, filter, db)
 }
 
 func manageFilter(
@@ -89,6 +113,22 @@ func manageFilter(
 		// Get value from field
 		val := fVal.Interface()
 
+		// Parse tag options
+		col, opt, _ := strings.Cut(tagVal, ";")
+		// Check if it is an association filter
+		if opt == filterTagAssociationOption {
+			// Manage result
+			tmpDB = appendFilterGroup(tmpDB, originalDB.Where(&associationFilterExpression{name: col, filter: val}))
+
+			continue
+		}
+		// Check that option is supported
+		if opt != "" {
+			return nil, errors.NewInvalidInputError(
+				fmt.Sprintf("field %s unsupported option in tag %s", fType.Name, dbColTagName),
+			)
+		}
+
 		// Try to cast it as GenericFilterBuilder
 		v1, castGFB := val.(GenericFilterBuilder)
 		// Check that type is supported
@@ -117,14 +157,7 @@ func manageFilter(
 		}
 
 		// Manage result
-		// Check if it is the first time
-		if tmpDB == nil {
-			// Init with result
-			tmpDB = res2
-		} else {
-			// Simply add the value with the where to the previous one
-			tmpDB = tmpDB.Where(res2)
-		}
+		tmpDB = appendFilterGroup(tmpDB, res2)
 	}
 
 	// Check if it is set
@@ -235,6 +268,17 @@ func manageFilter(
 	return res, nil
 }
 
+func appendFilterGroup(tmpDB, res *gorm.DB) *gorm.DB {
+	// Check if it is the first time
+	if tmpDB == nil {
+		// Init with result
+		return res
+	}
+
+	// Simply add the value with the where to the previous one
+	return tmpDB.Where(res)
+}
+
 func GenerateQueryTemplate(
 	operation string,
 	value string,
@@ -265,13 +309,66 @@ func GenerateQueryTemplate(
 	return first + " " + operation + " " + second
 }
 
+// EscapeLikeValue will escape LIKE wildcards in order to match them literally.
+// Backslash is used as escape character on all dialects.
+func EscapeLikeValue(s string) string {
+	return likeValueEscaper.Replace(s)
+}
+
+// generateLikeQuery will generate a LIKE query on column supported by dialect.
+func generateLikeQuery(dialect, dbCol string, v *GenericFilter) string {
+	// Initialize operation
+	operation := "LIKE"
+	// LIKE is case insensitive on mysql default collations, a binary comparison keeps it case sensitive
+	if dialect == "mysql" && !v.CaseInsensitive {
+		operation = "LIKE BINARY"
+	}
+
+	// Generate query
+	res := fmt.Sprintf(
+		GenerateQueryTemplate(
+			operation,
+			"?",
+			v.FieldUppercase,
+			v.FieldLowercase,
+			v.ValueUppercase,
+			v.ValueLowercase,
+			v.CaseInsensitive,
+		),
+		dbCol,
+	)
+
+	// Sqlite doesn't have a default escape character
+	if dialect == "sqlite" {
+		res += ` ESCAPE '\'`
+	}
+
+	return res
+}
+
+// getEqualOperator will return the equal operator supported by dialect for value.
+func getEqualOperator(dialect string, v *GenericFilter, value any) string {
+	// Check if it is a string comparison on mysql
+	// Comparisons are case insensitive on mysql default collations, a binary comparison keeps them case sensitive
+	if dialect == "mysql" && !v.CaseInsensitive {
+		// Check if value is a string
+		if _, err := getStringValue(value); err == nil {
+			return "= BINARY"
+		}
+	}
+
+	return "="
+}
+
 func manageFilterRequest(dbCol string, v *GenericFilter, db *gorm.DB) (*gorm.DB, error) {
 	// Create result
 	dbRes := db
+	// Get dialect
+	dialect := db.Dialector.Name()
 	// Check Equal case
 	if v.Eq != nil {
 		tpl := GenerateQueryTemplate(
-			"=",
+			getEqualOperator(dialect, v, v.Eq),
 			"?",
 			v.FieldUppercase,
 			v.FieldLowercase,
@@ -284,7 +381,7 @@ func manageFilterRequest(dbCol string, v *GenericFilter, db *gorm.DB) (*gorm.DB,
 	// Check not equal case
 	if v.NotEq != nil {
 		tpl := GenerateQueryTemplate(
-			"=",
+			getEqualOperator(dialect, v, v.NotEq),
 			"?",
 			v.FieldUppercase,
 			v.FieldLowercase,
@@ -335,16 +432,7 @@ func manageFilterRequest(dbCol string, v *GenericFilter, db *gorm.DB) (*gorm.DB,
 			return nil, errors.NewInvalidInputError("contains " + err.Error())
 		}
 
-		tpl := GenerateQueryTemplate(
-			"LIKE",
-			"?",
-			v.FieldUppercase,
-			v.FieldLowercase,
-			v.ValueUppercase,
-			v.ValueLowercase,
-			v.CaseInsensitive,
-		)
-		dbRes = dbRes.Where(fmt.Sprintf(tpl, dbCol), fmt.Sprintf("%%%s%%", s))
+		dbRes = dbRes.Where(generateLikeQuery(dialect, dbCol, v), "%"+EscapeLikeValue(s)+"%")
 	}
 	// Check not contains case
 	if v.NotContains != nil {
@@ -355,16 +443,7 @@ func manageFilterRequest(dbCol string, v *GenericFilter, db *gorm.DB) (*gorm.DB,
 			return nil, errors.NewInvalidInputError("notContains " + err.Error())
 		}
 
-		tpl := GenerateQueryTemplate(
-			"LIKE",
-			"?",
-			v.FieldUppercase,
-			v.FieldLowercase,
-			v.ValueUppercase,
-			v.ValueLowercase,
-			v.CaseInsensitive,
-		)
-		dbRes = dbRes.Not(fmt.Sprintf(tpl, dbCol), fmt.Sprintf("%%%s%%", s))
+		dbRes = dbRes.Not(generateLikeQuery(dialect, dbCol, v), "%"+EscapeLikeValue(s)+"%")
 	}
 	// Check starts with case
 	if v.StartsWith != nil {
@@ -375,16 +454,7 @@ func manageFilterRequest(dbCol string, v *GenericFilter, db *gorm.DB) (*gorm.DB,
 			return nil, errors.NewInvalidInputError("startsWith " + err.Error())
 		}
 
-		tpl := GenerateQueryTemplate(
-			"LIKE",
-			"?",
-			v.FieldUppercase,
-			v.FieldLowercase,
-			v.ValueUppercase,
-			v.ValueLowercase,
-			v.CaseInsensitive,
-		)
-		dbRes = dbRes.Where(fmt.Sprintf(tpl, dbCol), s+"%")
+		dbRes = dbRes.Where(generateLikeQuery(dialect, dbCol, v), EscapeLikeValue(s)+"%")
 	}
 	// Check not starts with case
 	if v.NotStartsWith != nil {
@@ -395,16 +465,7 @@ func manageFilterRequest(dbCol string, v *GenericFilter, db *gorm.DB) (*gorm.DB,
 			return nil, errors.NewInvalidInputError("notStartsWith " + err.Error())
 		}
 
-		tpl := GenerateQueryTemplate(
-			"LIKE",
-			"?",
-			v.FieldUppercase,
-			v.FieldLowercase,
-			v.ValueUppercase,
-			v.ValueLowercase,
-			v.CaseInsensitive,
-		)
-		dbRes = dbRes.Not(fmt.Sprintf(tpl, dbCol), s+"%")
+		dbRes = dbRes.Not(generateLikeQuery(dialect, dbCol, v), EscapeLikeValue(s)+"%")
 	}
 	// Check ends with case
 	if v.EndsWith != nil {
@@ -415,16 +476,7 @@ func manageFilterRequest(dbCol string, v *GenericFilter, db *gorm.DB) (*gorm.DB,
 			return nil, errors.NewInvalidInputError("endsWith " + err.Error())
 		}
 
-		tpl := GenerateQueryTemplate(
-			"LIKE",
-			"?",
-			v.FieldUppercase,
-			v.FieldLowercase,
-			v.ValueUppercase,
-			v.ValueLowercase,
-			v.CaseInsensitive,
-		)
-		dbRes = dbRes.Where(fmt.Sprintf(tpl, dbCol), "%"+s)
+		dbRes = dbRes.Where(generateLikeQuery(dialect, dbCol, v), "%"+EscapeLikeValue(s))
 	}
 	// Check not ends with case
 	if v.NotEndsWith != nil {
@@ -435,16 +487,21 @@ func manageFilterRequest(dbCol string, v *GenericFilter, db *gorm.DB) (*gorm.DB,
 			return nil, errors.NewInvalidInputError("notEndsWith " + err.Error())
 		}
 
-		tpl := GenerateQueryTemplate(
-			"LIKE",
-			"?",
-			v.FieldUppercase,
-			v.FieldLowercase,
-			v.ValueUppercase,
-			v.ValueLowercase,
-			v.CaseInsensitive,
-		)
-		dbRes = dbRes.Not(fmt.Sprintf(tpl, dbCol), "%"+s)
+		dbRes = dbRes.Not(generateLikeQuery(dialect, dbCol, v), "%"+EscapeLikeValue(s))
+	}
+	// Check search case
+	if v.Search != nil {
+		// Get string value
+		s, err := getStringValue(v.Search)
+		// Check error
+		if err != nil {
+			return nil, errors.NewInvalidInputError("search " + err.Error())
+		}
+
+		// Ignore empty search
+		if strings.TrimSpace(s) != "" {
+			dbRes = dbRes.Where(&searchExpression{column: dbCol, term: s})
+		}
 	}
 	// Check in case
 	if v.In != nil {
@@ -488,6 +545,28 @@ func manageFilterRequest(dbCol string, v *GenericFilter, db *gorm.DB) (*gorm.DB,
 		)
 		dbRes = dbRes.Where(fmt.Sprintf(tpl, dbCol), val)
 	}
+	// Check between case
+	if v.Between != nil {
+		// Get bounds
+		start, end, err := getRangeValues(v.Between)
+		// Check error
+		if err != nil {
+			return nil, errors.NewInvalidInputError("between " + err.Error())
+		}
+
+		dbRes = dbRes.Where(dbCol+" BETWEEN ? AND ?", start, end)
+	}
+	// Check not between case
+	if v.NotBetween != nil {
+		// Get bounds
+		start, end, err := getRangeValues(v.NotBetween)
+		// Check error
+		if err != nil {
+			return nil, errors.NewInvalidInputError("notBetween " + err.Error())
+		}
+
+		dbRes = dbRes.Not(dbCol+" BETWEEN ? AND ?", start, end)
+	}
 	// Check is null case
 	if v.IsNull {
 		dbRes = dbRes.Where(dbCol + " IS NULL")
@@ -583,3 +662,14 @@ func getStringValue(x any) (string, error) {
 
 	return val.String(), nil
 }
+
+func getRangeValues(x any) (any, any, error) {
+	// Get reflect value
+	val := reflect.ValueOf(x)
+	// Check if type is acceptable
+	if (val.Kind() != reflect.Slice && val.Kind() != reflect.Array) || val.Len() != 2 { //nolint:mnd // Start and end
+		return nil, nil, errors.NewInvalidInputError("value must be an array of 2 values")
+	}
+
+	return val.Index(0).Interface(), val.Index(1).Interface(), nil
+}
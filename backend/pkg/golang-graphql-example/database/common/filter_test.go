@@ -10,7 +10,9 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
@@ -2076,6 +2078,14 @@ func Test_manageFilterRequest(t *testing.T) {
 			expectedArgs:              []driver.Value{"fake"},
 		},
 		// CONTAINS
+		{
+			name: "contains case with wildcards must be escaped",
+			args: args{
+				v: &GenericFilter{Contains: `50%_of\`},
+			},
+			expectedIntermediateQuery: "WHERE field_1 LIKE $1",
+			expectedArgs:              []driver.Value{`%50\%\_of\\%`},
+		},
 		{
 			name: "contains case with *string",
 			args: args{
@@ -3089,6 +3099,56 @@ func Test_manageFilterRequest(t *testing.T) {
 			expectedIntermediateQuery: "WHERE field_1 IS NOT NULL",
 			expectedArgs:              []driver.Value{},
 		},
+		// BETWEEN
+		{
+			name: "between case with []int",
+			args: args{
+				v: &GenericFilter{Between: []int{1, 2}},
+			},
+			expectedIntermediateQuery: "WHERE field_1 BETWEEN $1 AND $2",
+			expectedArgs:              []driver.Value{1, 2},
+		},
+		{
+			name: "between case with []*time.Time",
+			args: args{
+				v: &GenericFilter{Between: []*time.Time{&now, &now}},
+			},
+			expectedIntermediateQuery: "WHERE field_1 BETWEEN $1 AND $2",
+			expectedArgs:              []driver.Value{now, now},
+		},
+		{
+			name: "between case with 1 value",
+			args: args{
+				v: &GenericFilter{Between: []int{1}},
+			},
+			wantErr:     true,
+			errorString: "between value must be an array of 2 values",
+		},
+		{
+			name: "between case with int",
+			args: args{
+				v: &GenericFilter{Between: 1},
+			},
+			wantErr:     true,
+			errorString: "between value must be an array of 2 values",
+		},
+		// NOT BETWEEN
+		{
+			name: "not between case with []int",
+			args: args{
+				v: &GenericFilter{NotBetween: []int{1, 2}},
+			},
+			expectedIntermediateQuery: "WHERE NOT (field_1 BETWEEN $1 AND $2)",
+			expectedArgs:              []driver.Value{1, 2},
+		},
+		{
+			name: "not between case with 3 values",
+			args: args{
+				v: &GenericFilter{NotBetween: []int{1, 2, 3}},
+			},
+			wantErr:     true,
+			errorString: "notBetween value must be an array of 2 values",
+		},
 		// All at the same time
 		{
 			name: "all at the same time",
@@ -3191,6 +3251,182 @@ func Test_manageFilterRequest(t *testing.T) {
 	}
 }
 
+func Test_manageFilterRequest_MySQL(t *testing.T) {
+	type Person struct {
+		Name string
+	}
+	tests := []struct {
+		name                      string
+		v                         *GenericFilter
+		expectedIntermediateQuery string
+		expectedArgs              []driver.Value
+	}{
+		{
+			name:                      "eq case with string",
+			v:                         &GenericFilter{Eq: "fake"},
+			expectedIntermediateQuery: "WHERE field_1 = BINARY ?",
+			expectedArgs:              []driver.Value{"fake"},
+		},
+		{
+			name:                      "eq case with int",
+			v:                         &GenericFilter{Eq: 1},
+			expectedIntermediateQuery: "WHERE field_1 = ?",
+			expectedArgs:              []driver.Value{1},
+		},
+		{
+			name:                      "eq case with case insensitive",
+			v:                         &GenericFilter{Eq: "fake", CaseInsensitive: true},
+			expectedIntermediateQuery: "WHERE lower(field_1) = lower(?)",
+			expectedArgs:              []driver.Value{"fake"},
+		},
+		{
+			name:                      "not eq case with value lowercase",
+			v:                         &GenericFilter{NotEq: "fake", ValueLowercase: true},
+			expectedIntermediateQuery: "WHERE NOT field_1 = BINARY lower(?)",
+			expectedArgs:              []driver.Value{"fake"},
+		},
+		{
+			name:                      "contains case",
+			v:                         &GenericFilter{Contains: "fa%ke"},
+			expectedIntermediateQuery: "WHERE field_1 LIKE BINARY ?",
+			expectedArgs:              []driver.Value{`%fa\%ke%`},
+		},
+		{
+			name:                      "contains case with case insensitive",
+			v:                         &GenericFilter{Contains: "fake", CaseInsensitive: true},
+			expectedIntermediateQuery: "WHERE lower(field_1) LIKE lower(?)",
+			expectedArgs:              []driver.Value{"%fake%"},
+		},
+		{
+			name:                      "not starts with case with field uppercase",
+			v:                         &GenericFilter{NotStartsWith: "fake", FieldUppercase: true},
+			expectedIntermediateQuery: "WHERE NOT upper(field_1) LIKE BINARY ?",
+			expectedArgs:              []driver.Value{"fake%"},
+		},
+		{
+			name:                      "ends with case",
+			v:                         &GenericFilter{EndsWith: "fake_"},
+			expectedIntermediateQuery: "WHERE field_1 LIKE BINARY ?",
+			expectedArgs:              []driver.Value{`%fake\_`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			defer sqlDB.Close()
+
+			db, err := gorm.Open(
+				mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}),
+				&gorm.Config{Logger: logger.Discard},
+			)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			got, err := manageFilterRequest("field_1", tt.v, db)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			mock.ExpectQuery("SELECT * FROM `people` " + tt.expectedIntermediateQuery + " ORDER BY `people`.`name` LIMIT ?").
+				WithArgs(append(tt.expectedArgs, 1)...).
+				WillReturnRows(
+					sqlmock.NewRows([]string{"name"}).AddRow("fake"),
+				)
+
+			// Run fake find to force query to be run
+			res := got.First(&Person{})
+			// Test error
+			if res.Error != nil {
+				t.Error(res.Error)
+			}
+		})
+	}
+}
+
+func Test_manageFilterRequest_SQLite(t *testing.T) {
+	type Person struct {
+		Name string
+	}
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = db.AutoMigrate(&Person{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = db.Create([]*Person{{Name: "100% sure"}, {Name: "100 percent"}, {Name: "a_b"}, {Name: "axb"}, {Name: "Fake"}}).Error
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	tests := []struct {
+		name     string
+		v        *GenericFilter
+		expected []string
+	}{
+		{
+			name:     "contains with percent wildcard must be escaped",
+			v:        &GenericFilter{Contains: "0%"},
+			expected: []string{"100% sure"},
+		},
+		{
+			name:     "contains with underscore wildcard must be escaped",
+			v:        &GenericFilter{Contains: "_"},
+			expected: []string{"a_b"},
+		},
+		{
+			name:     "not starts with escaped",
+			v:        &GenericFilter{NotStartsWith: "a_"},
+			expected: []string{"100% sure", "100 percent", "axb", "Fake"},
+		},
+		{
+			name:     "ends with case insensitive",
+			v:        &GenericFilter{EndsWith: "AKE", CaseInsensitive: true},
+			expected: []string{"Fake"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := manageFilterRequest("name", tt.v, db)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			var res []*Person
+
+			err = got.Find(&res).Error
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			names := make([]string, 0, len(res))
+			for _, p := range res {
+				names = append(names, p.Name)
+			}
+
+			if !reflect.DeepEqual(names, tt.expected) {
+				t.Errorf("manageFilterRequest() = %v, want %v", names, tt.expected)
+			}
+		})
+	}
+}
+
 func Test_transformToLowerOrUpperCasesList(t *testing.T) {
 	type args struct {
 		input       any
@@ -0,0 +1,287 @@
+package common
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"emperror.dev/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FullTextSearchLanguage is the text search configuration used on POSTGRES.
+// Indexes must be created with the same configuration to be used.
+const FullTextSearchLanguage = "simple"
+
+// Setting key used to save search terms per column in gorm statement.
+// This is used by relevance sorts.
+const searchTermsSettingKey = "common:search_terms"
+
+// ErrFullTextSearchNotSupported is raised when the database driver doesn't support full text search.
+var ErrFullTextSearchNotSupported = errors.Sentinel("full text search isn't supported by database driver")
+
+// GetFullTextSearchTableName will return the SQLITE FTS5 table name used for a column.
+func GetFullTextSearchTableName(table, column string) string {
+	return table + "_" + getBareColumnName(column) + "_fts"
+}
+
+// SQLITE FTS5 support per dialector.
+var sqliteFTS5Support sync.Map
+
+// HasSQLiteFTS5 will check if the SQLITE library is compiled with FTS5 (sqlite_fts5 build tag).
+// Without it, search filters fall back to LIKE on all words and relevance isn't computed.
+func HasSQLiteFTS5(db *gorm.DB) bool {
+	// Check cache
+	if v, ok := sqliteFTS5Support.Load(db.Dialector); ok {
+		return v.(bool) //nolint:forcetypeassert // Only booleans are stored
+	}
+
+	// Get compile options
+	var opts []string
+
+	err := db.Session(&gorm.Session{NewDB: true}).Raw("pragma compile_options").Scan(&opts).Error
+	// Check result
+	res := err == nil && slices.Contains(opts, "ENABLE_FTS5")
+
+	// Save
+	sqliteFTS5Support.Store(db.Dialector, res)
+
+	return res
+}
+
+type searchExpression struct {
+	column string
+	term   string
+}
+
+func (e *searchExpression) Build(builder clause.Builder) {
+	// Get statement
+	stmt, ok := builder.(*gorm.Statement)
+	// Check if it is a statement
+	if !ok {
+		return
+	}
+
+	switch stmt.Dialector.Name() {
+	case "postgres":
+		builder.WriteString("to_tsvector('" + FullTextSearchLanguage + "', " + e.column + ") @@ ")
+		builder.WriteString("plainto_tsquery('" + FullTextSearchLanguage + "', ")
+		builder.AddVar(builder, e.term)
+		builder.WriteByte(')')
+	case "sqlite":
+		// Check if FTS5 is available
+		if !HasSQLiteFTS5(stmt.DB) {
+			buildLikeSearch(builder, e.column, e.term)
+
+			return
+		}
+
+		// Get fts table name
+		ftsTable := GetFullTextSearchTableName(stmt.Table, e.column)
+
+		builder.WriteQuoted(clause.Table{Name: stmt.Table})
+		builder.WriteString(".rowid IN (SELECT rowid FROM ")
+		builder.WriteQuoted(ftsTable)
+		builder.WriteString(" WHERE ")
+		builder.WriteQuoted(ftsTable)
+		builder.WriteString(" MATCH ")
+		builder.AddVar(builder, buildFTS5MatchQuery(e.term))
+		builder.WriteByte(')')
+	case "mysql":
+		builder.WriteString("MATCH (" + e.column + ") AGAINST (")
+		builder.AddVar(builder, buildMySQLBooleanMatchQuery(e.term))
+		builder.WriteString(" IN BOOLEAN MODE)")
+	default:
+		_ = stmt.AddError(errors.WithStack(ErrFullTextSearchNotSupported))
+	}
+}
+
+type searchRankExpression struct {
+	column string
+	term   string
+}
+
+func (e *searchRankExpression) Build(builder clause.Builder) {
+	// Get statement
+	stmt, ok := builder.(*gorm.Statement)
+	// Check if it is a statement
+	if !ok {
+		return
+	}
+
+	switch stmt.Dialector.Name() {
+	case "postgres":
+		builder.WriteString("ts_rank(to_tsvector('" + FullTextSearchLanguage + "', " + e.column + "), ")
+		builder.WriteString("plainto_tsquery('" + FullTextSearchLanguage + "', ")
+		builder.AddVar(builder, e.term)
+		builder.WriteString("))")
+	case "sqlite":
+		// Check if FTS5 is available
+		if !HasSQLiteFTS5(stmt.DB) {
+			// All matching rows have the same relevance
+			builder.WriteString("NULL")
+
+			return
+		}
+
+		// Get fts table name
+		ftsTable := GetFullTextSearchTableName(stmt.Table, e.column)
+
+		// Bm25 is lower when it is more relevant, so negate it to be coherent with postgres rank
+		builder.WriteString("-(SELECT bm25(")
+		builder.WriteQuoted(ftsTable)
+		builder.WriteString(") FROM ")
+		builder.WriteQuoted(ftsTable)
+		builder.WriteString(" WHERE ")
+		builder.WriteQuoted(ftsTable)
+		builder.WriteString(" MATCH ")
+		builder.AddVar(builder, buildFTS5MatchQuery(e.term))
+		builder.WriteString(" AND rowid = ")
+		builder.WriteQuoted(clause.Table{Name: stmt.Table})
+		builder.WriteString(".rowid)")
+	case "mysql":
+		// Match returns the relevance when it is used in a select or an order by
+		builder.WriteString("MATCH (" + e.column + ") AGAINST (")
+		builder.AddVar(builder, buildMySQLBooleanMatchQuery(e.term))
+		builder.WriteString(" IN BOOLEAN MODE)")
+	default:
+		_ = stmt.AddError(errors.WithStack(ErrFullTextSearchNotSupported))
+	}
+}
+
+// Build a LIKE condition matching all words like plainto_tsquery does.
+// This is used as fallback when full text search isn't available.
+func buildLikeSearch(builder clause.Builder, column, term string) {
+	// Split words
+	words := strings.Fields(term)
+	// Check if there isn't any word
+	if len(words) == 0 {
+		// Match all like an empty search
+		builder.WriteString("1 = 1")
+
+		return
+	}
+
+	builder.WriteByte('(')
+	// Loop over words
+	for i, w := range words {
+		// Add separator
+		if i > 0 {
+			builder.WriteString(" AND ")
+		}
+
+		builder.WriteString("lower(" + column + ") LIKE lower(")
+		builder.AddVar(builder, "%"+EscapeLikeValue(w)+"%")
+		builder.WriteString(`) ESCAPE '\'`)
+	}
+
+	builder.WriteByte(')')
+}
+
+// Build a FTS5 query matching all words like plainto_tsquery does.
+// Words are quoted to avoid FTS5 syntax interpretation.
+func buildFTS5MatchQuery(term string) string {
+	// Split words
+	words := strings.Fields(term)
+	// Quote them
+	for i, w := range words {
+		words[i] = `"` + strings.ReplaceAll(w, `"`, `""`) + `"`
+	}
+
+	return strings.Join(words, " ")
+}
+
+// Build a MYSQL boolean mode query matching all words like plainto_tsquery does.
+// Words are quoted to avoid boolean operators interpretation.
+func buildMySQLBooleanMatchQuery(term string) string {
+	// Split words
+	words := strings.Fields(strings.ReplaceAll(term, `"`, " "))
+	// Mark them as required
+	for i, w := range words {
+		words[i] = `+"` + w + `"`
+	}
+
+	return strings.Join(words, " ")
+}
+
+func getBareColumnName(column string) string {
+	// Check if column is prefixed by table
+	if i := strings.LastIndex(column, "."); i != -1 {
+		return column[i+1:]
+	}
+
+	return column
+}
+
+// Collect search terms per column in filter.
+// First found term is kept for a column.
+func collectSearchTerms(filter any, res map[string]string) {
+	// Get reflect value of filter object
+	rVal := reflect.Indirect(reflect.ValueOf(filter))
+	// Check if filter is an object
+	if rVal.Kind() != reflect.Struct {
+		return
+	}
+
+	// Get type
+	rType := rVal.Type()
+	// Loop over all fields
+	for i := 0; i < rVal.NumField(); i++ {
+		// Get field type
+		fType := rType.Field(i)
+		// Get field value
+		fVal := rVal.Field(i)
+
+		// Check if it is AND or OR field
+		if (fType.Name == andFieldName || fType.Name == orFieldName) && fVal.Kind() == reflect.Slice {
+			// Loop over elements
+			for j := 0; j < fVal.Len(); j++ {
+				collectSearchTerms(fVal.Index(j).Interface(), res)
+			}
+
+			continue
+		}
+
+		// Get tag on field
+		tagVal := fType.Tag.Get(dbColTagName)
+		// Check that field have a tag set and correct
+		if tagVal == "" || tagVal == "-" || fVal.Kind() != reflect.Pointer || fVal.IsNil() {
+			continue
+		}
+
+		// Cast it as GenericFilter
+		gf, ok := fVal.Interface().(*GenericFilter)
+		// Check if it is a generic filter with a search
+		if !ok || gf.Search == nil {
+			continue
+		}
+
+		// Get string value
+		s, err := getStringValue(gf.Search)
+		// Check error or empty search
+		if err != nil || strings.TrimSpace(s) == "" {
+			continue
+		}
+
+		// Check if term isn't already set
+		if _, exists := res[tagVal]; !exists {
+			res[tagVal] = s
+		}
+	}
+}
+
+func getSearchTerms(db *gorm.DB) map[string]string {
+	// Get setting
+	v, ok := db.Get(searchTermsSettingKey)
+	// Check if it exists
+	if !ok {
+		return nil
+	}
+
+	// Cast
+	res, _ := v.(map[string]string)
+
+	return res
+}
@@ -0,0 +1,257 @@
+//go:build unit
+
+package common
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type searchTestPerson struct {
+	Name string
+}
+
+func (*searchTestPerson) TableName() string {
+	return "people"
+}
+
+type searchTestFilter struct {
+	Name *GenericFilter `dbfield:"name"`
+	AND  []*searchTestFilter
+	OR   []*searchTestFilter
+}
+
+type searchTestSort struct {
+	Name      *SortOrderEnum `dbfield:"name"`
+	Relevance *SortOrderEnum `dbfield:"name;relevance"`
+}
+
+func Test_buildFTS5MatchQuery(t *testing.T) {
+	tests := []struct {
+		name string
+		term string
+		want string
+	}{
+		{name: "empty", term: "  ", want: ""},
+		{name: "one word", term: "fake", want: `"fake"`},
+		{name: "multiple words", term: " fake  value ", want: `"fake" "value"`},
+		{name: "fts5 syntax must be quoted", term: `fa"ke OR name:*`, want: `"fa""ke" "OR" "name:*"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, buildFTS5MatchQuery(tt.term))
+		})
+	}
+}
+
+func Test_buildMySQLBooleanMatchQuery(t *testing.T) {
+	tests := []struct {
+		name string
+		term string
+		want string
+	}{
+		{name: "empty", term: "  ", want: ""},
+		{name: "one word", term: "fake", want: `+"fake"`},
+		{name: "multiple words", term: " fake  value ", want: `+"fake" +"value"`},
+		{name: "boolean syntax must be quoted", term: `fa"ke -name*`, want: `+"fa" +"ke" +"-name*"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, buildMySQLBooleanMatchQuery(tt.term))
+		})
+	}
+}
+
+func Test_Search_MySQL(t *testing.T) {
+	sqlDB, _, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer sqlDB.Close()
+
+	db, err := gorm.Open(
+		mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}),
+		&gorm.Config{Logger: logger.Discard},
+	)
+	require.NoError(t, err)
+
+	sql, err := buildSearchTestSQL(
+		db,
+		&searchTestFilter{Name: &GenericFilter{Search: "fake value"}},
+		&searchTestSort{Relevance: &SortOrderEnumDesc},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(
+		t,
+		"SELECT * FROM `people` WHERE MATCH (name) AGAINST ('+\"fake\" +\"value\"' IN BOOLEAN MODE) "+
+			"ORDER BY MATCH (name) AGAINST ('+\"fake\" +\"value\"' IN BOOLEAN MODE) DESC",
+		sql,
+	)
+}
+
+func Test_collectSearchTerms(t *testing.T) {
+	res := map[string]string{}
+	collectSearchTerms(&searchTestFilter{
+		AND: []*searchTestFilter{
+			{Name: &GenericFilter{Search: "first"}},
+			{Name: &GenericFilter{Search: "second"}},
+		},
+	}, res)
+
+	assert.Equal(t, map[string]string{"name": "first"}, res)
+}
+
+func Test_Search_Postgres(t *testing.T) {
+	tests := []struct {
+		name        string
+		filter      *searchTestFilter
+		sort        any
+		wantSQL     string
+		wantErr     bool
+		errorString string
+	}{
+		{
+			name:    "search filter",
+			filter:  &searchTestFilter{Name: &GenericFilter{Search: "fake value"}},
+			wantSQL: `SELECT * FROM "people" WHERE to_tsvector('simple', name) @@ plainto_tsquery('simple', 'fake value') ORDER BY created_at DESC`,
+		},
+		{
+			name:    "empty search filter must be ignored",
+			filter:  &searchTestFilter{Name: &GenericFilter{Search: "  "}},
+			wantSQL: `SELECT * FROM "people" ORDER BY created_at DESC`,
+		},
+		{
+			name:        "search filter with wrong type",
+			filter:      &searchTestFilter{Name: &GenericFilter{Search: 1}},
+			wantErr:     true,
+			errorString: "search value must be a string or *string",
+		},
+		{
+			name:   "relevance sort with search",
+			filter: &searchTestFilter{Name: &GenericFilter{Search: "fake"}},
+			sort: []*searchTestSort{
+				{Relevance: &SortOrderEnumDesc},
+				{Name: &SortOrderEnumAsc},
+			},
+			wantSQL: `SELECT * FROM "people" WHERE to_tsvector('simple', name) @@ plainto_tsquery('simple', 'fake') ` +
+				`ORDER BY ts_rank(to_tsvector('simple', name), plainto_tsquery('simple', 'fake')) DESC, name ASC`,
+		},
+		{
+			name:    "relevance sort without search must be ignored",
+			sort:    &searchTestSort{Relevance: &SortOrderEnumDesc},
+			wantSQL: `SELECT * FROM "people" ORDER BY created_at DESC`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlDB, _, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			require.NoError(t, err)
+			defer sqlDB.Close()
+
+			db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Discard})
+			require.NoError(t, err)
+
+			sql, err := buildSearchTestSQL(db, tt.filter, tt.sort)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ManageFilter() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if err != nil {
+				assert.Equal(t, tt.errorString, err.Error())
+
+				return
+			}
+
+			assert.Equal(t, tt.wantSQL, sql)
+		})
+	}
+}
+
+func Test_Search_Sqlite(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+
+	if !HasSQLiteFTS5(db) {
+		t.Skip("sqlite isn't built with fts5")
+	}
+
+	sql, err := buildSearchTestSQL(
+		db,
+		&searchTestFilter{Name: &GenericFilter{Search: "fake"}},
+		&searchTestSort{Relevance: &SortOrderEnumDesc},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(
+		t,
+		"SELECT * FROM `people` WHERE `people`.rowid IN (SELECT rowid FROM `people_name_fts` WHERE `people_name_fts` MATCH \"\"\"fake\"\"\") "+
+			"ORDER BY -(SELECT bm25(`people_name_fts`) FROM `people_name_fts` WHERE `people_name_fts` MATCH \"\"\"fake\"\"\" AND rowid = `people`.rowid) DESC",
+		sql,
+	)
+}
+
+func Test_Search_Sqlite_WithoutFTS5(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+
+	// Force detection result
+	sqliteFTS5Support.Store(db.Dialector, false)
+	defer sqliteFTS5Support.Delete(db.Dialector)
+
+	filter := &searchTestFilter{Name: &GenericFilter{Search: " fake_ value "}}
+	sort := &searchTestSort{Relevance: &SortOrderEnumDesc}
+
+	sql, err := buildSearchTestSQL(db, filter, sort)
+	require.NoError(t, err)
+
+	assert.Equal(
+		t,
+		"SELECT * FROM `people` WHERE (lower(name) LIKE lower(\"%fake\\_%\") ESCAPE '\\' AND lower(name) LIKE lower(\"%value%\") ESCAPE '\\') "+
+			"ORDER BY NULL DESC",
+		sql,
+	)
+
+	// Query must be valid
+	require.NoError(t, db.AutoMigrate(&searchTestPerson{}))
+	require.NoError(t, db.Create([]*searchTestPerson{{Name: "Fake_ Value"}, {Name: "fakex value"}}).Error)
+
+	res, err := ManageFilter(filter, db.Model(&searchTestPerson{}))
+	require.NoError(t, err)
+	res, err = ManageSortOrder(sort, res)
+	require.NoError(t, err)
+
+	var list []*searchTestPerson
+	require.NoError(t, res.Find(&list).Error)
+	assert.Equal(t, []*searchTestPerson{{Name: "Fake_ Value"}}, list)
+}
+
+func buildSearchTestSQL(db *gorm.DB, filter *searchTestFilter, sort any) (string, error) {
+	var err error
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var res *gorm.DB
+
+		res, err = ManageFilter(filter, tx.Model(&searchTestPerson{}))
+		if err != nil {
+			return tx
+		}
+
+		res, err = ManageSortOrder(sort, res)
+		if err != nil {
+			return tx
+		}
+
+		return res.Find(&[]*searchTestPerson{})
+	})
+
+	return sql, err
+}
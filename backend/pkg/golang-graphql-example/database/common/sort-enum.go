@@ -21,18 +21,34 @@ import (
 type SortOrderEnum string
 
 var (
-	SortOrderEnumAsc  SortOrderEnum = "ASC"
-	SortOrderEnumDesc SortOrderEnum = "DESC"
+	SortOrderEnumAsc            SortOrderEnum = "ASC"
+	SortOrderEnumDesc           SortOrderEnum = "DESC"
+	SortOrderEnumAscNullsFirst  SortOrderEnum = "ASC_NULLS_FIRST"
+	SortOrderEnumAscNullsLast   SortOrderEnum = "ASC_NULLS_LAST"
+	SortOrderEnumDescNullsFirst SortOrderEnum = "DESC_NULLS_FIRST"
+	SortOrderEnumDescNullsLast  SortOrderEnum = "DESC_NULLS_LAST"
 )
 
 var AllSortOrderEnum = []SortOrderEnum{
 	SortOrderEnumAsc,
 	SortOrderEnumDesc,
+	SortOrderEnumAscNullsFirst,
+	SortOrderEnumAscNullsLast,
+	SortOrderEnumDescNullsFirst,
+	SortOrderEnumDescNullsLast,
 }
 
+// Nulls ordering values.
+const (
+	nullsFirst = "FIRST"
+	nullsLast  = "LAST"
+)
+
 func (e SortOrderEnum) IsValid() bool {
 	switch e {
-	case SortOrderEnumAsc, SortOrderEnumDesc:
+	case SortOrderEnumAsc, SortOrderEnumDesc,
+		SortOrderEnumAscNullsFirst, SortOrderEnumAscNullsLast,
+		SortOrderEnumDescNullsFirst, SortOrderEnumDescNullsLast:
 		return true
 	}
 
@@ -43,6 +59,29 @@ func (e SortOrderEnum) String() string {
 	return string(e)
 }
 
+// Direction will return the sort direction without nulls ordering (ASC or DESC).
+func (e SortOrderEnum) Direction() SortOrderEnum {
+	switch e {
+	case SortOrderEnumDesc, SortOrderEnumDescNullsFirst, SortOrderEnumDescNullsLast:
+		return SortOrderEnumDesc
+	}
+
+	return SortOrderEnumAsc
+}
+
+// NullsOrder will return the nulls ordering (FIRST or LAST).
+// Result is empty when database default ordering must be used.
+func (e SortOrderEnum) NullsOrder() string {
+	switch e {
+	case SortOrderEnumAscNullsFirst, SortOrderEnumDescNullsFirst:
+		return nullsFirst
+	case SortOrderEnumAscNullsLast, SortOrderEnumDescNullsLast:
+		return nullsLast
+	}
+
+	return ""
+}
+
 func (e *SortOrderEnum) UnmarshalGQL(v any) error {
 	str, ok := v.(string)
 	if !ok {
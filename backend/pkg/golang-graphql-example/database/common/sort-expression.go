@@ -0,0 +1,68 @@
+package common
+
+import (
+	"fmt"
+	"sync"
+
+	gerrors "emperror.dev/errors"
+)
+
+// ErrSortExpressionNotFound is raised when a computed sort references an unregistered expression.
+var ErrSortExpressionNotFound = gerrors.Sentinel("sort expression not found")
+
+// SortExpression is a computed sort expression.
+// Expressions are used as is in queries, they mustn't contain any user input.
+type SortExpression struct {
+	// Dialect specific SQL expressions indexed by dialector name.
+	// They take precedence over the default one.
+	Dialects map[string]string
+	// Default SQL expression
+	SQL string
+}
+
+var (
+	sortExpressionsMutex sync.RWMutex
+	sortExpressions      = map[string]*SortExpression{}
+)
+
+// RegisterSortExpression will register a computed sort expression in order to be used
+// by sort fields with a computed tag option.
+// Name is returned to allow registration in a package variable.
+// This will panic if name is already registered.
+func RegisterSortExpression(name string, expr *SortExpression) string {
+	sortExpressionsMutex.Lock()
+	defer sortExpressionsMutex.Unlock()
+
+	// Check if it is already registered
+	if _, ok := sortExpressions[name]; ok {
+		panic(fmt.Sprintf("sort expression %s is already registered", name))
+	}
+
+	sortExpressions[name] = expr
+
+	return name
+}
+
+func getSortExpression(name string) (*SortExpression, error) {
+	sortExpressionsMutex.RLock()
+	defer sortExpressionsMutex.RUnlock()
+
+	// Get expression
+	expr, ok := sortExpressions[name]
+	// Check if it exists
+	if !ok {
+		return nil, gerrors.Wrapf(ErrSortExpressionNotFound, "sort expression %s", name)
+	}
+
+	return expr, nil
+}
+
+// getSQL will return the SQL expression for dialect.
+func (e *SortExpression) getSQL(dialect string) string {
+	// Check if there is a dialect specific expression
+	if sql, ok := e.Dialects[dialect]; ok {
+		return sql
+	}
+
+	return e.SQL
+}
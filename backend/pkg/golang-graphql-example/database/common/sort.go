@@ -3,8 +3,11 @@ package common
 import (
 	"fmt"
 	"reflect"
+	"slices"
+	"strings"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	gerrors "emperror.dev/errors"
 
@@ -19,11 +22,88 @@ var ErrSortListMustNotHaveMultipleFields = gerrors.New(
 // Supported enum type for testing purpose.
 var supportedEnumType = reflect.TypeFor[*SortOrderEnum]()
 
+// ErrRelevanceSortNotSupported is raised when a relevance sort is used where it cannot be.
+var ErrRelevanceSortNotSupported = gerrors.Sentinel("relevance sort isn't supported with keyset pagination")
+
+// Sort tag option to sort on full text search relevance of the column.
+// Example:
+//
+//	type Sort struct {
+//		Relevance *SortOrderEnum `dbfield:"field_1;relevance"`
+//	}
+//
+// The sort is applied only if a search filter is set on the same column.
+const sortTagRelevanceOption = "relevance"
+
+// ErrKeysetSortNotSupported is raised when a sort that cannot be used to seek is used with keyset pagination.
+var ErrKeysetSortNotSupported = gerrors.Sentinel(
+	"case insensitive, computed and nulls ordered sorts aren't supported with keyset pagination",
+)
+
+// Sort tag option to sort on lowercased column values.
+// Example:
+//
+//	type Sort struct {
+//		Field1CaseInsensitive *SortOrderEnum `dbfield:"field_1;caseInsensitive"`
+//	}
+//
+// .
+const sortTagCaseInsensitiveOption = "caseInsensitive"
+
+// Sort tag option to sort on a registered computed sort expression.
+// Column part of the tag is the registered expression name.
+// Example:
+//
+//	type Sort struct {
+//		Field1Length *SortOrderEnum `dbfield:"field_1_length;computed"`
+//	}
+//
+// .
+const sortTagComputedOption = "computed"
+
+// SortColumn represents a sort applied on a database column.
+type SortColumn struct {
+	// Database column or registered sort expression name when computed
+	Column string
+	// Sort order
+	Order SortOrderEnum
+	// Sort on full text search relevance of the column instead of its value
+	Relevance bool
+	// Sort on lowercased column values
+	CaseInsensitive bool
+	// Sort on a registered computed sort expression
+	Computed bool
+}
+
+// SupportsKeyset will return true when column values can be used to seek in keyset pagination.
+func (c *SortColumn) SupportsKeyset() bool {
+	return !c.Relevance && !c.CaseInsensitive && !c.Computed && c.Order.NullsOrder() == ""
+}
+
+// DefaultSortColumn is the sort applied when no sort is asked.
+var DefaultSortColumn = &SortColumn{Column: "created_at", Order: SortOrderEnumDesc}
+
 func ManageSortOrder(sort any, db *gorm.DB) (*gorm.DB, error) {
 	return manageSortOrder(sort, db)
 }
 
-func manageSortOrder(sort any, db *gorm.DB) (*gorm.DB, error) {
+// SupportsKeysetPagination will return true when all sorts of a sort object or a sort list
+// can be used with keyset pagination.
+func SupportsKeysetPagination(sort any) bool {
+	// Get sort columns
+	cols, err := GetSortColumns(sort)
+	// Check error
+	if err != nil {
+		// Error will be raised when sort will be applied
+		return true
+	}
+
+	return !slices.ContainsFunc(cols, func(c *SortColumn) bool { return !c.SupportsKeyset() })
+}
+
+// GetSortColumns will return the list of sort columns in apply order from a sort object or a sort list.
+// If no sort is found, the default sort column will be returned.
+func GetSortColumns(sort any) ([]*SortColumn, error) {
 	// Get reflect value of sort object
 	rVal := reflect.ValueOf(sort)
 	// Get kind of sort
@@ -31,56 +111,172 @@ func manageSortOrder(sort any, db *gorm.DB) (*gorm.DB, error) {
 	// Check nil
 	if rKind == reflect.Invalid || (rKind == reflect.Pointer && rVal.IsNil()) {
 		// Stop here
-		return manageDefaultSort(db), nil
+		return []*SortColumn{DefaultSortColumn}, nil
 	}
 
 	// Check if it is a slice
 	if rKind == reflect.Array || rKind == reflect.Slice {
-		return manageListSortOrder(&rVal, db)
+		return manageListSortOrder(&rVal)
 	}
 
 	// Manage object as default
-	res, sortApplied, err := manageObjectSortOrder(rKind, &rVal, false, db)
+	res, err := manageObjectSortOrder(rKind, &rVal, false)
 	// Check error
 	if err != nil {
 		return nil, err
 	}
 	// Check if one sort was applied or not in order to put the default one
-	if !sortApplied {
-		res = manageDefaultSort(res)
+	if len(res) == 0 {
+		res = append(res, DefaultSortColumn)
 	}
 
 	// Default
 	return res, nil
 }
 
-func manageListSortOrder(rVal *reflect.Value, db *gorm.DB) (*gorm.DB, error) {
+func manageSortOrder(sort any, db *gorm.DB) (*gorm.DB, error) {
+	// Get sort columns
+	cols, err := GetSortColumns(sort)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if relevance sorts are present
+	if slices.ContainsFunc(cols, func(c *SortColumn) bool { return c.Relevance }) {
+		return manageRelevanceSortOrder(cols, db)
+	}
+
 	// Create result
 	res := db
-	// Initialize error
-	var err error
-	// Initialize sort applied marker
-	sortApplied := false
+	// Loop over columns to apply them
+	for _, c := range cols {
+		// Get order
+		order, err := getSortOrderSQL(db.Dialector.Name(), c)
+		// Check error
+		if err != nil {
+			return nil, err
+		}
+
+		// Apply order
+		res = res.Order(order)
+	}
+
+	// Default
+	return res, nil
+}
+
+// getSortOrderSQL will return the order by SQL of a sort column supported by dialect.
+func getSortOrderSQL(dialect string, c *SortColumn) (string, error) {
+	// Initialize expression
+	expr := c.Column
+	// Check if it is a computed sort
+	if c.Computed {
+		// Get registered expression
+		se, err := getSortExpression(c.Column)
+		// Check error
+		if err != nil {
+			return "", err
+		}
+
+		expr = se.getSQL(dialect)
+	}
+
+	// Check if it is a case insensitive sort
+	if c.CaseInsensitive {
+		expr = "lower(" + expr + ")"
+	}
+
+	// Get direction
+	direction := c.Order.Direction().String()
+	// Get nulls ordering
+	nulls := c.Order.NullsOrder()
+
+	// Check if nulls ordering is asked
+	if nulls == "" {
+		return expr + " " + direction, nil
+	}
+
+	switch dialect {
+	case "sqlite", "mysql":
+		// Emulate nulls ordering with a sort on nullity first
+		nullsDirection := SortOrderEnumAsc
+		if nulls == nullsFirst {
+			nullsDirection = SortOrderEnumDesc
+		}
+
+		return fmt.Sprintf("%s IS NULL %s, %s %s", expr, nullsDirection.String(), expr, direction), nil
+	default:
+		return fmt.Sprintf("%s %s NULLS %s", expr, direction, nulls), nil
+	}
+}
+
+func manageRelevanceSortOrder(cols []*SortColumn, db *gorm.DB) (*gorm.DB, error) {
+	// Get search terms saved by filters
+	terms := getSearchTerms(db)
+
+	// Build order expression
+	// This is done in one expression as gorm cannot mix columns and expressions in order by
+	sqlParts := make([]string, 0, len(cols))
+	vars := make([]any, 0)
+	// Loop over columns
+	for _, c := range cols {
+		// Check if it isn't a relevance sort
+		if !c.Relevance {
+			// Get order
+			order, err := getSortOrderSQL(db.Dialector.Name(), c)
+			// Check error
+			if err != nil {
+				return nil, err
+			}
+
+			sqlParts = append(sqlParts, order)
+
+			continue
+		}
+
+		// Get search term
+		term, ok := terms[c.Column]
+		// Ignore relevance sort without search
+		if !ok {
+			continue
+		}
+
+		sqlParts = append(sqlParts, "? "+c.Order.Direction().String())
+		vars = append(vars, &searchRankExpression{column: c.Column, term: term})
+	}
+
+	// Check if all sorts have been ignored
+	if len(sqlParts) == 0 {
+		return db.Order(fmt.Sprintf("%s %s", DefaultSortColumn.Column, DefaultSortColumn.Order.String())), nil
+	}
+
+	return db.Order(clause.OrderBy{
+		Expression: clause.Expr{SQL: strings.Join(sqlParts, ", "), Vars: vars, WithoutParentheses: true},
+	}), nil
+}
+
+func manageListSortOrder(rVal *reflect.Value) ([]*SortColumn, error) {
+	// Create result
+	res := make([]*SortColumn, 0)
 
 	// Loop over slice
 	for i := 0; i < rVal.Len(); i++ {
 		// Get value
 		rElem := rVal.Index(i)
-		// Initialize sort applied result
-		sortAppliedRes := false //nolint:wastedassign // False positive
 		// Manage object
-		res, sortAppliedRes, err = manageObjectSortOrder(rElem.Kind(), &rElem, true, res)
+		cols, err := manageObjectSortOrder(rElem.Kind(), &rElem, true)
 		// Check error
 		if err != nil {
 			return nil, err
 		}
-		// Save sort applied
-		sortApplied = sortApplied || sortAppliedRes
+		// Save columns
+		res = append(res, cols...)
 	}
 
 	// Check if one sort was applied or not in order to put the default one
-	if !sortApplied {
-		res = manageDefaultSort(res)
+	if len(res) == 0 {
+		res = append(res, DefaultSortColumn)
 	}
 
 	return res, nil
@@ -90,13 +286,12 @@ func manageObjectSortOrder(
 	rKind reflect.Kind,
 	rVal *reflect.Value,
 	refuseMultipleField bool,
-	db *gorm.DB,
-) (*gorm.DB, bool, error) {
+) ([]*SortColumn, error) {
 	// Create result
-	res := db
+	res := make([]*SortColumn, 0)
 	// Check if kind is supported
 	if rKind != reflect.Struct && rKind != reflect.Pointer {
-		return nil, false, errors.NewInvalidInputError("sort must be an object")
+		return nil, errors.NewInvalidInputError("sort must be an object")
 	}
 
 	// Indirect value
@@ -105,8 +300,6 @@ func manageObjectSortOrder(
 	indData := indirect.Interface()
 	// Get type of indirect value
 	typeOfIndi := reflect.TypeOf(indData)
-	// Variable to know at the end if one sort was applied
-	sortApplied := false
 
 	// Loop over all num fields
 	for i := 0; i < indirect.NumField(); i++ {
@@ -121,7 +314,7 @@ func manageObjectSortOrder(
 		}
 		// Check that type is supported
 		if fType.Type != supportedEnumType {
-			return nil, false, errors.NewInvalidInputError(
+			return nil, errors.NewInvalidInputError(
 				fmt.Sprintf("field %s with sort tag must be a *SortOrderEnum", fType.Name),
 			)
 		}
@@ -133,8 +326,8 @@ func manageObjectSortOrder(
 			continue
 		}
 		// Check if sort have been already applied
-		if refuseMultipleField && sortApplied {
-			return nil, false, errors.NewInvalidInputErrorWithError(
+		if refuseMultipleField && len(res) != 0 {
+			return nil, errors.NewInvalidInputErrorWithError(
 				ErrSortListMustNotHaveMultipleFields,
 				errors.WithPublicError(ErrSortListMustNotHaveMultipleFields),
 			)
@@ -146,17 +339,23 @@ func manageObjectSortOrder(
 		enu, ok := val.(*SortOrderEnum)
 		// Check if it is ok or not
 		if !ok {
-			return nil, false, gerrors.Errorf("%v isn't a valid SortOrderEnum value", val)
+			return nil, gerrors.Errorf("%v isn't a valid SortOrderEnum value", val)
 		}
-		// Apply order
-		res = res.Order(fmt.Sprintf("%s %s", tagVal, enu.String()))
-		// Store sort applied
-		sortApplied = true
+		// Check value as it is used in queries
+		if !enu.IsValid() {
+			return nil, errors.NewInvalidInputError(fmt.Sprintf("%s is not a valid SortOrderEnum", enu.String()))
+		}
+		// Parse tag options
+		col, opt, _ := strings.Cut(tagVal, ";")
+		// Store sort
+		res = append(res, &SortColumn{
+			Column:          col,
+			Order:           *enu,
+			Relevance:       opt == sortTagRelevanceOption,
+			CaseInsensitive: opt == sortTagCaseInsensitiveOption,
+			Computed:        opt == sortTagComputedOption,
+		})
 	}
 
-	return res, sortApplied, nil
-}
-
-func manageDefaultSort(db *gorm.DB) *gorm.DB {
-	return db.Order("created_at DESC")
+	return res, nil
 }
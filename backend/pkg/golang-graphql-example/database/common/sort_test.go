@@ -3,10 +3,14 @@
 package common
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
@@ -257,3 +261,119 @@ func Test_ManageSortOrder(t *testing.T) {
 		})
 	}
 }
+
+func Test_ManageSortOrder_Options(t *testing.T) {
+	type Person struct {
+		Name string
+	}
+	type Sort struct {
+		Name                *SortOrderEnum `dbfield:"name"`
+		NameCaseInsensitive *SortOrderEnum `dbfield:"name;caseInsensitive"`
+		NameLength          *SortOrderEnum `dbfield:"sort_test_name_length;computed"`
+		Unknown             *SortOrderEnum `dbfield:"sort_test_unknown;computed"`
+	}
+
+	RegisterSortExpression("sort_test_name_length", &SortExpression{
+		SQL:      "char_length(name)",
+		Dialects: map[string]string{"sqlite": "length(name)"},
+	})
+
+	wrongEnum := SortOrderEnum("ASC; DROP TABLE people")
+
+	tests := []struct {
+		name            string
+		sort            any
+		wantPostgres    string
+		wantSqlite      string
+		wantErrorString string
+	}{
+		{
+			name:         "nulls first",
+			sort:         &Sort{Name: &SortOrderEnumAscNullsFirst},
+			wantPostgres: `name ASC NULLS FIRST`,
+			wantSqlite:   `name IS NULL DESC, name ASC`,
+		},
+		{
+			name:         "nulls last",
+			sort:         &Sort{Name: &SortOrderEnumDescNullsLast},
+			wantPostgres: `name DESC NULLS LAST`,
+			wantSqlite:   `name IS NULL ASC, name DESC`,
+		},
+		{
+			name:         "case insensitive",
+			sort:         &Sort{NameCaseInsensitive: &SortOrderEnumAsc},
+			wantPostgres: `lower(name) ASC`,
+			wantSqlite:   `lower(name) ASC`,
+		},
+		{
+			name:         "computed",
+			sort:         &Sort{NameLength: &SortOrderEnumDesc},
+			wantPostgres: `char_length(name) DESC`,
+			wantSqlite:   `length(name) DESC`,
+		},
+		{
+			name: "list with all options",
+			sort: []*Sort{
+				{NameLength: &SortOrderEnumDescNullsFirst},
+				{NameCaseInsensitive: &SortOrderEnumAsc},
+			},
+			wantPostgres: `char_length(name) DESC NULLS FIRST,lower(name) ASC`,
+			wantSqlite:   `length(name) IS NULL DESC, length(name) DESC,lower(name) ASC`,
+		},
+		{
+			name:            "unknown computed expression",
+			sort:            &Sort{Unknown: &SortOrderEnumAsc},
+			wantErrorString: "sort expression sort_test_unknown: sort expression not found",
+		},
+		{
+			name:            "invalid enum value",
+			sort:            &Sort{Name: &wrongEnum},
+			wantErrorString: "ASC; DROP TABLE people is not a valid SortOrderEnum",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlDB, _, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer sqlDB.Close()
+
+			pgDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Discard})
+			require.NoError(t, err)
+
+			sqliteDB, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+			require.NoError(t, err)
+
+			for db, want := range map[*gorm.DB]string{pgDB: tt.wantPostgres, sqliteDB: tt.wantSqlite} {
+				got, err := ManageSortOrder(tt.sort, db.Session(&gorm.Session{DryRun: true}))
+				if tt.wantErrorString != "" {
+					assert.EqualError(t, err, tt.wantErrorString)
+
+					continue
+				}
+
+				require.NoError(t, err)
+
+				// Get order part of query
+				_, order, _ := strings.Cut(got.Find(&[]*Person{}).Statement.SQL.String(), " ORDER BY ")
+				assert.Equal(t, want, order)
+			}
+		})
+	}
+}
+
+func Test_SupportsKeysetPagination(t *testing.T) {
+	type Sort struct {
+		Name                *SortOrderEnum `dbfield:"name"`
+		NameCaseInsensitive *SortOrderEnum `dbfield:"name;caseInsensitive"`
+		NameLength          *SortOrderEnum `dbfield:"name_length;computed"`
+		Relevance           *SortOrderEnum `dbfield:"name;relevance"`
+	}
+
+	assert.True(t, SupportsKeysetPagination(nil))
+	assert.True(t, SupportsKeysetPagination([]*Sort{{Name: &SortOrderEnumAsc}}))
+	assert.False(t, SupportsKeysetPagination([]*Sort{{Name: &SortOrderEnumAsc}, {Name: &SortOrderEnumDescNullsLast}}))
+	assert.False(t, SupportsKeysetPagination(&Sort{NameCaseInsensitive: &SortOrderEnumAsc}))
+	assert.False(t, SupportsKeysetPagination(&Sort{NameLength: &SortOrderEnumAsc}))
+	assert.False(t, SupportsKeysetPagination(&Sort{Relevance: &SortOrderEnumAsc}))
+}
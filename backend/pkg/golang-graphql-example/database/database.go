@@ -15,11 +15,13 @@ import (
 const (
 	PostgresDriverSelector = "POSTGRES"
 	SqliteDriverSelector   = "SQLITE"
+	MysqlDriverSelector    = "MYSQL"
 )
 
 //go:generate mockgen -destination=./mocks/mock_DB.go -package=mocks github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database DB
 type DB interface {
 	// ExecuteTransaction will execute a transaction.
+	// Nested calls are using savepoints in the transaction found in context.
 	ExecuteTransaction(
 		ctx context.Context,
 		cb func(context.Context) error,
@@ -39,9 +41,12 @@ type DB interface {
 	Ping() error
 	// Reconnect to database.
 	Reconnect() error
+	// CheckReplica will check replica at index and eject it from reads when it is unreachable or lagging.
+	CheckReplica(index int) error
 }
 
 // NewDatabase will generate a new DB object.
+// Connection name must be main or a connection declared in databases configuration.
 func NewDatabase(
 	connectionName string,
 	cfgManager config.Manager,
@@ -0,0 +1,3 @@
+package dbtest
+
+// This package will contains database helpers shared by unit tests.
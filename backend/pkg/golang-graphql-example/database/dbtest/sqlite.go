@@ -0,0 +1,37 @@
+//go:build unit
+
+package dbtest
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// NewSQLiteDB will open a SQLite database stored in the test temporary directory and migrate models.
+// A file is used rather than memory to share the database between pool connections.
+func NewSQLiteDB(t testing.TB, models ...any) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+
+	// Close connections at the end of the test to release the file
+	t.Cleanup(func() {
+		sqlDB, err := db.DB()
+		if err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+
+	// Migrate
+	if len(models) != 0 {
+		require.NoError(t, db.AutoMigrate(models...))
+	}
+
+	return db
+}
@@ -0,0 +1,352 @@
+package databasehelpers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+
+	cerrors "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/common/errors"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/common"
+)
+
+// Time formats returned by drivers that don't type expressions results (like SQLITE).
+var aggregationTimeFormats = []string{
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02T15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+type aggregationMetricColumn struct {
+	field *schema.Field
+	fn    string
+}
+
+func Aggregate[T any](
+	ctx context.Context,
+	db database.DB,
+	input T,
+	filter any,
+	groupBy any,
+	metrics *common.AggregationMetrics,
+	opts ...GormOpt,
+) ([]*common.AggregationBucket, error) {
+	// Get gorm gdb
+	gdb := db.GetTransactionalOrDefaultGormDB(ctx)
+
+	// Get group by columns
+	groupCols, err := common.GetGroupByColumns(groupBy)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse model schema to validate and type columns
+	stmt := &gorm.Statement{DB: gdb}
+	// Parse
+	err = stmt.Parse(input)
+	// Check error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	// Get metric columns
+	metricCols, err := getAggregationMetricColumns(stmt.Schema, metrics)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply filter
+	gdb, err = common.ManageFilter(filter, gdb)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply tenant scope
+	gdb, err = database.ApplyTenantScope(ctx, gdb, input)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply options
+	for _, o := range opts {
+		gdb, err = o(ctx, gdb)
+		// Check error
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	// Build selects
+	selects := make([]string, 0, len(groupCols)+len(metricCols)+1)
+	// Loop over group by columns
+	for i, c := range groupCols {
+		// Get expression
+		expr, err := common.GetGroupByExpression(gdb, c)
+		// Check error
+		if err != nil {
+			return nil, err
+		}
+
+		selects = append(selects, fmt.Sprintf("%s AS key_%d", expr, i))
+		gdb = gdb.Group(expr).Order(expr)
+	}
+	// Add count
+	selects = append(selects, "COUNT(*) AS count")
+	// Loop over metrics
+	for i, m := range metricCols {
+		selects = append(selects, fmt.Sprintf("%s(%s) AS metric_%d", m.fn, m.field.DBName, i))
+	}
+
+	// Run query
+	rows, err := gdb.Model(input).Select(strings.Join(selects, ", ")).Rows()
+	// Check error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	// Close rows at the end
+	defer rows.Close()
+
+	// Initialize result
+	res := make([]*common.AggregationBucket, 0)
+	// Loop over rows
+	for rows.Next() {
+		// Create scan destinations
+		values := make([]any, len(selects))
+		dest := make([]any, len(selects))
+
+		for i := range values {
+			dest[i] = &values[i]
+		}
+
+		// Scan
+		err = rows.Scan(dest...)
+		// Check error
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		// Build bucket
+		b, err := buildAggregationBucket(stmt.Schema, groupCols, metricCols, values)
+		// Check error
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, b)
+	}
+
+	// Check rows error
+	err = rows.Err()
+	// Check error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return res, nil
+}
+
+func getAggregationMetricColumns(s *schema.Schema, metrics *common.AggregationMetrics) ([]*aggregationMetricColumn, error) {
+	// Check if metrics are set
+	if metrics == nil {
+		return nil, nil
+	}
+
+	// Initialize result
+	res := make([]*aggregationMetricColumn, 0)
+
+	// Loop over metrics functions
+	for _, it := range []struct {
+		fn   string
+		cols []string
+	}{
+		{fn: "SUM", cols: metrics.Sum},
+		{fn: "MIN", cols: metrics.Min},
+		{fn: "MAX", cols: metrics.Max},
+	} {
+		// Loop over columns
+		for _, c := range it.cols {
+			// Get field
+			// Only database column names are accepted to avoid any injection
+			f, ok := s.FieldsByDBName[c]
+			// Check if it exists
+			if !ok {
+				return nil, cerrors.NewInvalidInputError(fmt.Sprintf("column %s doesn't exist on %s", c, s.Name))
+			}
+
+			res = append(res, &aggregationMetricColumn{field: f, fn: it.fn})
+		}
+	}
+
+	return res, nil
+}
+
+func buildAggregationBucket(
+	s *schema.Schema,
+	groupCols []*common.GroupByColumn,
+	metricCols []*aggregationMetricColumn,
+	values []any,
+) (*common.AggregationBucket, error) {
+	// Initialize bucket
+	res := &common.AggregationBucket{
+		Keys: map[string]any{},
+		Sum:  map[string]*float64{},
+		Min:  map[string]any{},
+		Max:  map[string]any{},
+	}
+
+	// Loop over group by columns
+	for i, c := range groupCols {
+		// Compute expected type
+		var typ reflect.Type
+		// Check if it is a date truncation
+		if c.DateTrunc != nil {
+			typ = timeType
+		} else if f, ok := s.FieldsByDBName[c.Column]; ok {
+			typ = f.IndirectFieldType
+		}
+
+		// Convert value
+		v, err := convertAggregationValue(values[i], typ)
+		// Check error
+		if err != nil {
+			return nil, err
+		}
+
+		res.Keys[c.Column] = v
+	}
+
+	// Get count value
+	count, err := convertAggregationFloat(values[len(groupCols)])
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+	// Save count
+	if count != nil {
+		res.Count = int64(*count)
+	}
+
+	// Loop over metrics
+	for i, m := range metricCols {
+		// Get value
+		val := values[len(groupCols)+1+i]
+
+		// Check if it is a sum
+		if m.fn == "SUM" {
+			// Convert
+			v, err := convertAggregationFloat(val)
+			// Check error
+			if err != nil {
+				return nil, err
+			}
+
+			res.Sum[m.field.DBName] = v
+
+			continue
+		}
+
+		// Convert value
+		v, err := convertAggregationValue(val, m.field.IndirectFieldType)
+		// Check error
+		if err != nil {
+			return nil, err
+		}
+
+		// Save
+		if m.fn == "MIN" {
+			res.Min[m.field.DBName] = v
+		} else {
+			res.Max[m.field.DBName] = v
+		}
+	}
+
+	return res, nil
+}
+
+func convertAggregationValue(v any, typ reflect.Type) (any, error) {
+	// Check if it is a byte array
+	if b, ok := v.([]byte); ok {
+		v = string(b)
+	}
+
+	// Check if type is unknown or value is nil
+	if typ == nil || v == nil {
+		return v, nil
+	}
+
+	// Check if time is expected
+	if typ == timeType {
+		// Check if it is a string
+		s, ok := v.(string)
+		// Check if it isn't a string
+		if !ok {
+			return v, nil
+		}
+
+		// Try to parse it
+		for _, f := range aggregationTimeFormats {
+			t, err := time.Parse(f, s)
+			// Check error
+			if err == nil {
+				return t.UTC(), nil
+			}
+		}
+
+		return nil, errors.Errorf("cannot parse aggregation time value %s", s)
+	}
+
+	// Check if bool is expected
+	if typ.Kind() == reflect.Bool {
+		// Check if it is an integer
+		if i, ok := v.(int64); ok {
+			return i != 0, nil
+		}
+	}
+
+	return v, nil
+}
+
+func convertAggregationFloat(v any) (*float64, error) {
+	// Initialize result
+	var res float64
+
+	switch t := v.(type) {
+	case nil:
+		return nil, nil
+	case int64:
+		res = float64(t)
+	case float64:
+		res = t
+	case []byte:
+		return convertAggregationFloat(string(t))
+	case string:
+		// Parse
+		f, err := strconv.ParseFloat(t, 64)
+		// Check error
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		res = f
+	default:
+		return nil, errors.Errorf("unsupported aggregation numeric value type %T", v)
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,82 @@
+//go:build integration
+
+package databasehelpers
+
+import (
+	"context"
+	"time"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/common"
+)
+
+type aggregateIntegrationPeople struct {
+	database.Base
+	Name  string
+	Done  bool
+	Score int
+}
+
+type aggregateIntegrationFilter struct {
+	Name *common.GenericFilter `dbfield:"name"`
+}
+
+type aggregateIntegrationGroupBy struct {
+	CreatedAt *common.DateTruncEnum `dbfield:"created_at"`
+	Done      *bool                 `dbfield:"done"`
+}
+
+func (suite *HelpersTestSuite) TestAggregate() {
+	suite.migrate(&aggregateIntegrationPeople{})
+
+	monday := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	nextMonday := monday.AddDate(0, 0, 7)
+
+	suite.Require().NoError(suite.db.GetGormDB().Create([]*aggregateIntegrationPeople{
+		{Base: database.Base{ID: "1", CreatedAt: monday}, Name: "fake1", Done: true, Score: 1},
+		{Base: database.Base{ID: "2", CreatedAt: monday.AddDate(0, 0, 6)}, Name: "fake2", Done: false, Score: 2},
+		{Base: database.Base{ID: "3", CreatedAt: nextMonday}, Name: "fake3", Done: true, Score: 4},
+		{Base: database.Base{ID: "4", CreatedAt: nextMonday}, Name: "ignored", Done: true, Score: 8},
+	}).Error)
+
+	week := common.DateTruncEnumWeek
+	trueValue := true
+	filter := &aggregateIntegrationFilter{Name: &common.GenericFilter{StartsWith: "fake"}}
+
+	// Group by week
+	got, err := Aggregate(
+		context.TODO(),
+		suite.db,
+		&aggregateIntegrationPeople{},
+		filter,
+		&aggregateIntegrationGroupBy{CreatedAt: &week},
+		&common.AggregationMetrics{Sum: []string{"score"}, Min: []string{"score"}},
+	)
+	suite.Require().NoError(err)
+	suite.Require().Len(got, 2)
+
+	suite.True(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Equal(got[0].Keys["created_at"].(time.Time)))
+	suite.Equal(int64(2), got[0].Count)
+	suite.Equal(3.0, *got[0].Sum["score"])
+	suite.EqualValues(1, got[0].Min["score"])
+
+	suite.True(time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC).Equal(got[1].Keys["created_at"].(time.Time)))
+	suite.Equal(int64(1), got[1].Count)
+	suite.Equal(4.0, *got[1].Sum["score"])
+
+	// Group by value
+	got, err = Aggregate(
+		context.TODO(),
+		suite.db,
+		&aggregateIntegrationPeople{},
+		filter,
+		&aggregateIntegrationGroupBy{Done: &trueValue},
+		nil,
+	)
+	suite.Require().NoError(err)
+	suite.Require().Len(got, 2)
+	suite.Equal(map[string]any{"done": false}, got[0].Keys)
+	suite.Equal(int64(1), got[0].Count)
+	suite.Equal(map[string]any{"done": true}, got[1].Keys)
+	suite.Equal(int64(2), got[1].Count)
+}
@@ -0,0 +1,194 @@
+//go:build unit
+
+package databasehelpers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/common"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/dbtest"
+	dbmocks "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/mocks"
+)
+
+type aggregateTestPeople struct {
+	database.Base
+	Name  string
+	Done  bool
+	Score int
+}
+
+type aggregateTestFilter struct {
+	Name *common.GenericFilter `dbfield:"name"`
+}
+
+type aggregateTestGroupBy struct {
+	CreatedAt *common.DateTruncEnum `dbfield:"created_at"`
+	Done      *bool                 `dbfield:"done"`
+}
+
+func TestAggregate(t *testing.T) {
+	gdb := dbtest.NewSQLiteDB(t, &aggregateTestPeople{})
+
+	monday := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	nextMonday := monday.AddDate(0, 0, 7)
+
+	require.NoError(t, gdb.Create([]*aggregateTestPeople{
+		{Base: database.Base{ID: "1", CreatedAt: monday}, Name: "fake1", Done: true, Score: 1},
+		{Base: database.Base{ID: "2", CreatedAt: monday.AddDate(0, 0, 6)}, Name: "fake2", Done: false, Score: 2},
+		{Base: database.Base{ID: "3", CreatedAt: nextMonday}, Name: "fake3", Done: true, Score: 4},
+		{Base: database.Base{ID: "4", CreatedAt: nextMonday}, Name: "ignored", Done: true, Score: 8},
+	}).Error)
+	// Soft deleted rows mustn't be aggregated
+	require.NoError(t, gdb.Create(&aggregateTestPeople{Base: database.Base{ID: "5", CreatedAt: monday}, Name: "fake5"}).Error)
+	require.NoError(t, gdb.Delete(&aggregateTestPeople{Base: database.Base{ID: "5"}}).Error)
+
+	week := common.DateTruncEnumWeek
+	trueValue := true
+
+	tests := []struct {
+		name        string
+		groupBy     *aggregateTestGroupBy
+		metrics     *common.AggregationMetrics
+		want        []*common.AggregationBucket
+		wantErr     bool
+		errorString string
+	}{
+		{
+			name: "without group by",
+			metrics: &common.AggregationMetrics{
+				Sum: []string{"score"},
+				Max: []string{"created_at"},
+			},
+			want: []*common.AggregationBucket{
+				{
+					Keys:  map[string]any{},
+					Sum:   map[string]*float64{"score": floatPtr(7)},
+					Min:   map[string]any{},
+					Max:   map[string]any{"created_at": nextMonday},
+					Count: 3,
+				},
+			},
+		},
+		{
+			name:    "group by week",
+			groupBy: &aggregateTestGroupBy{CreatedAt: &week},
+			metrics: &common.AggregationMetrics{Min: []string{"score"}},
+			want: []*common.AggregationBucket{
+				{
+					Keys:  map[string]any{"created_at": time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+					Sum:   map[string]*float64{},
+					Min:   map[string]any{"score": int64(1)},
+					Max:   map[string]any{},
+					Count: 2,
+				},
+				{
+					Keys:  map[string]any{"created_at": time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)},
+					Sum:   map[string]*float64{},
+					Min:   map[string]any{"score": int64(4)},
+					Max:   map[string]any{},
+					Count: 1,
+				},
+			},
+		},
+		{
+			name:    "group by value",
+			groupBy: &aggregateTestGroupBy{Done: &trueValue},
+			want: []*common.AggregationBucket{
+				{Keys: map[string]any{"done": false}, Sum: map[string]*float64{}, Min: map[string]any{}, Max: map[string]any{}, Count: 1},
+				{Keys: map[string]any{"done": true}, Sum: map[string]*float64{}, Min: map[string]any{}, Max: map[string]any{}, Count: 2},
+			},
+		},
+		{
+			name:        "unknown metric column",
+			metrics:     &common.AggregationMetrics{Sum: []string{"score; DROP TABLE people"}},
+			wantErr:     true,
+			errorString: "column score; DROP TABLE people doesn't exist on aggregateTestPeople",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			dbSvc := dbmocks.NewMockDB(ctrl)
+			dbSvc.EXPECT().GetTransactionalOrDefaultGormDB(gomock.Any()).AnyTimes().Return(gdb)
+
+			got, err := Aggregate(
+				context.TODO(),
+				dbSvc,
+				&aggregateTestPeople{},
+				&aggregateTestFilter{Name: &common.GenericFilter{StartsWith: "fake"}},
+				tt.groupBy,
+				tt.metrics,
+			)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Aggregate() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if err != nil {
+				assert.Equal(t, tt.errorString, err.Error())
+
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAggregatePostgres(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer sqlDB.Close()
+
+	gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	dbSvc := dbmocks.NewMockDB(ctrl)
+	dbSvc.EXPECT().GetTransactionalOrDefaultGormDB(gomock.Any()).AnyTimes().Return(gdb)
+
+	day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	month := common.DateTruncEnumMonth
+
+	mock.ExpectQuery(`SELECT date_trunc('month', created_at) AS key_0, COUNT(*) AS count, SUM(score) AS metric_0 ` +
+		`FROM "aggregate_test_peoples" WHERE name = $1 AND "aggregate_test_peoples"."deleted_at" IS NULL ` +
+		`GROUP BY date_trunc('month', created_at) ORDER BY date_trunc('month', created_at)`).
+		WithArgs("fake").
+		WillReturnRows(sqlmock.NewRows([]string{"key_0", "count", "metric_0"}).AddRow(day, int64(2), "3"))
+
+	got, err := Aggregate(
+		context.TODO(),
+		dbSvc,
+		&aggregateTestPeople{},
+		&aggregateTestFilter{Name: &common.GenericFilter{Eq: "fake"}},
+		&aggregateTestGroupBy{CreatedAt: &month},
+		&common.AggregationMetrics{Sum: []string{"score"}},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []*common.AggregationBucket{
+		{
+			Keys:  map[string]any{"created_at": day},
+			Sum:   map[string]*float64{"score": floatPtr(3)},
+			Min:   map[string]any{},
+			Max:   map[string]any{},
+			Count: 2,
+		},
+	}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
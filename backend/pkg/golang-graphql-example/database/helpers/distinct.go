@@ -0,0 +1,148 @@
+package databasehelpers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"emperror.dev/errors"
+	"gorm.io/gorm"
+
+	cerrors "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/common/errors"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/common"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/pagination"
+)
+
+// Default number of distinct values returned.
+const defaultDistinctLimit = 10
+
+// Distinct will return distinct non null values of a database column matching filter, ordered by value.
+// Search is a case insensitive prefix that can only be used on string columns.
+// Only offset pagination is supported and total count is never computed.
+func Distinct[T any](
+	ctx context.Context,
+	db database.DB,
+	input T,
+	page *pagination.PageInput,
+	field string,
+	filter any,
+	search string,
+	opts ...GormOpt,
+) ([]*common.FieldValue, *pagination.PageOutput, error) {
+	// Check page input
+	if page == nil {
+		page = &pagination.PageInput{}
+	}
+	// Check that keyset pagination isn't asked as values don't have any id
+	if page.Keyset != nil {
+		return nil, nil, cerrors.NewInvalidInputError("keyset pagination isn't supported on distinct values")
+	}
+	// Manage default limit
+	if page.Limit == 0 {
+		page.Limit = defaultDistinctLimit
+	}
+
+	// Get gorm gdb
+	gdb := db.GetTransactionalOrDefaultGormDB(ctx)
+
+	// Parse model schema to validate and type column
+	stmt := &gorm.Statement{DB: gdb}
+	// Parse
+	err := stmt.Parse(input)
+	// Check error
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	// Get field
+	// Only database column names are accepted to avoid any injection
+	f, ok := stmt.Schema.FieldsByDBName[field]
+	// Check if it exists
+	if !ok {
+		return nil, nil, cerrors.NewInvalidInputError(fmt.Sprintf("column %s doesn't exist on %s", field, stmt.Schema.Name))
+	}
+
+	// Apply filter
+	gdb, err = common.ManageFilter(filter, gdb)
+	// Check error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Check if search is set
+	if search != "" {
+		// Check that column is a string one
+		if f.IndirectFieldType.Kind() != reflect.String {
+			return nil, nil, cerrors.NewInvalidInputError(fmt.Sprintf("search isn't supported on column %s", field))
+		}
+
+		// Apply search
+		gdb, err = common.ManageColumnFilter(f.DBName, &common.GenericFilter{StartsWith: search, CaseInsensitive: true}, gdb)
+		// Check error
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Apply tenant scope
+	gdb, err = database.ApplyTenantScope(ctx, gdb, input)
+	// Check error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Apply options
+	for _, o := range opts {
+		gdb, err = o(ctx, gdb)
+		// Check error
+		if err != nil {
+			return nil, nil, errors.WithStack(err)
+		}
+	}
+
+	// Initialize values
+	var values []any
+	// Run query with one more value to detect next page
+	err = gdb.Model(input).
+		Where(f.DBName+" IS NOT NULL").
+		Distinct().
+		Order(f.DBName).
+		Limit(page.Limit+1).
+		Offset(page.Skip).
+		Pluck(f.DBName, &values).
+		Error
+	// Check error
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	// Build page output
+	pageOut := &pagination.PageOutput{
+		Limit:       page.Limit,
+		Skip:        page.Skip,
+		CountMode:   pagination.CountModeNone,
+		HasPrevious: page.Skip > 0,
+		HasNext:     len(values) > page.Limit,
+	}
+	// Remove probe value
+	if pageOut.HasNext {
+		values = values[:page.Limit]
+	}
+
+	// Initialize result
+	res := make([]*common.FieldValue, 0, len(values))
+	// Loop over values
+	for _, v := range values {
+		// Convert value
+		cv, err := convertAggregationValue(v, f.IndirectFieldType)
+		// Check error
+		if err != nil {
+			return nil, nil, err
+		}
+
+		res = append(res, &common.FieldValue{Value: cv})
+	}
+
+	return res, pageOut, nil
+}
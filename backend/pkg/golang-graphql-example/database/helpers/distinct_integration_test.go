@@ -0,0 +1,88 @@
+//go:build integration
+
+package databasehelpers
+
+import (
+	"context"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/common"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/pagination"
+)
+
+type distinctIntegrationPeople struct {
+	database.Base
+	Name     string
+	Nickname *string
+	Done     bool
+}
+
+type distinctIntegrationFilter struct {
+	Done *common.GenericFilter `dbfield:"done"`
+}
+
+func (suite *HelpersTestSuite) TestDistinct() {
+	suite.migrate(&distinctIntegrationPeople{})
+
+	nickname := "nick"
+
+	suite.Require().NoError(suite.db.GetGormDB().Create([]*distinctIntegrationPeople{
+		{Base: database.Base{ID: "1"}, Name: "bob", Nickname: &nickname, Done: true},
+		{Base: database.Base{ID: "2"}, Name: "alice", Done: true},
+		{Base: database.Base{ID: "3"}, Name: "carol", Done: false},
+		{Base: database.Base{ID: "4"}, Name: "bob", Done: true},
+		{Base: database.Base{ID: "5"}, Name: "b_100%", Done: true},
+	}).Error)
+
+	getValues := func(res []*common.FieldValue) []any {
+		values := make([]any, 0, len(res))
+		for _, v := range res {
+			values = append(values, v.Value)
+		}
+
+		return values
+	}
+
+	// Paginated values
+	got, pageOut, err := Distinct(
+		context.TODO(),
+		suite.db,
+		&distinctIntegrationPeople{},
+		&pagination.PageInput{Limit: 2},
+		"name",
+		&distinctIntegrationFilter{Done: &common.GenericFilter{Eq: true}},
+		"",
+	)
+	suite.Require().NoError(err)
+	suite.Equal([]any{"alice", "b_100%"}, getValues(got))
+	suite.True(pageOut.HasNext)
+
+	got, pageOut, err = Distinct(
+		context.TODO(),
+		suite.db,
+		&distinctIntegrationPeople{},
+		&pagination.PageInput{Limit: 2, Skip: 2},
+		"name",
+		&distinctIntegrationFilter{Done: &common.GenericFilter{Eq: true}},
+		"",
+	)
+	suite.Require().NoError(err)
+	suite.Equal([]any{"bob"}, getValues(got))
+	suite.False(pageOut.HasNext)
+	suite.True(pageOut.HasPrevious)
+
+	// Escaped case insensitive search
+	got, _, err = Distinct(context.TODO(), suite.db, &distinctIntegrationPeople{}, nil, "name", nil, "B_")
+	suite.Require().NoError(err)
+	suite.Equal([]any{"b_100%"}, getValues(got))
+
+	// Boolean values
+	got, _, err = Distinct(context.TODO(), suite.db, &distinctIntegrationPeople{}, nil, "done", nil, "")
+	suite.Require().NoError(err)
+	suite.Equal([]any{false, true}, getValues(got))
+
+	// Null values are ignored
+	got, _, err = Distinct(context.TODO(), suite.db, &distinctIntegrationPeople{}, nil, "nickname", nil, "")
+	suite.Require().NoError(err)
+	suite.Equal([]any{"nick"}, getValues(got))
+}
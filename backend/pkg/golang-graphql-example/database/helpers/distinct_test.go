@@ -0,0 +1,160 @@
+//go:build unit
+
+package databasehelpers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/common"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/dbtest"
+	dbmocks "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/mocks"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/pagination"
+)
+
+type distinctTestPeople struct {
+	database.Base
+	Name     string
+	Nickname *string
+	Done     bool
+}
+
+type distinctTestFilter struct {
+	Done *common.GenericFilter `dbfield:"done"`
+}
+
+func TestDistinct(t *testing.T) {
+	gdb := dbtest.NewSQLiteDB(t, &distinctTestPeople{})
+
+	nickname := "nick"
+
+	require.NoError(t, gdb.Create([]*distinctTestPeople{
+		{Base: database.Base{ID: "1"}, Name: "Bob", Nickname: &nickname, Done: true},
+		{Base: database.Base{ID: "2"}, Name: "alice", Done: true},
+		{Base: database.Base{ID: "3"}, Name: "bob", Done: false},
+		{Base: database.Base{ID: "4"}, Name: "Bob", Done: true},
+		{Base: database.Base{ID: "5"}, Name: "b_100%", Done: true},
+	}).Error)
+	// Soft deleted rows mustn't be listed
+	require.NoError(t, gdb.Create(&distinctTestPeople{Base: database.Base{ID: "6"}, Name: "deleted"}).Error)
+	require.NoError(t, gdb.Delete(&distinctTestPeople{Base: database.Base{ID: "6"}}).Error)
+
+	tests := []struct {
+		name        string
+		page        *pagination.PageInput
+		field       string
+		filter      *distinctTestFilter
+		search      string
+		want        []any
+		wantPageOut *pagination.PageOutput
+		errorString string
+	}{
+		{
+			name:        "all values",
+			field:       "name",
+			want:        []any{"Bob", "alice", "b_100%", "bob"},
+			wantPageOut: &pagination.PageOutput{Limit: 10, CountMode: pagination.CountModeNone},
+		},
+		{
+			name:        "first page",
+			page:        &pagination.PageInput{Limit: 2},
+			field:       "name",
+			want:        []any{"Bob", "alice"},
+			wantPageOut: &pagination.PageOutput{Limit: 2, CountMode: pagination.CountModeNone, HasNext: true},
+		},
+		{
+			name:        "last page",
+			page:        &pagination.PageInput{Limit: 2, Skip: 2},
+			field:       "name",
+			want:        []any{"b_100%", "bob"},
+			wantPageOut: &pagination.PageOutput{Limit: 2, Skip: 2, CountMode: pagination.CountModeNone, HasPrevious: true},
+		},
+		{
+			name:        "with filter",
+			field:       "name",
+			filter:      &distinctTestFilter{Done: &common.GenericFilter{Eq: true}},
+			want:        []any{"Bob", "alice", "b_100%"},
+			wantPageOut: &pagination.PageOutput{Limit: 10, CountMode: pagination.CountModeNone},
+		},
+		{
+			name:        "with case insensitive search",
+			field:       "name",
+			search:      "BO",
+			want:        []any{"Bob", "bob"},
+			wantPageOut: &pagination.PageOutput{Limit: 10, CountMode: pagination.CountModeNone},
+		},
+		{
+			name:        "with escaped search",
+			field:       "name",
+			search:      "b_",
+			want:        []any{"b_100%"},
+			wantPageOut: &pagination.PageOutput{Limit: 10, CountMode: pagination.CountModeNone},
+		},
+		{
+			name:        "boolean values",
+			field:       "done",
+			want:        []any{false, true},
+			wantPageOut: &pagination.PageOutput{Limit: 10, CountMode: pagination.CountModeNone},
+		},
+		{
+			name:        "null values are ignored",
+			field:       "nickname",
+			want:        []any{"nick"},
+			wantPageOut: &pagination.PageOutput{Limit: 10, CountMode: pagination.CountModeNone},
+		},
+		{
+			name:        "unknown column",
+			field:       "name; DROP TABLE people",
+			errorString: "column name; DROP TABLE people doesn't exist on distinctTestPeople",
+		},
+		{
+			name:        "search on non string column",
+			field:       "done",
+			search:      "t",
+			errorString: "search isn't supported on column done",
+		},
+		{
+			name:        "keyset pagination",
+			page:        &pagination.PageInput{Keyset: &pagination.KeysetInput{}},
+			field:       "name",
+			errorString: "keyset pagination isn't supported on distinct values",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			dbSvc := dbmocks.NewMockDB(ctrl)
+			dbSvc.EXPECT().GetTransactionalOrDefaultGormDB(gomock.Any()).AnyTimes().Return(gdb)
+
+			got, pageOut, err := Distinct(
+				context.TODO(),
+				dbSvc,
+				&distinctTestPeople{},
+				tt.page,
+				tt.field,
+				tt.filter,
+				tt.search,
+			)
+			if tt.errorString != "" {
+				assert.EqualError(t, err, tt.errorString)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			values := make([]any, 0, len(got))
+			for _, v := range got {
+				values = append(values, v.Value)
+			}
+
+			assert.Equal(t, tt.want, values)
+			assert.Equal(t, tt.wantPageOut, pageOut)
+		})
+	}
+}
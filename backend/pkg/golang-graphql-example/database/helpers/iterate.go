@@ -0,0 +1,133 @@
+package databasehelpers
+
+import (
+	"context"
+	"iter"
+	"slices"
+
+	"emperror.dev/errors"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/pagination"
+)
+
+// errStopIteration is used to stop batches when iterator consumer stops.
+var errStopIteration = errors.Sentinel("stop iteration")
+
+/**
+ * FindInBatches will find all objects matching filter and call the callback for each batch.
+ * Batches are paged with keyset pagination on sort columns and id, in a single read transaction.
+ * Params:
+ * - ctx context. Iteration is stopped when it is cancelled.
+ * - db database service
+ * - batchSize is the number of objects per batch. DefaultBatchSize is used when lower or equal to 0.
+ * - sort, filter and projection are managed like in Find. Sorts must support keyset pagination.
+ * - cb is called with the transactional context for each non empty batch. Iteration is stopped when it returns an error.
+ * - tOpts transaction options
+ */
+func FindInBatches[T any](
+	ctx context.Context,
+	db database.DB,
+	batchSize int,
+	sort any,
+	filter any,
+	projection any,
+	cb func(ctx context.Context, batch []T) error,
+	tOpts ...database.TransactionOption,
+) error {
+	// Check batch size
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	// Create local transaction options
+	localTOpts := slices.Clone(tOpts)
+	// Add read transaction
+	localTOpts = append(localTOpts, database.WithReadTransactionOpt)
+
+	return db.ExecuteTransaction(ctx, func(ctx context.Context) error {
+		// Initialize keyset on first page
+		keyset := &pagination.KeysetInput{}
+
+		for {
+			// Check context cancellation
+			err := ctx.Err()
+			if err != nil {
+				return errors.WithStack(err)
+			}
+
+			// Initialize batch
+			var batch []T
+			// Find batch in the iteration transaction without any savepoint
+			pageOut, err := pagination.PagingWithoutTransaction(ctx, &batch, &pagination.PagingOptions{
+				DBSvc: db,
+				PageInput: &pagination.PageInput{
+					Keyset:    keyset,
+					Limit:     batchSize,
+					CountMode: pagination.CountModeNone,
+				},
+				Sort:       sort,
+				Filter:     filter,
+				Projection: projection,
+			})
+			// Check error
+			if err != nil {
+				return err
+			}
+
+			// Check if there is nothing left
+			if len(batch) == 0 {
+				return nil
+			}
+
+			// Call callback
+			err = cb(ctx, batch)
+			// Check error
+			if err != nil {
+				return err
+			}
+
+			// Check if it is the last batch
+			if !pageOut.HasNext {
+				return nil
+			}
+
+			// Seek after last object
+			keyset = &pagination.KeysetInput{Values: pageOut.Keysets[len(pageOut.Keysets)-1]}
+		}
+	}, localTOpts...)
+}
+
+/**
+ * Iterate will return an iterator over all objects matching filter.
+ * Objects are loaded with FindInBatches, so the iteration is running in a read transaction.
+ * On error, the error is yielded with a zero object and iteration is stopped.
+ */
+func Iterate[T any](
+	ctx context.Context,
+	db database.DB,
+	batchSize int,
+	sort any,
+	filter any,
+	projection any,
+	tOpts ...database.TransactionOption,
+) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		// Find in batches
+		err := FindInBatches(ctx, db, batchSize, sort, filter, projection, func(_ context.Context, batch []T) error {
+			// Loop over batch
+			for _, it := range batch {
+				// Yield and check if consumer stopped
+				if !yield(it, nil) {
+					return errStopIteration
+				}
+			}
+
+			return nil
+		}, tOpts...)
+		// Check error
+		if err != nil && !errors.Is(err, errStopIteration) {
+			yield(*new(T), err)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+//go:build integration
+
+package databasehelpers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/common"
+)
+
+type iterateIntegrationPeople struct {
+	database.Base
+	Name string
+}
+
+type iterateIntegrationSortOrder struct {
+	CreatedAt *common.SortOrderEnum `dbfield:"created_at"`
+}
+
+func (suite *HelpersTestSuite) TestFindInBatches() {
+	suite.migrate(&iterateIntegrationPeople{})
+
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	list := make([]*iterateIntegrationPeople, 0, 25)
+	// Create people with some identical creation dates to check tie-breaker
+	for i := range 25 {
+		list = append(list, &iterateIntegrationPeople{
+			Base: database.Base{ID: fmt.Sprintf("%02d", i), CreatedAt: now.Add(time.Duration(i/2) * time.Microsecond)},
+			Name: fmt.Sprintf("name-%02d", i),
+		})
+	}
+
+	suite.Require().NoError(suite.db.GetGormDB().Create(list).Error)
+
+	batchSizes := []int{}
+	ids := []string{}
+	err := FindInBatches(
+		context.TODO(),
+		suite.db,
+		10,
+		&iterateIntegrationSortOrder{CreatedAt: &common.SortOrderEnumAsc},
+		nil,
+		nil,
+		func(_ context.Context, batch []*iterateIntegrationPeople) error {
+			batchSizes = append(batchSizes, len(batch))
+			for _, it := range batch {
+				ids = append(ids, it.ID)
+			}
+
+			return nil
+		},
+	)
+	suite.Require().NoError(err)
+	suite.Equal([]int{10, 10, 5}, batchSizes)
+
+	expectedIDs := make([]string, 0, 25)
+	for i := range 25 {
+		expectedIDs = append(expectedIDs, fmt.Sprintf("%02d", i))
+	}
+
+	suite.Equal(expectedIDs, ids)
+
+	// Iterate in an existing transaction
+	count := 0
+	err = suite.db.ExecuteTransaction(context.TODO(), func(ctx context.Context) error {
+		for it, err := range Iterate[*iterateIntegrationPeople](ctx, suite.db, 3, nil, nil, nil) {
+			suite.Require().NoError(err)
+			suite.NotEmpty(it.Name)
+
+			count++
+		}
+
+		return nil
+	})
+	suite.Require().NoError(err)
+	suite.Equal(25, count)
+}
@@ -0,0 +1,224 @@
+//go:build unit
+
+package databasehelpers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/common"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/dbtest"
+	dbmocks "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/mocks"
+)
+
+type iterateTestPeople struct {
+	database.Base
+	Name string
+	Team int
+}
+
+type iterateTestFilter struct {
+	Team *common.GenericFilter `dbfield:"team"`
+}
+
+type iterateTestSortOrder struct {
+	Name *common.SortOrderEnum `dbfield:"name"`
+}
+
+type iterateTestProjection struct {
+	ID   bool `dbfield:"id"`
+	Name bool `dbfield:"name"`
+}
+
+// iterateTestTransactionKey is used to save the test transaction in context.
+type iterateTestTransactionKey struct{}
+
+// iterateTestTransactions will record transactions created by the database mock.
+type iterateTestTransactions struct {
+	// Transaction options per transaction
+	options []*database.TransactionOptionsConfig
+	// Number of queries executed outside a transaction
+	queriesWithoutTransaction int
+}
+
+func setupIterateTest(t *testing.T) (*dbmocks.MockDB, *iterateTestTransactions) {
+	t.Helper()
+
+	gdb := dbtest.NewSQLiteDB(t, &iterateTestPeople{})
+
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	list := make([]*iterateTestPeople, 0, 25)
+	// Create people with some identical creation dates to check tie-breaker
+	for i := range 25 {
+		list = append(list, &iterateTestPeople{
+			Base: database.Base{ID: fmt.Sprintf("%02d", i), CreatedAt: now.Add(time.Duration(i/2) * time.Minute)},
+			Name: fmt.Sprintf("name-%02d", 24-i),
+			Team: i % 2,
+		})
+	}
+
+	require.NoError(t, gdb.Create(list).Error)
+
+	ctrl := gomock.NewController(t)
+	txs := &iterateTestTransactions{}
+
+	dbSvc := dbmocks.NewMockDB(ctrl)
+	dbSvc.EXPECT().GetTransactionalOrDefaultGormDB(gomock.Any()).AnyTimes().DoAndReturn(
+		func(ctx context.Context) *gorm.DB {
+			// Get transaction
+			tx, ok := ctx.Value(iterateTestTransactionKey{}).(*gorm.DB)
+			if !ok {
+				txs.queriesWithoutTransaction++
+
+				return gdb
+			}
+
+			return tx
+		},
+	)
+	dbSvc.EXPECT().ExecuteTransaction(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(ctx context.Context, cb func(context.Context) error, opts ...database.TransactionOption) error {
+			// Save options
+			cfg := &database.TransactionOptionsConfig{}
+			for _, o := range opts {
+				o(cfg)
+			}
+
+			txs.options = append(txs.options, cfg)
+
+			return gdb.Transaction(func(tx *gorm.DB) error {
+				return cb(context.WithValue(ctx, iterateTestTransactionKey{}, tx))
+			})
+		},
+	)
+
+	return dbSvc, txs
+}
+
+func TestFindInBatches(t *testing.T) {
+	dbSvc, txs := setupIterateTest(t)
+
+	// Default sort
+	batchSizes := []int{}
+	ids := []string{}
+	err := FindInBatches(
+		context.TODO(),
+		dbSvc,
+		10,
+		nil,
+		nil,
+		nil,
+		func(ctx context.Context, batch []*iterateTestPeople) error {
+			// Callback is called in iteration transaction
+			assert.NotNil(t, ctx.Value(iterateTestTransactionKey{}))
+
+			batchSizes = append(batchSizes, len(batch))
+			for _, it := range batch {
+				ids = append(ids, it.ID)
+			}
+
+			return nil
+		},
+		database.WithIsolationLevelOpt(sql.LevelRepeatableRead),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []int{10, 10, 5}, batchSizes)
+	assert.Equal(t, []string{
+		"24", "23", "22", "21", "20", "19", "18", "17", "16", "15", "14", "13", "12",
+		"11", "10", "09", "08", "07", "06", "05", "04", "03", "02", "01", "00",
+	}, ids)
+	// All batches are found in a single read transaction with given options
+	assert.Equal(t, []*database.TransactionOptionsConfig{
+		{ReadTransaction: true, IsolationLevel: sql.LevelRepeatableRead},
+	}, txs.options)
+	assert.Zero(t, txs.queriesWithoutTransaction)
+
+	// Filter, sort and projection
+	names := []string{}
+	err = FindInBatches(
+		context.TODO(),
+		dbSvc,
+		3,
+		[]*iterateTestSortOrder{{Name: &common.SortOrderEnumAsc}},
+		&iterateTestFilter{Team: &common.GenericFilter{Eq: 1}},
+		&iterateTestProjection{Name: true},
+		func(_ context.Context, batch []*iterateTestPeople) error {
+			for _, it := range batch {
+				assert.Zero(t, it.Team)
+				names = append(names, it.Name)
+			}
+
+			return nil
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"name-01", "name-03", "name-05", "name-07", "name-09", "name-11",
+		"name-13", "name-15", "name-17", "name-19", "name-21", "name-23",
+	}, names)
+
+	// Callback error
+	cbErr := errors.New("fake")
+	calls := 0
+	err = FindInBatches(context.TODO(), dbSvc, 10, nil, nil, nil, func(_ context.Context, _ []*iterateTestPeople) error {
+		calls++
+
+		return cbErr
+	})
+	require.ErrorIs(t, err, cbErr)
+	assert.Equal(t, 1, calls)
+}
+
+func TestIterate(t *testing.T) {
+	dbSvc, txs := setupIterateTest(t)
+
+	// Default batch size
+	count := 0
+	for it, err := range Iterate[*iterateTestPeople](context.TODO(), dbSvc, 0, nil, nil, nil) {
+		require.NoError(t, err)
+		assert.NotEmpty(t, it.ID)
+
+		count++
+	}
+
+	assert.Equal(t, 25, count)
+	// Iteration is running in a single read transaction
+	assert.Equal(t, []*database.TransactionOptionsConfig{{ReadTransaction: true}}, txs.options)
+	assert.Zero(t, txs.queriesWithoutTransaction)
+
+	// Stop iteration
+	ids := []string{}
+	for it, err := range Iterate[*iterateTestPeople](context.TODO(), dbSvc, 2, nil, nil, nil) {
+		require.NoError(t, err)
+
+		ids = append(ids, it.ID)
+		if len(ids) == 3 {
+			break
+		}
+	}
+
+	assert.Equal(t, []string{"24", "23", "22"}, ids)
+
+	// Cancelled context
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+
+	var lastErr error
+	for it, err := range Iterate[*iterateTestPeople](ctx, dbSvc, 2, nil, nil, nil) {
+		assert.Nil(t, it)
+
+		lastErr = err
+	}
+
+	require.ErrorIs(t, lastErr, context.Canceled)
+}
@@ -2,12 +2,30 @@ package databasehelpers
 
 import (
 	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"strings"
+	"time"
 
 	"emperror.dev/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
 
+	cerrors "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/common/errors"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
 )
 
+// Version column name used by versioned models.
+const versionColumnName = "version"
+
+// Soft delete column name.
+const deletedAtColumnName = "deleted_at"
+
+// ErrVersionConflict is used when a versioned object was modified by someone else.
+var ErrVersionConflict = errors.Sentinel("object was modified by someone else, version conflict")
+
 func CreateOrUpdate[T any](
 	ctx context.Context,
 	input T,
@@ -28,6 +46,46 @@ func CreateOrUpdate[T any](
 		}
 	}
 
+	// Check tenant
+	err = database.CheckTenantWrite(ctx, input)
+	// Check error
+	if err != nil {
+		return *new(T), err
+	}
+
+	// Apply tenant scope
+	gdb, err = database.ApplyTenantScope(ctx, gdb, input)
+	// Check error
+	if err != nil {
+		return *new(T), err
+	}
+
+	// Check if input is versioned
+	if v, ok := any(input).(database.Versioned); ok {
+		// Save with version check
+		err = saveVersioned(gdb, input, v)
+		// Check error
+		if err != nil {
+			return *new(T), err
+		}
+
+		// Return result
+		return input, nil
+	}
+
+	// Check if input is tenant scoped
+	if database.IsTenantScoped(input) {
+		// Save only in tenant
+		err = saveTenantScoped(ctx, gdb, input)
+		// Check error
+		if err != nil {
+			return *new(T), err
+		}
+
+		// Return result
+		return input, nil
+	}
+
 	// Save
 	dbres := gdb.Save(input)
 
@@ -41,6 +99,471 @@ func CreateOrUpdate[T any](
 	return input, nil
 }
 
+func saveVersioned(gdb *gorm.DB, input any, v database.Versioned) error {
+	// Get current version
+	current := v.GetVersion()
+
+	// Check if it is a creation
+	if current == 0 {
+		// Initialize version
+		v.SetVersion(1)
+
+		// Create
+		dbres := gdb.Create(input)
+		// Check error
+		if dbres.Error != nil {
+			// Restore version
+			v.SetVersion(current)
+
+			return errors.WithStack(dbres.Error)
+		}
+
+		return nil
+	}
+
+	// Increment version
+	v.SetVersion(current + 1)
+
+	// Update all fields only if version is the expected one
+	dbres := gdb.Model(input).
+		Where(versionColumnName+" = ?", current).
+		Select("*").
+		Updates(input)
+	// Check error
+	if dbres.Error != nil {
+		// Restore version
+		v.SetVersion(current)
+
+		return errors.WithStack(dbres.Error)
+	}
+
+	// Check if a line was updated
+	if dbres.RowsAffected == 0 {
+		// Restore version
+		v.SetVersion(current)
+
+		return cerrors.NewConflictError(ErrVersionConflict.Error())
+	}
+
+	return nil
+}
+
+// saveTenantScoped will save the object without using the gorm save upsert fallback
+// that could overwrite a row of another tenant.
+func saveTenantScoped(ctx context.Context, gdb *gorm.DB, input any) error {
+	// Create session to reuse scoped gorm db twice
+	gdb = gdb.Session(&gorm.Session{})
+
+	// Check if primary key is set
+	zero, err := hasZeroPrimaryKey(ctx, gdb, input)
+	// Check error
+	if err != nil {
+		return err
+	}
+
+	// Check if it is a creation
+	if !zero {
+		// Update all fields only in tenant
+		dbres := gdb.Model(input).Select("*").Updates(input)
+		// Check error
+		if dbres.Error != nil {
+			return errors.WithStack(dbres.Error)
+		}
+
+		// Check if a line was updated
+		if dbres.RowsAffected != 0 {
+			return nil
+		}
+	}
+
+	// Create
+	// This will fail if primary key already exists in another tenant
+	dbres := gdb.Create(input)
+	// Check error
+	if dbres.Error != nil {
+		return errors.WithStack(dbres.Error)
+	}
+
+	return nil
+}
+
+func hasZeroPrimaryKey(ctx context.Context, gdb *gorm.DB, input any) (bool, error) {
+	// Parse schema
+	stmt := &gorm.Statement{DB: gdb}
+	// Parse
+	err := stmt.Parse(input)
+	// Check error
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	// Get value
+	rv := reflect.Indirect(reflect.ValueOf(input))
+	// Loop over primary fields
+	for _, f := range stmt.Schema.PrimaryFields {
+		// Check if value is zero
+		if _, isZero := f.ValueOf(ctx, rv); isZero {
+			return true, nil
+		}
+	}
+
+	return len(stmt.Schema.PrimaryFields) == 0, nil
+}
+
+// DefaultBatchSize is the batch size used when none is provided.
+const DefaultBatchSize = 100
+
+/**
+ * CreateInBatches will insert all given objects in batches and return them.
+ * Params:
+ * - ctx context
+ * - input is a list of objects to insert.
+ * - batchSize is the number of objects per insert query. DefaultBatchSize is used when lower or equal to 0.
+ */
+func CreateInBatches[T any](
+	ctx context.Context,
+	input []T,
+	batchSize int,
+	db database.DB,
+	opts ...GormOpt,
+) ([]T, error) {
+	// Check if there is something to insert
+	if len(input) == 0 {
+		return input, nil
+	}
+
+	// Check batch size
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	// Check tenant
+	err := database.CheckTenantWrite(ctx, input)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	// Get gorm gdb
+	gdb := db.GetTransactionalOrDefaultGormDB(ctx)
+
+	// Apply options
+	for _, o := range opts {
+		gdb, err = o(ctx, gdb)
+		// Check error
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	// Loop over input to initialize versions
+	for _, v := range input {
+		// Check if object is versioned with an unknown version
+		if vv, ok := any(v).(database.Versioned); ok && vv.GetVersion() == 0 {
+			vv.SetVersion(1)
+		}
+	}
+
+	// Create
+	dbres := gdb.CreateInBatches(input, batchSize)
+
+	// Check error
+	err = dbres.Error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	// Return result
+	return input, nil
+}
+
+/**
+ * Upsert will insert all given objects in batches or update them on conflict.
+ * Params:
+ * - ctx context
+ * - input is a list of objects to insert or update.
+ * - batchSize is the number of objects per query. DefaultBatchSize is used when lower or equal to 0.
+ * - conflictColumns is the conflict target. Primary keys are used when empty.
+ * - updateColumns is the list of columns to update on conflict. All columns are updated when empty.
+ * For tenant scoped objects, a conflict with a row of another tenant will return a forbidden error.
+ * For versioned objects, version of updated rows is incremented.
+ * With conflict columns, tenant scoped or versioned objects, returned objects are reloaded from stored rows.
+ */
+func Upsert[T any](
+	ctx context.Context,
+	input []T,
+	batchSize int,
+	conflictColumns []string,
+	updateColumns []string,
+	db database.DB,
+	opts ...GormOpt,
+) ([]T, error) {
+	// Check if there is something to upsert
+	if len(input) == 0 {
+		return input, nil
+	}
+
+	// Check batch size
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	// Check tenant
+	err := database.CheckTenantWrite(ctx, input)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse schema
+	stmt := &gorm.Statement{DB: db.GetTransactionalOrDefaultGormDB(ctx)}
+	// Parse
+	err = stmt.Parse(input[0])
+	// Check error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	// Get conflict fields
+	fields, err := getConflictFields(stmt.Schema, conflictColumns)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	// Build on conflict clause
+	onConflict := buildOnConflictClause(conflictColumns, updateColumns)
+	// Check if input is versioned
+	_, versioned := any(input[0]).(database.Versioned)
+	if versioned {
+		// Increment version of updated rows
+		onConflict = withVersionIncrement(onConflict, stmt.Schema)
+	}
+
+	// Create new options
+	lOpts := make([]GormOpt, 0)
+	// Save input options
+	lOpts = append(lOpts, opts...)
+	// Check if input is tenant scoped
+	tenantScoped := database.IsTenantScoped(input)
+	if tenantScoped {
+		// Append on conflict limited to tenant rows
+		lOpts = append(lOpts, withTenantOnConflictGormOpt(onConflict))
+	} else {
+		// Append on conflict
+		lOpts = append(lOpts, withOnConflictClauseGormOpt(onConflict))
+	}
+
+	// Loop over batches
+	// Each batch is checked and reloaded on its own to stay under database bind parameters limits
+	for start := 0; start < len(input); start += batchSize {
+		// Get batch
+		batch := input[start:min(start+batchSize, len(input))]
+
+		// Check if input is tenant scoped
+		if tenantScoped {
+			// Check that conflicting rows belong to tenant
+			err = checkUpsertTenant(ctx, batch, fields, db)
+			// Check error
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// Create
+		_, err = CreateInBatches(ctx, batch, batchSize, db, lOpts...)
+		// Check error
+		if err != nil {
+			return nil, err
+		}
+
+		// Check if conflict target is the primary key and objects aren't tenant scoped or versioned
+		// In this case, objects already have the ids and values of stored rows
+		if len(conflictColumns) == 0 && !tenantScoped && !versioned {
+			continue
+		}
+
+		// Reload objects as conflicting rows keep their own ids and versions
+		err = reloadUpserted(ctx, batch, fields, db)
+		// Check error
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return input, nil
+}
+
+// getConflictFields will return schema fields of conflict columns or primary fields when empty.
+func getConflictFields(sch *schema.Schema, conflictColumns []string) ([]*schema.Field, error) {
+	// Check if conflict columns are set
+	if len(conflictColumns) == 0 {
+		return sch.PrimaryFields, nil
+	}
+
+	// Get conflict fields
+	fields := make([]*schema.Field, 0, len(conflictColumns))
+	// Loop over conflict columns
+	for _, c := range conflictColumns {
+		// Get field
+		f := sch.LookUpField(c)
+		// Check if it exists
+		if f == nil {
+			return nil, errors.Errorf("conflict column %s not found", c)
+		}
+
+		fields = append(fields, f)
+	}
+
+	return fields, nil
+}
+
+// getConflictKey will return the key of an object computed from conflict fields values
+// and the condition matching its stored row.
+// Each value is typed and quoted in key to avoid collisions.
+func getConflictKey(ctx context.Context, fields []*schema.Field, v any) (string, clause.Expression) {
+	// Get value
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	// Initialize
+	keys := make([]string, 0, len(fields))
+	exprs := make([]clause.Expression, 0, len(fields))
+	// Loop over fields
+	for _, f := range fields {
+		// Get field value
+		fv, _ := f.ValueOf(ctx, rv)
+		// Dereference pointers to compare values and not addresses
+		fv = indirectValue(fv)
+		// Check if value is a time to ignore location and monotonic clock
+		if t, ok := fv.(time.Time); ok {
+			fv = t.UTC()
+		}
+
+		keys = append(keys, fmt.Sprintf("%T:%q", fv, fmt.Sprint(fv)))
+		exprs = append(exprs, clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: f.DBName}, Value: fv})
+	}
+
+	return strings.Join(keys, ","), clause.And(exprs...)
+}
+
+func indirectValue(v any) any {
+	// Get value
+	rv := reflect.ValueOf(v)
+	// Loop over pointers
+	for rv.Kind() == reflect.Pointer {
+		// Check nil
+		if rv.IsNil() {
+			return nil
+		}
+
+		rv = rv.Elem()
+	}
+
+	// Check if value is valid
+	if !rv.IsValid() {
+		return nil
+	}
+
+	return rv.Interface()
+}
+
+// checkUpsertTenant will check that no row of another tenant conflicts with given objects.
+// Those rows would be silently skipped by the tenant on conflict clause or overwritten on drivers ignoring it.
+func checkUpsertTenant[T any](ctx context.Context, input []T, fields []*schema.Field, db database.DB) error {
+	// Get tenant
+	tenantID, err := database.GetTenantFromContext(ctx)
+	// Check error
+	if err != nil {
+		return err
+	}
+
+	// Build conditions
+	conds := make([]clause.Expression, 0, len(input))
+	// Loop over input
+	for _, v := range input {
+		_, cond := getConflictKey(ctx, fields, v)
+		conds = append(conds, cond)
+	}
+
+	var list []T
+	// Find a conflicting row of another tenant including soft deleted ones
+	err = db.GetTransactionalOrDefaultGormDB(ctx).
+		Unscoped().
+		Where(clause.Or(conds...)).
+		Where(clause.Neq{Column: clause.Column{Table: clause.CurrentTable, Name: database.TenantIDColumnName}, Value: tenantID}).
+		Limit(1).
+		Find(&list).Error
+	// Check error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Check if a row was found
+	if len(list) != 0 {
+		return cerrors.NewForbiddenError(database.ErrTenantMismatch.Error())
+	}
+
+	return nil
+}
+
+// reloadUpserted will replace objects by the stored rows found with conflict fields values.
+// For tenant scoped objects, a missing stored row in tenant means that the row belongs to another tenant.
+func reloadUpserted[T any](ctx context.Context, input []T, fields []*schema.Field, db database.DB) error {
+	// Check if there is something to reload
+	if len(input) == 0 {
+		return nil
+	}
+
+	// Build conditions
+	conds := make([]clause.Expression, 0, len(input))
+	// Loop over input
+	for _, v := range input {
+		_, cond := getConflictKey(ctx, fields, v)
+		conds = append(conds, cond)
+	}
+
+	// Apply tenant scope
+	gdb, err := database.ApplyTenantScope(ctx, db.GetTransactionalOrDefaultGormDB(ctx), input[0])
+	// Check error
+	if err != nil {
+		return err
+	}
+
+	var list []T
+	// Find stored rows including soft deleted ones
+	err = gdb.Unscoped().Where(clause.Or(conds...)).Find(&list).Error
+	// Check error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Index stored rows by key
+	stored := make(map[string]T, len(list))
+	// Loop over stored rows
+	for _, v := range list {
+		k, _ := getConflictKey(ctx, fields, v)
+		stored[k] = v
+	}
+
+	// Loop over input to replace objects
+	for i, v := range input {
+		k, _ := getConflictKey(ctx, fields, v)
+		// Check if stored row exists
+		sv, ok := stored[k]
+		if !ok {
+			// Check if object is tenant scoped
+			if database.IsTenantScoped(v) {
+				return cerrors.NewForbiddenError(database.ErrTenantMismatch.Error())
+			}
+
+			continue
+		}
+
+		input[i] = sv
+	}
+
+	return nil
+}
+
 func PermanentDelete[T any](
 	ctx context.Context,
 	input T,
@@ -61,6 +584,20 @@ func PermanentDelete[T any](
 		}
 	}
 
+	// Check tenant
+	err = database.CheckTenantOwnership(ctx, input)
+	// Check error
+	if err != nil {
+		return *new(T), err
+	}
+
+	// Apply tenant scope
+	gdb, err = database.ApplyTenantScope(ctx, gdb, input)
+	// Check error
+	if err != nil {
+		return *new(T), err
+	}
+
 	dbres := gdb.Unscoped().Delete(input)
 
 	// Check error
@@ -113,6 +650,20 @@ func SoftDelete[T any](
 		}
 	}
 
+	// Check tenant
+	err = database.CheckTenantOwnership(ctx, input)
+	// Check error
+	if err != nil {
+		return *new(T), err
+	}
+
+	// Apply tenant scope
+	gdb, err = database.ApplyTenantScope(ctx, gdb, input)
+	// Check error
+	if err != nil {
+		return *new(T), err
+	}
+
 	dbres := gdb.Delete(input)
 
 	// Check error
@@ -145,6 +696,29 @@ func SoftDeleteFiltered[T any](
 	return err
 }
 
+/**
+ * Restore will restore a soft deleted object/model.
+ * Params:
+ * - ctx context
+ * - input is the object to restore. Primary key must be set.
+ */
+func Restore[T any](
+	ctx context.Context,
+	input T,
+	db database.DB,
+	opts ...GormOpt,
+) (T, error) {
+	// Create new options
+	lOpts := make([]GormOpt, 0)
+	// Save input options
+	lOpts = append(lOpts, opts...)
+	// Append with deleted to be able to find soft deleted row
+	lOpts = append(lOpts, WithDeletedGormOpt())
+
+	// Patch
+	return PatchUpdate(ctx, input, map[string]any{deletedAtColumnName: nil}, db, lOpts...)
+}
+
 /**
  * PatchUpdate will update specific columns and return the updated object/model.
  * Params:
@@ -173,6 +747,33 @@ func PatchUpdate[T any](
 		}
 	}
 
+	// Check tenant
+	err = checkTenantPatch(ctx, originalObject, input)
+	// Check error
+	if err != nil {
+		return *new(T), err
+	}
+
+	// Apply tenant scope
+	gdb, err = database.ApplyTenantScope(ctx, gdb, originalObject)
+	// Check error
+	if err != nil {
+		return *new(T), err
+	}
+
+	// Check if object is versioned
+	if v, ok := any(originalObject).(database.Versioned); ok {
+		// Patch with version check
+		err = patchUpdateVersioned(gdb, originalObject, input, v)
+		// Check error
+		if err != nil {
+			return *new(T), err
+		}
+
+		// Return result
+		return originalObject, nil
+	}
+
 	dbres := gdb.Model(originalObject).Updates(input)
 
 	// Check error
@@ -185,6 +786,83 @@ func PatchUpdate[T any](
 	return originalObject, nil
 }
 
+func checkTenantPatch(ctx context.Context, originalObject any, input map[string]any) error {
+	// Check object tenant
+	err := database.CheckTenantOwnership(ctx, originalObject)
+	// Check error
+	if err != nil {
+		return err
+	}
+
+	// Check if tenant is patched
+	v, ok := input[database.TenantIDColumnName]
+	// Check if it exists
+	if !ok || !database.IsTenantScoped(originalObject) {
+		return nil
+	}
+
+	// Get tenant
+	tenantID, err := database.GetTenantFromContext(ctx)
+	// Check error
+	if err != nil {
+		return err
+	}
+
+	// Check value
+	if v != tenantID {
+		return cerrors.NewForbiddenError(database.ErrTenantMismatch.Error())
+	}
+
+	return nil
+}
+
+func patchUpdateVersioned(gdb *gorm.DB, originalObject any, input map[string]any, v database.Versioned) error {
+	// Get current version
+	current := v.GetVersion()
+
+	// Copy input to avoid modifying it
+	patch := maps.Clone(input)
+	// Check if patch is nil
+	if patch == nil {
+		patch = map[string]any{}
+	}
+
+	// Check if version is known
+	// Unknown version is used on filtered patch, in this case, only an increment is done
+	if current == 0 {
+		// Increment
+		patch[versionColumnName] = gorm.Expr(versionColumnName + " + 1")
+
+		// Update
+		dbres := gdb.Model(originalObject).Updates(patch)
+
+		return errors.WithStack(dbres.Error)
+	}
+
+	// Set new version
+	patch[versionColumnName] = current + 1
+
+	// Update only if version is the expected one
+	dbres := gdb.Model(originalObject).Where(versionColumnName+" = ?", current).Updates(patch)
+	// Check error
+	if dbres.Error != nil {
+		// Restore version
+		v.SetVersion(current)
+
+		return errors.WithStack(dbres.Error)
+	}
+
+	// Check if a line was updated
+	if dbres.RowsAffected == 0 {
+		// Restore version
+		v.SetVersion(current)
+
+		return cerrors.NewConflictError(ErrVersionConflict.Error())
+	}
+
+	return nil
+}
+
 /**
  * PatchUpdateAllFiltered will update specific columns filtered on where.
  * Params:
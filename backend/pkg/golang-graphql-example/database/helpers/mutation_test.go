@@ -5,18 +5,23 @@ package databasehelpers
 import (
 	"context"
 	"database/sql/driver"
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/common/tenant"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/common"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/dbtest"
 	dbmocks "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/mocks"
 )
 
@@ -52,7 +57,7 @@ func TestPatchUpdate(t *testing.T) {
 				},
 				input: map[string]any{"name": "updated"},
 			},
-			expectedSQLQuery: `UPDATE "peoples" SET "name"=$1,"updated_at"=$2 WHERE "id" = $3`,
+			expectedSQLQuery: `UPDATE "peoples" SET "name"=$1,"updated_at"=$2 WHERE "peoples"."deleted_at" IS NULL AND "id" = $3`,
 			expectedSQLArgs:  []driver.Value{"updated", now, "id1"},
 			want: &People{
 				Base:       database.Base{ID: "id1", UpdatedAt: now},
@@ -70,7 +75,7 @@ func TestPatchUpdate(t *testing.T) {
 				},
 				input: map[string]any{"full__name": "updated"},
 			},
-			expectedSQLQuery: `UPDATE "peoples" SET "full__name"=$1,"updated_at"=$2 WHERE "id" = $3`,
+			expectedSQLQuery: `UPDATE "peoples" SET "full__name"=$1,"updated_at"=$2 WHERE "peoples"."deleted_at" IS NULL AND "id" = $3`,
 			expectedSQLArgs:  []driver.Value{"updated", now, "id1"},
 			want: &People{
 				Base:       database.Base{ID: "id1", UpdatedAt: now},
@@ -89,7 +94,7 @@ func TestPatchUpdate(t *testing.T) {
 				},
 				input: map[string]any{"name": "updated", "logged_once": false},
 			},
-			expectedSQLQuery: `UPDATE "peoples" SET "logged_once"=$1,"name"=$2,"updated_at"=$3 WHERE "id" = $4`,
+			expectedSQLQuery: `UPDATE "peoples" SET "logged_once"=$1,"name"=$2,"updated_at"=$3 WHERE "peoples"."deleted_at" IS NULL AND "id" = $4`,
 			expectedSQLArgs:  []driver.Value{false, "updated", now, "id1"},
 			want: &People{
 				Base:       database.Base{ID: "id1", UpdatedAt: now},
@@ -107,7 +112,7 @@ func TestPatchUpdate(t *testing.T) {
 				},
 				input: map[string]any{"full__name": "updated", "name": "updated"},
 			},
-			expectedSQLQuery: `UPDATE "peoples" SET "full__name"=$1,"name"=$2,"updated_at"=$3 WHERE "id" = $4`,
+			expectedSQLQuery: `UPDATE "peoples" SET "full__name"=$1,"name"=$2,"updated_at"=$3 WHERE "peoples"."deleted_at" IS NULL AND "id" = $4`,
 			expectedSQLArgs:  []driver.Value{"updated", "updated", now, "id1"},
 			want: &People{
 				Base:       database.Base{ID: "id1", UpdatedAt: now},
@@ -204,7 +209,7 @@ func TestPatchUpdateAllFiltered(t *testing.T) {
 					},
 				},
 			},
-			expectedSQLQuery: `UPDATE "peoples" SET "name"=$1,"updated_at"=$2 WHERE name = $3 OR logged_once = $4`,
+			expectedSQLQuery: `UPDATE "peoples" SET "name"=$1,"updated_at"=$2 WHERE (name = $3 OR logged_once = $4) AND "peoples"."deleted_at" IS NULL`,
 			expectedSQLArgs:  []driver.Value{"updated", now, "fake", true},
 		},
 		{
@@ -219,7 +224,7 @@ func TestPatchUpdateAllFiltered(t *testing.T) {
 					},
 				},
 			},
-			expectedSQLQuery: `UPDATE "peoples" SET "full__name"=$1,"updated_at"=$2 WHERE name = $3 OR logged_once = $4`,
+			expectedSQLQuery: `UPDATE "peoples" SET "full__name"=$1,"updated_at"=$2 WHERE (name = $3 OR logged_once = $4) AND "peoples"."deleted_at" IS NULL`,
 			expectedSQLArgs:  []driver.Value{"updated", now, "fake", true},
 		},
 		{
@@ -234,7 +239,7 @@ func TestPatchUpdateAllFiltered(t *testing.T) {
 					},
 				},
 			},
-			expectedSQLQuery: `UPDATE "peoples" SET "logged_once"=$1,"name"=$2,"updated_at"=$3 WHERE name = $4 OR logged_once = $5`,
+			expectedSQLQuery: `UPDATE "peoples" SET "logged_once"=$1,"name"=$2,"updated_at"=$3 WHERE (name = $4 OR logged_once = $5) AND "peoples"."deleted_at" IS NULL`,
 			expectedSQLArgs:  []driver.Value{false, "updated", now, "fake", true},
 		},
 		{
@@ -249,7 +254,7 @@ func TestPatchUpdateAllFiltered(t *testing.T) {
 					},
 				},
 			},
-			expectedSQLQuery: `UPDATE "peoples" SET "full__name"=$1,"name"=$2,"updated_at"=$3 WHERE name = $4 OR logged_once = $5`,
+			expectedSQLQuery: `UPDATE "peoples" SET "full__name"=$1,"name"=$2,"updated_at"=$3 WHERE (name = $4 OR logged_once = $5) AND "peoples"."deleted_at" IS NULL`,
 			expectedSQLArgs:  []driver.Value{"updated", "updated", now, "fake", true},
 		},
 	}
@@ -328,10 +333,14 @@ func TestSoftDelete(t *testing.T) {
 					LoggedOnce: true,
 				},
 			},
-			expectedSQLQuery: `DELETE FROM "peoples" WHERE "peoples"."id" = $1`,
-			expectedSQLArgs:  []driver.Value{"id1"},
+			expectedSQLQuery: `UPDATE "peoples" SET "deleted_at"=$1 WHERE "peoples"."id" = $2 AND "peoples"."deleted_at" IS NULL`,
+			expectedSQLArgs:  []driver.Value{now, "id1"},
 			want: &People{
-				Base:       database.Base{ID: "id1", UpdatedAt: now.Add(-time.Second)},
+				Base: database.Base{
+					ID:        "id1",
+					UpdatedAt: now.Add(-time.Second),
+					DeletedAt: gorm.DeletedAt{Time: now, Valid: true},
+				},
 				Name:       "original",
 				LoggedOnce: true,
 			},
@@ -422,8 +431,8 @@ func TestSoftDeleteFiltered(t *testing.T) {
 					},
 				},
 			},
-			expectedSQLQuery: `DELETE FROM "peoples" WHERE name = $1 OR logged_once = $2`,
-			expectedSQLArgs:  []driver.Value{"fake", true},
+			expectedSQLQuery: `UPDATE "peoples" SET "deleted_at"=$1 WHERE (name = $2 OR logged_once = $3) AND "peoples"."deleted_at" IS NULL`,
+			expectedSQLArgs:  []driver.Value{now, "fake", true},
 		},
 		{
 			name: "1 custom field",
@@ -436,8 +445,8 @@ func TestSoftDeleteFiltered(t *testing.T) {
 					},
 				},
 			},
-			expectedSQLQuery: `DELETE FROM "peoples" WHERE name = $1 OR logged_once = $2`,
-			expectedSQLArgs:  []driver.Value{"fake", true},
+			expectedSQLQuery: `UPDATE "peoples" SET "deleted_at"=$1 WHERE (name = $2 OR logged_once = $3) AND "peoples"."deleted_at" IS NULL`,
+			expectedSQLArgs:  []driver.Value{now, "fake", true},
 		},
 	}
 	for _, tt := range tests {
@@ -485,6 +494,96 @@ func TestSoftDeleteFiltered(t *testing.T) {
 	}
 }
 
+func TestRestore(t *testing.T) {
+	now := time.Now()
+
+	type People struct {
+		database.Base
+		Name       string
+		FullName   string `gorm:"column:full__name"`
+		LoggedOnce bool
+	}
+	type args struct {
+		input any
+	}
+	tests := []struct {
+		name             string
+		args             args
+		want             any
+		wantErr          bool
+		errorString      string
+		expectedSQLQuery string
+		expectedSQLArgs  []driver.Value
+	}{
+		{
+			name: "simple case",
+			args: args{
+				input: &People{
+					Base: database.Base{
+						ID:        "id1",
+						UpdatedAt: now.Add(-time.Second),
+						DeletedAt: gorm.DeletedAt{Time: now.Add(-time.Second), Valid: true},
+					},
+					Name:       "original",
+					LoggedOnce: true,
+				},
+			},
+			expectedSQLQuery: `UPDATE "peoples" SET "deleted_at"=$1,"updated_at"=$2 WHERE "id" = $3`,
+			expectedSQLArgs:  []driver.Value{nil, now, "id1"},
+			want: &People{
+				Base:       database.Base{ID: "id1", UpdatedAt: now},
+				Name:       "original",
+				LoggedOnce: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Error(err)
+
+				return
+			}
+			defer sqlDB.Close()
+
+			db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Discard, NowFunc: func() time.Time {
+				return now
+			}})
+			if err != nil {
+				t.Error(err)
+
+				return
+			}
+
+			ctrl := gomock.NewController(t)
+			dbSvc := dbmocks.NewMockDB(ctrl)
+			dbSvc.EXPECT().GetTransactionalOrDefaultGormDB(gomock.Any()).AnyTimes().Return(db)
+
+			mock.ExpectBegin()
+			mock.ExpectExec(tt.expectedSQLQuery).
+				WithArgs(tt.expectedSQLArgs...).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
+			ctx := context.TODO()
+			got, err := Restore(ctx, tt.args.input, dbSvc)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Restore() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+			if err != nil && err.Error() != tt.errorString {
+				t.Errorf("Restore() error = %v, wantErr %v", err, tt.errorString)
+
+				return
+			}
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 func TestPermanentDelete(t *testing.T) {
 	now := time.Now()
 
@@ -671,3 +770,944 @@ func TestPermanentDeleteFiltered(t *testing.T) {
 		})
 	}
 }
+
+func TestUpsert(t *testing.T) {
+	now := time.Now()
+
+	type People struct {
+		database.Base
+		Name       string
+		LoggedOnce bool
+	}
+	type args struct {
+		input           []*People
+		batchSize       int
+		conflictColumns []string
+		updateColumns   []string
+	}
+	tests := []struct {
+		name                   string
+		args                   args
+		want                   []*People
+		wantErr                bool
+		errorString            string
+		noSQLQuery             bool
+		expectedSQLQuery       string
+		expectedSQLArgs        []driver.Value
+		expectedReloadSQLQuery string
+		expectedReloadSQLArgs  []driver.Value
+		reloadRows             *sqlmock.Rows
+	}{
+		{
+			name: "empty input",
+			args: args{
+				input: []*People{},
+			},
+			noSQLQuery: true,
+			want:       []*People{},
+		},
+		{
+			name: "default conflict target and update all",
+			args: args{
+				input: []*People{
+					{Base: database.Base{ID: "id1"}, Name: "name1"},
+					{Base: database.Base{ID: "id2"}, Name: "name2", LoggedOnce: true},
+				},
+			},
+			expectedSQLQuery: `INSERT INTO "peoples" ("created_at","updated_at","deleted_at","id","name","logged_once") VALUES ($1,$2,$3,$4,$5,$6),($7,$8,$9,$10,$11,$12) ON CONFLICT ("id") DO UPDATE SET "updated_at"=$13,"deleted_at"="excluded"."deleted_at","name"="excluded"."name","logged_once"="excluded"."logged_once"`,
+			expectedSQLArgs: []driver.Value{
+				now, now, nil, "id1", "name1", false,
+				now, now, nil, "id2", "name2", true,
+				now,
+			},
+			want: []*People{
+				{Base: database.Base{ID: "id1", CreatedAt: now, UpdatedAt: now}, Name: "name1"},
+				{Base: database.Base{ID: "id2", CreatedAt: now, UpdatedAt: now}, Name: "name2", LoggedOnce: true},
+			},
+		},
+		{
+			name: "custom conflict target and update columns",
+			args: args{
+				input: []*People{
+					{Base: database.Base{ID: "id1"}, Name: "name1"},
+				},
+				conflictColumns: []string{"name"},
+				updateColumns:   []string{"logged_once", "updated_at"},
+			},
+			expectedSQLQuery:       `INSERT INTO "peoples" ("created_at","updated_at","deleted_at","id","name","logged_once") VALUES ($1,$2,$3,$4,$5,$6) ON CONFLICT ("name") DO UPDATE SET "logged_once"="excluded"."logged_once","updated_at"="excluded"."updated_at"`,
+			expectedSQLArgs:        []driver.Value{now, now, nil, "id1", "name1", false},
+			expectedReloadSQLQuery: `SELECT * FROM "peoples" WHERE "peoples"."name" = $1`,
+			expectedReloadSQLArgs:  []driver.Value{"name1"},
+			reloadRows: sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name", "logged_once"}).
+				AddRow("existing-id", now.Add(-time.Hour), now, "name1", false),
+			want: []*People{
+				{Base: database.Base{ID: "existing-id", CreatedAt: now.Add(-time.Hour), UpdatedAt: now}, Name: "name1"},
+			},
+		},
+		{
+			name: "custom conflict target with multiple columns and new rows",
+			args: args{
+				input: []*People{
+					{Base: database.Base{ID: "id1"}, Name: "name1"},
+					{Base: database.Base{ID: "id2"}, Name: "name2", LoggedOnce: true},
+				},
+				conflictColumns: []string{"name", "logged_once"},
+				updateColumns:   []string{"updated_at"},
+			},
+			expectedSQLQuery: `INSERT INTO "peoples" ("created_at","updated_at","deleted_at","id","name","logged_once") VALUES ($1,$2,$3,$4,$5,$6),($7,$8,$9,$10,$11,$12) ON CONFLICT ("name","logged_once") DO UPDATE SET "updated_at"="excluded"."updated_at"`,
+			expectedSQLArgs: []driver.Value{
+				now, now, nil, "id1", "name1", false,
+				now, now, nil, "id2", "name2", true,
+			},
+			expectedReloadSQLQuery: `SELECT * FROM "peoples" WHERE (("peoples"."name" = $1 AND "peoples"."logged_once" = $2) OR ("peoples"."name" = $3 AND "peoples"."logged_once" = $4))`,
+			expectedReloadSQLArgs:  []driver.Value{"name1", false, "name2", true},
+			reloadRows: sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name", "logged_once"}).
+				AddRow("id1", now, now, "name1", false).
+				AddRow("existing-id", now.Add(-time.Hour), now, "name2", true),
+			want: []*People{
+				{Base: database.Base{ID: "id1", CreatedAt: now, UpdatedAt: now}, Name: "name1"},
+				{Base: database.Base{ID: "existing-id", CreatedAt: now.Add(-time.Hour), UpdatedAt: now}, Name: "name2", LoggedOnce: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Error(err)
+
+				return
+			}
+			defer sqlDB.Close()
+
+			db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Discard, NowFunc: func() time.Time {
+				return now
+			}})
+			if err != nil {
+				t.Error(err)
+
+				return
+			}
+
+			ctrl := gomock.NewController(t)
+			dbSvc := dbmocks.NewMockDB(ctrl)
+			dbSvc.EXPECT().GetTransactionalOrDefaultGormDB(gomock.Any()).AnyTimes().Return(db)
+
+			if !tt.noSQLQuery {
+				mock.ExpectBegin()
+				mock.ExpectExec(tt.expectedSQLQuery).
+					WithArgs(tt.expectedSQLArgs...).
+					WillReturnResult(sqlmock.NewResult(0, int64(len(tt.args.input))))
+				mock.ExpectCommit()
+			}
+
+			if tt.expectedReloadSQLQuery != "" {
+				mock.ExpectQuery(tt.expectedReloadSQLQuery).
+					WithArgs(tt.expectedReloadSQLArgs...).
+					WillReturnRows(tt.reloadRows)
+			}
+
+			ctx := context.TODO()
+			got, err := Upsert(ctx, tt.args.input, tt.args.batchSize, tt.args.conflictColumns, tt.args.updateColumns, dbSvc)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Upsert() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+			if err != nil && err.Error() != tt.errorString {
+				t.Errorf("Upsert() error = %v, wantErr %v", err, tt.errorString)
+
+				return
+			}
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUpsertSQLite(t *testing.T) {
+	now := time.Now()
+
+	type People struct {
+		database.Base
+		Name       string
+		LoggedOnce bool
+	}
+	type args struct {
+		input           []*People
+		conflictColumns []string
+		updateColumns   []string
+	}
+	tests := []struct {
+		name                   string
+		args                   args
+		want                   []*People
+		expectedSQLQuery       string
+		expectedSQLArgs        []driver.Value
+		expectedReloadSQLQuery string
+		expectedReloadSQLArgs  []driver.Value
+		reloadRows             *sqlmock.Rows
+	}{
+		{
+			name: "default conflict target and update all",
+			args: args{
+				input: []*People{
+					{Base: database.Base{ID: "id1"}, Name: "name1"},
+				},
+			},
+			expectedSQLQuery: "INSERT INTO `peoples` (`created_at`,`updated_at`,`deleted_at`,`id`,`name`,`logged_once`) VALUES (?,?,?,?,?,?) ON CONFLICT (`id`) DO UPDATE SET `updated_at`=?,`deleted_at`=`excluded`.`deleted_at`,`name`=`excluded`.`name`,`logged_once`=`excluded`.`logged_once`",
+			expectedSQLArgs:  []driver.Value{now, now, nil, "id1", "name1", false, now},
+			want: []*People{
+				{Base: database.Base{ID: "id1", CreatedAt: now, UpdatedAt: now}, Name: "name1"},
+			},
+		},
+		{
+			name: "custom conflict target and update columns",
+			args: args{
+				input: []*People{
+					{Base: database.Base{ID: "id1"}, Name: "name1"},
+				},
+				conflictColumns: []string{"name"},
+				updateColumns:   []string{"logged_once", "updated_at"},
+			},
+			expectedSQLQuery:       "INSERT INTO `peoples` (`created_at`,`updated_at`,`deleted_at`,`id`,`name`,`logged_once`) VALUES (?,?,?,?,?,?) ON CONFLICT (`name`) DO UPDATE SET `logged_once`=`excluded`.`logged_once`,`updated_at`=`excluded`.`updated_at`",
+			expectedSQLArgs:        []driver.Value{now, now, nil, "id1", "name1", false},
+			expectedReloadSQLQuery: "SELECT * FROM `peoples` WHERE `peoples`.`name` = ?",
+			expectedReloadSQLArgs:  []driver.Value{"name1"},
+			reloadRows: sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name", "logged_once"}).
+				AddRow("existing-id", now.Add(-time.Hour), now, "name1", false),
+			want: []*People{
+				{Base: database.Base{ID: "existing-id", CreatedAt: now.Add(-time.Hour), UpdatedAt: now}, Name: "name1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Error(err)
+
+				return
+			}
+			defer sqlDB.Close()
+
+			mock.ExpectQuery("select sqlite_version()").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow("3.46.0"))
+
+			db, err := gorm.Open(sqlite.New(sqlite.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Discard, NowFunc: func() time.Time {
+				return now
+			}})
+			if err != nil {
+				t.Error(err)
+
+				return
+			}
+
+			ctrl := gomock.NewController(t)
+			dbSvc := dbmocks.NewMockDB(ctrl)
+			dbSvc.EXPECT().GetTransactionalOrDefaultGormDB(gomock.Any()).AnyTimes().Return(db)
+
+			mock.ExpectBegin()
+			mock.ExpectExec(tt.expectedSQLQuery).
+				WithArgs(tt.expectedSQLArgs...).
+				WillReturnResult(sqlmock.NewResult(0, int64(len(tt.args.input))))
+			mock.ExpectCommit()
+
+			if tt.expectedReloadSQLQuery != "" {
+				mock.ExpectQuery(tt.expectedReloadSQLQuery).
+					WithArgs(tt.expectedReloadSQLArgs...).
+					WillReturnRows(tt.reloadRows)
+			}
+
+			got, err := Upsert(context.TODO(), tt.args.input, 0, tt.args.conflictColumns, tt.args.updateColumns, dbSvc)
+			if err != nil {
+				t.Error(err)
+
+				return
+			}
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCreateInBatches(t *testing.T) {
+	now := time.Now()
+
+	type People struct {
+		database.Base
+		Name string
+	}
+	tests := []struct {
+		name               string
+		input              []*People
+		batchSize          int
+		want               []*People
+		expectedSQLQueries []string
+		expectedSQLArgs    [][]driver.Value
+	}{
+		{
+			name:  "empty input",
+			input: []*People{},
+			want:  []*People{},
+		},
+		{
+			name: "default batch size",
+			input: []*People{
+				{Base: database.Base{ID: "id1"}, Name: "name1"},
+				{Base: database.Base{ID: "id2"}, Name: "name2"},
+			},
+			expectedSQLQueries: []string{
+				`INSERT INTO "peoples" ("created_at","updated_at","deleted_at","id","name") VALUES ($1,$2,$3,$4,$5),($6,$7,$8,$9,$10)`,
+			},
+			expectedSQLArgs: [][]driver.Value{
+				{now, now, nil, "id1", "name1", now, now, nil, "id2", "name2"},
+			},
+			want: []*People{
+				{Base: database.Base{ID: "id1", CreatedAt: now, UpdatedAt: now}, Name: "name1"},
+				{Base: database.Base{ID: "id2", CreatedAt: now, UpdatedAt: now}, Name: "name2"},
+			},
+		},
+		{
+			name: "batch size of 2",
+			input: []*People{
+				{Base: database.Base{ID: "id1"}, Name: "name1"},
+				{Base: database.Base{ID: "id2"}, Name: "name2"},
+				{Base: database.Base{ID: "id3"}, Name: "name3"},
+			},
+			batchSize: 2,
+			expectedSQLQueries: []string{
+				`INSERT INTO "peoples" ("created_at","updated_at","deleted_at","id","name") VALUES ($1,$2,$3,$4,$5),($6,$7,$8,$9,$10)`,
+				`INSERT INTO "peoples" ("created_at","updated_at","deleted_at","id","name") VALUES ($1,$2,$3,$4,$5)`,
+			},
+			expectedSQLArgs: [][]driver.Value{
+				{now, now, nil, "id1", "name1", now, now, nil, "id2", "name2"},
+				{now, now, nil, "id3", "name3"},
+			},
+			want: []*People{
+				{Base: database.Base{ID: "id1", CreatedAt: now, UpdatedAt: now}, Name: "name1"},
+				{Base: database.Base{ID: "id2", CreatedAt: now, UpdatedAt: now}, Name: "name2"},
+				{Base: database.Base{ID: "id3", CreatedAt: now, UpdatedAt: now}, Name: "name3"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Error(err)
+
+				return
+			}
+			defer sqlDB.Close()
+
+			db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Discard, NowFunc: func() time.Time {
+				return now
+			}})
+			if err != nil {
+				t.Error(err)
+
+				return
+			}
+
+			ctrl := gomock.NewController(t)
+			dbSvc := dbmocks.NewMockDB(ctrl)
+			dbSvc.EXPECT().GetTransactionalOrDefaultGormDB(gomock.Any()).AnyTimes().Return(db)
+
+			if len(tt.expectedSQLQueries) != 0 {
+				mock.ExpectBegin()
+				for i, q := range tt.expectedSQLQueries {
+					mock.ExpectExec(q).
+						WithArgs(tt.expectedSQLArgs[i]...).
+						WillReturnResult(sqlmock.NewResult(0, 1))
+				}
+				mock.ExpectCommit()
+			}
+
+			got, err := CreateInBatches(context.TODO(), tt.input, tt.batchSize, dbSvc)
+			if err != nil {
+				t.Error(err)
+
+				return
+			}
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCreateOrUpdateVersioned(t *testing.T) {
+	now := time.Now()
+
+	type People struct {
+		database.BaseWithVersion
+		Name string
+	}
+	tests := []struct {
+		name             string
+		input            *People
+		rowsAffected     int64
+		want             *People
+		wantErr          bool
+		errorString      string
+		expectedSQLQuery string
+		expectedSQLArgs  []driver.Value
+	}{
+		{
+			name:             "creation",
+			input:            &People{BaseWithVersion: database.BaseWithVersion{Base: database.Base{ID: "id1"}}, Name: "name"},
+			rowsAffected:     1,
+			expectedSQLQuery: `INSERT INTO "peoples" ("created_at","updated_at","deleted_at","id","version","name") VALUES ($1,$2,$3,$4,$5,$6)`,
+			expectedSQLArgs:  []driver.Value{now, now, nil, "id1", 1, "name"},
+			want: &People{
+				BaseWithVersion: database.BaseWithVersion{Base: database.Base{ID: "id1", CreatedAt: now, UpdatedAt: now}, Version: 1},
+				Name:            "name",
+			},
+		},
+		{
+			name: "update",
+			input: &People{
+				BaseWithVersion: database.BaseWithVersion{Base: database.Base{ID: "id1", CreatedAt: now}, Version: 2},
+				Name:            "name",
+			},
+			rowsAffected:     1,
+			expectedSQLQuery: `UPDATE "peoples" SET "created_at"=$1,"updated_at"=$2,"deleted_at"=$3,"version"=$4,"name"=$5 WHERE version = $6 AND "peoples"."deleted_at" IS NULL AND "id" = $7`,
+			expectedSQLArgs:  []driver.Value{now, now, nil, 3, "name", 2, "id1"},
+			want: &People{
+				BaseWithVersion: database.BaseWithVersion{Base: database.Base{ID: "id1", CreatedAt: now, UpdatedAt: now}, Version: 3},
+				Name:            "name",
+			},
+		},
+		{
+			name: "conflict",
+			input: &People{
+				BaseWithVersion: database.BaseWithVersion{Base: database.Base{ID: "id1", CreatedAt: now}, Version: 2},
+				Name:            "name",
+			},
+			rowsAffected:     0,
+			expectedSQLQuery: `UPDATE "peoples" SET "created_at"=$1,"updated_at"=$2,"deleted_at"=$3,"version"=$4,"name"=$5 WHERE version = $6 AND "peoples"."deleted_at" IS NULL AND "id" = $7`,
+			expectedSQLArgs:  []driver.Value{now, now, nil, 3, "name", 2, "id1"},
+			wantErr:          true,
+			errorString:      "object was modified by someone else, version conflict",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Error(err)
+
+				return
+			}
+			defer sqlDB.Close()
+
+			db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Discard, NowFunc: func() time.Time {
+				return now
+			}})
+			if err != nil {
+				t.Error(err)
+
+				return
+			}
+
+			ctrl := gomock.NewController(t)
+			dbSvc := dbmocks.NewMockDB(ctrl)
+			dbSvc.EXPECT().GetTransactionalOrDefaultGormDB(gomock.Any()).AnyTimes().Return(db)
+
+			mock.ExpectBegin()
+			mock.ExpectExec(tt.expectedSQLQuery).
+				WithArgs(tt.expectedSQLArgs...).
+				WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+			mock.ExpectCommit()
+
+			ctx := context.TODO()
+			got, err := CreateOrUpdate(ctx, tt.input, dbSvc)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateOrUpdate() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+			if err != nil && err.Error() != tt.errorString {
+				t.Errorf("CreateOrUpdate() error = %v, wantErr %v", err, tt.errorString)
+
+				return
+			}
+			if err != nil {
+				assert.Equal(t, 2, tt.input.Version)
+
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPatchUpdateVersioned(t *testing.T) {
+	now := time.Now()
+
+	type People struct {
+		database.BaseWithVersion
+		Name string
+	}
+	tests := []struct {
+		name             string
+		input            *People
+		patch            map[string]any
+		opts             []GormOpt
+		rowsAffected     int64
+		want             *People
+		wantErr          bool
+		errorString      string
+		expectedSQLQuery string
+		expectedSQLArgs  []driver.Value
+	}{
+		{
+			name: "known version",
+			input: &People{
+				BaseWithVersion: database.BaseWithVersion{Base: database.Base{ID: "id1"}, Version: 2},
+				Name:            "original",
+			},
+			patch:            map[string]any{"name": "updated"},
+			rowsAffected:     1,
+			expectedSQLQuery: `UPDATE "peoples" SET "name"=$1,"version"=$2,"updated_at"=$3 WHERE version = $4 AND "peoples"."deleted_at" IS NULL AND "id" = $5`,
+			expectedSQLArgs:  []driver.Value{"updated", 3, now, 2, "id1"},
+			want: &People{
+				BaseWithVersion: database.BaseWithVersion{Base: database.Base{ID: "id1", UpdatedAt: now}, Version: 3},
+				Name:            "updated",
+			},
+		},
+		{
+			name:  "unknown version",
+			input: &People{},
+			patch: map[string]any{"name": "updated"},
+			opts: []GormOpt{func(_ context.Context, gdb *gorm.DB) (*gorm.DB, error) {
+				return gdb.Where("name = ?", "original"), nil
+			}},
+			rowsAffected:     3,
+			expectedSQLQuery: `UPDATE "peoples" SET "name"=$1,"version"=version + 1,"updated_at"=$2 WHERE name = $3 AND "peoples"."deleted_at" IS NULL`,
+			expectedSQLArgs:  []driver.Value{"updated", now, "original"},
+			want: &People{
+				BaseWithVersion: database.BaseWithVersion{Base: database.Base{UpdatedAt: now}},
+				Name:            "updated",
+			},
+		},
+		{
+			name: "conflict",
+			input: &People{
+				BaseWithVersion: database.BaseWithVersion{Base: database.Base{ID: "id1"}, Version: 2},
+				Name:            "original",
+			},
+			patch:            map[string]any{"name": "updated"},
+			rowsAffected:     0,
+			expectedSQLQuery: `UPDATE "peoples" SET "name"=$1,"version"=$2,"updated_at"=$3 WHERE version = $4 AND "peoples"."deleted_at" IS NULL AND "id" = $5`,
+			expectedSQLArgs:  []driver.Value{"updated", 3, now, 2, "id1"},
+			wantErr:          true,
+			errorString:      "object was modified by someone else, version conflict",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Error(err)
+
+				return
+			}
+			defer sqlDB.Close()
+
+			db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Discard, NowFunc: func() time.Time {
+				return now
+			}})
+			if err != nil {
+				t.Error(err)
+
+				return
+			}
+
+			ctrl := gomock.NewController(t)
+			dbSvc := dbmocks.NewMockDB(ctrl)
+			dbSvc.EXPECT().GetTransactionalOrDefaultGormDB(gomock.Any()).AnyTimes().Return(db)
+
+			mock.ExpectBegin()
+			mock.ExpectExec(tt.expectedSQLQuery).
+				WithArgs(tt.expectedSQLArgs...).
+				WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+			mock.ExpectCommit()
+
+			ctx := context.TODO()
+			got, err := PatchUpdate(ctx, tt.input, tt.patch, dbSvc, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PatchUpdate() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+			if err != nil && err.Error() != tt.errorString {
+				t.Errorf("PatchUpdate() error = %v, wantErr %v", err, tt.errorString)
+
+				return
+			}
+			if err != nil {
+				assert.Equal(t, 2, tt.input.Version)
+
+				return
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, map[string]any{"name": "updated"}, tt.patch)
+		})
+	}
+}
+
+func TestCreateOrUpdateTenantScoped(t *testing.T) {
+	now := time.Now()
+
+	type People struct {
+		database.BaseWithTenant
+		Name string
+	}
+	tests := []struct {
+		name             string
+		tenantID         string
+		input            *People
+		rowsAffected     int64
+		want             *People
+		wantErr          bool
+		errorString      string
+		expectedSQLQuery string
+		expectedSQLArgs  []driver.Value
+	}{
+		{
+			name:     "update in tenant",
+			tenantID: "tenant1",
+			input: &People{
+				BaseWithTenant: database.BaseWithTenant{Base: database.Base{ID: "id1"}, TenantID: "tenant1"},
+				Name:           "name",
+			},
+			rowsAffected:     1,
+			expectedSQLQuery: `UPDATE "peoples" SET "created_at"=$1,"updated_at"=$2,"deleted_at"=$3,"tenant_id"=$4,"name"=$5 WHERE "peoples"."tenant_id" = $6 AND "peoples"."deleted_at" IS NULL AND "id" = $7`,
+			expectedSQLArgs:  []driver.Value{time.Time{}, now, nil, "tenant1", "name", "tenant1", "id1"},
+			want: &People{
+				BaseWithTenant: database.BaseWithTenant{Base: database.Base{ID: "id1", UpdatedAt: now}, TenantID: "tenant1"},
+				Name:           "name",
+			},
+		},
+		{
+			name:     "set tenant from context on creation",
+			tenantID: "tenant1",
+			input: &People{
+				Name: "name",
+			},
+			rowsAffected:     1,
+			expectedSQLQuery: `INSERT INTO "peoples" ("created_at","updated_at","deleted_at","id","tenant_id","name") VALUES ($1,$2,$3,$4,$5,$6)`,
+			expectedSQLArgs:  []driver.Value{now, now, nil, sqlmock.AnyArg(), "tenant1", "name"},
+		},
+		{
+			name:     "reject another tenant object",
+			tenantID: "tenant1",
+			input: &People{
+				BaseWithTenant: database.BaseWithTenant{Base: database.Base{ID: "id1"}, TenantID: "tenant2"},
+				Name:           "name",
+			},
+			wantErr:     true,
+			errorString: "object belongs to another tenant",
+		},
+		{
+			name: "reject without tenant in context",
+			input: &People{
+				BaseWithTenant: database.BaseWithTenant{Base: database.Base{ID: "id1"}, TenantID: "tenant1"},
+				Name:           "name",
+			},
+			wantErr:     true,
+			errorString: "no tenant found in context",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Error(err)
+
+				return
+			}
+			defer sqlDB.Close()
+
+			db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Discard, NowFunc: func() time.Time {
+				return now
+			}})
+			if err != nil {
+				t.Error(err)
+
+				return
+			}
+
+			ctrl := gomock.NewController(t)
+			dbSvc := dbmocks.NewMockDB(ctrl)
+			dbSvc.EXPECT().GetTransactionalOrDefaultGormDB(gomock.Any()).AnyTimes().Return(db)
+
+			if tt.expectedSQLQuery != "" {
+				mock.ExpectBegin()
+				mock.ExpectExec(tt.expectedSQLQuery).
+					WithArgs(tt.expectedSQLArgs...).
+					WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+				mock.ExpectCommit()
+			}
+
+			ctx := tenant.SetInContext(context.TODO(), tt.tenantID)
+			got, err := CreateOrUpdate(ctx, tt.input, dbSvc)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateOrUpdate() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+			if err != nil && err.Error() != tt.errorString {
+				t.Errorf("CreateOrUpdate() error = %v, wantErr %v", err, tt.errorString)
+
+				return
+			}
+			if err != nil {
+				return
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+			assert.Equal(t, "tenant1", got.TenantID)
+			if tt.want != nil {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPatchUpdateTenantScoped(t *testing.T) {
+	now := time.Now()
+
+	type People struct {
+		database.BaseWithTenant
+		Name string
+	}
+	tests := []struct {
+		name             string
+		input            *People
+		patch            map[string]any
+		wantErr          bool
+		errorString      string
+		expectedSQLQuery string
+		expectedSQLArgs  []driver.Value
+	}{
+		{
+			name: "patch in tenant",
+			input: &People{
+				BaseWithTenant: database.BaseWithTenant{Base: database.Base{ID: "id1"}, TenantID: "tenant1"},
+			},
+			patch:            map[string]any{"name": "updated"},
+			expectedSQLQuery: `UPDATE "peoples" SET "name"=$1,"updated_at"=$2 WHERE "peoples"."tenant_id" = $3 AND "peoples"."deleted_at" IS NULL AND "id" = $4`,
+			expectedSQLArgs:  []driver.Value{"updated", now, "tenant1", "id1"},
+		},
+		{
+			name:             "filtered patch on empty model",
+			input:            &People{},
+			patch:            map[string]any{"name": "updated"},
+			expectedSQLQuery: `UPDATE "peoples" SET "name"=$1,"updated_at"=$2 WHERE "peoples"."tenant_id" = $3 AND "peoples"."deleted_at" IS NULL`,
+			expectedSQLArgs:  []driver.Value{"updated", now, "tenant1"},
+		},
+		{
+			name: "reject patch to another tenant",
+			input: &People{
+				BaseWithTenant: database.BaseWithTenant{Base: database.Base{ID: "id1"}, TenantID: "tenant1"},
+			},
+			patch:       map[string]any{"tenant_id": "tenant2"},
+			wantErr:     true,
+			errorString: "object belongs to another tenant",
+		},
+		{
+			name: "reject patch of another tenant object",
+			input: &People{
+				BaseWithTenant: database.BaseWithTenant{Base: database.Base{ID: "id1"}, TenantID: "tenant2"},
+			},
+			patch:       map[string]any{"name": "updated"},
+			wantErr:     true,
+			errorString: "object belongs to another tenant",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Error(err)
+
+				return
+			}
+			defer sqlDB.Close()
+
+			db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Discard, NowFunc: func() time.Time {
+				return now
+			}})
+			if err != nil {
+				t.Error(err)
+
+				return
+			}
+
+			ctrl := gomock.NewController(t)
+			dbSvc := dbmocks.NewMockDB(ctrl)
+			dbSvc.EXPECT().GetTransactionalOrDefaultGormDB(gomock.Any()).AnyTimes().Return(db)
+
+			if tt.expectedSQLQuery != "" {
+				mock.ExpectBegin()
+				mock.ExpectExec(tt.expectedSQLQuery).
+					WithArgs(tt.expectedSQLArgs...).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			}
+
+			ctx := tenant.SetInContext(context.TODO(), "tenant1")
+			_, err = PatchUpdate(ctx, tt.input, tt.patch, dbSvc)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PatchUpdate() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+			if err != nil && err.Error() != tt.errorString {
+				t.Errorf("PatchUpdate() error = %v, wantErr %v", err, tt.errorString)
+
+				return
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+type upsertTestPeople struct {
+	database.Base
+	FirstName string  `gorm:"uniqueIndex:idx_upsert_test_people_names"`
+	LastName  string  `gorm:"uniqueIndex:idx_upsert_test_people_names"`
+	Code      *string `gorm:"uniqueIndex"`
+	Age       int
+}
+
+type upsertTestTenantPeople struct {
+	database.BaseWithTenant
+	Name string
+}
+
+type upsertTestVersionedPeople struct {
+	database.BaseWithVersion
+	Name string `gorm:"uniqueIndex"`
+	Age  int
+}
+
+func TestUpsertSQLiteDatabase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	gdb := dbtest.NewSQLiteDB(t, &upsertTestPeople{}, &upsertTestTenantPeople{}, &upsertTestVersionedPeople{})
+	dbSvc := dbmocks.NewMockDB(ctrl)
+	dbSvc.EXPECT().GetTransactionalOrDefaultGormDB(gomock.Any()).AnyTimes().Return(gdb)
+
+	t.Run("composite keys with same concatenation", func(t *testing.T) {
+		require.NoError(t, gdb.Create(&upsertTestPeople{Base: database.Base{ID: "existing-names"}, FirstName: "ab", LastName: "c"}).Error)
+
+		got, err := Upsert(context.TODO(), []*upsertTestPeople{
+			{Base: database.Base{ID: "new-names"}, FirstName: "a", LastName: "bc", Age: 1},
+			{Base: database.Base{ID: "other-names"}, FirstName: "ab", LastName: "c", Age: 2},
+		}, 0, []string{"first_name", "last_name"}, []string{"age"}, dbSvc)
+		require.NoError(t, err)
+
+		require.Len(t, got, 2)
+		assert.Equal(t, "new-names", got[0].ID)
+		assert.Equal(t, 1, got[0].Age)
+		assert.Equal(t, "existing-names", got[1].ID)
+		assert.Equal(t, 2, got[1].Age)
+	})
+
+	t.Run("pointer keys", func(t *testing.T) {
+		code := "code1"
+		require.NoError(t, gdb.Create(&upsertTestPeople{Base: database.Base{ID: "existing-code"}, FirstName: "code", Code: &code}).Error)
+
+		inputCode := "code1"
+		got, err := Upsert(context.TODO(), []*upsertTestPeople{
+			{Base: database.Base{ID: "other-code"}, FirstName: "other", Code: &inputCode, Age: 3},
+		}, 0, []string{"code"}, []string{"age"}, dbSvc)
+		require.NoError(t, err)
+
+		require.Len(t, got, 1)
+		assert.Equal(t, "existing-code", got[0].ID)
+		assert.Equal(t, 3, got[0].Age)
+	})
+
+	t.Run("many batches", func(t *testing.T) {
+		require.NoError(t, gdb.Create(&upsertTestPeople{Base: database.Base{ID: "existing-batch"}, FirstName: "batch", LastName: "149"}).Error)
+
+		input := make([]*upsertTestPeople, 0, 250)
+		for i := range 250 {
+			input = append(input, &upsertTestPeople{FirstName: "batch", LastName: strconv.Itoa(i), Age: i})
+		}
+
+		got, err := Upsert(context.TODO(), input, 0, []string{"first_name", "last_name"}, []string{"age"}, dbSvc)
+		require.NoError(t, err)
+
+		require.Len(t, got, 250)
+		assert.Equal(t, "existing-batch", got[149].ID)
+		assert.Equal(t, 149, got[149].Age)
+		assert.Equal(t, "1", got[1].LastName)
+		assert.NotEmpty(t, got[1].ID)
+
+		var count int64
+		require.NoError(t, gdb.Model(&upsertTestPeople{}).Where("first_name = ?", "batch").Count(&count).Error)
+		assert.Equal(t, int64(250), count)
+	})
+
+	t.Run("reject conflict with another tenant row", func(t *testing.T) {
+		require.NoError(t, gdb.Create(&upsertTestTenantPeople{
+			BaseWithTenant: database.BaseWithTenant{Base: database.Base{ID: "tenant2-id"}, TenantID: "tenant2"},
+			Name:           "tenant2",
+		}).Error)
+
+		ctx := tenant.SetInContext(context.TODO(), "tenant1")
+		_, err := Upsert(ctx, []*upsertTestTenantPeople{
+			{BaseWithTenant: database.BaseWithTenant{Base: database.Base{ID: "tenant2-id"}}, Name: "tenant1"},
+		}, 0, nil, nil, dbSvc)
+		assert.EqualError(t, err, "object belongs to another tenant")
+
+		var stored upsertTestTenantPeople
+		require.NoError(t, gdb.First(&stored, "id = ?", "tenant2-id").Error)
+		assert.Equal(t, "tenant2", stored.TenantID)
+		assert.Equal(t, "tenant2", stored.Name)
+	})
+
+	t.Run("upsert in tenant", func(t *testing.T) {
+		ctx := tenant.SetInContext(context.TODO(), "tenant1")
+		got, err := Upsert(ctx, []*upsertTestTenantPeople{
+			{BaseWithTenant: database.BaseWithTenant{Base: database.Base{ID: "tenant1-id"}}, Name: "first"},
+		}, 0, nil, nil, dbSvc)
+		require.NoError(t, err)
+		assert.Equal(t, "tenant1", got[0].TenantID)
+
+		got, err = Upsert(ctx, []*upsertTestTenantPeople{
+			{BaseWithTenant: database.BaseWithTenant{Base: database.Base{ID: "tenant1-id"}}, Name: "second"},
+		}, 0, nil, nil, dbSvc)
+		require.NoError(t, err)
+		assert.Equal(t, "second", got[0].Name)
+	})
+
+	t.Run("increment version of updated rows", func(t *testing.T) {
+		got, err := Upsert(context.TODO(), []*upsertTestVersionedPeople{
+			{BaseWithVersion: database.BaseWithVersion{Base: database.Base{ID: "versioned-id"}}, Name: "versioned"},
+		}, 0, nil, nil, dbSvc)
+		require.NoError(t, err)
+		assert.Equal(t, 1, got[0].Version)
+
+		// Upsert all columns on primary key
+		got, err = Upsert(context.TODO(), []*upsertTestVersionedPeople{
+			{BaseWithVersion: database.BaseWithVersion{Base: database.Base{ID: "versioned-id"}}, Name: "versioned", Age: 1},
+		}, 0, nil, nil, dbSvc)
+		require.NoError(t, err)
+		assert.Equal(t, 2, got[0].Version)
+		assert.Equal(t, 1, got[0].Age)
+
+		// Upsert given columns on custom conflict target, version in update columns is ignored
+		got, err = Upsert(context.TODO(), []*upsertTestVersionedPeople{
+			{BaseWithVersion: database.BaseWithVersion{Base: database.Base{ID: "other-id"}, Version: 1}, Name: "versioned", Age: 2},
+		}, 0, []string{"name"}, []string{"age", "version"}, dbSvc)
+		require.NoError(t, err)
+		assert.Equal(t, "versioned-id", got[0].ID)
+		assert.Equal(t, 3, got[0].Version)
+		assert.Equal(t, 2, got[0].Age)
+	})
+}
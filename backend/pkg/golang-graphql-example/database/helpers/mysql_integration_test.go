@@ -0,0 +1,144 @@
+//go:build integration
+
+package databasehelpers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/config"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/common"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/pagination"
+)
+
+var mysqlIntegrationTestsCfg *config.Config = &config.Config{
+	Log:     &config.LogConfig{Level: "debug", Format: "human"},
+	Tracing: &config.TracingConfig{Enabled: false},
+	Database: &config.DatabaseConfig{
+		Driver: database.MysqlDriverSelector,
+		ConnectionURL: &config.CredentialConfig{
+			Value: "root:mysql@tcp(localhost:3306)/mysql-integration?charset=utf8mb4&parseTime=True&loc=Local",
+		},
+	},
+}
+
+type mysqlIntegrationPeople struct {
+	database.Base
+	Name string
+}
+
+type mysqlIntegrationFilter struct {
+	Name *common.GenericFilter `dbfield:"name"`
+}
+
+type mysqlIntegrationSortOrder struct {
+	Name *common.SortOrderEnum `dbfield:"name"`
+}
+
+// MySQLHelpersTestSuite will run helpers on a MySQL database to check dialect specific queries.
+type MySQLHelpersTestSuite struct {
+	suite.Suite
+
+	db database.DB
+}
+
+func (suite *MySQLHelpersTestSuite) SetupSuite() {
+	fmt.Println("SetupSuite phase")
+
+	suite.db = connectIntegrationDB(&suite.Suite, mysqlIntegrationTestsCfg)
+}
+
+func (suite *MySQLHelpersTestSuite) TearDownSuite() {
+	fmt.Println("TearDownSuite phase")
+
+	if suite.db != nil {
+		suite.NoError(suite.db.Close())
+	}
+}
+
+func (suite *MySQLHelpersTestSuite) SetupTest() {
+	migrateIntegrationModels(&suite.Suite, suite.db, &mysqlIntegrationPeople{})
+
+	suite.Require().NoError(suite.db.GetGormDB().Create([]*mysqlIntegrationPeople{
+		{Base: database.Base{ID: "1"}, Name: "Fake"},
+		{Base: database.Base{ID: "2"}, Name: "fake"},
+		{Base: database.Base{ID: "3"}, Name: "50% fake"},
+		{Base: database.Base{ID: "4"}, Name: "500 fake"},
+	}).Error)
+}
+
+func (suite *MySQLHelpersTestSuite) findNames(filter *mysqlIntegrationFilter) []string {
+	asc := common.SortOrderEnumAsc
+
+	res, err := Find(
+		context.TODO(),
+		[]*mysqlIntegrationPeople{},
+		suite.db,
+		&mysqlIntegrationSortOrder{Name: &asc},
+		filter,
+		nil,
+	)
+	suite.Require().NoError(err)
+
+	names := make([]string, 0, len(res))
+	for _, p := range res {
+		names = append(names, p.Name)
+	}
+
+	return names
+}
+
+func (suite *MySQLHelpersTestSuite) TestFilterCaseSensitive() {
+	// Default collation is case insensitive, filters must stay case sensitive
+	suite.Equal([]string{"fake"}, suite.findNames(&mysqlIntegrationFilter{Name: &common.GenericFilter{Eq: "fake"}}))
+	suite.Equal([]string{"Fake"}, suite.findNames(&mysqlIntegrationFilter{Name: &common.GenericFilter{StartsWith: "F"}}))
+	suite.Equal(
+		[]string{"50% fake", "500 fake", "fake"},
+		suite.findNames(&mysqlIntegrationFilter{Name: &common.GenericFilter{NotEq: "Fake"}}),
+	)
+}
+
+func (suite *MySQLHelpersTestSuite) TestFilterCaseInsensitive() {
+	// Names are equal on default collation, so their order isn't stable
+	suite.ElementsMatch(
+		[]string{"Fake", "fake"},
+		suite.findNames(&mysqlIntegrationFilter{Name: &common.GenericFilter{StartsWith: "F", CaseInsensitive: true}}),
+	)
+	suite.ElementsMatch(
+		[]string{"Fake", "fake"},
+		suite.findNames(&mysqlIntegrationFilter{Name: &common.GenericFilter{Eq: "FAKE", CaseInsensitive: true}}),
+	)
+}
+
+func (suite *MySQLHelpersTestSuite) TestFilterLikeWildcards() {
+	// Wildcards must be matched literally
+	suite.Equal([]string{"50% fake"}, suite.findNames(&mysqlIntegrationFilter{Name: &common.GenericFilter{Contains: "0%"}}))
+}
+
+func (suite *MySQLHelpersTestSuite) TestGetAllPaginated() {
+	asc := common.SortOrderEnumAsc
+
+	res, pageOut, err := GetAllPaginated(
+		context.TODO(),
+		[]*mysqlIntegrationPeople{},
+		suite.db,
+		&pagination.PageInput{Limit: 2},
+		&mysqlIntegrationSortOrder{Name: &asc},
+		&mysqlIntegrationFilter{Name: &common.GenericFilter{Contains: "fake", CaseInsensitive: true}},
+		nil,
+	)
+	suite.Require().NoError(err)
+	suite.Require().Len(res, 2)
+	suite.Equal("50% fake", res[0].Name)
+	suite.Equal("500 fake", res[1].Name)
+	suite.Equal(4, pageOut.TotalRecord)
+	suite.True(pageOut.HasNext)
+}
+
+func TestMySQLHelpersTestSuite(t *testing.T) {
+	suite.Run(t, new(MySQLHelpersTestSuite))
+}
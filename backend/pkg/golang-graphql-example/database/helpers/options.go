@@ -2,9 +2,14 @@ package databasehelpers
 
 import (
 	"context"
+	"slices"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
 
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/common"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/pagination"
 )
@@ -34,3 +39,112 @@ func WithPaginationGormOpt(page *pagination.PageInput) GormOpt {
 		return gdb.Offset(page.Skip).Limit(page.Limit), nil
 	}
 }
+
+// WithStatementTimeoutGormOpt will override the default statement timeout for this call.
+// See database.WithStatementTimeout for details.
+func WithStatementTimeoutGormOpt(timeout time.Duration) GormOpt {
+	return func(_ context.Context, gdb *gorm.DB) (*gorm.DB, error) {
+		return database.WithStatementTimeout(gdb, timeout), nil
+	}
+}
+
+// WithOnConflictGormOpt will add an "ON CONFLICT" clause on insert.
+// Conflict columns are defaulted to primary keys when empty.
+// All columns are updated when update columns list is empty.
+func WithOnConflictGormOpt(conflictColumns, updateColumns []string) GormOpt {
+	return withOnConflictClauseGormOpt(buildOnConflictClause(conflictColumns, updateColumns))
+}
+
+func withOnConflictClauseGormOpt(onConflict clause.OnConflict) GormOpt {
+	return func(_ context.Context, gdb *gorm.DB) (*gorm.DB, error) {
+		return gdb.Clauses(onConflict), nil
+	}
+}
+
+// withTenantOnConflictGormOpt will add the "ON CONFLICT" clause on insert
+// and will only update conflicting rows of the tenant in context.
+func withTenantOnConflictGormOpt(onConflict clause.OnConflict) GormOpt {
+	return func(ctx context.Context, gdb *gorm.DB) (*gorm.DB, error) {
+		// Get tenant
+		tenantID, err := database.GetTenantFromContext(ctx)
+		// Check error
+		if err != nil {
+			return nil, err
+		}
+
+		// Limit update to tenant rows
+		onConflict.Where = clause.Where{Exprs: []clause.Expression{database.GetTenantClause(tenantID)}}
+
+		return gdb.Clauses(onConflict), nil
+	}
+}
+
+func buildOnConflictClause(conflictColumns, updateColumns []string) clause.OnConflict {
+	// Create clause
+	onConflict := clause.OnConflict{}
+	// Loop over conflict columns
+	for _, c := range conflictColumns {
+		onConflict.Columns = append(onConflict.Columns, clause.Column{Name: c})
+	}
+
+	// Check if update columns are set
+	if len(updateColumns) == 0 {
+		onConflict.UpdateAll = true
+	} else {
+		onConflict.DoUpdates = clause.AssignmentColumns(updateColumns)
+	}
+
+	return onConflict
+}
+
+// withVersionIncrement will replace the version update of the on conflict clause by an increment of the stored version.
+// Otherwise, an upsert would overwrite a row and reset its version without any concurrency check.
+func withVersionIncrement(onConflict clause.OnConflict, sch *schema.Schema) clause.OnConflict {
+	// Check if all columns are updated
+	if onConflict.UpdateAll {
+		// Compute updated columns like gorm without version
+		columns := make([]string, 0, len(sch.Fields))
+		// Loop over fields
+		for _, f := range sch.Fields {
+			// Ignore fields that aren't inserted or mustn't be updated
+			if f.DBName == "" || !f.Creatable || f.PrimaryKey || f.AutoCreateTime != 0 || f.DBName == versionColumnName {
+				continue
+			}
+
+			columns = append(columns, f.DBName)
+		}
+
+		onConflict.UpdateAll = false
+		onConflict.DoUpdates = clause.AssignmentColumns(columns)
+	} else {
+		// Remove version from updated columns
+		onConflict.DoUpdates = slices.DeleteFunc(slices.Clone(onConflict.DoUpdates), func(a clause.Assignment) bool {
+			return a.Column.Name == versionColumnName
+		})
+	}
+
+	// Increment stored version
+	onConflict.DoUpdates = append(onConflict.DoUpdates, clause.Assignment{
+		Column: clause.Column{Name: versionColumnName},
+		Value:  gorm.Expr("? + 1", clause.Column{Table: clause.CurrentTable, Name: versionColumnName}),
+	})
+
+	return onConflict
+}
+
+// WithDeletedGormOpt will include soft deleted rows in results.
+func WithDeletedGormOpt() GormOpt {
+	return func(_ context.Context, gdb *gorm.DB) (*gorm.DB, error) {
+		return gdb.Unscoped(), nil
+	}
+}
+
+// OnlyDeletedGormOpt will only return soft deleted rows.
+func OnlyDeletedGormOpt() GormOpt {
+	return func(_ context.Context, gdb *gorm.DB) (*gorm.DB, error) {
+		return gdb.Unscoped().Where(clause.Expr{
+			SQL:  "? IS NOT NULL",
+			Vars: []any{clause.Column{Table: clause.CurrentTable, Name: deletedAtColumnName}},
+		}), nil
+	}
+}
@@ -30,6 +30,13 @@ func Find[T any](
 		return nil, err
 	}
 
+	// Apply tenant scope
+	gdb, err = database.ApplyTenantScope(ctx, gdb, res)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
 	// Apply sort
 	gdb, err = common.ManageSortOrder(sort, gdb)
 	// Check error
@@ -133,6 +140,13 @@ func Count[T any](
 		return 0, err
 	}
 
+	// Apply tenant scope
+	gdb, err = database.ApplyTenantScope(ctx, gdb, input)
+	// Check error
+	if err != nil {
+		return 0, err
+	}
+
 	// Apply options
 	for _, o := range opts {
 		gdb, err = o(ctx, gdb)
@@ -197,6 +211,13 @@ func FindByID[T any](
 		return *new(T), err
 	}
 
+	// Apply tenant scope
+	gdb, err = database.ApplyTenantScope(ctx, gdb, res)
+	// Check error
+	if err != nil {
+		return *new(T), err
+	}
+
 	// Apply options
 	for _, o := range opts {
 		gdb, err = o(ctx, gdb)
@@ -241,6 +262,13 @@ func FindOne[T any](
 		return *new(T), err
 	}
 
+	// Apply tenant scope
+	gdb, err = database.ApplyTenantScope(ctx, gdb, res)
+	// Check error
+	if err != nil {
+		return *new(T), err
+	}
+
 	// Apply sort
 	gdb, err = common.ManageSortOrder(sort, gdb)
 	// Check error
@@ -15,6 +15,7 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/common/tenant"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/common"
 	dbmocks "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/mocks"
@@ -50,6 +51,7 @@ func TestFind(t *testing.T) {
 		filter     *Filter
 		sorts      []*SortOrder
 		projection *Projection
+		opts       []GormOpt
 	}
 	tests := []struct {
 		name             string
@@ -69,7 +71,7 @@ func TestFind(t *testing.T) {
 					},
 				},
 			},
-			expectedSQLQuery: `SELECT * FROM "peoples" WHERE name = $1 OR logged_once = $2 ORDER BY created_at DESC`,
+			expectedSQLQuery: `SELECT * FROM "peoples" WHERE (name = $1 OR logged_once = $2) AND "peoples"."deleted_at" IS NULL ORDER BY created_at DESC`,
 			expectedSQLArgs:  []driver.Value{"fake", true},
 		},
 		{
@@ -77,7 +79,7 @@ func TestFind(t *testing.T) {
 			args: args{
 				sorts: []*SortOrder{{Name: &common.SortOrderEnumAsc}},
 			},
-			expectedSQLQuery: `SELECT * FROM "peoples" ORDER BY name ASC`,
+			expectedSQLQuery: `SELECT * FROM "peoples" WHERE "peoples"."deleted_at" IS NULL ORDER BY name ASC`,
 			expectedSQLArgs:  []driver.Value{},
 		},
 		{
@@ -85,7 +87,7 @@ func TestFind(t *testing.T) {
 			args: args{
 				projection: &Projection{Name: true},
 			},
-			expectedSQLQuery: `SELECT "name" FROM "peoples" ORDER BY created_at DESC`,
+			expectedSQLQuery: `SELECT "name" FROM "peoples" WHERE "peoples"."deleted_at" IS NULL ORDER BY created_at DESC`,
 			expectedSQLArgs:  []driver.Value{},
 		},
 		{
@@ -100,9 +102,26 @@ func TestFind(t *testing.T) {
 				sorts:      []*SortOrder{{Name: &common.SortOrderEnumAsc}},
 				projection: &Projection{Name: true},
 			},
-			expectedSQLQuery: `SELECT "name" FROM "peoples" WHERE name = $1 OR logged_once = $2 ORDER BY name ASC`,
+			expectedSQLQuery: `SELECT "name" FROM "peoples" WHERE (name = $1 OR logged_once = $2) AND "peoples"."deleted_at" IS NULL ORDER BY name ASC`,
 			expectedSQLArgs:  []driver.Value{"fake", true},
 		},
+		{
+			name: "with deleted",
+			args: args{
+				opts: []GormOpt{WithDeletedGormOpt()},
+			},
+			expectedSQLQuery: `SELECT * FROM "peoples" ORDER BY created_at DESC`,
+			expectedSQLArgs:  []driver.Value{},
+		},
+		{
+			name: "only deleted",
+			args: args{
+				filter: &Filter{Name: &common.GenericFilter{Eq: "fake"}},
+				opts:   []GormOpt{OnlyDeletedGormOpt()},
+			},
+			expectedSQLQuery: `SELECT * FROM "peoples" WHERE name = $1 AND "peoples"."deleted_at" IS NOT NULL ORDER BY created_at DESC`,
+			expectedSQLArgs:  []driver.Value{"fake"},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -134,7 +153,7 @@ func TestFind(t *testing.T) {
 				)
 
 			ctx := context.TODO()
-			res, err := Find(ctx, make([]*People, 0), dbSvc, tt.args.sorts, tt.args.filter, tt.args.projection)
+			res, err := Find(ctx, make([]*People, 0), dbSvc, tt.args.sorts, tt.args.filter, tt.args.projection, tt.args.opts...)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Find() error = %v, wantErr %v", err, tt.wantErr)
 
@@ -194,7 +213,7 @@ func TestFindWithPagination(t *testing.T) {
 			args: args{
 				page: &pagination.PageInput{Limit: 100, Skip: 50},
 			},
-			expectedSQLQuery: `SELECT * FROM "peoples" ORDER BY created_at DESC LIMIT $1 OFFSET $2`,
+			expectedSQLQuery: `SELECT * FROM "peoples" WHERE "peoples"."deleted_at" IS NULL ORDER BY created_at DESC LIMIT $1 OFFSET $2`,
 			expectedSQLArgs:  []driver.Value{100, 50},
 		},
 		{
@@ -208,7 +227,7 @@ func TestFindWithPagination(t *testing.T) {
 				},
 				page: &pagination.PageInput{Limit: 100},
 			},
-			expectedSQLQuery: `SELECT * FROM "peoples" WHERE name = $1 OR logged_once = $2 ORDER BY created_at DESC LIMIT $3`,
+			expectedSQLQuery: `SELECT * FROM "peoples" WHERE (name = $1 OR logged_once = $2) AND "peoples"."deleted_at" IS NULL ORDER BY created_at DESC LIMIT $3`,
 			expectedSQLArgs:  []driver.Value{"fake", true, 100},
 		},
 		{
@@ -217,7 +236,7 @@ func TestFindWithPagination(t *testing.T) {
 				sorts: []*SortOrder{{Name: &common.SortOrderEnumAsc}},
 				page:  &pagination.PageInput{Limit: 100},
 			},
-			expectedSQLQuery: `SELECT * FROM "peoples" ORDER BY name ASC LIMIT $1`,
+			expectedSQLQuery: `SELECT * FROM "peoples" WHERE "peoples"."deleted_at" IS NULL ORDER BY name ASC LIMIT $1`,
 			expectedSQLArgs:  []driver.Value{100},
 		},
 		{
@@ -226,7 +245,7 @@ func TestFindWithPagination(t *testing.T) {
 				projection: &Projection{Name: true},
 				page:       &pagination.PageInput{Limit: 100},
 			},
-			expectedSQLQuery: `SELECT "name" FROM "peoples" ORDER BY created_at DESC LIMIT $1`,
+			expectedSQLQuery: `SELECT "name" FROM "peoples" WHERE "peoples"."deleted_at" IS NULL ORDER BY created_at DESC LIMIT $1`,
 			expectedSQLArgs:  []driver.Value{100},
 		},
 		{
@@ -242,7 +261,7 @@ func TestFindWithPagination(t *testing.T) {
 				projection: &Projection{Name: true},
 				page:       &pagination.PageInput{Limit: 100},
 			},
-			expectedSQLQuery: `SELECT "name" FROM "peoples" WHERE name = $1 OR logged_once = $2 ORDER BY name ASC LIMIT $3`,
+			expectedSQLQuery: `SELECT "name" FROM "peoples" WHERE (name = $1 OR logged_once = $2) AND "peoples"."deleted_at" IS NULL ORDER BY name ASC LIMIT $3`,
 			expectedSQLArgs:  []driver.Value{"fake", true, 100},
 		},
 	}
@@ -338,7 +357,7 @@ func TestCount(t *testing.T) {
 					},
 				},
 			},
-			expectedSQLQuery: `SELECT count(*) FROM "peoples" WHERE name = $1 OR logged_once = $2`,
+			expectedSQLQuery: `SELECT count(*) FROM "peoples" WHERE (name = $1 OR logged_once = $2) AND "peoples"."deleted_at" IS NULL`,
 			expectedSQLArgs:  []driver.Value{"fake", true},
 		},
 	}
@@ -432,7 +451,7 @@ func TestCountPaginated(t *testing.T) {
 			args: args{
 				page: &pagination.PageInput{Limit: 100, Skip: 50},
 			},
-			expectedSQLQuery: `SELECT count(*) FROM "peoples" LIMIT $1 OFFSET $2`,
+			expectedSQLQuery: `SELECT count(*) FROM "peoples" WHERE "peoples"."deleted_at" IS NULL LIMIT $1 OFFSET $2`,
 			expectedSQLArgs:  []driver.Value{100, 50},
 		},
 		{
@@ -446,7 +465,7 @@ func TestCountPaginated(t *testing.T) {
 				},
 				page: &pagination.PageInput{Limit: 100},
 			},
-			expectedSQLQuery: `SELECT count(*) FROM "peoples" WHERE name = $1 OR logged_once = $2 LIMIT $3`,
+			expectedSQLQuery: `SELECT count(*) FROM "peoples" WHERE (name = $1 OR logged_once = $2) AND "peoples"."deleted_at" IS NULL LIMIT $3`,
 			expectedSQLArgs:  []driver.Value{"fake", true, 100},
 		},
 	}
@@ -538,7 +557,7 @@ func TestFindByID(t *testing.T) {
 			args: args{
 				id: "fake",
 			},
-			expectedSQLQuery: `SELECT * FROM "peoples" WHERE id = $1 ORDER BY "peoples"."id" LIMIT $2`,
+			expectedSQLQuery: `SELECT * FROM "peoples" WHERE id = $1 AND "peoples"."deleted_at" IS NULL ORDER BY "peoples"."id" LIMIT $2`,
 			expectedSQLArgs:  []driver.Value{"fake", 1},
 		},
 		{
@@ -547,7 +566,7 @@ func TestFindByID(t *testing.T) {
 				id:         "fake",
 				projection: &Projection{Name: true},
 			},
-			expectedSQLQuery: `SELECT "name" FROM "peoples" WHERE id = $1 ORDER BY "peoples"."id" LIMIT $2`,
+			expectedSQLQuery: `SELECT "name" FROM "peoples" WHERE id = $1 AND "peoples"."deleted_at" IS NULL ORDER BY "peoples"."id" LIMIT $2`,
 			expectedSQLArgs:  []driver.Value{"fake", 1},
 		},
 	}
@@ -645,7 +664,7 @@ func TestFindOne(t *testing.T) {
 					},
 				},
 			},
-			expectedSQLQuery: `SELECT * FROM "peoples" WHERE name = $1 OR logged_once = $2 ORDER BY created_at DESC,"peoples"."id" LIMIT $3`,
+			expectedSQLQuery: `SELECT * FROM "peoples" WHERE (name = $1 OR logged_once = $2) AND "peoples"."deleted_at" IS NULL ORDER BY created_at DESC,"peoples"."id" LIMIT $3`,
 			expectedSQLArgs:  []driver.Value{"fake", true, 1},
 		},
 		{
@@ -653,7 +672,7 @@ func TestFindOne(t *testing.T) {
 			args: args{
 				sorts: []*SortOrder{{Name: &common.SortOrderEnumAsc}},
 			},
-			expectedSQLQuery: `SELECT * FROM "peoples" ORDER BY name ASC,"peoples"."id" LIMIT $1`,
+			expectedSQLQuery: `SELECT * FROM "peoples" WHERE "peoples"."deleted_at" IS NULL ORDER BY name ASC,"peoples"."id" LIMIT $1`,
 			expectedSQLArgs:  []driver.Value{1},
 		},
 		{
@@ -661,7 +680,7 @@ func TestFindOne(t *testing.T) {
 			args: args{
 				projection: &Projection{Name: true},
 			},
-			expectedSQLQuery: `SELECT "name" FROM "peoples" ORDER BY created_at DESC,"peoples"."id" LIMIT $1`,
+			expectedSQLQuery: `SELECT "name" FROM "peoples" WHERE "peoples"."deleted_at" IS NULL ORDER BY created_at DESC,"peoples"."id" LIMIT $1`,
 			expectedSQLArgs:  []driver.Value{1},
 		},
 		{
@@ -676,7 +695,7 @@ func TestFindOne(t *testing.T) {
 				sorts:      []*SortOrder{{Name: &common.SortOrderEnumAsc}},
 				projection: &Projection{Name: true},
 			},
-			expectedSQLQuery: `SELECT "name" FROM "peoples" WHERE name = $1 OR logged_once = $2 ORDER BY name ASC,"peoples"."id" LIMIT $3`,
+			expectedSQLQuery: `SELECT "name" FROM "peoples" WHERE (name = $1 OR logged_once = $2) AND "peoples"."deleted_at" IS NULL ORDER BY name ASC,"peoples"."id" LIMIT $3`,
 			expectedSQLArgs:  []driver.Value{"fake", true, 1},
 		},
 	}
@@ -725,3 +744,80 @@ func TestFindOne(t *testing.T) {
 		})
 	}
 }
+
+func TestFindTenantScoped(t *testing.T) {
+	now := time.Now()
+
+	type People struct {
+		database.BaseWithTenant
+		Name string
+	}
+	tests := []struct {
+		name             string
+		tenantID         string
+		wantErr          bool
+		errorString      string
+		expectedSQLQuery string
+		expectedSQLArgs  []driver.Value
+	}{
+		{
+			name:             "scoped on tenant",
+			tenantID:         "tenant1",
+			expectedSQLQuery: `SELECT * FROM "peoples" WHERE "peoples"."tenant_id" = $1 AND "peoples"."deleted_at" IS NULL ORDER BY created_at DESC`,
+			expectedSQLArgs:  []driver.Value{"tenant1"},
+		},
+		{
+			name:        "without tenant in context",
+			wantErr:     true,
+			errorString: "no tenant found in context",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Error(err)
+
+				return
+			}
+			defer sqlDB.Close()
+
+			db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Discard, NowFunc: func() time.Time {
+				return now
+			}})
+			if err != nil {
+				t.Error(err)
+
+				return
+			}
+
+			ctrl := gomock.NewController(t)
+			dbSvc := dbmocks.NewMockDB(ctrl)
+			dbSvc.EXPECT().GetTransactionalOrDefaultGormDB(gomock.Any()).AnyTimes().Return(db)
+
+			if tt.expectedSQLQuery != "" {
+				mock.ExpectQuery(tt.expectedSQLQuery).
+					WithArgs(tt.expectedSQLArgs...).
+					WillReturnRows(
+						sqlmock.NewRows([]string{}),
+					)
+			}
+
+			ctx := tenant.SetInContext(context.TODO(), tt.tenantID)
+			res, err := Find(ctx, make([]*People, 0), dbSvc, nil, nil, nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Find() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+			if err != nil && err.Error() != tt.errorString {
+				t.Errorf("Find() error = %v, wantErr %v", err, tt.errorString)
+
+				return
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+			assert.Len(t, res, 0)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+//go:build integration
+
+package databasehelpers
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/config"
+	cmocks "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/config/mocks"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/log"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/metrics"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/tracing"
+)
+
+var integrationTestsCfg *config.Config = &config.Config{
+	Log:     &config.LogConfig{Level: "debug", Format: "human"},
+	Tracing: &config.TracingConfig{Enabled: false},
+	Database: &config.DatabaseConfig{
+		Driver: config.DefaultDatabaseDriver,
+		ConnectionURL: &config.CredentialConfig{
+			Value: "host=localhost port=5432 user=postgres dbname=postgres-integration password=postgres sslmode=disable",
+		},
+	},
+}
+
+// HelpersTestSuite will run helpers on a real database to check generated queries.
+type HelpersTestSuite struct {
+	suite.Suite
+
+	db database.DB
+}
+
+func (suite *HelpersTestSuite) SetupSuite() {
+	fmt.Println("SetupSuite phase")
+
+	suite.db = connectIntegrationDB(&suite.Suite, integrationTestsCfg)
+}
+
+func (suite *HelpersTestSuite) TearDownSuite() {
+	fmt.Println("TearDownSuite phase")
+
+	if suite.db != nil {
+		suite.NoError(suite.db.Close())
+	}
+}
+
+// migrate will create tables for models and drop them at the end of the test.
+func (suite *HelpersTestSuite) migrate(models ...any) {
+	migrateIntegrationModels(&suite.Suite, suite.db, models...)
+}
+
+// connectIntegrationDB will connect to the database described in configuration.
+func connectIntegrationDB(s *suite.Suite, cfg *config.Config) database.DB {
+	ctrl := gomock.NewController(s.T())
+	cfgManagerMock := cmocks.NewMockManager(ctrl)
+	cfgManagerMock.EXPECT().GetConfig().AnyTimes().Return(cfg)
+
+	logger := log.NewLogger()
+	err := logger.Configure(cfg.Log.Level, cfg.Log.Format, "")
+	s.Require().NoError(err)
+
+	tracingSvc := tracing.New(cfgManagerMock, logger)
+	err = tracingSvc.InitializeAndReload()
+	s.Require().NoError(err)
+
+	db := database.NewDatabase("main", cfgManagerMock, logger, metrics.NewService(), tracingSvc)
+	err = db.Connect()
+	s.Require().NoError(err)
+
+	return db
+}
+
+// migrateIntegrationModels will create tables for models and drop them at the end of the test.
+func migrateIntegrationModels(s *suite.Suite, db database.DB, models ...any) {
+	gdb := db.GetGormDB()
+
+	s.Require().NoError(gdb.AutoMigrate(models...))
+	s.T().Cleanup(func() {
+		s.NoError(gdb.Migrator().DropTable(models...))
+	})
+}
+
+func TestHelpersTestSuite(t *testing.T) {
+	suite.Run(t, new(HelpersTestSuite))
+}
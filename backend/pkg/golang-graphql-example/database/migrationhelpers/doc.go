@@ -0,0 +1,3 @@
+package migrationhelpers
+
+// This package will contains helpers used in migration sequences.
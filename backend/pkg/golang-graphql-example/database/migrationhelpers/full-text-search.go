@@ -0,0 +1,138 @@
+package migrationhelpers
+
+import (
+	"fmt"
+
+	"emperror.dev/errors"
+	"gorm.io/gorm"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/common"
+)
+
+// GetFullTextSearchIndexName will return the POSTGRES and MYSQL full text search index name for a column.
+func GetFullTextSearchIndexName(table, column string) string {
+	return fmt.Sprintf("idx_%s_%s_fts", table, column)
+}
+
+// CreateFullTextSearchIndex will create what is needed to use the search filter on a column.
+// On POSTGRES, a GIN index on to_tsvector is created.
+// On SQLITE, a FTS5 external content table is created and kept in sync with triggers.
+// Nothing is created when SQLITE is built without FTS5 as search filters fall back to LIKE.
+// On MYSQL, a FULLTEXT index is created.
+func CreateFullTextSearchIndex(tx *gorm.DB, table, column string) error {
+	// Initialize queries
+	var queries []string
+
+	switch tx.Dialector.Name() {
+	case "postgres":
+		queries = []string{
+			fmt.Sprintf(
+				`CREATE INDEX IF NOT EXISTS %q ON %q USING GIN (to_tsvector('%s', %q))`,
+				GetFullTextSearchIndexName(table, column),
+				table,
+				common.FullTextSearchLanguage,
+				column,
+			),
+		}
+	case "sqlite":
+		// Check if FTS5 is available
+		if !common.HasSQLiteFTS5(tx) {
+			return nil
+		}
+
+		// Get fts table name
+		fts := common.GetFullTextSearchTableName(table, column)
+
+		queries = []string{
+			fmt.Sprintf(
+				`CREATE VIRTUAL TABLE IF NOT EXISTS %q USING fts5(%q, content=%q, content_rowid='rowid')`,
+				fts, column, table,
+			),
+			fmt.Sprintf(
+				`CREATE TRIGGER IF NOT EXISTS %q AFTER INSERT ON %q BEGIN `+
+					`INSERT INTO %q(rowid, %q) VALUES (new.rowid, new.%q); END`,
+				fts+"_ai", table, fts, column, column,
+			),
+			fmt.Sprintf(
+				`CREATE TRIGGER IF NOT EXISTS %q AFTER DELETE ON %q BEGIN `+
+					`INSERT INTO %q(%q, rowid, %q) VALUES ('delete', old.rowid, old.%q); END`,
+				fts+"_ad", table, fts, fts, column, column,
+			),
+			fmt.Sprintf(
+				`CREATE TRIGGER IF NOT EXISTS %q AFTER UPDATE ON %q BEGIN `+
+					`INSERT INTO %q(%q, rowid, %q) VALUES ('delete', old.rowid, old.%q); `+
+					`INSERT INTO %q(rowid, %q) VALUES (new.rowid, new.%q); END`,
+				fts+"_au", table, fts, fts, column, column, fts, column, column,
+			),
+			// Index existing rows
+			fmt.Sprintf(`INSERT INTO %q(%q) VALUES ('rebuild')`, fts, fts),
+		}
+	case "mysql":
+		// Get index name
+		name := GetFullTextSearchIndexName(table, column)
+		// Mysql doesn't support IF NOT EXISTS on indexes
+		if tx.Migrator().HasIndex(table, name) {
+			return nil
+		}
+
+		queries = []string{
+			fmt.Sprintf("CREATE FULLTEXT INDEX `%s` ON `%s` (`%s`)", name, table, column),
+		}
+	default:
+		return errors.WithStack(common.ErrFullTextSearchNotSupported)
+	}
+
+	return execAll(tx, queries)
+}
+
+// DropFullTextSearchIndex will remove what have been created by CreateFullTextSearchIndex.
+func DropFullTextSearchIndex(tx *gorm.DB, table, column string) error {
+	// Initialize queries
+	var queries []string
+
+	switch tx.Dialector.Name() {
+	case "postgres":
+		queries = []string{
+			fmt.Sprintf(`DROP INDEX IF EXISTS %q`, GetFullTextSearchIndexName(table, column)),
+		}
+	case "sqlite":
+		// Get fts table name
+		fts := common.GetFullTextSearchTableName(table, column)
+
+		queries = []string{
+			fmt.Sprintf(`DROP TRIGGER IF EXISTS %q`, fts+"_ai"),
+			fmt.Sprintf(`DROP TRIGGER IF EXISTS %q`, fts+"_ad"),
+			fmt.Sprintf(`DROP TRIGGER IF EXISTS %q`, fts+"_au"),
+			fmt.Sprintf(`DROP TABLE IF EXISTS %q`, fts),
+		}
+	case "mysql":
+		// Get index name
+		name := GetFullTextSearchIndexName(table, column)
+		// Mysql doesn't support IF EXISTS on indexes
+		if !tx.Migrator().HasIndex(table, name) {
+			return nil
+		}
+
+		queries = []string{
+			fmt.Sprintf("DROP INDEX `%s` ON `%s`", name, table),
+		}
+	default:
+		return errors.WithStack(common.ErrFullTextSearchNotSupported)
+	}
+
+	return execAll(tx, queries)
+}
+
+func execAll(tx *gorm.DB, queries []string) error {
+	// Loop over queries
+	for _, q := range queries {
+		// Execute
+		err := tx.Exec(q).Error
+		// Check error
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	return nil
+}
@@ -43,6 +43,20 @@ func (m *MockDB) EXPECT() *MockDBMockRecorder {
 	return m.recorder
 }
 
+// CheckReplica mocks base method.
+func (m *MockDB) CheckReplica(index int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckReplica", index)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckReplica indicates an expected call of CheckReplica.
+func (mr *MockDBMockRecorder) CheckReplica(index any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckReplica", reflect.TypeOf((*MockDB)(nil).CheckReplica), index)
+}
+
 // Close mocks base method.
 func (m *MockDB) Close() error {
 	m.ctrl.T.Helper()
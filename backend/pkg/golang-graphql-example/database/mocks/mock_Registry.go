@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database (interfaces: Registry)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock_Registry.go -package=mocks github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database Registry
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	database "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRegistry is a mock of Registry interface.
+type MockRegistry struct {
+	ctrl     *gomock.Controller
+	recorder *MockRegistryMockRecorder
+	isgomock struct{}
+}
+
+// MockRegistryMockRecorder is the mock recorder for MockRegistry.
+type MockRegistryMockRecorder struct {
+	mock *MockRegistry
+}
+
+// NewMockRegistry creates a new mock instance.
+func NewMockRegistry(ctrl *gomock.Controller) *MockRegistry {
+	mock := &MockRegistry{ctrl: ctrl}
+	mock.recorder = &MockRegistryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRegistry) EXPECT() *MockRegistryMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockRegistry) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockRegistryMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockRegistry)(nil).Close))
+}
+
+// Connect mocks base method.
+func (m *MockRegistry) Connect() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Connect")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Connect indicates an expected call of Connect.
+func (mr *MockRegistryMockRecorder) Connect() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Connect", reflect.TypeOf((*MockRegistry)(nil).Connect))
+}
+
+// Get mocks base method.
+func (m *MockRegistry) Get(connectionName string) (database.DB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", connectionName)
+	ret0, _ := ret[0].(database.DB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRegistryMockRecorder) Get(connectionName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRegistry)(nil).Get), connectionName)
+}
+
+// GetConnectionNames mocks base method.
+func (m *MockRegistry) GetConnectionNames() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConnectionNames")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetConnectionNames indicates an expected call of GetConnectionNames.
+func (mr *MockRegistryMockRecorder) GetConnectionNames() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConnectionNames", reflect.TypeOf((*MockRegistry)(nil).GetConnectionNames))
+}
+
+// GetMain mocks base method.
+func (m *MockRegistry) GetMain() database.DB {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMain")
+	ret0, _ := ret[0].(database.DB)
+	return ret0
+}
+
+// GetMain indicates an expected call of GetMain.
+func (mr *MockRegistryMockRecorder) GetMain() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMain", reflect.TypeOf((*MockRegistry)(nil).GetMain))
+}
+
+// Reload mocks base method.
+func (m *MockRegistry) Reload(connectionName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reload", connectionName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reload indicates an expected call of Reload.
+func (mr *MockRegistryMockRecorder) Reload(connectionName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reload", reflect.TypeOf((*MockRegistry)(nil).Reload), connectionName)
+}
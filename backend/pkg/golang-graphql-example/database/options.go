@@ -6,10 +6,36 @@ type TransactionOption func(cfg *TransactionOptionsConfig)
 
 type TransactionOptionsConfig struct {
 	ReadTransaction bool
-	IsolationLevel  sql.IsolationLevel
+	// Write transaction explicitly asked
+	WriteTransaction bool
+	// Force a new independent transaction even if one exists in context
+	NewTransaction bool
+	// Retry policy applied on root transaction, no retry when nil
+	RetryPolicy    *TransactionRetryPolicy
+	IsolationLevel sql.IsolationLevel
 }
 
 var (
-	WithReadTransactionOpt  TransactionOption = func(cfg *TransactionOptionsConfig) { cfg.ReadTransaction = true }
-	WithWriteTransactionOpt TransactionOption = func(cfg *TransactionOptionsConfig) { cfg.ReadTransaction = false }
+	WithReadTransactionOpt TransactionOption = func(cfg *TransactionOptionsConfig) {
+		cfg.ReadTransaction = true
+		cfg.WriteTransaction = false
+	}
+	WithWriteTransactionOpt TransactionOption = func(cfg *TransactionOptionsConfig) {
+		cfg.ReadTransaction = false
+		cfg.WriteTransaction = true
+	}
+	// WithNewTransactionOpt will force the creation of a new transaction committed independently of
+	// the one that may exist in context.
+	// Be careful, this will use another connection from pool.
+	WithNewTransactionOpt TransactionOption = func(cfg *TransactionOptionsConfig) { cfg.NewTransaction = true }
 )
+
+// WithIsolationLevelOpt will set transaction isolation level.
+func WithIsolationLevelOpt(level sql.IsolationLevel) TransactionOption {
+	return func(cfg *TransactionOptionsConfig) { cfg.IsolationLevel = level }
+}
+
+// WithRetryPolicyOpt will set the retry policy applied when the transaction fails.
+func WithRetryPolicyOpt(policy *TransactionRetryPolicy) TransactionOption {
+	return func(cfg *TransactionOptionsConfig) { cfg.RetryPolicy = policy }
+}
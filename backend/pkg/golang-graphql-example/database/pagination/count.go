@@ -0,0 +1,173 @@
+package pagination
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"emperror.dev/errors"
+	"gorm.io/gorm"
+)
+
+// CountMode represents how total records are counted in paginated queries.
+type CountMode int
+
+const (
+	// CountModeExact will run a "COUNT(*)" query. This is the default mode.
+	CountModeExact CountMode = iota
+	// CountModeNone will skip the count query.
+	// A "limit+1" probe is used to detect if a next page exists.
+	CountModeNone
+	// CountModeEstimated will use the query planner estimation on Postgres and MySQL.
+	// Other drivers and queries without estimation will fallback on exact count.
+	// A "limit+1" probe is used to detect if a next page exists.
+	CountModeEstimated
+)
+
+// Dialector names.
+const (
+	postgresDialectorName = "postgres"
+	mysqlDialectorName    = "mysql"
+)
+
+type postgresExplainResult struct {
+	Plan struct {
+		PlanRows float64 `json:"Plan Rows"`
+	} `json:"Plan"`
+}
+
+type mysqlExplainResult struct {
+	QueryBlock struct {
+		// Table is only present when a single table is read
+		Table *struct {
+			RowsProducedPerJoin float64 `json:"rows_produced_per_join"`
+		} `json:"table"`
+	} `json:"query_block"`
+}
+
+func countRecords(db *gorm.DB, result any, mode CountMode) (int64, CountMode, error) {
+	// Initialize
+	var count int64
+
+	// Switch on mode
+	switch mode {
+	case CountModeNone:
+		return 0, mode, nil
+	case CountModeEstimated:
+		// Check if driver supports estimation
+		switch db.Dialector.Name() {
+		case postgresDialectorName:
+			// Estimate
+			count, err := estimateCount(db, result)
+
+			return count, mode, err
+		case mysqlDialectorName:
+			// Estimate
+			count, ok, err := estimateMySQLCount(db, result)
+			// Check if estimation is available
+			if err != nil || ok {
+				return count, mode, err
+			}
+		}
+	case CountModeExact:
+	}
+
+	// Count all objects
+	db = db.Model(result).Count(&count)
+	// Check error
+	if db.Error != nil {
+		return 0, CountModeExact, errors.WithStack(db.Error)
+	}
+
+	return count, CountModeExact, nil
+}
+
+func estimateCount(db *gorm.DB, result any) (int64, error) {
+	// Ask planner
+	plan, err := explainQuery(db, result, "EXPLAIN (FORMAT JSON) ")
+	// Check error
+	if err != nil {
+		return 0, err
+	}
+
+	// Parse
+	var res []*postgresExplainResult
+	// Unmarshal
+	err = json.Unmarshal([]byte(plan), &res)
+	// Check error
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	// Check result
+	if len(res) == 0 {
+		return 0, errors.New("empty explain result")
+	}
+
+	return int64(res[0].Plan.PlanRows), nil
+}
+
+// estimateMySQLCount will return the planner estimation and false when query
+// is too complex to have one.
+func estimateMySQLCount(db *gorm.DB, result any) (int64, bool, error) {
+	// Ask planner
+	plan, err := explainQuery(db, result, "EXPLAIN FORMAT=JSON ")
+	// Check error
+	if err != nil {
+		return 0, false, err
+	}
+
+	// Parse
+	var res mysqlExplainResult
+	// Unmarshal
+	err = json.Unmarshal([]byte(plan), &res)
+	// Check error
+	if err != nil {
+		return 0, false, errors.WithStack(err)
+	}
+	// Check if estimation is available
+	if res.QueryBlock.Table == nil {
+		return 0, false, nil
+	}
+
+	return int64(res.QueryBlock.Table.RowsProducedPerJoin), true, nil
+}
+
+// explainQuery will run the query planner with prefix on the find query.
+func explainQuery(db *gorm.DB, result any, prefix string) (string, error) {
+	// Build query without running it
+	stmt := db.Session(&gorm.Session{DryRun: true}).Model(result).Find(result).Statement
+	// Check error
+	if stmt.Error != nil {
+		return "", errors.WithStack(stmt.Error)
+	}
+
+	// Initialize result
+	var plan string
+	// Ask planner
+	err := db.Statement.ConnPool.QueryRowContext(
+		db.Statement.Context,
+		prefix+stmt.SQL.String(),
+		stmt.Vars...,
+	).Scan(&plan)
+	// Check error
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	return plan, nil
+}
+
+// trimProbeResult will remove the probe element from result list if it is present
+// and will return true in this case.
+func trimProbeResult(result any, limit int) bool {
+	// Get result list
+	rv := reflect.Indirect(reflect.ValueOf(result))
+	// Check if there are more results
+	if rv.Len() <= limit {
+		return false
+	}
+
+	// Trim
+	rv.Set(rv.Slice(0, limit))
+
+	return true
+}
@@ -0,0 +1,331 @@
+package pagination
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+
+	cerrors "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/common/errors"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/common"
+)
+
+// Tie-breaker column added at the end of sort columns in keyset mode.
+const keysetTieBreakerColumn = "id"
+
+// ErrKeysetCursorNotMatchingSort is returned when keyset values aren't matching the active sort.
+var ErrKeysetCursorNotMatchingSort = errors.Sentinel("keyset cursor isn't matching current sort")
+
+type keysetOutput struct {
+	keysets [][]any
+	hasMore bool
+}
+
+func findWithKeyset(db *gorm.DB, result any, options *PagingOptions) (*keysetOutput, error) {
+	// Get keyset input
+	kInput := options.PageInput.Keyset
+	// Get sort columns
+	cols, err := common.GetSortColumns(options.Sort)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+	// Check if a relevance sort is present
+	// Relevance isn't a column value that can be used to seek
+	if slices.ContainsFunc(cols, func(c *common.SortColumn) bool { return c.Relevance }) {
+		return nil, cerrors.NewInvalidInputErrorWithError(
+			common.ErrRelevanceSortNotSupported,
+			cerrors.WithPublicError(common.ErrRelevanceSortNotSupported),
+		)
+	}
+	// Check if a sort that cannot be used to seek is present
+	if slices.ContainsFunc(cols, func(c *common.SortColumn) bool { return !c.SupportsKeyset() }) {
+		return nil, cerrors.NewInvalidInputErrorWithError(
+			common.ErrKeysetSortNotSupported,
+			cerrors.WithPublicError(common.ErrKeysetSortNotSupported),
+		)
+	}
+	// Add tie-breaker
+	cols = addKeysetTieBreaker(cols)
+
+	// Check values
+	if len(kInput.Values) != 0 && len(kInput.Values) != len(cols) {
+		return nil, cerrors.NewInvalidInputErrorWithError(
+			ErrKeysetCursorNotMatchingSort,
+			cerrors.WithPublicError(ErrKeysetCursorNotMatchingSort),
+		)
+	}
+
+	// Parse result schema in order to coerce and extract values
+	stmt := &gorm.Statement{DB: db}
+	// Parse
+	err = stmt.Parse(result)
+	// Check error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	// Get fields for columns
+	fields := make([]*schema.Field, len(cols))
+	// Loop over columns
+	for i, c := range cols {
+		// Lookup field
+		f := stmt.Schema.LookUpField(c.Column)
+		// Check if it exists
+		if f == nil {
+			return nil, cerrors.NewInternalServerError(
+				fmt.Sprintf("keyset pagination column %s not found in model", c.Column),
+			)
+		}
+		// Save
+		fields[i] = f
+	}
+
+	// Check if seek must be applied
+	if len(kInput.Values) != 0 {
+		// Coerce values
+		values := make([]any, len(kInput.Values))
+		// Loop over values
+		for i, v := range kInput.Values {
+			values[i], err = coerceKeysetValue(fields[i], v)
+			// Check error
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// Build condition
+		cond, args := buildKeysetCondition(cols, values, kInput.Backward)
+		// Apply it
+		db = db.Where(cond, args...)
+	}
+
+	// Apply order
+	for _, c := range cols {
+		db = db.Order(fmt.Sprintf("%s %s", c.Column, getKeysetSortOrder(c.Order, kInput.Backward).String()))
+	}
+
+	// Apply projection
+	db, err = common.ManageProjection(options.Projection, db)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if a projection is applied in order to add keyset columns
+	if len(db.Statement.Selects) != 0 {
+		// Copy selects
+		selects := slices.Clone(db.Statement.Selects)
+		// Loop over columns
+		for _, c := range cols {
+			// Check if it is already present
+			if !slices.Contains(selects, c.Column) {
+				selects = append(selects, c.Column)
+			}
+		}
+		// Apply
+		db = db.Select(selects)
+	}
+
+	// Request to database with limit + 1 in order to detect if there are more results
+	db = db.Limit(options.PageInput.Limit + 1).Find(result)
+	// Check error
+	if db.Error != nil {
+		return nil, errors.WithStack(db.Error)
+	}
+
+	// Create output
+	res := &keysetOutput{hasMore: trimProbeResult(result, options.PageInput.Limit)}
+	// Get result list
+	rv := reflect.Indirect(reflect.ValueOf(result))
+	// Check if results must be reversed to keep asked order
+	if kInput.Backward {
+		// Get swapper
+		swap := reflect.Swapper(rv.Interface())
+		// Loop
+		for i, j := 0, rv.Len()-1; i < j; i, j = i+1, j-1 {
+			swap(i, j)
+		}
+	}
+
+	// Extract keysets
+	res.keysets = make([][]any, rv.Len())
+	// Loop over results
+	for i := 0; i < rv.Len(); i++ {
+		// Get element
+		el := rv.Index(i)
+		// Create values
+		values := make([]any, len(fields))
+		// Loop over fields
+		for j, f := range fields {
+			values[j], _ = f.ValueOf(db.Statement.Context, el)
+		}
+		// Save
+		res.keysets[i] = values
+	}
+
+	return res, nil
+}
+
+func addKeysetTieBreaker(cols []*common.SortColumn) []*common.SortColumn {
+	// Check if tie-breaker is already present
+	if slices.ContainsFunc(cols, func(c *common.SortColumn) bool { return c.Column == keysetTieBreakerColumn }) {
+		return cols
+	}
+
+	// Add tie-breaker with the same order as the last column to allow row values comparison
+	return append(slices.Clone(cols), &common.SortColumn{
+		Column: keysetTieBreakerColumn,
+		Order:  cols[len(cols)-1].Order,
+	})
+}
+
+func getKeysetSortOrder(order common.SortOrderEnum, backward bool) common.SortOrderEnum {
+	// Check if backward
+	if !backward {
+		return order
+	}
+
+	// Reverse
+	if order == common.SortOrderEnumDesc {
+		return common.SortOrderEnumAsc
+	}
+
+	return common.SortOrderEnumDesc
+}
+
+func getKeysetOperator(order common.SortOrderEnum, backward bool) string {
+	// Check order
+	if getKeysetSortOrder(order, backward) == common.SortOrderEnumDesc {
+		return "<"
+	}
+
+	return ">"
+}
+
+func buildKeysetCondition(cols []*common.SortColumn, values []any, backward bool) (string, []any) {
+	// Check if all columns have the same order
+	sameOrder := !slices.ContainsFunc(cols, func(c *common.SortColumn) bool { return c.Order != cols[0].Order })
+	// Row values comparison can be used when all columns have the same order
+	if sameOrder {
+		// Build columns and placeholders
+		colNames := make([]string, len(cols))
+		placeholders := make([]string, len(cols))
+		// Loop
+		for i, c := range cols {
+			colNames[i] = c.Column
+			placeholders[i] = "?"
+		}
+
+		return fmt.Sprintf(
+			"(%s) %s (%s)",
+			strings.Join(colNames, ", "),
+			getKeysetOperator(cols[0].Order, backward),
+			strings.Join(placeholders, ", "),
+		), values
+	}
+
+	// Otherwise, expand to (c1 > v1) OR (c1 = v1 AND c2 < v2) OR ...
+	ors := make([]string, len(cols))
+	args := make([]any, 0)
+	// Loop over columns
+	for i, c := range cols {
+		// Create ands
+		ands := make([]string, 0, i+1)
+		// Loop over previous columns
+		for j := range i {
+			ands = append(ands, cols[j].Column+" = ?")
+			args = append(args, values[j])
+		}
+		// Add current column
+		ands = append(ands, fmt.Sprintf("%s %s ?", c.Column, getKeysetOperator(c.Order, backward)))
+		args = append(args, values[i])
+		// Save
+		ors[i] = "(" + strings.Join(ands, " AND ") + ")"
+	}
+
+	return "(" + strings.Join(ors, " OR ") + ")", args
+}
+
+func coerceKeysetValue(field *schema.Field, v any) (any, error) {
+	// Get field type
+	fType := field.FieldType
+	// Indirect pointer type
+	if fType.Kind() == reflect.Pointer {
+		fType = fType.Elem()
+	}
+
+	// Switch on value type
+	switch val := v.(type) {
+	case string:
+		// Check if field is a time
+		if fType == reflect.TypeFor[time.Time]() {
+			// Parse
+			t, err := time.Parse(time.RFC3339Nano, val)
+			// Check error
+			if err != nil {
+				return nil, cerrors.NewInvalidInputErrorWithError(err)
+			}
+
+			return t, nil
+		}
+	case json.Number:
+		// Switch on kind
+		switch fType.Kind() { //nolint:exhaustive // Only numbers are managed
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			// Parse
+			i, err := val.Int64()
+			// Check error
+			if err != nil {
+				return nil, cerrors.NewInvalidInputErrorWithError(err)
+			}
+
+			return i, nil
+		case reflect.Float32, reflect.Float64:
+			// Parse
+			f, err := val.Float64()
+			// Check error
+			if err != nil {
+				return nil, cerrors.NewInvalidInputErrorWithError(err)
+			}
+
+			return f, nil
+		default:
+			return val.String(), nil
+		}
+	}
+
+	// Default
+	return v, nil
+}
+
+func getKeysetPageOutput(p *PageInput, count int64, countMode CountMode, out *keysetOutput) *PageOutput {
+	// Check if a cursor was given
+	hasCursor := len(p.Keyset.Values) != 0
+
+	// Create result
+	res := &PageOutput{
+		TotalRecord: int(count),
+		Limit:       p.Limit,
+		CountMode:   countMode,
+		Keysets:     out.keysets,
+	}
+
+	// Check direction
+	if p.Keyset.Backward {
+		res.HasPrevious = out.hasMore
+		res.HasNext = hasCursor
+	} else {
+		res.HasNext = out.hasMore
+		res.HasPrevious = hasCursor
+	}
+
+	return res
+}
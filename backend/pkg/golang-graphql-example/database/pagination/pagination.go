@@ -12,15 +12,38 @@ import (
 
 // PageInput represents an input pagination configuration.
 type PageInput struct {
-	Skip  int
-	Limit int
+	// Keyset input.
+	// When set, pagination will seek on sort columns values instead of using an offset.
+	// Skip is ignored in this mode.
+	Keyset *KeysetInput
+	Skip   int
+	Limit  int
+	// Count mode. Default is exact count.
+	// Stored here and not in paging options so generated Find<Model>Paginated methods
+	// and business services can choose it per request without any signature change.
+	CountMode CountMode
+}
+
+// KeysetInput represents a keyset (seek) pagination input.
+type KeysetInput struct {
+	// Values of active sort columns followed by the id tie-breaker value.
+	// Empty values means first page.
+	Values []any
+	// Backward will seek before values instead of after.
+	Backward bool
 }
 
 // PageOutput represents an output pagination structure.
 type PageOutput struct {
+	// Keyset values for each returned element, in the same order as results.
+	// Only filled in keyset mode.
+	Keysets [][]any
+	// Total records. Estimated when count mode is estimated and empty when count mode is none.
 	TotalRecord int
 	Limit       int
 	Skip        int
+	// Count mode effectively used.
+	CountMode   CountMode
 	HasPrevious bool
 	HasNext     bool
 }
@@ -44,6 +67,7 @@ type PagingOptions struct {
 }
 
 // Paging function in order to have a paginated sorted and filters list of objects.
+// Count and find are executed in a read transaction.
 // Parameters:
 // - result: Must be a pointer to a list of objects
 // - options: Pagination options
@@ -53,14 +77,6 @@ func Paging(
 	result any,
 	options *PagingOptions,
 ) (*PageOutput, error) {
-	// Manage default limit
-	if options.PageInput.Limit == 0 {
-		options.PageInput.Limit = 10
-	}
-
-	// Initialize
-	var count int64
-
 	// Create local transaction options
 	localTOpts := options.TOpts
 	// Check if nil
@@ -71,62 +87,123 @@ func Paging(
 	// Add local options
 	localTOpts = append(localTOpts, database.WithReadTransactionOpt)
 
+	// Initialize
+	var res *PageOutput
+
 	// Create transaction to avoid situations where count and find are different
 	err := options.DBSvc.ExecuteTransaction(ctx, func(ctx context.Context) error {
-		// Get gorm db
-		db := options.DBSvc.GetTransactionalOrDefaultGormDB(ctx)
-		// Apply filter
-		db, err := common.ManageFilter(options.Filter, db)
-		// Check error
-		if err != nil {
-			return err
-		}
+		var err error
+		// Page
+		res, err = PagingWithoutTransaction(ctx, result, options)
 
-		// Extra function
-		if options.ExtraFunc != nil {
-			db, err = options.ExtraFunc(db)
-			// Check error
-			if err != nil {
-				return err
-			}
-		}
+		return err
+	}, localTOpts...)
+	// Check error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 
-		// Count all objects
-		db = db.Model(result).Count(&count)
-		// Check error
-		if db.Error != nil {
-			return errors.WithStack(db.Error)
-		}
+	return res, nil
+}
 
-		// Apply sort
-		db, err = common.ManageSortOrder(options.Sort, db)
+// PagingWithoutTransaction is like Paging but queries are executed on the transaction of the context
+// or on the default database, without creating a transaction or a savepoint.
+// Transaction options are ignored.
+// This is used to page in an existing transaction. Outside one, count and find can see different data.
+func PagingWithoutTransaction(
+	ctx context.Context,
+	result any,
+	options *PagingOptions,
+) (*PageOutput, error) {
+	// Manage default limit
+	if options.PageInput.Limit == 0 {
+		options.PageInput.Limit = 10
+	}
+
+	// Get gorm db
+	db := options.DBSvc.GetTransactionalOrDefaultGormDB(ctx)
+	// Apply filter
+	db, err := common.ManageFilter(options.Filter, db)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply tenant scope
+	db, err = database.ApplyTenantScope(ctx, db, result)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	// Extra function
+	if options.ExtraFunc != nil {
+		db, err = options.ExtraFunc(db)
 		// Check error
 		if err != nil {
-			return err
+			return nil, err
 		}
+	}
 
-		// Apply projection
-		db, err = common.ManageProjection(options.Projection, db)
+	// Count all objects
+	count, countMode, err := countRecords(db, result, options.PageInput.CountMode)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+	// Set model
+	db = db.Model(result)
+
+	// Check if keyset mode is enabled
+	if options.PageInput.Keyset != nil {
+		// Find with keyset
+		keysetOut, err := findWithKeyset(db, result, options)
 		// Check error
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		// Request to database with limit and offset
-		db = db.Limit(options.PageInput.Limit).Offset(options.PageInput.Skip).Find(result)
-		// Check error
-		if db.Error != nil {
-			return errors.WithStack(db.Error)
-		}
+		return getKeysetPageOutput(options.PageInput, count, countMode, keysetOut), nil
+	}
 
-		return nil
-	}, localTOpts...)
+	// Apply sort
+	db, err = common.ManageSortOrder(options.Sort, db)
 	// Check error
 	if err != nil {
-		return nil, errors.WithStack(err)
+		return nil, err
+	}
+
+	// Apply projection
+	db, err = common.ManageProjection(options.Projection, db)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	// Initialize limit
+	limit := options.PageInput.Limit
+	// Check if a probe is needed to detect next page
+	if countMode != CountModeExact {
+		limit++
+	}
+
+	// Request to database with limit and offset
+	db = db.Limit(limit).Offset(options.PageInput.Skip).Find(result)
+	// Check error
+	if db.Error != nil {
+		return nil, errors.WithStack(db.Error)
+	}
+
+	// Get page output
+	res := getPageOutput(options.PageInput, count)
+	// Save count mode
+	res.CountMode = countMode
+	// Check if a probe was used
+	if countMode != CountModeExact {
+		res.HasNext = trimProbeResult(result, options.PageInput.Limit)
 	}
 
-	return getPageOutput(options.PageInput, count), nil
+	return res, nil
 }
 
 func getPageOutput(p *PageInput, count int64) *PageOutput {
@@ -8,15 +8,19 @@ import (
 	"errors"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/common"
+	metricsmocks "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/metrics/mocks"
 )
 
 type DBSvcTest interface {
@@ -24,6 +28,15 @@ type DBSvcTest interface {
 	SetGormDB(*gorm.DB)
 }
 
+func newPagingTestMetricsService(t *testing.T) *metricsmocks.MockService {
+	t.Helper()
+
+	metricsSvc := metricsmocks.NewMockService(gomock.NewController(t))
+	metricsSvc.EXPECT().IncreaseDatabaseTransactionAttempt(gomock.Any(), gomock.Any()).AnyTimes()
+
+	return metricsSvc
+}
+
 func TestPaging(t *testing.T) {
 	type Person struct{ Name string }
 	type Sort struct {
@@ -181,7 +194,7 @@ func TestPaging(t *testing.T) {
 
 			// Create mock controller
 			// ctrl := gomock.NewController(t)
-			officialDBSvc := database.NewDatabase("test", nil, nil, nil, nil)
+			officialDBSvc := database.NewDatabase("test", nil, nil, newPagingTestMetricsService(t), nil)
 			// Cheat mode to inject a custom gorm db instance
 			dbSvc, ok := officialDBSvc.(DBSvcTest)
 			if !ok {
@@ -230,6 +243,403 @@ func TestPaging(t *testing.T) {
 	}
 }
 
+func TestPagingKeyset(t *testing.T) {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	type Person struct {
+		CreatedAt time.Time
+		ID        string
+		Name      string
+	}
+	type Sort struct {
+		CreatedAt *common.SortOrderEnum `dbfield:"created_at"`
+		Name      *common.SortOrderEnum `dbfield:"name"`
+		Relevance *common.SortOrderEnum `dbfield:"name;relevance"`
+		NameLower *common.SortOrderEnum `dbfield:"name;caseInsensitive"`
+	}
+	type Projection struct {
+		Name bool `dbfield:"name"`
+	}
+	type args struct {
+		p          *PageInput
+		sort       any
+		projection any
+	}
+	tests := []struct {
+		name                            string
+		args                            args
+		selectExpectedIntermediateQuery string
+		selectExpectedArgs              []driver.Value
+		selectExpectedProjectionQuery   string
+		selectResultIDs                 []string
+		want                            *PageOutput
+		wantErr                         bool
+	}{
+		{
+			name: "first page with default sort",
+			args: args{
+				p: &PageInput{Limit: 2, Keyset: &KeysetInput{}},
+			},
+			selectExpectedIntermediateQuery: "ORDER BY created_at DESC,id DESC LIMIT $1",
+			selectExpectedArgs:              []driver.Value{3},
+			selectExpectedProjectionQuery:   "*",
+			selectResultIDs:                 []string{"1", "2", "3"},
+			want: &PageOutput{
+				TotalRecord: 30,
+				Limit:       2,
+				HasNext:     true,
+				Keysets:     [][]any{{createdAt, "1"}, {createdAt, "2"}},
+			},
+		},
+		{
+			name: "after cursor with default sort",
+			args: args{
+				p: &PageInput{Limit: 2, Keyset: &KeysetInput{Values: []any{createdAt.Format(time.RFC3339Nano), "0"}}},
+			},
+			selectExpectedIntermediateQuery: "WHERE (created_at, id) < ($1, $2) ORDER BY created_at DESC,id DESC LIMIT $3",
+			selectExpectedArgs:              []driver.Value{createdAt, "0", 3},
+			selectExpectedProjectionQuery:   "*",
+			selectResultIDs:                 []string{"1"},
+			want: &PageOutput{
+				TotalRecord: 30,
+				Limit:       2,
+				HasPrevious: true,
+				Keysets:     [][]any{{createdAt, "1"}},
+			},
+		},
+		{
+			name: "before cursor with mixed sorts and projection",
+			args: args{
+				p: &PageInput{Limit: 2, Keyset: &KeysetInput{Values: []any{"fake", createdAt, "0"}, Backward: true}},
+				sort: []*Sort{
+					{Name: &common.SortOrderEnumAsc},
+					{CreatedAt: &common.SortOrderEnumDesc},
+				},
+				projection: &Projection{Name: true},
+			},
+			selectExpectedIntermediateQuery: "WHERE ((name < $1) OR (name = $2 AND created_at > $3) OR (name = $4 AND created_at = $5 AND id > $6)) " +
+				"ORDER BY name DESC,created_at ASC,id ASC LIMIT $7",
+			selectExpectedArgs:            []driver.Value{"fake", "fake", createdAt, "fake", createdAt, "0", 3},
+			selectExpectedProjectionQuery: `"name","created_at","id"`,
+			selectResultIDs:               []string{"2", "1"},
+			want: &PageOutput{
+				TotalRecord: 30,
+				Limit:       2,
+				HasNext:     true,
+				Keysets:     [][]any{{"fake", createdAt, "1"}, {"fake", createdAt, "2"}},
+			},
+		},
+		{
+			name: "cursor not matching sort",
+			args: args{
+				p: &PageInput{Limit: 2, Keyset: &KeysetInput{Values: []any{"fake"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "relevance sort isn't supported",
+			args: args{
+				p:    &PageInput{Limit: 2, Keyset: &KeysetInput{}},
+				sort: &Sort{Relevance: &common.SortOrderEnumDesc},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case insensitive sort isn't supported",
+			args: args{
+				p:    &PageInput{Limit: 2, Keyset: &KeysetInput{}},
+				sort: &Sort{NameLower: &common.SortOrderEnumAsc},
+			},
+			wantErr: true,
+		},
+		{
+			name: "nulls ordered sort isn't supported",
+			args: args{
+				p:    &PageInput{Limit: 2, Keyset: &KeysetInput{}},
+				sort: &Sort{Name: &common.SortOrderEnumAscNullsLast},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			defer sqlDB.Close()
+
+			db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Discard})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			officialDBSvc := database.NewDatabase("test", nil, nil, newPagingTestMetricsService(t), nil)
+			// Cheat mode to inject a custom gorm db instance
+			dbSvc, ok := officialDBSvc.(DBSvcTest)
+			if !ok {
+				panic("perdu")
+			}
+			dbSvc.SetGormDB(db)
+
+			// Create expected query
+			selectExpectedQuery := `SELECT ` + tt.selectExpectedProjectionQuery +
+				` FROM "people" ` + tt.selectExpectedIntermediateQuery
+
+			rows := sqlmock.NewRows([]string{"created_at", "id", "name"})
+			for _, id := range tt.selectResultIDs {
+				rows.AddRow(createdAt, id, "fake")
+			}
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(`SELECT count(*) FROM "people"`).
+				WillReturnRows(
+					sqlmock.NewRows([]string{"count"}).AddRow(30),
+				)
+			mock.ExpectQuery(selectExpectedQuery).
+				WithArgs(tt.selectExpectedArgs...).
+				WillReturnRows(rows)
+			mock.ExpectCommit()
+
+			res := make([]*Person, 0)
+
+			got, err := Paging(context.TODO(), &res, &PagingOptions{
+				DBSvc:      dbSvc,
+				PageInput:  tt.args.p,
+				Sort:       tt.args.sort,
+				Projection: tt.args.projection,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Paging() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPagingCountMode(t *testing.T) {
+	type Person struct{ Name string }
+	tests := []struct {
+		name                string
+		p                   *PageInput
+		countExpectedQuery  string
+		countResult         string
+		selectExpectedQuery string
+		selectExpectedArgs  []driver.Value
+		selectResultLength  int
+		want                *PageOutput
+	}{
+		{
+			name:                "none with next page",
+			p:                   &PageInput{Limit: 2, CountMode: CountModeNone},
+			selectExpectedQuery: `SELECT * FROM "people" ORDER BY created_at DESC LIMIT $1`,
+			selectExpectedArgs:  []driver.Value{3},
+			selectResultLength:  3,
+			want:                &PageOutput{Limit: 2, CountMode: CountModeNone, HasNext: true},
+		},
+		{
+			name:                "none without next page",
+			p:                   &PageInput{Limit: 2, Skip: 2, CountMode: CountModeNone},
+			selectExpectedQuery: `SELECT * FROM "people" ORDER BY created_at DESC LIMIT $1 OFFSET $2`,
+			selectExpectedArgs:  []driver.Value{3, 2},
+			selectResultLength:  2,
+			want:                &PageOutput{Limit: 2, Skip: 2, CountMode: CountModeNone, HasPrevious: true},
+		},
+		{
+			name:                "estimated",
+			p:                   &PageInput{Limit: 2, CountMode: CountModeEstimated},
+			countExpectedQuery:  `EXPLAIN (FORMAT JSON) SELECT * FROM "people"`,
+			countResult:         `[{"Plan": {"Plan Rows": 42}}]`,
+			selectExpectedQuery: `SELECT * FROM "people" ORDER BY created_at DESC LIMIT $1`,
+			selectExpectedArgs:  []driver.Value{3},
+			selectResultLength:  3,
+			want:                &PageOutput{TotalRecord: 42, Limit: 2, CountMode: CountModeEstimated, HasNext: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			defer sqlDB.Close()
+
+			db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Discard})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			officialDBSvc := database.NewDatabase("test", nil, nil, newPagingTestMetricsService(t), nil)
+			// Cheat mode to inject a custom gorm db instance
+			dbSvc, ok := officialDBSvc.(DBSvcTest)
+			if !ok {
+				panic("perdu")
+			}
+			dbSvc.SetGormDB(db)
+
+			rows := sqlmock.NewRows([]string{"name"})
+			for range tt.selectResultLength {
+				rows.AddRow("fake")
+			}
+
+			mock.ExpectBegin()
+			if tt.countExpectedQuery != "" {
+				mock.ExpectQuery(tt.countExpectedQuery).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"QUERY PLAN"}).AddRow(tt.countResult),
+					)
+			}
+			mock.ExpectQuery(tt.selectExpectedQuery).
+				WithArgs(tt.selectExpectedArgs...).
+				WillReturnRows(rows)
+			mock.ExpectCommit()
+
+			res := make([]*Person, 0)
+
+			got, err := Paging(context.TODO(), &res, &PagingOptions{
+				DBSvc:     dbSvc,
+				PageInput: tt.p,
+			})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+			assert.Len(t, res, tt.p.Limit)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPagingMySQL(t *testing.T) {
+	type Person struct{ Name string }
+	type Filter struct {
+		Name *common.GenericFilter `dbfield:"name"`
+	}
+	tests := []struct {
+		name                string
+		p                   *PageInput
+		filter              any
+		countExpectedQuery  string
+		countExpectedArgs   []driver.Value
+		countResult         driver.Value
+		exactCountQuery     string
+		selectExpectedQuery string
+		selectExpectedArgs  []driver.Value
+		selectResultLength  int
+		want                *PageOutput
+	}{
+		{
+			name:                "exact with filter",
+			p:                   &PageInput{Limit: 2, Skip: 2},
+			filter:              &Filter{Name: &common.GenericFilter{Contains: "fa_ke"}},
+			countExpectedQuery:  "SELECT count(*) FROM `people` WHERE name LIKE BINARY ?",
+			countExpectedArgs:   []driver.Value{`%fa\_ke%`},
+			countResult:         5,
+			selectExpectedQuery: "SELECT * FROM `people` WHERE name LIKE BINARY ? ORDER BY created_at DESC LIMIT ? OFFSET ?",
+			selectExpectedArgs:  []driver.Value{`%fa\_ke%`, 2, 2},
+			selectResultLength:  2,
+			want:                &PageOutput{TotalRecord: 5, Limit: 2, Skip: 2, HasNext: true, HasPrevious: true},
+		},
+		{
+			name:                "estimated",
+			p:                   &PageInput{Limit: 2, CountMode: CountModeEstimated},
+			countExpectedQuery:  "EXPLAIN FORMAT=JSON SELECT * FROM `people`",
+			countResult:         `{"query_block": {"table": {"rows_produced_per_join": 42}}}`,
+			selectExpectedQuery: "SELECT * FROM `people` ORDER BY created_at DESC LIMIT ?",
+			selectExpectedArgs:  []driver.Value{3},
+			selectResultLength:  3,
+			want:                &PageOutput{TotalRecord: 42, Limit: 2, CountMode: CountModeEstimated, HasNext: true},
+		},
+		{
+			name:                "estimated without estimation must fallback on exact count",
+			p:                   &PageInput{Limit: 2, CountMode: CountModeEstimated},
+			countExpectedQuery:  "EXPLAIN FORMAT=JSON SELECT * FROM `people`",
+			countResult:         `{"query_block": {"nested_loop": []}}`,
+			exactCountQuery:     "SELECT count(*) FROM `people`",
+			selectExpectedQuery: "SELECT * FROM `people` ORDER BY created_at DESC LIMIT ?",
+			selectExpectedArgs:  []driver.Value{2},
+			selectResultLength:  2,
+			want:                &PageOutput{TotalRecord: 7, Limit: 2, HasNext: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			defer sqlDB.Close()
+
+			db, err := gorm.Open(
+				mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}),
+				&gorm.Config{Logger: logger.Discard},
+			)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			officialDBSvc := database.NewDatabase("test", nil, nil, newPagingTestMetricsService(t), nil)
+			// Cheat mode to inject a custom gorm db instance
+			dbSvc, ok := officialDBSvc.(DBSvcTest)
+			if !ok {
+				panic("perdu")
+			}
+			dbSvc.SetGormDB(db)
+
+			rows := sqlmock.NewRows([]string{"name"})
+			for range tt.selectResultLength {
+				rows.AddRow("fake")
+			}
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(tt.countExpectedQuery).
+				WithArgs(tt.countExpectedArgs...).
+				WillReturnRows(
+					sqlmock.NewRows([]string{"result"}).AddRow(tt.countResult),
+				)
+			if tt.exactCountQuery != "" {
+				mock.ExpectQuery(tt.exactCountQuery).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"count"}).AddRow(7),
+					)
+			}
+			mock.ExpectQuery(tt.selectExpectedQuery).
+				WithArgs(tt.selectExpectedArgs...).
+				WillReturnRows(rows)
+			mock.ExpectCommit()
+
+			res := make([]*Person, 0)
+
+			got, err := Paging(context.TODO(), &res, &PagingOptions{
+				DBSvc:     dbSvc,
+				PageInput: tt.p,
+				Filter:    tt.filter,
+			})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+			assert.Len(t, res, tt.p.Limit)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 func Test_getPageOutput(t *testing.T) {
 	type args struct {
 		p     *PageInput
@@ -0,0 +1,143 @@
+package database
+
+import (
+	"emperror.dev/errors"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/config"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/log"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/metrics"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/tracing"
+)
+
+//go:generate mockgen -destination=./mocks/mock_Registry.go -package=mocks github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database Registry
+type Registry interface {
+	// Get will return the database of a named connection.
+	Get(connectionName string) (DB, error)
+	// GetMain will return the database of the main connection.
+	GetMain() DB
+	// GetConnectionNames will return all connection names, main is the first one.
+	GetConnectionNames() []string
+	// Connect will create and connect all databases declared in configuration.
+	Connect() error
+	// Reload will reconnect database of a named connection only when its configuration have changed.
+	Reload(connectionName string) error
+	// Close will close all database connections.
+	Close() error
+}
+
+type registry struct {
+	cfgManager config.Manager
+	logger     log.Logger
+	metricsSvc metrics.Service
+	tracingSvc tracing.Service
+	dbs        map[string]*sqldb
+	names      []string
+}
+
+// NewRegistry will generate a new database registry.
+func NewRegistry(
+	cfgManager config.Manager,
+	logger log.Logger,
+	metricsSvc metrics.Service,
+	tracingSvc tracing.Service,
+) Registry {
+	return &registry{
+		cfgManager: cfgManager,
+		logger:     logger,
+		metricsSvc: metricsSvc,
+		tracingSvc: tracingSvc,
+		dbs:        map[string]*sqldb{},
+	}
+}
+
+func (r *registry) Get(connectionName string) (DB, error) {
+	// Get database
+	db, ok := r.dbs[connectionName]
+	// Check if it exists
+	if !ok {
+		return nil, errors.Wrapf(ErrConnectionNotFound, "connection %s", connectionName)
+	}
+
+	return db, nil
+}
+
+func (r *registry) GetMain() DB {
+	// Get database
+	db, ok := r.dbs[config.MainDatabaseConnectionName]
+	// Check if it exists
+	if !ok {
+		return nil
+	}
+
+	return db
+}
+
+func (r *registry) GetConnectionNames() []string {
+	return r.names
+}
+
+func (r *registry) Connect() error {
+	// Get connection names
+	names := r.cfgManager.GetConfig().GetDatabaseConnectionNames()
+
+	// Loop over connections
+	for _, name := range names {
+		// Create database
+		db := NewDatabase(
+			name,
+			r.cfgManager,
+			r.logger.WithField("database-connection", name),
+			r.metricsSvc,
+			r.tracingSvc,
+		).(*sqldb) //nolint:forcetypeassert // Only sqldb are created
+		// Connect
+		err := db.Connect()
+		// Check error
+		if err != nil {
+			return err
+		}
+
+		// Save
+		r.dbs[name] = db
+		r.names = append(r.names, name)
+	}
+
+	return nil
+}
+
+func (r *registry) Reload(connectionName string) error {
+	// Get database
+	db, ok := r.dbs[connectionName]
+	// Check if it exists
+	if !ok {
+		return errors.Wrapf(ErrConnectionNotFound, "connection %s", connectionName)
+	}
+
+	// Check if connection still exists in configuration
+	if r.cfgManager.GetConfig().GetDatabaseConfig(connectionName) == nil {
+		return errors.Wrapf(ErrConnectionNotFound, "connection %s have been removed from configuration", connectionName)
+	}
+
+	// Check if configuration have changed
+	if !db.hasConfigurationChanged() {
+		r.logger.Debugf("Database %s configuration haven't changed, ignoring reload", connectionName)
+
+		return nil
+	}
+
+	return db.Reconnect()
+}
+
+func (r *registry) Close() error {
+	// Loop over connections
+	for _, name := range r.names {
+		// Close
+		err := r.dbs[name].Close()
+		// Check error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+//go:build unit
+
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/config"
+	cmocks "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/config/mocks"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/log"
+	metricsmocks "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/metrics/mocks"
+	tracingmocks "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/tracing/mocks"
+)
+
+type registryTestPlugin struct {
+	name string
+}
+
+func (p *registryTestPlugin) Name() string { return p.name }
+
+func (*registryTestPlugin) Initialize(*gorm.DB) error { return nil }
+
+func newRegistryTestDatabaseConfig(t *testing.T, name string) *config.DatabaseConfig {
+	t.Helper()
+
+	return &config.DatabaseConfig{
+		Driver:        SqliteDriverSelector,
+		ConnectionURL: &config.CredentialConfig{Value: filepath.Join(t.TempDir(), name+".db")},
+	}
+}
+
+// setupTestRegistry will create a registry with a main and an analytics connections.
+func setupTestRegistry(t *testing.T) (*registry, *config.Config) {
+	t.Helper()
+
+	cfg := &config.Config{
+		Database: newRegistryTestDatabaseConfig(t, "main"),
+		Databases: map[string]*config.DatabaseConfig{
+			"analytics": newRegistryTestDatabaseConfig(t, "analytics"),
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	cfgManager := cmocks.NewMockManager(ctrl)
+	cfgManager.EXPECT().GetConfig().AnyTimes().DoAndReturn(func() *config.Config { return cfg })
+
+	metricsSvc := metricsmocks.NewMockService(ctrl)
+	metricsSvc.EXPECT().IncreaseDatabaseTransactionAttempt(gomock.Any(), gomock.Any()).AnyTimes()
+	metricsSvc.EXPECT().DatabaseMiddleware(gomock.Any()).AnyTimes().Return(&registryTestPlugin{name: "metrics"})
+
+	tracingSvc := tracingmocks.NewMockService(ctrl)
+	tracingSvc.EXPECT().DatabaseMiddleware().AnyTimes().Return(&registryTestPlugin{name: "tracing"})
+
+	reg := NewRegistry(cfgManager, log.NewLogger(), metricsSvc, tracingSvc).(*registry) //nolint:forcetypeassert // Test
+
+	require.NoError(t, reg.Connect())
+
+	t.Cleanup(func() { _ = reg.Close() })
+
+	return reg, cfg
+}
+
+func TestRegistry_Get(t *testing.T) {
+	reg, _ := setupTestRegistry(t)
+
+	assert.Equal(t, []string{config.MainDatabaseConnectionName, "analytics"}, reg.GetConnectionNames())
+
+	mainDB, err := reg.Get(config.MainDatabaseConnectionName)
+	require.NoError(t, err)
+	assert.Same(t, reg.GetMain(), mainDB)
+
+	analyticsDB, err := reg.Get("analytics")
+	require.NoError(t, err)
+	assert.NotSame(t, mainDB, analyticsDB)
+
+	_, err = reg.Get("unknown")
+	assert.ErrorIs(t, err, ErrConnectionNotFound)
+}
+
+func TestRegistry_Connect_UnknownConnection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cfgManager := cmocks.NewMockManager(ctrl)
+	cfgManager.EXPECT().GetConfig().AnyTimes().Return(&config.Config{})
+
+	db := NewDatabase("unknown", cfgManager, log.NewLogger(), nil, nil)
+
+	assert.ErrorIs(t, db.Connect(), ErrConnectionNotFound)
+}
+
+func TestRegistry_TransactionsAreScopedByConnection(t *testing.T) {
+	reg, _ := setupTestRegistry(t)
+
+	mainDB := reg.GetMain()
+	analyticsDB, err := reg.Get("analytics")
+	require.NoError(t, err)
+
+	require.NoError(t, mainDB.GetGormDB().AutoMigrate(&replicaTestPeople{}))
+	require.NoError(t, analyticsDB.GetGormDB().AutoMigrate(&replicaTestPeople{}))
+
+	err = mainDB.ExecuteTransaction(context.TODO(), func(ctx context.Context) error {
+		// Main transaction is used
+		assert.NotSame(t, mainDB.GetGormDB(), mainDB.GetTransactionalOrDefaultGormDB(ctx))
+
+		// Analytics connection doesn't use main transaction
+		return analyticsDB.GetTransactionalOrDefaultGormDB(ctx).Create(&replicaTestPeople{ID: "analytics"}).Error
+	})
+	require.NoError(t, err)
+
+	var count int64
+
+	require.NoError(t, analyticsDB.GetGormDB().Model(&replicaTestPeople{}).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+	require.NoError(t, mainDB.GetGormDB().Model(&replicaTestPeople{}).Count(&count).Error)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestRegistry_Reload(t *testing.T) {
+	reg, cfg := setupTestRegistry(t)
+
+	mainGormDB := reg.GetMain().GetGormDB()
+	analyticsGormDB := reg.dbs["analytics"].GetGormDB()
+
+	// Nothing changed
+	require.NoError(t, reg.Reload(config.MainDatabaseConnectionName))
+	require.NoError(t, reg.Reload("analytics"))
+	assert.Same(t, mainGormDB, reg.GetMain().GetGormDB())
+	assert.Same(t, analyticsGormDB, reg.dbs["analytics"].GetGormDB())
+
+	// Credential reloaded in place on analytics connection
+	cfg.Databases["analytics"].ConnectionURL.Value = filepath.Join(t.TempDir(), "analytics-new.db")
+
+	require.NoError(t, reg.Reload(config.MainDatabaseConnectionName))
+	require.NoError(t, reg.Reload("analytics"))
+	assert.Same(t, mainGormDB, reg.GetMain().GetGormDB())
+	assert.NotSame(t, analyticsGormDB, reg.dbs["analytics"].GetGormDB())
+
+	// Unknown connection
+	require.ErrorIs(t, reg.Reload("unknown"), ErrConnectionNotFound)
+
+	// Connection removed from configuration
+	delete(cfg.Databases, "analytics")
+	require.ErrorIs(t, reg.Reload("analytics"), ErrConnectionNotFound)
+}
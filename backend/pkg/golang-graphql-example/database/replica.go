@@ -0,0 +1,491 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"emperror.dev/errors"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+var (
+	primaryContextKey        = &contextKey{name: "PRIMARY"}
+	readYourWritesContextKey = &contextKey{name: "READ_YOUR_WRITES"}
+)
+
+// ErrReplicaNotFound is returned when a replica index doesn't exist.
+var ErrReplicaNotFound = errors.Sentinel("database replica not found")
+
+// Query used to measure replication lag on postgres.
+// When all received WAL are replayed, replica is up to date even if last replay is old.
+const postgresReplicationLagQuery = `SELECT CASE
+	WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+	ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+END`
+
+// Query used to get replica status on mysql and mariadb.
+const mysqlReplicaStatusQuery = "SHOW REPLICA STATUS"
+
+type replica struct {
+	// Direct database connection used to check replica
+	db *gorm.DB
+	// Connection pool used by resolver
+	connPool gorm.ConnPool
+	name     string
+	ejected  atomic.Bool
+}
+
+type readYourWritesTracker struct {
+	sessionKey string
+	// Last write time by connection name
+	lastWrites sync.Map
+}
+
+// replicaPolicy is a dbresolver policy that ignores ejected replicas.
+type replicaPolicy struct {
+	replicas []*replica
+}
+
+func (p *replicaPolicy) Resolve(connPools []gorm.ConnPool) gorm.ConnPool {
+	// Filter ejected replicas
+	healthy := make([]gorm.ConnPool, 0, len(connPools))
+	// Loop over connection pools
+	for _, cp := range connPools {
+		// Check if replica is ejected
+		if !p.isEjected(cp) {
+			healthy = append(healthy, cp)
+		}
+	}
+
+	// Check if all replicas are ejected
+	// This shouldn't happen as primary is forced in this case, but resolver needs a replica
+	if len(healthy) == 0 {
+		healthy = connPools
+	}
+
+	return healthy[rand.IntN(len(healthy))] //nolint:gosec // No need of a secure random here
+}
+
+func (p *replicaPolicy) isEjected(cp gorm.ConnPool) bool {
+	// Find replica
+	for _, r := range p.replicas {
+		if r.connPool == cp {
+			return r.ejected.Load()
+		}
+	}
+
+	return false
+}
+
+// WithPrimary will force all database requests done with this context to be executed on primary.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryContextKey, true)
+}
+
+// NewReadYourWritesContext will create a context that tracks writes in order to read on primary after them.
+// Session key is optional and allows to share writes between requests of the same session.
+func NewReadYourWritesContext(ctx context.Context, sessionKey string) context.Context {
+	return context.WithValue(ctx, readYourWritesContextKey, &readYourWritesTracker{sessionKey: sessionKey})
+}
+
+func getReadYourWritesTrackerFromContext(ctx context.Context) *readYourWritesTracker {
+	// Get tracker from context
+	res, _ := ctx.Value(readYourWritesContextKey).(*readYourWritesTracker)
+
+	return res
+}
+
+// markWrite will save that a write have been done with this context.
+func (sdb *sqldb) markWrite(ctx context.Context) {
+	// Check if there are replicas
+	if len(sdb.replicas) == 0 {
+		return
+	}
+
+	// Get tracker
+	tracker := getReadYourWritesTrackerFromContext(ctx)
+	// Check if it exists
+	if tracker == nil {
+		return
+	}
+
+	// Get now
+	now := time.Now().UnixNano()
+
+	tracker.lastWrites.Store(sdb.connectionName, now)
+	// Save it for session
+	if tracker.sessionKey != "" {
+		sdb.sessionWrites.Store(tracker.sessionKey, now)
+	}
+}
+
+// shouldUsePrimary will return true when reads must be done on primary.
+func (sdb *sqldb) shouldUsePrimary(ctx context.Context) bool {
+	// Check if there are replicas
+	if len(sdb.replicas) == 0 {
+		return false
+	}
+
+	// Check if primary is forced
+	if forced, _ := ctx.Value(primaryContextKey).(bool); forced {
+		return true
+	}
+
+	// Check if all replicas are ejected
+	allEjected := true
+	// Loop over replicas
+	for _, r := range sdb.replicas {
+		if !r.ejected.Load() {
+			allEjected = false
+
+			break
+		}
+	}
+	// Check result
+	if allEjected {
+		return true
+	}
+
+	// Get tracker
+	tracker := getReadYourWritesTrackerFromContext(ctx)
+	// Check if it exists
+	if tracker == nil || sdb.readYourWritesWindow == 0 {
+		return false
+	}
+
+	// Compute limit
+	limit := time.Now().Add(-sdb.readYourWritesWindow).UnixNano()
+
+	// Check request write
+	if v, ok := tracker.lastWrites.Load(sdb.connectionName); ok && v.(int64) > limit { //nolint:forcetypeassert // Only int64 are stored
+		return true
+	}
+
+	// Check session write
+	if tracker.sessionKey != "" {
+		// Get last write
+		v, ok := sdb.sessionWrites.Load(tracker.sessionKey)
+		// Check if it exists
+		if ok {
+			// Check if it is in window
+			if v.(int64) > limit { //nolint:forcetypeassert // Only int64 are stored
+				return true
+			}
+
+			// Clean old value
+			sdb.sessionWrites.CompareAndDelete(tracker.sessionKey, v)
+		}
+	}
+
+	return false
+}
+
+// CheckReplica will measure replica replication lag and eject it from reads when it is unreachable
+// or when lag is above maximum.
+func (sdb *sqldb) CheckReplica(index int) error {
+	// Check index
+	if index < 0 || index >= len(sdb.replicas) {
+		return errors.WithStack(ErrReplicaNotFound)
+	}
+
+	// Get replica
+	r := sdb.replicas[index]
+
+	// Clean expired session writes
+	sdb.cleanSessionWrites()
+
+	// Measure lag
+	lag, err := measureReplicationLag(r.db)
+	// Check error
+	if err != nil {
+		sdb.ejectReplica(r, err.Error())
+
+		return err
+	}
+
+	// Check lag
+	if sdb.replicaMaxLag != 0 && lag > sdb.replicaMaxLag {
+		// Create error
+		err = errors.Errorf("replication lag %s of %s is above maximum %s", lag, r.name, sdb.replicaMaxLag)
+
+		sdb.ejectReplica(r, err.Error())
+
+		return err
+	}
+
+	// Restore replica
+	if r.ejected.Swap(false) {
+		sdb.logger.Infof("Database replica %s is back in reads", r.name)
+	}
+
+	return nil
+}
+
+func (sdb *sqldb) cleanSessionWrites() {
+	// Compute limit
+	limit := time.Now().Add(-sdb.readYourWritesWindow).UnixNano()
+
+	sdb.sessionWrites.Range(func(key, value any) bool {
+		// Check if it is expired
+		if value.(int64) <= limit { //nolint:forcetypeassert // Only int64 are stored
+			sdb.sessionWrites.CompareAndDelete(key, value)
+		}
+
+		return true
+	})
+}
+
+func (sdb *sqldb) ejectReplica(r *replica, reason string) {
+	// Eject
+	if !r.ejected.Swap(true) {
+		sdb.logger.Warnf("Database replica %s is ejected from reads: %s", r.name, reason)
+	}
+}
+
+func measureReplicationLag(db *gorm.DB) (time.Duration, error) {
+	switch db.Dialector.Name() {
+	case "postgres":
+		var lag float64
+		// Run query
+		err := db.Raw(postgresReplicationLagQuery).Scan(&lag).Error
+		// Check error
+		if err != nil {
+			return 0, errors.WithStack(err)
+		}
+
+		return time.Duration(lag * float64(time.Second)), nil
+	case "mysql":
+		return measureMySQLReplicationLag(db)
+	default:
+		// No replication lag to measure, ping instead
+		// Get sql db
+		sqlDB, err := db.DB()
+		// Check error
+		if err != nil {
+			return 0, errors.WithStack(err)
+		}
+
+		return 0, errors.WithStack(sqlDB.Ping()) //nolint:noctx // false positive
+	}
+}
+
+func measureMySQLReplicationLag(db *gorm.DB) (time.Duration, error) {
+	// Get replica status
+	rows, err := db.Raw(mysqlReplicaStatusQuery).Rows()
+	// Check error
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	// Defer close
+	defer rows.Close()
+
+	// Check if there is a replication status
+	if !rows.Next() {
+		// Not a replica, consider it up to date
+		return 0, errors.WithStack(rows.Err())
+	}
+
+	// Get columns
+	cols, err := rows.Columns()
+	// Check error
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	// Scan all columns as their number depends on version
+	values := make([]sql.NullString, len(cols))
+	dest := make([]any, len(cols))
+
+	for i := range values {
+		dest[i] = &values[i]
+	}
+
+	err = rows.Scan(dest...)
+	// Check error
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	// Find lag column
+	for i, c := range cols {
+		// Column is renamed in recent versions
+		if c != "Seconds_Behind_Source" && c != "Seconds_Behind_Master" {
+			continue
+		}
+
+		// Check if replication is running
+		if !values[i].Valid {
+			return 0, errors.New("replication isn't running")
+		}
+
+		// Parse
+		lag, err := strconv.ParseInt(values[i].String, 10, 64)
+		// Check error
+		if err != nil {
+			return 0, errors.WithStack(err)
+		}
+
+		return time.Duration(lag) * time.Second, nil
+	}
+
+	return 0, errors.New("replication lag not found in replica status")
+}
+
+// openReplicas will open direct connections to replicas and return dialectors
+// reusing them for the resolver.
+func openReplicas(
+	driver string,
+	urls []string,
+	newGormConfig func() *gorm.Config,
+	openFunction func(dsn string) gorm.Dialector,
+) ([]*replica, []gorm.Dialector, error) {
+	// Initialize results
+	replicas := make([]*replica, 0, len(urls))
+	dialectors := make([]gorm.Dialector, 0, len(urls))
+
+	// Loop over urls
+	for i, u := range urls {
+		// Open
+		db, err := gorm.Open(openFunction(u), newGormConfig())
+		// Check error
+		if err != nil {
+			return nil, nil, errors.WithStack(err)
+		}
+
+		// Get sql db
+		sqlDB, err := db.DB()
+		// Check error
+		if err != nil {
+			return nil, nil, errors.WithStack(err)
+		}
+
+		// Reuse connection pool in resolver to identify replica
+		var dial gorm.Dialector
+
+		switch driver {
+		case SqliteDriverSelector:
+			dial = &sqlite.Dialector{Conn: sqlDB}
+		case MysqlDriverSelector:
+			dial = mysql.New(mysql.Config{Conn: sqlDB})
+		default:
+			dial = postgres.New(postgres.Config{Conn: sqlDB})
+		}
+
+		replicas = append(replicas, &replica{
+			db:       db,
+			connPool: sqlDB,
+			name:     fmt.Sprintf("replica-%d", i),
+		})
+		dialectors = append(dialectors, dial)
+	}
+
+	return replicas, dialectors, nil
+}
+
+// registerReplicas will open replicas and register them in db resolver with write tracking.
+func (sdb *sqldb) registerReplicas(
+	db *gorm.DB,
+	driver string,
+	urls []string,
+	newGormConfig func() *gorm.Config,
+	openFunction func(dsn string) gorm.Dialector,
+) ([]*replica, error) {
+	// Open replicas
+	replicas, dialectors, err := openReplicas(driver, urls, newGormConfig, openFunction)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	// Inject db resolver configuration
+	err = db.Use(dbresolver.Register(dbresolver.Config{
+		Replicas: dialectors,
+		// Replicas load balancing policy ignoring ejected ones
+		Policy: &replicaPolicy{replicas: replicas},
+		// print sources/replicas mode in logger
+		TraceResolverMode: true,
+	}))
+	// Check error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	// Register callbacks to track writes
+	err = sdb.registerWriteCallbacks(db)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	return replicas, nil
+}
+
+// registerWriteCallbacks will register gorm callbacks to track writes for read your writes.
+func (sdb *sqldb) registerWriteCallbacks(db *gorm.DB) error {
+	// Create callback
+	fn := func(tx *gorm.DB) {
+		// Check error
+		if tx.Error == nil {
+			sdb.markWrite(tx.Statement.Context)
+		}
+	}
+
+	// Register on all write operations
+	err := db.Callback().Create().After("gorm:create").Register("read-your-writes:create", fn)
+	// Check error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	err = db.Callback().Update().After("gorm:update").Register("read-your-writes:update", fn)
+	// Check error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	err = db.Callback().Delete().After("gorm:delete").Register("read-your-writes:delete", fn)
+	// Check error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Raw callback is used by Exec
+	err = db.Callback().Raw().After("gorm:raw").Register("read-your-writes:raw", fn)
+	// Check error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func closeReplicas(replicas []*replica) error {
+	// Loop over replicas
+	for _, r := range replicas {
+		// Get sql db
+		sqlDB, err := r.db.DB()
+		// Check error
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		// Close
+		err = sqlDB.Close()
+		// Check error
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,269 @@
+//go:build unit
+
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/log"
+	metricsmocks "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/metrics/mocks"
+)
+
+type replicaTestPeople struct {
+	ID   string
+	Name string
+}
+
+// setupReplicaTestDB will create a primary and replicas databases.
+// Each database contains a people with the database name to know where a read was done.
+func setupReplicaTestDB(t *testing.T, replicaNames ...string) *sqldb {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	newGormConfig := func() *gorm.Config { return &gorm.Config{Logger: logger.Discard} }
+
+	// Create databases
+	urls := make([]string, 0, len(replicaNames))
+	for _, name := range append([]string{"primary"}, replicaNames...) {
+		u := filepath.Join(dir, name+".db")
+
+		db, err := gorm.Open(sqlite.Open(u), newGormConfig())
+		require.NoError(t, err)
+		require.NoError(t, db.AutoMigrate(&replicaTestPeople{}))
+		require.NoError(t, db.Create(&replicaTestPeople{ID: "origin", Name: name}).Error)
+
+		sqlDB, err := db.DB()
+		require.NoError(t, err)
+		require.NoError(t, sqlDB.Close())
+
+		if name != "primary" {
+			urls = append(urls, u)
+		}
+	}
+
+	// Open primary
+	gdb, err := gorm.Open(sqlite.Open(filepath.Join(dir, "primary.db")), newGormConfig())
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	metricsSvc := metricsmocks.NewMockService(ctrl)
+	metricsSvc.EXPECT().IncreaseDatabaseTransactionAttempt(gomock.Any(), gomock.Any()).AnyTimes()
+
+	sdb := &sqldb{
+		logger:               log.NewLogger(),
+		metricsSvc:           metricsSvc,
+		connectionName:       "main",
+		readYourWritesWindow: time.Minute,
+	}
+	sdb.SetGormDB(gdb)
+
+	// Register replicas
+	sdb.replicas, err = sdb.registerReplicas(gdb, SqliteDriverSelector, urls, newGormConfig, sqlite.Open)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = sdb.Close() })
+
+	return sdb
+}
+
+func readReplicaTestSource(t *testing.T, ctx context.Context, sdb *sqldb) string {
+	t.Helper()
+
+	var res replicaTestPeople
+
+	require.NoError(t, sdb.GetTransactionalOrDefaultGormDB(ctx).Where("id = ?", "origin").First(&res).Error)
+
+	return res.Name
+}
+
+func TestReplicaRouting_Default(t *testing.T) {
+	sdb := setupReplicaTestDB(t, "replica")
+
+	// Reads are done on replica
+	assert.Equal(t, "replica", readReplicaTestSource(t, context.TODO(), sdb))
+	// Reads are done on primary when forced
+	assert.Equal(t, "primary", readReplicaTestSource(t, WithPrimary(context.TODO()), sdb))
+}
+
+func TestReplicaRouting_WithoutReplicas(t *testing.T) {
+	sdb := setupReplicaTestDB(t)
+
+	assert.Equal(t, "primary", readReplicaTestSource(t, context.TODO(), sdb))
+	assert.Equal(t, "primary", readReplicaTestSource(t, WithPrimary(context.TODO()), sdb))
+}
+
+func TestReplicaRouting_ReadYourWrites(t *testing.T) {
+	sdb := setupReplicaTestDB(t, "replica")
+
+	ctx := NewReadYourWritesContext(context.TODO(), "")
+
+	// Reads are done on replica before any write
+	assert.Equal(t, "replica", readReplicaTestSource(t, ctx, sdb))
+
+	// Write
+	require.NoError(t, sdb.GetTransactionalOrDefaultGormDB(ctx).Create(&replicaTestPeople{ID: "new"}).Error)
+
+	// Reads are now done on primary
+	assert.Equal(t, "primary", readReplicaTestSource(t, ctx, sdb))
+
+	// Read transactions too
+	err := sdb.ExecuteTransaction(ctx, func(ctx context.Context) error {
+		assert.Equal(t, "primary", readReplicaTestSource(t, ctx, sdb))
+
+		return nil
+	}, WithReadTransactionOpt)
+	require.NoError(t, err)
+
+	// Another request isn't impacted
+	assert.Equal(t, "replica", readReplicaTestSource(t, NewReadYourWritesContext(context.TODO(), ""), sdb))
+
+	// Reads are done on replica when window is over
+	getReadYourWritesTrackerFromContext(ctx).lastWrites.Store("main", time.Now().Add(-2*time.Minute).UnixNano())
+	assert.Equal(t, "replica", readReplicaTestSource(t, ctx, sdb))
+}
+
+func TestReplicaRouting_ReadYourWritesInTransaction(t *testing.T) {
+	sdb := setupReplicaTestDB(t, "replica")
+
+	ctx := NewReadYourWritesContext(context.TODO(), "")
+
+	// Write in transaction
+	err := sdb.ExecuteTransaction(ctx, func(ctx context.Context) error {
+		return sdb.GetTransactionalOrDefaultGormDB(ctx).Exec("UPDATE replica_test_peoples SET name = ?", "primary-updated").Error
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "primary-updated", readReplicaTestSource(t, ctx, sdb))
+}
+
+func TestReplicaRouting_ReadYourWritesSession(t *testing.T) {
+	sdb := setupReplicaTestDB(t, "replica")
+
+	// Write in a first request
+	ctx := NewReadYourWritesContext(context.TODO(), "user1")
+	require.NoError(t, sdb.GetTransactionalOrDefaultGormDB(ctx).Create(&replicaTestPeople{ID: "new"}).Error)
+
+	// Reads of another request of same session are done on primary
+	assert.Equal(t, "primary", readReplicaTestSource(t, NewReadYourWritesContext(context.TODO(), "user1"), sdb))
+	// Other sessions aren't impacted
+	assert.Equal(t, "replica", readReplicaTestSource(t, NewReadYourWritesContext(context.TODO(), "user2"), sdb))
+
+	// Expired session writes are cleaned
+	sdb.sessionWrites.Store("user1", time.Now().Add(-2*time.Minute).UnixNano())
+	require.NoError(t, sdb.CheckReplica(0))
+
+	_, ok := sdb.sessionWrites.Load("user1")
+	assert.False(t, ok)
+	assert.Equal(t, "replica", readReplicaTestSource(t, NewReadYourWritesContext(context.TODO(), "user1"), sdb))
+}
+
+func TestReplicaRouting_Ejection(t *testing.T) {
+	sdb := setupReplicaTestDB(t, "replica1", "replica2")
+
+	// Eject first replica
+	sdb.replicas[0].ejected.Store(true)
+
+	// Reads are always done on second replica
+	for range 20 {
+		assert.Equal(t, "replica2", readReplicaTestSource(t, context.TODO(), sdb))
+	}
+
+	// Eject all replicas
+	sdb.replicas[1].ejected.Store(true)
+
+	assert.Equal(t, "primary", readReplicaTestSource(t, context.TODO(), sdb))
+
+	// A successful check restores replica
+	require.NoError(t, sdb.CheckReplica(1))
+	assert.False(t, sdb.replicas[1].ejected.Load())
+	assert.Equal(t, "replica2", readReplicaTestSource(t, context.TODO(), sdb))
+}
+
+func TestCheckReplica(t *testing.T) {
+	sdb := setupReplicaTestDB(t, "replica")
+
+	// Unknown replica
+	assert.ErrorIs(t, sdb.CheckReplica(1), ErrReplicaNotFound)
+	assert.ErrorIs(t, sdb.CheckReplica(-1), ErrReplicaNotFound)
+
+	// Healthy replica
+	require.NoError(t, sdb.CheckReplica(0))
+	assert.False(t, sdb.replicas[0].ejected.Load())
+
+	// Unreachable replica is ejected
+	sqlDB, err := sdb.replicas[0].db.DB()
+	require.NoError(t, err)
+	require.NoError(t, sqlDB.Close())
+
+	require.Error(t, sdb.CheckReplica(0))
+	assert.True(t, sdb.replicas[0].ejected.Load())
+	assert.Equal(t, "primary", readReplicaTestSource(t, context.TODO(), sdb))
+}
+
+func TestMeasureReplicationLag_MySQL(t *testing.T) {
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		want    time.Duration
+		wantErr bool
+	}{
+		{
+			name: "not a replica",
+			rows: sqlmock.NewRows([]string{"Seconds_Behind_Source"}),
+		},
+		{
+			name: "lagging replica",
+			rows: sqlmock.NewRows([]string{"Replica_IO_State", "Seconds_Behind_Source"}).AddRow("Waiting", "12"),
+			want: 12 * time.Second,
+		},
+		{
+			name: "lagging mariadb replica",
+			rows: sqlmock.NewRows([]string{"Slave_IO_State", "Seconds_Behind_Master"}).AddRow("Waiting", "3"),
+			want: 3 * time.Second,
+		},
+		{
+			name:    "stopped replication",
+			rows:    sqlmock.NewRows([]string{"Seconds_Behind_Source"}).AddRow(nil),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			require.NoError(t, err)
+			defer sqlDB.Close()
+
+			db, err := gorm.Open(
+				mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}),
+				&gorm.Config{Logger: logger.Discard},
+			)
+			require.NoError(t, err)
+
+			mock.ExpectQuery(mysqlReplicaStatusQuery).WillReturnRows(tt.rows)
+
+			got, err := measureReplicationLag(db)
+			if tt.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
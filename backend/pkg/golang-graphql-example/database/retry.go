@@ -0,0 +1,105 @@
+package database
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/mattn/go-sqlite3"
+)
+
+const (
+	postgresSerializationFailureCode = "40001"
+	postgresDeadlockDetectedCode     = "40P01"
+	mysqlLockWaitTimeoutNumber       = 1205
+	mysqlDeadlockNumber              = 1213
+)
+
+// TransactionRetryPolicy is the policy applied to retry a transaction in error.
+// This is only applied on root transactions as a nested one cannot be retried alone.
+type TransactionRetryPolicy struct {
+	// Classifier used to know if error can be retried.
+	// IsRetryableTransactionError is used when nil.
+	Classifier func(err error) bool
+	// Maximum number of attempts including the first one.
+	MaxAttempts int
+	// Backoff before the first retry, doubled on each attempt.
+	InitialBackoff time.Duration
+	// Maximum backoff.
+	MaxBackoff time.Duration
+}
+
+// DefaultTransactionRetryPolicy is a retry policy that can be used by default.
+var DefaultTransactionRetryPolicy = &TransactionRetryPolicy{
+	MaxAttempts:    3, //nolint:mnd // Default value
+	InitialBackoff: 50 * time.Millisecond,
+	MaxBackoff:     time.Second,
+}
+
+// IsRetryableTransactionError will return true if error is a serialization failure or a deadlock on Postgres,
+// a deadlock or a lock wait timeout on MySQL or a busy database on SQLite.
+func IsRetryableTransactionError(err error) bool {
+	// Check postgres error
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == postgresSerializationFailureCode || pgErr.Code == postgresDeadlockDetectedCode
+	}
+
+	// Check mysql error
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == mysqlDeadlockNumber || mysqlErr.Number == mysqlLockWaitTimeoutNumber
+	}
+
+	// Check sqlite error
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code == sqlite3.ErrBusy
+	}
+
+	return false
+}
+
+func (p *TransactionRetryPolicy) isRetryable(err error) bool {
+	// Check if classifier is overridden
+	if p.Classifier != nil {
+		return p.Classifier(err)
+	}
+
+	return IsRetryableTransactionError(err)
+}
+
+func (p *TransactionRetryPolicy) getBackoff(attempt int) time.Duration {
+	// Compute exponential backoff
+	backoff := p.InitialBackoff << (attempt - 1)
+	// Check maximum and overflow
+	if p.MaxBackoff > 0 && (backoff > p.MaxBackoff || backoff <= 0) {
+		backoff = p.MaxBackoff
+	}
+
+	// Check if backoff is valid to add jitter
+	if backoff <= 1 {
+		return backoff
+	}
+
+	// Add jitter to avoid retrying concurrent transactions at the same time
+	half := backoff / 2 //nolint:mnd // Half
+
+	return half + rand.N(backoff-half) //nolint:gosec // No need of a secure random here
+}
+
+func waitTransactionRetryBackoff(ctx context.Context, d time.Duration) error {
+	// Create timer
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return errors.WithStack(ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
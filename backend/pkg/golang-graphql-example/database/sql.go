@@ -3,27 +3,61 @@ package database
 import (
 	"context"
 	"database/sql"
+	"reflect"
 	"strings"
+	"sync"
 	"time"
 
 	"emperror.dev/errors"
 	"github.com/samber/lo"
+	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/plugin/dbresolver"
 
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/config"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/audit"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/log"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/metrics"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/tracing"
 )
 
 var (
-	transactionContextKey = &contextKey{name: "TRANSACTION"}
-	transactionTraceName  = "database:execute-transaction"
+	transactionTraceName        = "database:execute-transaction"
+	transactionAttemptEventName = "database:transaction-attempt"
 )
 
+const (
+	transactionAttemptSuccessStatus = "success"
+	transactionAttemptRetryStatus   = "retry"
+	transactionAttemptErrorStatus   = "error"
+)
+
+// ErrNestedWriteTransactionInReadTransaction is returned when a write transaction is asked inside a read one.
+var ErrNestedWriteTransactionInReadTransaction = errors.Sentinel(
+	"cannot execute a nested write transaction inside a read transaction, use an independent transaction instead",
+)
+
+// ErrNestedTransactionIsolationLevelConflict is returned when a nested transaction asks for another isolation level.
+var ErrNestedTransactionIsolationLevelConflict = errors.Sentinel(
+	"cannot change isolation level in a nested transaction, use an independent transaction instead",
+)
+
+// ErrConnectionNotFound is returned when a database connection isn't declared in configuration.
+var ErrConnectionNotFound = errors.Sentinel("database connection not found")
+
+// transactionContextKey is scoped by connection name in order to never use
+// a transaction of another connection.
+type transactionContextKey struct {
+	connectionName string
+}
+
+type transactionContext struct {
+	tx      *gorm.DB
+	options *TransactionOptionsConfig
+}
+
 type sqldb struct {
 	logger         log.Logger
 	cfgManager     config.Manager
@@ -31,38 +65,123 @@ type sqldb struct {
 	tracingSvc     tracing.Service
 	db             *gorm.DB
 	connectionName string
+	// Configuration used by current connection
+	connectedCfg *config.DatabaseConfig
+	replicas     []*replica
+	// Last write time by read your writes session key
+	sessionWrites        sync.Map
+	readYourWritesWindow time.Duration
+	replicaMaxLag        time.Duration
 }
 
+// SetTransactionalGormDBToContext will inject a transactional db of main connection in context.
 func SetTransactionalGormDBToContext(ctx context.Context, db *gorm.DB) context.Context {
-	return context.WithValue(ctx, transactionContextKey, db)
+	return setTransactionToContext(ctx, config.MainDatabaseConnectionName, db, nil)
 }
 
+// GetTransactionalGormDBFromContext will return the transactional db of main connection found in context.
 func GetTransactionalGormDBFromContext(ctx context.Context) *gorm.DB {
-	// Get transactional db from context
-	resInt := ctx.Value(transactionContextKey)
+	return getTransactionalGormDBFromContext(ctx, config.MainDatabaseConnectionName)
+}
+
+func setTransactionToContext(
+	ctx context.Context,
+	connectionName string,
+	db *gorm.DB,
+	options *TransactionOptionsConfig,
+) context.Context {
+	return context.WithValue(
+		ctx,
+		transactionContextKey{connectionName: connectionName},
+		&transactionContext{tx: db, options: options},
+	)
+}
+
+func getTransactionContext(ctx context.Context, connectionName string) *transactionContext {
+	// Get transaction from context
+	res, _ := ctx.Value(transactionContextKey{connectionName: connectionName}).(*transactionContext)
 
+	return res
+}
+
+func getTransactionalGormDBFromContext(ctx context.Context, connectionName string) *gorm.DB {
+	// Get transaction from context
+	res := getTransactionContext(ctx, connectionName)
 	// Check if exists
-	if resInt == nil {
+	if res == nil {
 		return nil
 	}
 
-	// Cast it
-	res, _ := resInt.(*gorm.DB)
+	return res.tx
+}
 
-	return res
+func getTransactionOptionsFromContext(ctx context.Context, connectionName string) *TransactionOptionsConfig {
+	// Get transaction from context
+	res := getTransactionContext(ctx, connectionName)
+	// Check if exists
+	if res == nil {
+		return nil
+	}
+
+	return res.options
 }
 
+// ExecuteTransaction will execute callback in a transaction.
+// When a transaction already exists in context, a savepoint is created in it
+// and only the callback work is rolled back on error.
+// WithNewTransactionOpt can be used to create an independent transaction instead.
 func (sdb *sqldb) ExecuteTransaction(
 	ctx context.Context,
 	cb func(context.Context) error,
 	opts ...TransactionOption,
 ) error {
+	// Create options
+	optCfg := &TransactionOptionsConfig{}
+	// Apply options
+	for _, fn := range opts {
+		fn(optCfg)
+	}
+
+	// Get parent transaction
+	parentTx := getTransactionalGormDBFromContext(ctx, sdb.connectionName)
+	// Check if it is a nested transaction
+	nested := parentTx != nil && !optCfg.NewTransaction
+	// Manage nested transaction options
+	if nested {
+		// Get parent options
+		parentCfg := getTransactionOptionsFromContext(ctx, sdb.connectionName)
+		// Check if they exist
+		if parentCfg != nil {
+			// Check read/write conflict
+			if parentCfg.ReadTransaction && optCfg.WriteTransaction {
+				return errors.WithStack(ErrNestedWriteTransactionInReadTransaction)
+			}
+
+			// Check isolation level conflict
+			if optCfg.IsolationLevel != sql.LevelDefault && optCfg.IsolationLevel != parentCfg.IsolationLevel {
+				return errors.WithStack(ErrNestedTransactionIsolationLevelConflict)
+			}
+
+			// Inherit from parent as a savepoint is running in the same transaction
+			optCfg.ReadTransaction = parentCfg.ReadTransaction
+			optCfg.WriteTransaction = parentCfg.WriteTransaction
+			optCfg.IsolationLevel = parentCfg.IsolationLevel
+		}
+	}
+
 	// Create transaction callback
 	txCb := func(tx *gorm.DB) (err error) {
+		// Track statement timeout of this transaction or savepoint
+		tx = withTransactionStatementTimeout(tx)
 		// Get parent trace
 		parentTrace := tracing.GetTraceFromContext(ctx)
 		// Create child trace
 		cctx, childTrace := parentTrace.GetChildTrace(ctx, transactionTraceName)
+		// Add tags
+		childTrace.SetTags(map[string]any{
+			"database.transaction.nested":    nested,
+			"database.transaction.read-only": optCfg.ReadTransaction,
+		})
 		// Defer close
 		defer func() {
 			// Check error
@@ -73,40 +192,98 @@ func (sdb *sqldb) ExecuteTransaction(
 			childTrace.Finish()
 		}()
 
-		// Inject transactional db in context
-		newCtx := SetTransactionalGormDBToContext(cctx, tx)
+		// Inject transactional db and options in context
+		newCtx := setTransactionToContext(cctx, sdb.connectionName, tx, optCfg)
 
 		// Callback
 		return cb(newCtx)
 	}
 
-	// Create options
-	optCfg := &TransactionOptionsConfig{}
-	// Apply options
-	for _, fn := range opts {
-		fn(optCfg)
+	// Check if it is a nested transaction
+	if nested {
+		// Savepoint may change or restore statement timeout of parent transaction
+		defer resetTransactionStatementTimeout(parentTx)
+
+		// Gorm will create a savepoint as db is already a transaction
+		// and will rollback to it on error or panic
+		return parentTx.WithContext(ctx).Transaction(txCb)
 	}
 
-	db := sdb.GetTransactionalOrDefaultGormDB(ctx)
-	sqlOpts := &sql.TxOptions{}
-	// Apply clauses for read transaction
-	if optCfg.ReadTransaction {
-		db = db.Clauses(dbresolver.Read)
-		// Mark sql options too
-		sqlOpts.ReadOnly = true
-	} else {
-		db = db.Clauses(dbresolver.Write)
+	sqlOpts := &sql.TxOptions{
+		// Mark sql options for read transaction
+		ReadOnly: optCfg.ReadTransaction,
+		// Add isolation
+		Isolation: optCfg.IsolationLevel,
 	}
 
-	// Add isolation
-	sqlOpts.Isolation = optCfg.IsolationLevel
+	// Loop over attempts
+	for attempt := 1; ; attempt++ {
+		// Get root db to start a new transaction
+		db := sdb.GetGormDB().WithContext(ctx)
+		// Apply clauses for read transaction
+		// Reads are done on primary if it is forced or after a write
+		if optCfg.ReadTransaction && !sdb.shouldUsePrimary(ctx) {
+			db = db.Clauses(dbresolver.Read)
+		} else {
+			db = db.Clauses(dbresolver.Write)
+		}
+
+		// Execute transaction
+		err := db.Transaction(txCb, sqlOpts)
+		// Compute attempt status
+		status := transactionAttemptSuccessStatus
+		// Check if it can be retried
+		if err != nil {
+			status = transactionAttemptErrorStatus
+			if optCfg.RetryPolicy != nil &&
+				attempt < optCfg.RetryPolicy.MaxAttempts &&
+				optCfg.RetryPolicy.isRetryable(err) {
+				status = transactionAttemptRetryStatus
+			}
+		}
+
+		// Count attempt
+		sdb.metricsSvc.IncreaseDatabaseTransactionAttempt(sdb.connectionName, status)
+
+		// Prepare attempt event with its outcome
+		eventAttributes := map[string]any{
+			"attempt": attempt,
+			"status":  status,
+		}
+		// Check error
+		if err != nil {
+			eventAttributes["error"] = err.Error()
+		}
+
+		// Get backoff when attempt will be retried
+		var backoff time.Duration
+		if status == transactionAttemptRetryStatus {
+			backoff = optCfg.RetryPolicy.getBackoff(attempt)
+			eventAttributes["backoff"] = backoff.String()
+		}
+
+		// Record first, retried and final attempts in trace
+		tracing.GetTraceFromContext(ctx).AddEvent(transactionAttemptEventName, tracing.WithEventAttributes(eventAttributes))
 
-	return db.Transaction(txCb, sqlOpts)
+		// Check if it is the final attempt
+		if status != transactionAttemptRetryStatus {
+			return err
+		}
+
+		sdb.logger.Debugf("Retrying transaction after attempt %d in error: %v", attempt, err)
+
+		// Wait before next attempt
+		err = waitTransactionRetryBackoff(ctx, backoff)
+		// Check error
+		if err != nil {
+			return err
+		}
+	}
 }
 
 func (sdb *sqldb) GetTransactionalOrDefaultGormDB(ctx context.Context) *gorm.DB {
 	// Get transactional gorm db in context
-	tx := GetTransactionalGormDBFromContext(ctx)
+	tx := getTransactionalGormDBFromContext(ctx, sdb.connectionName)
 
 	// Check if last exists
 	if tx != nil {
@@ -114,8 +291,14 @@ func (sdb *sqldb) GetTransactionalOrDefaultGormDB(ctx context.Context) *gorm.DB
 		return tx.WithContext(ctx)
 	}
 
-	// Default
-	return sdb.GetGormDB().WithContext(ctx)
+	// Get default db
+	db := sdb.GetGormDB().WithContext(ctx)
+	// Check if reads must be done on primary
+	if sdb.shouldUsePrimary(ctx) {
+		db = db.Clauses(dbresolver.Write)
+	}
+
+	return db
 }
 
 func (sdb *sqldb) GetSQLDB() (*sql.DB, error) {
@@ -130,16 +313,20 @@ func (sdb *sqldb) GetGormDB() *gorm.DB {
 // Connect will connect to database engine.
 func (sdb *sqldb) Connect() error {
 	// Get configuration
-	cfg := sdb.cfgManager.GetConfig()
+	cfg := sdb.cfgManager.GetConfig().GetDatabaseConfig(sdb.connectionName)
+	// Check if connection is declared
+	if cfg == nil {
+		return errors.Wrapf(ErrConnectionNotFound, "connection %s", sdb.connectionName)
+	}
 
 	var sqlConnectionMaxLifetimeDuration time.Duration
 	// Try to parse sql connection max lifetime duration
-	if cfg.Database.SQLConnectionMaxLifetimeDuration != "" {
+	if cfg.SQLConnectionMaxLifetimeDuration != "" {
 		// Initialize
 		var err error
 		// Parse time
 		sqlConnectionMaxLifetimeDuration, err = time.ParseDuration(
-			cfg.Database.SQLConnectionMaxLifetimeDuration,
+			cfg.SQLConnectionMaxLifetimeDuration,
 		)
 		// Check error
 		if err != nil {
@@ -147,61 +334,88 @@ func (sdb *sqldb) Connect() error {
 		}
 	}
 
+	// Parse replica durations
+	readYourWritesWindow, err := parseOptionalDuration(cfg.ReplicaReadYourWritesWindow)
+	// Check error
+	if err != nil {
+		return err
+	}
+
+	replicaMaxLag, err := parseOptionalDuration(cfg.ReplicaMaxLag)
+	// Check error
+	if err != nil {
+		return err
+	}
+
+	// Parse query durations
+	statementTimeout, err := parseOptionalDuration(cfg.StatementTimeout)
+	// Check error
+	if err != nil {
+		return err
+	}
+
+	slowQueryThreshold, err := parseOptionalDuration(cfg.SlowQueryThreshold)
+	// Check error
+	if err != nil {
+		return err
+	}
+
 	// Create gorm configuration
-	gcfg := &gorm.Config{
-		// Insert now function to be sure that automatic dates are in UTC
-		NowFunc: func() time.Time {
-			return time.Now().UTC()
-		},
-		// Add logger
-		Logger: sdb.logger.GetGormLogger(),
-		// Disable foreign key constraint when migrating
-		DisableForeignKeyConstraintWhenMigrating: cfg.Database.DisableForeignKeyWhenMigrating,
-		// Allow global update
-		AllowGlobalUpdate: cfg.Database.AllowGlobalUpdate,
-		// Prepare statement for caching
-		PrepareStmt: cfg.Database.PrepareStatement,
-	}
-
-	// Select postgres driver
-	openFunction := postgres.Open
-	// Check if sqlite driver is selected
-	if cfg.Database.Driver == SqliteDriverSelector {
-		openFunction = sqlite.Open
+	// A function is used because gorm is modifying configuration on open
+	newGormConfig := func() *gorm.Config {
+		return &gorm.Config{
+			// Insert now function to be sure that automatic dates are in UTC
+			NowFunc: func() time.Time {
+				return time.Now().UTC()
+			},
+			// Add logger
+			Logger: sdb.logger.GetGormLogger(&log.GormLoggerOptions{
+				SlowQueryThreshold: slowQueryThreshold,
+				OnSlowQuery: func() {
+					sdb.metricsSvc.IncreaseDatabaseSlowQuery(sdb.connectionName)
+				},
+			}),
+			// Disable foreign key constraint when migrating
+			DisableForeignKeyConstraintWhenMigrating: cfg.DisableForeignKeyWhenMigrating,
+			// Allow global update
+			AllowGlobalUpdate: cfg.AllowGlobalUpdate,
+			// Prepare statement for caching
+			PrepareStmt: cfg.PrepareStatement,
+		}
 	}
 
+	// Select driver
+	openFunction := getOpenFunction(cfg.Driver)
+
 	// Trim url
-	sURL := strings.TrimSpace(cfg.Database.ConnectionURL.Value)
+	sURL := strings.TrimSpace(cfg.ConnectionURL.Value)
 
-	sdb.logger.Debugf("Trying to connect to database engine of type %s", cfg.Database.Driver)
+	sdb.logger.Debugf("Trying to connect to database %s engine of type %s", sdb.connectionName, cfg.Driver)
 	// Connect to database
-	dbResult, err := gorm.Open(openFunction(sURL), gcfg)
+	dbResult, err := gorm.Open(openFunction(sURL), newGormConfig())
 	// Check if error exists
 	if err != nil {
 		return errors.WithStack(err)
 	}
 
+	// Initialize replicas
+	var replicas []*replica
 	// Check if there are replica in configuration
-	if len(cfg.Database.ReplicaConnectionURLs) != 0 {
-		// Create connections list
-		conns := lo.Map(
-			cfg.Database.ReplicaConnectionURLs,
-			func(sc *config.CredentialConfig, _ int) gorm.Dialector {
-				return openFunction(strings.TrimSpace(sc.Value))
-			},
+	if len(cfg.ReplicaConnectionURLs) != 0 {
+		// Register replicas
+		replicas, err = sdb.registerReplicas(
+			dbResult,
+			cfg.Driver,
+			lo.Map(
+				cfg.ReplicaConnectionURLs,
+				func(sc *config.CredentialConfig, _ int) string { return strings.TrimSpace(sc.Value) },
+			),
+			newGormConfig,
+			openFunction,
 		)
-
-		// Inject db resolver configuration
-		err = dbResult.Use(dbresolver.Register(dbresolver.Config{
-			Replicas: conns,
-			// sources/replicas load balancing policy
-			Policy: dbresolver.RandomPolicy{},
-			// print sources/replicas mode in logger
-			TraceResolverMode: true,
-		}))
 		// Check error
 		if err != nil {
-			return errors.WithStack(err)
+			return err
 		}
 	}
 
@@ -221,6 +435,20 @@ func (sdb *sqldb) Connect() error {
 		return errors.WithStack(err)
 	}
 
+	// Apply audit middleware
+	err = dbResult.Use(audit.NewPlugin())
+	// Check if error exists
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Apply timeout middleware
+	err = dbResult.Use(newTimeoutPlugin(statementTimeout))
+	// Check if error exists
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
 	// Trying to ping database
 	sqlDB, err := dbResult.DB()
 	// Check error
@@ -236,27 +464,33 @@ func (sdb *sqldb) Connect() error {
 	}
 
 	// Check if max idle connections exists
-	if cfg.Database.SQLMaxIdleConnections != 0 {
+	if cfg.SQLMaxIdleConnections != 0 {
 		// SetMaxIdleConns sets the maximum number of connections in the idle connection pool.
-		sqlDB.SetMaxIdleConns(cfg.Database.SQLMaxIdleConnections)
+		sqlDB.SetMaxIdleConns(cfg.SQLMaxIdleConnections)
 	}
 
 	// Check if max opened connections exists
-	if cfg.Database.SQLMaxOpenConnections != 0 {
+	if cfg.SQLMaxOpenConnections != 0 {
 		// SetMaxOpenConns sets the maximum number of open connections to the database.
-		sqlDB.SetMaxOpenConns(cfg.Database.SQLMaxOpenConnections)
+		sqlDB.SetMaxOpenConns(cfg.SQLMaxOpenConnections)
 	}
 
 	// Check if connection max lifetime exists
-	if cfg.Database.SQLConnectionMaxLifetimeDuration != "" {
+	if cfg.SQLConnectionMaxLifetimeDuration != "" {
 		// SetConnMaxLifetime sets the maximum amount of time a connection may be reused.
 		sqlDB.SetConnMaxLifetime(sqlConnectionMaxLifetimeDuration)
 	}
 
 	// Save gorm db object
 	sdb.db = dbResult
+	// Save configuration used
+	sdb.connectedCfg = cloneDatabaseConfig(cfg)
+	// Save replicas
+	sdb.replicas = replicas
+	sdb.readYourWritesWindow = readYourWritesWindow
+	sdb.replicaMaxLag = replicaMaxLag
 
-	sdb.logger.Infof("Successfully connected to database engine of type %s", cfg.Database.Driver)
+	sdb.logger.Infof("Successfully connected to database %s engine of type %s", sdb.connectionName, cfg.Driver)
 
 	// Return
 	return nil
@@ -264,7 +498,7 @@ func (sdb *sqldb) Connect() error {
 
 // Close will close connection to database.
 func (sdb *sqldb) Close() error {
-	sdb.logger.Info("Closing database connection")
+	sdb.logger.Infof("Closing database %s connection", sdb.connectionName)
 	// Get sql database
 	sqlDB, err := sdb.db.DB()
 	// Check error
@@ -279,8 +513,8 @@ func (sdb *sqldb) Close() error {
 		return errors.WithStack(err)
 	}
 
-	// Default
-	return nil
+	// Close replicas connections
+	return closeReplicas(sdb.replicas)
 }
 
 // Ping will ping database engine in order to test connection to engine.
@@ -308,6 +542,8 @@ func (sdb *sqldb) Reconnect() error {
 	if err != nil {
 		return errors.WithStack(err)
 	}
+	// Get old replicas
+	oldReplicas := sdb.replicas
 	// Connect to new database
 	err = sdb.Connect()
 	// Check error
@@ -326,5 +562,73 @@ func (sdb *sqldb) Reconnect() error {
 		return errors.WithStack(err)
 	}
 
-	return nil
+	// Closing old replicas connections
+	return closeReplicas(oldReplicas)
+}
+
+// getOpenFunction will return the gorm open function of driver.
+func getOpenFunction(driver string) func(dsn string) gorm.Dialector {
+	switch driver {
+	case SqliteDriverSelector:
+		return sqlite.Open
+	case MysqlDriverSelector:
+		return mysql.Open
+	default:
+		return postgres.Open
+	}
+}
+
+func parseOptionalDuration(s string) (time.Duration, error) {
+	// Check if it is set
+	if s == "" {
+		return 0, nil
+	}
+
+	// Parse
+	res, err := time.ParseDuration(s)
+	// Check error
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	return res, nil
+}
+
+// hasConfigurationChanged will return true when configuration of connection isn't the one used by current connection.
+func (sdb *sqldb) hasConfigurationChanged() bool {
+	return !reflect.DeepEqual(
+		sdb.connectedCfg,
+		sdb.cfgManager.GetConfig().GetDatabaseConfig(sdb.connectionName),
+	)
+}
+
+// cloneDatabaseConfig will deep copy a database configuration.
+// Credentials are copied as their values can be reloaded in place.
+func cloneDatabaseConfig(in *config.DatabaseConfig) *config.DatabaseConfig {
+	// Copy
+	res := *in
+
+	// Copy credentials
+	res.ConnectionURL = cloneCredentialConfig(in.ConnectionURL)
+	// Check if there are replicas to keep nil slice
+	if in.ReplicaConnectionURLs != nil {
+		res.ReplicaConnectionURLs = lo.Map(
+			in.ReplicaConnectionURLs,
+			func(c *config.CredentialConfig, _ int) *config.CredentialConfig { return cloneCredentialConfig(c) },
+		)
+	}
+
+	return &res
+}
+
+func cloneCredentialConfig(in *config.CredentialConfig) *config.CredentialConfig {
+	// Check nil
+	if in == nil {
+		return nil
+	}
+
+	// Copy
+	res := *in
+
+	return &res
 }
@@ -0,0 +1,503 @@
+//go:build unit
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/mock/gomock"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/log"
+	metricsmocks "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/metrics/mocks"
+)
+
+type transactionTestPeople struct {
+	ID   string
+	Name string
+}
+
+var errTransactionTest = errors.Sentinel("fake")
+
+func setupTransactionTestDB(t *testing.T) *sqldb {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	metricsSvc := metricsmocks.NewMockService(ctrl)
+	metricsSvc.EXPECT().IncreaseDatabaseTransactionAttempt(gomock.Any(), gomock.Any()).AnyTimes()
+
+	return setupTransactionTestDBWithMetrics(t, metricsSvc)
+}
+
+func setupTransactionTestDBWithMetrics(t *testing.T, metricsSvc *metricsmocks.MockService) *sqldb {
+	t.Helper()
+
+	// Use a file to share database between connections
+	gdb, err := gorm.Open(
+		sqlite.Open(filepath.Join(t.TempDir(), "test.db")),
+		&gorm.Config{Logger: logger.Discard},
+	)
+	require.NoError(t, err)
+	require.NoError(t, gdb.AutoMigrate(&transactionTestPeople{}))
+
+	sdb := &sqldb{
+		logger:         log.NewLogger(),
+		metricsSvc:     metricsSvc,
+		connectionName: "main",
+	}
+	sdb.SetGormDB(gdb)
+
+	return sdb
+}
+
+func createTransactionTestPeople(ctx context.Context, sdb *sqldb, id string) error {
+	return sdb.GetTransactionalOrDefaultGormDB(ctx).Create(&transactionTestPeople{ID: id, Name: id}).Error
+}
+
+func getTransactionTestPeopleIDs(t *testing.T, sdb *sqldb) []string {
+	t.Helper()
+
+	var res []string
+
+	require.NoError(t, sdb.GetGormDB().Model(&transactionTestPeople{}).Order("id").Pluck("id", &res).Error)
+
+	return res
+}
+
+func TestExecuteTransaction_NestedRollbackToSavepoint(t *testing.T) {
+	sdb := setupTransactionTestDB(t)
+
+	err := sdb.ExecuteTransaction(context.TODO(), func(ctx context.Context) error {
+		// Create in outer transaction
+		err := createTransactionTestPeople(ctx, sdb, "1")
+		if err != nil {
+			return err
+		}
+
+		// Nested transaction in error
+		err = sdb.ExecuteTransaction(ctx, func(ctx context.Context) error {
+			err := createTransactionTestPeople(ctx, sdb, "2")
+			if err != nil {
+				return err
+			}
+
+			return errTransactionTest
+		})
+		assert.ErrorIs(t, err, errTransactionTest)
+
+		// Nested transaction in success
+		return sdb.ExecuteTransaction(ctx, func(ctx context.Context) error {
+			return createTransactionTestPeople(ctx, sdb, "3")
+		})
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"1", "3"}, getTransactionTestPeopleIDs(t, sdb))
+}
+
+func TestExecuteTransaction_NestedPanicRollbackToSavepoint(t *testing.T) {
+	sdb := setupTransactionTestDB(t)
+
+	err := sdb.ExecuteTransaction(context.TODO(), func(ctx context.Context) error {
+		err := createTransactionTestPeople(ctx, sdb, "1")
+		if err != nil {
+			return err
+		}
+
+		assert.Panics(t, func() {
+			_ = sdb.ExecuteTransaction(ctx, func(ctx context.Context) error {
+				err := createTransactionTestPeople(ctx, sdb, "2")
+				if err != nil {
+					return err
+				}
+
+				panic("fake")
+			})
+		})
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"1"}, getTransactionTestPeopleIDs(t, sdb))
+}
+
+func TestExecuteTransaction_OuterRollbackIncludesNested(t *testing.T) {
+	sdb := setupTransactionTestDB(t)
+
+	err := sdb.ExecuteTransaction(context.TODO(), func(ctx context.Context) error {
+		err := sdb.ExecuteTransaction(ctx, func(ctx context.Context) error {
+			return createTransactionTestPeople(ctx, sdb, "1")
+		})
+		if err != nil {
+			return err
+		}
+
+		return errTransactionTest
+	})
+	assert.ErrorIs(t, err, errTransactionTest)
+
+	assert.Empty(t, getTransactionTestPeopleIDs(t, sdb))
+}
+
+func TestExecuteTransaction_NewTransaction(t *testing.T) {
+	sdb := setupTransactionTestDB(t)
+
+	err := sdb.ExecuteTransaction(context.TODO(), func(ctx context.Context) error {
+		// Independent transaction must be committed even if outer one is rolled back
+		err := sdb.ExecuteTransaction(ctx, func(ctx context.Context) error {
+			return createTransactionTestPeople(ctx, sdb, "1")
+		}, WithNewTransactionOpt)
+		if err != nil {
+			return err
+		}
+
+		return errTransactionTest
+	})
+	assert.ErrorIs(t, err, errTransactionTest)
+
+	assert.Equal(t, []string{"1"}, getTransactionTestPeopleIDs(t, sdb))
+}
+
+func TestExecuteTransaction_NestedOptions(t *testing.T) {
+	tests := []struct {
+		name       string
+		outerOpts  []TransactionOption
+		nestedOpts []TransactionOption
+		wantErr    error
+		wantRead   bool
+	}{
+		{
+			name:     "default inside default",
+			wantRead: false,
+		},
+		{
+			name:      "default inside read",
+			outerOpts: []TransactionOption{WithReadTransactionOpt},
+			wantRead:  true,
+		},
+		{
+			name:       "read inside write",
+			outerOpts:  []TransactionOption{WithWriteTransactionOpt},
+			nestedOpts: []TransactionOption{WithReadTransactionOpt},
+			wantRead:   false,
+		},
+		{
+			name:       "write inside read",
+			outerOpts:  []TransactionOption{WithReadTransactionOpt},
+			nestedOpts: []TransactionOption{WithWriteTransactionOpt},
+			wantErr:    ErrNestedWriteTransactionInReadTransaction,
+		},
+		{
+			name:       "independent write inside read",
+			outerOpts:  []TransactionOption{WithReadTransactionOpt},
+			nestedOpts: []TransactionOption{WithWriteTransactionOpt, WithNewTransactionOpt},
+			wantRead:   false,
+		},
+		{
+			name:       "same isolation level",
+			outerOpts:  []TransactionOption{WithIsolationLevelOpt(sql.LevelSerializable)},
+			nestedOpts: []TransactionOption{WithIsolationLevelOpt(sql.LevelSerializable)},
+		},
+		{
+			name:       "another isolation level",
+			outerOpts:  []TransactionOption{WithIsolationLevelOpt(sql.LevelSerializable)},
+			nestedOpts: []TransactionOption{WithIsolationLevelOpt(sql.LevelReadCommitted)},
+			wantErr:    ErrNestedTransactionIsolationLevelConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sdb := setupTransactionTestDB(t)
+
+			called := false
+
+			err := sdb.ExecuteTransaction(context.TODO(), func(ctx context.Context) error {
+				return sdb.ExecuteTransaction(ctx, func(ctx context.Context) error {
+					called = true
+
+					// Check options
+					assert.Equal(t, tt.wantRead, getTransactionOptionsFromContext(ctx, "main").ReadTransaction)
+
+					return nil
+				}, tt.nestedOpts...)
+			}, tt.outerOpts...)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.False(t, called)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, called)
+		})
+	}
+}
+
+func TestExecuteTransaction_Retry(t *testing.T) {
+	busyErr := sqlite3.Error{Code: sqlite3.ErrBusy}
+
+	policy := &TransactionRetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+
+	tests := []struct {
+		name          string
+		opts          []TransactionOption
+		errors        []error
+		wantAttempts  int
+		wantErr       error
+		wantIDs       []string
+		wantSuccesses int
+		wantRetries   int
+		wantErrors    int
+	}{
+		{
+			name:         "no retry policy",
+			errors:       []error{busyErr},
+			wantAttempts: 1,
+			wantErr:      busyErr,
+			wantErrors:   1,
+			wantIDs:      []string{},
+		},
+		{
+			name:          "success after retries",
+			opts:          []TransactionOption{WithRetryPolicyOpt(policy)},
+			errors:        []error{busyErr, busyErr},
+			wantAttempts:  3,
+			wantIDs:       []string{"3"},
+			wantRetries:   2,
+			wantSuccesses: 1,
+		},
+		{
+			name:         "max attempts reached",
+			opts:         []TransactionOption{WithRetryPolicyOpt(policy)},
+			errors:       []error{busyErr, busyErr, busyErr},
+			wantAttempts: 3,
+			wantErr:      busyErr,
+			wantIDs:      []string{},
+			wantRetries:  2,
+			wantErrors:   1,
+		},
+		{
+			name:         "not retryable error",
+			opts:         []TransactionOption{WithRetryPolicyOpt(policy)},
+			errors:       []error{errTransactionTest},
+			wantAttempts: 1,
+			wantErr:      errTransactionTest,
+			wantIDs:      []string{},
+			wantErrors:   1,
+		},
+		{
+			name: "custom classifier",
+			opts: []TransactionOption{WithRetryPolicyOpt(&TransactionRetryPolicy{
+				MaxAttempts: 2,
+				Classifier:  func(err error) bool { return errors.Is(err, errTransactionTest) },
+			})},
+			errors:        []error{errTransactionTest},
+			wantAttempts:  2,
+			wantIDs:       []string{"2"},
+			wantRetries:   1,
+			wantSuccesses: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			metricsSvc := metricsmocks.NewMockService(ctrl)
+			metricsSvc.EXPECT().IncreaseDatabaseTransactionAttempt("main", "success").Times(tt.wantSuccesses)
+			metricsSvc.EXPECT().IncreaseDatabaseTransactionAttempt("main", "retry").Times(tt.wantRetries)
+			metricsSvc.EXPECT().IncreaseDatabaseTransactionAttempt("main", "error").Times(tt.wantErrors)
+
+			sdb := setupTransactionTestDBWithMetrics(t, metricsSvc)
+
+			attempts := 0
+
+			err := sdb.ExecuteTransaction(context.TODO(), func(ctx context.Context) error {
+				attempts++
+
+				// Write to ensure failed attempts are rolled back
+				err := createTransactionTestPeople(ctx, sdb, strconv.Itoa(attempts))
+				if err != nil {
+					return err
+				}
+
+				if attempts <= len(tt.errors) {
+					return tt.errors[attempts-1]
+				}
+
+				return nil
+			}, tt.opts...)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wantAttempts, attempts)
+			assert.ElementsMatch(t, tt.wantIDs, getTransactionTestPeopleIDs(t, sdb))
+		})
+	}
+}
+
+func TestExecuteTransaction_AttemptEvents(t *testing.T) {
+	busyErr := sqlite3.Error{Code: sqlite3.ErrBusy}
+
+	tests := []struct {
+		name       string
+		errors     []error
+		wantStatus []string
+	}{
+		{
+			name:       "first attempt succeeded",
+			wantStatus: []string{"success"},
+		},
+		{
+			name:       "first attempt failed",
+			errors:     []error{errTransactionTest},
+			wantStatus: []string{"error"},
+		},
+		{
+			name:       "final attempt succeeded after retry",
+			errors:     []error{busyErr},
+			wantStatus: []string{"retry", "success"},
+		},
+		{
+			name:       "final attempt failed after retry",
+			errors:     []error{busyErr, busyErr},
+			wantStatus: []string{"retry", "error"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sdb := setupTransactionTestDB(t)
+
+			recorder := tracetest.NewSpanRecorder()
+			ctx, span := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).
+				Tracer("test").
+				Start(context.TODO(), "test")
+
+			attempts := 0
+
+			_ = sdb.ExecuteTransaction(ctx, func(_ context.Context) error {
+				attempts++
+
+				if attempts <= len(tt.errors) {
+					return tt.errors[attempts-1]
+				}
+
+				return nil
+			}, WithRetryPolicyOpt(&TransactionRetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}))
+
+			span.End()
+
+			var status []string
+			// Get attempt events of parent span
+			for _, s := range recorder.Ended() {
+				if s.Name() != "test" {
+					continue
+				}
+
+				for _, ev := range s.Events() {
+					require.Equal(t, transactionAttemptEventName, ev.Name)
+
+					for _, attr := range ev.Attributes {
+						if attr.Key == "status" {
+							status = append(status, attr.Value.AsString())
+						}
+					}
+				}
+			}
+
+			assert.Equal(t, tt.wantStatus, status)
+		})
+	}
+}
+
+func TestExecuteTransaction_RetryNotAppliedOnNested(t *testing.T) {
+	sdb := setupTransactionTestDB(t)
+
+	attempts := 0
+
+	err := sdb.ExecuteTransaction(context.TODO(), func(ctx context.Context) error {
+		return sdb.ExecuteTransaction(ctx, func(_ context.Context) error {
+			attempts++
+
+			return sqlite3.Error{Code: sqlite3.ErrBusy}
+		}, WithRetryPolicyOpt(DefaultTransactionRetryPolicy))
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestExecuteTransaction_RetryContextCanceled(t *testing.T) {
+	sdb := setupTransactionTestDB(t)
+
+	ctx, cancel := context.WithCancel(context.TODO())
+
+	attempts := 0
+
+	err := sdb.ExecuteTransaction(ctx, func(_ context.Context) error {
+		attempts++
+		// Cancel to stop during backoff
+		cancel()
+
+		return sqlite3.Error{Code: sqlite3.ErrBusy}
+	}, WithRetryPolicyOpt(&TransactionRetryPolicy{MaxAttempts: 3, InitialBackoff: time.Minute}))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestIsRetryableTransactionError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "generic", err: errTransactionTest, want: false},
+		{name: "postgres serialization failure", err: &pgconn.PgError{Code: "40001"}, want: true},
+		{name: "postgres deadlock", err: errors.WithStack(&pgconn.PgError{Code: "40P01"}), want: true},
+		{name: "postgres unique violation", err: &pgconn.PgError{Code: "23505"}, want: false},
+		{name: "mysql deadlock", err: errors.WithStack(&mysql.MySQLError{Number: 1213}), want: true},
+		{name: "mysql lock wait timeout", err: &mysql.MySQLError{Number: 1205}, want: true},
+		{name: "mysql duplicate entry", err: &mysql.MySQLError{Number: 1062}, want: false},
+		{name: "sqlite busy", err: sqlite3.Error{Code: sqlite3.ErrBusy}, want: true},
+		{name: "sqlite constraint", err: sqlite3.Error{Code: sqlite3.ErrConstraint}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsRetryableTransactionError(tt.err))
+		})
+	}
+}
+
+func TestTransactionRetryPolicy_getBackoff(t *testing.T) {
+	p := &TransactionRetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond}
+
+	for attempt, want := range map[int]time.Duration{
+		1: 100 * time.Millisecond,
+		2: 200 * time.Millisecond,
+		3: 300 * time.Millisecond,
+		// Overflow must be managed
+		100: 300 * time.Millisecond,
+	} {
+		got := p.getBackoff(attempt)
+		assert.GreaterOrEqual(t, got, want/2)
+		assert.Less(t, got, want)
+	}
+}
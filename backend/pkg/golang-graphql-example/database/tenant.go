@@ -0,0 +1,178 @@
+package database
+
+import (
+	"context"
+	"reflect"
+
+	"emperror.dev/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	cerrors "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/common/errors"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/common/tenant"
+)
+
+// TenantIDColumnName is the tenant column name used by tenant scoped models.
+const TenantIDColumnName = "tenant_id"
+
+// ErrTenantMissing is used when a tenant scoped model is used without any tenant in context.
+var ErrTenantMissing = errors.Sentinel("no tenant found in context")
+
+// ErrTenantMismatch is used when a write is done on an object of another tenant.
+var ErrTenantMismatch = errors.Sentinel("object belongs to another tenant")
+
+var tenantScopedType = reflect.TypeFor[TenantScoped]()
+
+// IsTenantScoped will check if the model is tenant scoped.
+// Model can be an object, a pointer or a slice of them.
+func IsTenantScoped(model any) bool {
+	// Check nil
+	if model == nil {
+		return false
+	}
+
+	// Get type
+	t := reflect.TypeOf(model)
+	// Unwrap pointers and slices
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		// Check if pointer implements interface
+		if t.Implements(tenantScopedType) {
+			return true
+		}
+
+		t = t.Elem()
+	}
+
+	return t.Implements(tenantScopedType) || reflect.PointerTo(t).Implements(tenantScopedType)
+}
+
+// GetTenantFromContext will return the tenant in context or an error if it isn't set.
+func GetTenantFromContext(ctx context.Context) (string, error) {
+	// Get tenant
+	tenantID := tenant.GetFromContext(ctx)
+	// Check if it is set
+	if tenantID == "" {
+		return "", cerrors.NewForbiddenError(ErrTenantMissing.Error())
+	}
+
+	return tenantID, nil
+}
+
+// GetTenantClause will return the tenant column clause for the current table.
+func GetTenantClause(tenantID string) clause.Expression {
+	return clause.Eq{
+		Column: clause.Column{Table: clause.CurrentTable, Name: TenantIDColumnName},
+		Value:  tenantID,
+	}
+}
+
+// ApplyTenantScope will scope the gorm db on the tenant in context if the model is tenant scoped.
+func ApplyTenantScope(ctx context.Context, gdb *gorm.DB, model any) (*gorm.DB, error) {
+	// Check if model is tenant scoped
+	if !IsTenantScoped(model) {
+		return gdb, nil
+	}
+
+	// Get tenant
+	tenantID, err := GetTenantFromContext(ctx)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	return gdb.Where(GetTenantClause(tenantID)), nil
+}
+
+// CheckTenantWrite will check that objects belong to the tenant in context.
+// Objects without tenant will be assigned to the tenant in context.
+// Input can be an object, a pointer or a slice of them.
+func CheckTenantWrite(ctx context.Context, input any) error {
+	// Check if input is tenant scoped
+	if !IsTenantScoped(input) {
+		return nil
+	}
+
+	// Get tenant
+	tenantID, err := GetTenantFromContext(ctx)
+	// Check error
+	if err != nil {
+		return err
+	}
+
+	return checkTenantWriteValue(reflect.ValueOf(input), tenantID)
+}
+
+func checkTenantWriteValue(v reflect.Value, tenantID string) error {
+	// Check if value implements interface
+	if v.Kind() == reflect.Pointer && !v.IsNil() {
+		// Cast
+		if ts, ok := v.Interface().(TenantScoped); ok {
+			// Check if tenant is set
+			if ts.GetTenantID() == "" {
+				ts.SetTenantID(tenantID)
+
+				return nil
+			}
+
+			// Check tenant
+			if ts.GetTenantID() != tenantID {
+				return cerrors.NewForbiddenError(ErrTenantMismatch.Error())
+			}
+
+			return nil
+		}
+	}
+
+	switch v.Kind() { //nolint:exhaustive // Other kinds aren't managed
+	case reflect.Pointer, reflect.Interface:
+		// Ignore nil
+		if v.IsNil() {
+			return nil
+		}
+
+		return checkTenantWriteValue(v.Elem(), tenantID)
+	case reflect.Slice, reflect.Array:
+		// Loop over elements
+		for i := range v.Len() {
+			// Get element
+			el := v.Index(i)
+			// Check if element is addressable to use pointer methods
+			if el.Kind() != reflect.Pointer && el.CanAddr() {
+				el = el.Addr()
+			}
+
+			// Check element
+			err := checkTenantWriteValue(el, tenantID)
+			// Check error
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// CheckTenantOwnership will check that the object, if it has a tenant, belongs to the tenant in context.
+func CheckTenantOwnership(ctx context.Context, input any) error {
+	// Check if input is tenant scoped
+	if !IsTenantScoped(input) {
+		return nil
+	}
+
+	// Get tenant
+	tenantID, err := GetTenantFromContext(ctx)
+	// Check error
+	if err != nil {
+		return err
+	}
+
+	// Cast
+	ts, ok := input.(TenantScoped)
+	// Check if tenant is set and different
+	if ok && ts.GetTenantID() != "" && ts.GetTenantID() != tenantID {
+		return cerrors.NewForbiddenError(ErrTenantMismatch.Error())
+	}
+
+	return nil
+}
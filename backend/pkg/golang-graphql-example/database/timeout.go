@@ -0,0 +1,252 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"runtime"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"emperror.dev/errors"
+	"gorm.io/gorm"
+)
+
+const (
+	timeoutPluginName                     = "database:timeout"
+	statementTimeoutSettingKey            = "database:statement_timeout"
+	transactionStatementTimeoutSettingKey = "database:transaction_statement_timeout"
+	timeoutInstanceKey                    = "database:timeout"
+	postgresDialectorName                 = "postgres"
+)
+
+// unknownTransactionStatementTimeout is used when the statement timeout of a transaction isn't known.
+const unknownTransactionStatementTimeout = -1
+
+var withoutStatementTimeoutContextKey = &contextKey{name: "WITHOUT_STATEMENT_TIMEOUT"}
+
+// timeoutPlugin will bound statements execution time.
+// On Postgres, statements executed in a transaction are bounded with a "SET LOCAL statement_timeout",
+// other statements are bounded with a context deadline.
+type timeoutPlugin struct {
+	// Default timeout, disabled when 0
+	defaultTimeout time.Duration
+}
+
+// statementTimeout is saved in statement instance to restore it after execution.
+type statementTimeout struct {
+	parent context.Context //nolint:containedctx // Needed to restore statement context
+	cancel context.CancelFunc
+}
+
+// transactionStatementTimeout is shared by all statements of a transaction
+// to only set the timeout when it changes.
+type transactionStatementTimeout struct {
+	// Timeout in milliseconds applied in transaction
+	milliseconds atomic.Int64
+}
+
+// WithStatementTimeout will override the statement timeout of the gorm db.
+// A zero timeout will disable it.
+// On Postgres, a timeout set in a transaction stays applied on next statements of this transaction
+// until they set their own.
+func WithStatementTimeout(db *gorm.DB, timeout time.Duration) *gorm.DB {
+	return db.Set(statementTimeoutSettingKey, timeout)
+}
+
+// WithoutStatementTimeout will disable statement timeout for all database requests done with this context.
+// This is used for long operations like migrations.
+func WithoutStatementTimeout(ctx context.Context) context.Context {
+	return context.WithValue(ctx, withoutStatementTimeoutContextKey, true)
+}
+
+// withTransactionStatementTimeout will save the statement timeout state of a new transaction or savepoint.
+func withTransactionStatementTimeout(tx *gorm.DB) *gorm.DB {
+	st := &transactionStatementTimeout{}
+	st.milliseconds.Store(unknownTransactionStatementTimeout)
+
+	// Session is used to keep a reusable instance like the transaction one
+	return tx.Set(transactionStatementTimeoutSettingKey, st).Session(&gorm.Session{})
+}
+
+// resetTransactionStatementTimeout will force the next statement of the transaction to set its timeout.
+// This must be used after a savepoint as it may have changed or restored the timeout.
+func resetTransactionStatementTimeout(tx *gorm.DB) {
+	// Get state
+	st := getTransactionStatementTimeout(tx)
+	// Check if it exists
+	if st != nil {
+		st.milliseconds.Store(unknownTransactionStatementTimeout)
+	}
+}
+
+func newTimeoutPlugin(defaultTimeout time.Duration) gorm.Plugin {
+	return &timeoutPlugin{defaultTimeout: defaultTimeout}
+}
+
+func (*timeoutPlugin) Name() string {
+	return timeoutPluginName
+}
+
+func (p *timeoutPlugin) Initialize(db *gorm.DB) error {
+	// Register callbacks around all other callbacks to include transactions and preloads
+	err := errors.Combine(
+		db.Callback().Create().Before("*").Register("database:timeout:before_create", p.before),
+		db.Callback().Create().After("*").Register("database:timeout:after_create", p.after),
+		db.Callback().Query().Before("*").Register("database:timeout:before_query", p.before),
+		db.Callback().Query().After("*").Register("database:timeout:after_query", p.after),
+		db.Callback().Update().Before("*").Register("database:timeout:before_update", p.before),
+		db.Callback().Update().After("*").Register("database:timeout:after_update", p.after),
+		db.Callback().Delete().Before("*").Register("database:timeout:before_delete", p.before),
+		db.Callback().Delete().After("*").Register("database:timeout:after_delete", p.after),
+		db.Callback().Raw().Before("*").Register("database:timeout:before_raw", p.before),
+		db.Callback().Raw().After("*").Register("database:timeout:after_raw", p.after),
+		db.Callback().Row().Before("*").Register("database:timeout:before_row", p.before),
+		db.Callback().Row().After("*").Register("database:timeout:after_row", p.afterRow),
+	)
+
+	return errors.WithStack(err)
+}
+
+func (p *timeoutPlugin) before(db *gorm.DB) {
+	// Get parent context
+	parent := db.Statement.Context
+	// Check if it exists
+	if parent == nil {
+		parent = context.Background()
+	}
+
+	// Check if timeout is disabled by context
+	if disabled, _ := parent.Value(withoutStatementTimeoutContextKey).(bool); disabled {
+		return
+	}
+
+	// Get default timeout
+	timeout := p.defaultTimeout
+	// Check if it is overridden
+	v, overridden := db.Get(statementTimeoutSettingKey)
+	if overridden {
+		timeout, _ = v.(time.Duration)
+	}
+
+	// Check if it is a postgres transaction
+	if _, isTx := db.Statement.ConnPool.(gorm.TxCommitter); isTx && db.Dialector.Name() == postgresDialectorName {
+		// Check if timeout must be set
+		// A disabled timeout is set only when overridden to remove a previous one in the transaction
+		if timeout <= 0 && !overridden {
+			return
+		}
+
+		// Compute value
+		ms := max(timeout, 0).Milliseconds()
+		// Get transaction state
+		tst := getTransactionStatementTimeout(db)
+		// Check if timeout is already applied in transaction
+		if tst != nil && tst.milliseconds.Load() == ms {
+			return
+		}
+
+		// Set timeout until the end of the transaction
+		_, err := db.Statement.ConnPool.ExecContext(parent, "SET LOCAL statement_timeout = "+strconv.FormatInt(ms, 10))
+		// Check error
+		if err != nil {
+			_ = db.AddError(errors.WithStack(err))
+
+			return
+		}
+
+		// Save applied timeout
+		if tst != nil {
+			tst.milliseconds.Store(ms)
+		}
+
+		return
+	}
+
+	// Check if timeout is enabled
+	if timeout <= 0 {
+		return
+	}
+
+	// Create deadline
+	ctx, cancel := context.WithTimeout(parent, timeout)
+	// Save
+	db.Statement.Context = ctx
+	db.InstanceSet(timeoutInstanceKey, &statementTimeout{parent: parent, cancel: cancel})
+}
+
+func (*timeoutPlugin) after(db *gorm.DB) {
+	// Get statement timeout
+	st := getStatementTimeout(db)
+	// Check if it exists
+	if st == nil {
+		return
+	}
+
+	// Release deadline
+	st.cancel()
+	// Restore context to allow statement reuse
+	db.Statement.Context = st.parent
+}
+
+func (*timeoutPlugin) afterRow(db *gorm.DB) {
+	// Get statement timeout
+	st := getStatementTimeout(db)
+	// Check if it exists
+	if st == nil {
+		return
+	}
+
+	// Restore context to allow statement reuse
+	db.Statement.Context = st.parent
+
+	// Rows are read after callbacks, so deadline cannot be released here when they are returned.
+	// As database/sql doesn't notify rows closing, deadline is released when closed rows are collected.
+	switch dest := db.Statement.Dest.(type) {
+	case *sql.Rows:
+		// Check if query succeeded
+		if db.Error == nil && dest != nil {
+			runtime.AddCleanup(dest, func(cancel context.CancelFunc) { cancel() }, st.cancel)
+
+			return
+		}
+	case *sql.Row:
+		// Check if query succeeded
+		if dest != nil && dest.Err() == nil {
+			runtime.AddCleanup(dest, func(cancel context.CancelFunc) { cancel() }, st.cancel)
+
+			return
+		}
+	}
+
+	// Release deadline as nothing will be read
+	st.cancel()
+}
+
+func getStatementTimeout(db *gorm.DB) *statementTimeout {
+	// Get statement timeout
+	v, ok := db.InstanceGet(timeoutInstanceKey)
+	// Check if it exists
+	if !ok {
+		return nil
+	}
+
+	// Cast
+	st, _ := v.(*statementTimeout)
+
+	return st
+}
+
+func getTransactionStatementTimeout(db *gorm.DB) *transactionStatementTimeout {
+	// Get transaction state
+	v, ok := db.Get(transactionStatementTimeoutSettingKey)
+	// Check if it exists
+	if !ok {
+		return nil
+	}
+
+	// Cast
+	st, _ := v.(*transactionStatementTimeout)
+
+	return st
+}
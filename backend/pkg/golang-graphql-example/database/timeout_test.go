@@ -0,0 +1,181 @@
+//go:build unit
+
+package database
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/dbtest"
+)
+
+func setupTimeoutTestDB(t *testing.T, defaultTimeout time.Duration) *gorm.DB {
+	t.Helper()
+
+	db := dbtest.NewSQLiteDB(t, &replicaTestPeople{})
+	require.NoError(t, db.Use(newTimeoutPlugin(defaultTimeout)))
+
+	return db
+}
+
+func TestTimeoutPlugin(t *testing.T) {
+	// Without default timeout
+	db := setupTimeoutTestDB(t, 0)
+
+	require.NoError(t, db.Create(&replicaTestPeople{ID: "1"}).Error)
+	require.NoError(t, db.Find(&[]*replicaTestPeople{}).Error)
+
+	// Override with an expired timeout
+	err := WithStatementTimeout(db, time.Nanosecond).Find(&[]*replicaTestPeople{}).Error
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// With an expired default timeout
+	db = setupTimeoutTestDB(t, time.Nanosecond)
+
+	err = db.Find(&[]*replicaTestPeople{}).Error
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	err = db.Exec("DELETE FROM replica_test_peoples").Error
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Override disables default timeout
+	err = WithStatementTimeout(db, 0).Create(&replicaTestPeople{ID: "1"}).Error
+	require.NoError(t, err)
+
+	// Context disables default timeout
+	err = db.WithContext(WithoutStatementTimeout(context.TODO())).Find(&[]*replicaTestPeople{}).Error
+	require.NoError(t, err)
+
+	// Context is restored after execution to allow statement reuse
+	tx := WithStatementTimeout(db, time.Minute).Where("id = ?", "1")
+
+	var count int64
+
+	require.NoError(t, tx.Model(&replicaTestPeople{}).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+
+	_, ok := tx.Statement.Context.Deadline()
+	assert.False(t, ok)
+}
+
+func TestTimeoutPlugin_Rows(t *testing.T) {
+	db := setupTimeoutTestDB(t, time.Nanosecond)
+
+	// Row statements are bounded
+	_, err := db.Model(&replicaTestPeople{}).Rows() //nolint:rowserrcheck // Error is checked
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	err = db.Model(&replicaTestPeople{}).Row().Err()
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	var names []string
+
+	err = db.Model(&replicaTestPeople{}).Select("name").Scan(&names).Error
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Rows can be read after callbacks
+	gdb := WithStatementTimeout(db, time.Minute)
+	require.NoError(t, gdb.Create(&replicaTestPeople{ID: "1", Name: "name1"}).Error)
+
+	rows, err := gdb.Model(&replicaTestPeople{}).Select("name").Rows()
+	require.NoError(t, err)
+
+	defer rows.Close()
+
+	require.True(t, rows.Next())
+	require.NoError(t, rows.Scan(new(string)))
+	require.NoError(t, rows.Err())
+}
+
+func TestTimeoutPlugin_RowsDeadlineReleased(t *testing.T) {
+	db := setupTimeoutTestDB(t, time.Minute)
+	require.NoError(t, db.Create(&replicaTestPeople{ID: "1", Name: "name1"}).Error)
+
+	// Get statement context
+	var ctx context.Context
+
+	err := db.Callback().Row().Before("gorm:row").Register("test:context", func(db *gorm.DB) {
+		ctx = db.Statement.Context
+	})
+	require.NoError(t, err)
+
+	rows, err := db.Model(&replicaTestPeople{}).Select("name").Rows()
+	require.NoError(t, err)
+
+	// Deadline is kept while rows are read
+	require.True(t, rows.Next())
+	require.NoError(t, ctx.Err())
+	require.NoError(t, rows.Close())
+
+	rows = nil //nolint:ineffassign,wastedassign // Allow rows collection
+
+	// Deadline is released once closed rows are collected
+	assert.Eventually(t, func() bool {
+		runtime.GC()
+
+		return errors.Is(ctx.Err(), context.Canceled)
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestTimeoutPlugin_PostgresTransaction(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	defer sqlDB.Close()
+
+	db, err := gorm.Open(
+		postgres.New(postgres.Config{Conn: sqlDB}),
+		&gorm.Config{Logger: logger.Discard, SkipDefaultTransaction: true},
+	)
+	require.NoError(t, err)
+	require.NoError(t, db.Use(newTimeoutPlugin(time.Second)))
+	// Check that statements aren't bounded by a context deadline
+	err = db.Callback().Query().Before("gorm:query").Register("test:deadline", func(db *gorm.DB) {
+		_, ok := db.Statement.Context.Deadline()
+		assert.False(t, ok)
+	})
+	require.NoError(t, err)
+
+	mock.ExpectBegin()
+	// Default timeout
+	mock.ExpectExec("SET LOCAL statement_timeout = 1000").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT * FROM "replica_test_peoples"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	// Same timeout isn't set again
+	mock.ExpectQuery(`SELECT * FROM "replica_test_peoples"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	// Overridden timeout
+	mock.ExpectExec("SET LOCAL statement_timeout = 0").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`DELETE FROM replica_test_peoples`).WillReturnResult(sqlmock.NewResult(0, 0))
+	// Default timeout is set back
+	mock.ExpectExec("SET LOCAL statement_timeout = 1000").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT * FROM "replica_test_peoples"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	// Disabled by context
+	mock.ExpectExec(`DELETE FROM replica_test_peoples`).WillReturnResult(sqlmock.NewResult(0, 0))
+	// Timeout is set again after a savepoint
+	mock.ExpectExec("SET LOCAL statement_timeout = 1000").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT * FROM "replica_test_peoples"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectCommit()
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		tx = withTransactionStatementTimeout(tx)
+
+		require.NoError(t, tx.Find(&[]*replicaTestPeople{}).Error)
+		require.NoError(t, tx.Find(&[]*replicaTestPeople{}).Error)
+		require.NoError(t, WithStatementTimeout(tx, 0).Exec("DELETE FROM replica_test_peoples").Error)
+		require.NoError(t, tx.Find(&[]*replicaTestPeople{}).Error)
+		require.NoError(t, tx.WithContext(WithoutStatementTimeout(context.TODO())).Exec("DELETE FROM replica_test_peoples").Error)
+
+		resetTransactionStatementTimeout(tx)
+
+		return tx.Find(&[]*replicaTestPeople{}).Error
+	})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -15,28 +15,40 @@ import (
 type service struct {
 	cfgManager config.Manager
 	db         database.DB
-	cl         *pglock.Client
+	eng        engine
 }
 
 func (s *service) InitializeAndReload(logger log.Logger) error {
 	// Get configuration
 	cfg := s.cfgManager.GetConfig()
 
-	// Parse durations
-	ld, err := time.ParseDuration(cfg.LockDistributor.LeaseDuration)
+	// Get sql database
+	sqlDB, err := s.db.GetSQLDB()
 	// Check error
 	if err != nil {
 		return errors.WithStack(err)
 	}
 
-	hf, err := time.ParseDuration(cfg.LockDistributor.HeartbeatFrequency)
+	// Check if database is a mysql one
+	// Named locks are used in this case
+	if s.db.GetGormDB().Dialector.Name() == mysqlEngineName {
+		// Save engine
+		s.eng = &mysqlEngine{sqlDB: sqlDB}
+
+		// Log
+		logger.Info("Successfully created lock distributor client")
+
+		return nil
+	}
+
+	// Parse durations
+	ld, err := time.ParseDuration(cfg.LockDistributor.LeaseDuration)
 	// Check error
 	if err != nil {
 		return errors.WithStack(err)
 	}
 
-	// Get sql database
-	sqlDB, err := s.db.GetSQLDB()
+	hf, err := time.ParseDuration(cfg.LockDistributor.HeartbeatFrequency)
 	// Check error
 	if err != nil {
 		return errors.WithStack(err)
@@ -65,8 +77,8 @@ func (s *service) InitializeAndReload(logger log.Logger) error {
 		return errors.WithStack(err)
 	}
 
-	// Save client
-	s.cl = c
+	// Save engine
+	s.eng = &pglockEngine{cl: c}
 
 	// Log
 	logger.Info("Successfully created lock distributor client")
@@ -0,0 +1,171 @@
+package sqllockdistributor
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec // Only used to shorten lock names
+	"database/sql"
+	"encoding/hex"
+
+	"emperror.dev/errors"
+)
+
+const (
+	mysqlEngineName = "mysql"
+	// MySQL lock names are limited to 64 characters.
+	mysqlMaxLockNameLength = 64
+	// GET_LOCK timeout in seconds.
+	// It must be shorter than the acquire timeout to answer before the caller gives up.
+	mysqlGetLockTimeout = 20
+)
+
+// mysqlEngine is using MySQL named locks.
+// Those locks are bound to a session, so each acquired lock keeps a dedicated connection
+// until it is released.
+type mysqlEngine struct {
+	sqlDB *sql.DB
+}
+
+type mysqlEngineLock struct {
+	sqlDB        *sql.DB
+	conn         *sql.Conn
+	name         string
+	connectionID int64
+	released     bool
+}
+
+func (*mysqlEngine) getName() string {
+	return mysqlEngineName
+}
+
+func (e *mysqlEngine) isAlreadyTaken(ctx context.Context, name string) (bool, error) {
+	// Get lock owner
+	owner, err := getMySQLLockOwner(ctx, e.sqlDB, getMySQLLockName(name))
+	// Check error
+	if err != nil {
+		return false, err
+	}
+
+	return owner.Valid, nil
+}
+
+func (e *mysqlEngine) acquire(ctx context.Context, name string) (engineLock, error) {
+	// Compute name
+	lockName := getMySQLLockName(name)
+
+	// Get a dedicated connection
+	conn, err := e.sqlDB.Conn(ctx)
+	// Check error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var (
+		res          sql.NullInt64
+		connectionID int64
+	)
+
+	// Acquire lock
+	err = conn.QueryRowContext(
+		ctx,
+		"SELECT GET_LOCK(?, ?), CONNECTION_ID()",
+		lockName,
+		mysqlGetLockTimeout,
+	).Scan(&res, &connectionID)
+	// Check error
+	if err != nil {
+		_ = conn.Close()
+
+		return nil, errors.WithStack(err)
+	}
+
+	// Check result
+	// 1 means acquired, 0 means timeout and NULL means an error occurred
+	if !res.Valid || res.Int64 != 1 {
+		_ = conn.Close()
+
+		return nil, ErrLockNotAcquired
+	}
+
+	el := &mysqlEngineLock{
+		sqlDB:        e.sqlDB,
+		conn:         conn,
+		name:         lockName,
+		connectionID: connectionID,
+	}
+
+	// Check if caller is still waiting for the lock
+	// Otherwise, it would be kept until the end of the connection whereas nobody is using it
+	if ctx.Err() != nil {
+		_ = el.release()
+
+		return nil, errors.WithStack(ctx.Err())
+	}
+
+	return el, nil
+}
+
+func (l *mysqlEngineLock) isReleased() (bool, error) {
+	// Check if lock have been released
+	if l.released {
+		return true, nil
+	}
+
+	// Get lock owner
+	owner, err := getMySQLLockOwner(context.TODO(), l.sqlDB, l.name)
+	// Check error
+	if err != nil {
+		return false, err
+	}
+
+	// Lock is lost if owned by another session or free
+	return !owner.Valid || owner.Int64 != l.connectionID, nil
+}
+
+func (l *mysqlEngineLock) release() error {
+	// Check if lock have been released
+	if l.released {
+		return nil
+	}
+
+	// Release lock
+	// Result isn't checked as lock could have already been lost
+	_, err := l.conn.ExecContext(context.TODO(), "SELECT RELEASE_LOCK(?)", l.name)
+	// Check error
+	if err != nil {
+		_ = l.conn.Close()
+
+		return errors.WithStack(err)
+	}
+
+	// Save
+	l.released = true
+
+	// Give back connection
+	return errors.WithStack(l.conn.Close())
+}
+
+// getMySQLLockOwner will return the connection id holding the lock, NULL when lock is free.
+func getMySQLLockOwner(ctx context.Context, sqlDB *sql.DB, lockName string) (sql.NullInt64, error) {
+	var owner sql.NullInt64
+
+	// Get lock owner
+	err := sqlDB.QueryRowContext(ctx, "SELECT IS_USED_LOCK(?)", lockName).Scan(&owner)
+	// Check error
+	if err != nil {
+		return owner, errors.WithStack(err)
+	}
+
+	return owner, nil
+}
+
+func getMySQLLockName(name string) string {
+	// Check length
+	if len(name) <= mysqlMaxLockNameLength {
+		return name
+	}
+
+	// Hash name to respect limit
+	h := sha1.Sum([]byte(name)) //nolint:gosec // Only used to shorten lock names
+
+	return hex.EncodeToString(h[:])
+}
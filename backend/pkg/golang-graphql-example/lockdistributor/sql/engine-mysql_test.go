@@ -0,0 +1,146 @@
+//go:build unit
+
+package sqllockdistributor
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_getMySQLLockName(t *testing.T) {
+	assert.Equal(t, "lock1", getMySQLLockName("lock1"))
+
+	long := strings.Repeat("a", 65)
+	got := getMySQLLockName(long)
+	assert.Len(t, got, 40)
+	assert.Equal(t, got, getMySQLLockName(long))
+	assert.NotEqual(t, got, getMySQLLockName(long+"b"))
+}
+
+func Test_mysqlEngine(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	defer sqlDB.Close()
+
+	eng := &mysqlEngine{sqlDB: sqlDB}
+
+	assert.Equal(t, "mysql", eng.getName())
+
+	// Free lock
+	mock.ExpectQuery("SELECT IS_USED_LOCK(?)").
+		WithArgs("lock1").
+		WillReturnRows(sqlmock.NewRows([]string{"owner"}).AddRow(nil))
+
+	taken, err := eng.isAlreadyTaken(context.TODO(), "lock1")
+	require.NoError(t, err)
+	assert.False(t, taken)
+
+	// Acquire
+	mock.ExpectQuery("SELECT GET_LOCK(?, ?), CONNECTION_ID()").
+		WithArgs("lock1", mysqlGetLockTimeout).
+		WillReturnRows(sqlmock.NewRows([]string{"res", "id"}).AddRow(1, 12))
+
+	el, err := eng.acquire(context.TODO(), "lock1")
+	require.NoError(t, err)
+
+	// Owned by this session
+	mock.ExpectQuery("SELECT IS_USED_LOCK(?)").
+		WithArgs("lock1").
+		WillReturnRows(sqlmock.NewRows([]string{"owner"}).AddRow(12))
+
+	released, err := el.isReleased()
+	require.NoError(t, err)
+	assert.False(t, released)
+
+	// Lost
+	mock.ExpectQuery("SELECT IS_USED_LOCK(?)").
+		WithArgs("lock1").
+		WillReturnRows(sqlmock.NewRows([]string{"owner"}).AddRow(13))
+
+	released, err = el.isReleased()
+	require.NoError(t, err)
+	assert.True(t, released)
+
+	// Release
+	mock.ExpectExec("SELECT RELEASE_LOCK(?)").
+		WithArgs("lock1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, el.release())
+	// Second release is ignored
+	require.NoError(t, el.release())
+
+	released, err = el.isReleased()
+	require.NoError(t, err)
+	assert.True(t, released)
+
+	// Not acquired on timeout
+	mock.ExpectQuery("SELECT GET_LOCK(?, ?), CONNECTION_ID()").
+		WithArgs("lock1", mysqlGetLockTimeout).
+		WillReturnRows(sqlmock.NewRows([]string{"res", "id"}).AddRow(0, 14))
+
+	_, err = eng.acquire(context.TODO(), "lock1")
+	require.ErrorIs(t, err, ErrLockNotAcquired)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+// afterQueryCancelContext is a context canceled without closing its done channel.
+// This simulates a caller going away while the lock query is running.
+type afterQueryCancelContext struct {
+	context.Context
+
+	canceled bool
+}
+
+func (c *afterQueryCancelContext) Err() error {
+	if c.canceled {
+		return context.Canceled
+	}
+
+	return nil
+}
+
+// cancelOnMatch will cancel the context when query arguments are matched.
+type cancelOnMatch struct {
+	ctx   *afterQueryCancelContext
+	value string
+}
+
+func (m *cancelOnMatch) Match(v driver.Value) bool {
+	m.ctx.canceled = true
+
+	return v == m.value
+}
+
+func Test_mysqlEngine_acquire_CallerGone(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	defer sqlDB.Close()
+
+	eng := &mysqlEngine{sqlDB: sqlDB}
+	ctx := &afterQueryCancelContext{Context: context.Background()}
+
+	// Acquired after caller is gone
+	mock.ExpectQuery("SELECT GET_LOCK(?, ?), CONNECTION_ID()").
+		WithArgs(&cancelOnMatch{ctx: ctx, value: "lock1"}, mysqlGetLockTimeout).
+		WillReturnRows(sqlmock.NewRows([]string{"res", "id"}).AddRow(1, 12))
+	// Lock must be released
+	mock.ExpectExec("SELECT RELEASE_LOCK(?)").
+		WithArgs("lock1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	el, err := eng.acquire(ctx, "lock1")
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, el)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,70 @@
+package sqllockdistributor
+
+import (
+	"context"
+
+	"cirello.io/pglock"
+	"emperror.dev/errors"
+)
+
+const pglockEngineName = "postgresql"
+
+type pglockEngine struct {
+	cl *pglock.Client
+}
+
+type pglockEngineLock struct {
+	pl *pglock.Lock
+}
+
+func (*pglockEngine) getName() string {
+	return pglockEngineName
+}
+
+func (e *pglockEngine) isAlreadyTaken(_ context.Context, name string) (bool, error) {
+	// Get lock
+	lo, err := e.cl.Get(name)
+	// Check error
+	if err != nil {
+		// Check if error is a not found error
+		if errors.Is(err, pglock.ErrLockNotFound) {
+			return false, nil
+		}
+
+		return false, errors.WithStack(err)
+	}
+
+	// Check if lock exists or not
+	return lo != nil, nil
+}
+
+func (e *pglockEngine) acquire(ctx context.Context, name string) (engineLock, error) {
+	// Acquire lock
+	ll, err := e.cl.AcquireContext(ctx, name)
+	// Check error
+	if err != nil {
+		// Check if it is a not acquired error to wrap it
+		if errors.Is(err, pglock.ErrNotAcquired) {
+			return nil, ErrLockNotAcquired
+		}
+
+		return nil, errors.WithStack(err)
+	}
+
+	return &pglockEngineLock{pl: ll}, nil
+}
+
+func (l *pglockEngineLock) isReleased() (bool, error) {
+	return l.pl.IsReleased(), nil
+}
+
+func (l *pglockEngineLock) release() error {
+	// Close
+	err := l.pl.Close()
+	// Check error
+	if err != nil && !errors.Is(err, pglock.ErrLockAlreadyReleased) {
+		return errors.WithStack(err)
+	}
+	// Default
+	return nil
+}
@@ -0,0 +1,21 @@
+package sqllockdistributor
+
+import "context"
+
+// engine is the database specific lock implementation.
+type engine interface {
+	// Get engine name used in traces
+	getName() string
+	// Check if a lock with this name is already taken
+	isAlreadyTaken(ctx context.Context, name string) (bool, error)
+	// Acquire lock, wait until it is available
+	acquire(ctx context.Context, name string) (engineLock, error)
+}
+
+// engineLock is an acquired lock.
+type engineLock interface {
+	// Check if the lock is released or lost
+	isReleased() (bool, error)
+	// Release lock
+	release() error
+}
@@ -39,6 +39,13 @@ func (suite *LockDistributorTestSuite) SetupSuite() {
 	fmt.Println("SetupSuite phase")
 	lockTableName := config.DefaultLockDistributorTableName
 
+	dbCfg := &config.DatabaseConfig{
+		Driver: config.DefaultDatabaseDriver,
+		ConnectionURL: &config.CredentialConfig{
+			Value: "host=localhost port=5432 user=postgres dbname=postgres-integration password=postgres sslmode=disable",
+		},
+	}
+
 	cfg := &config.Config{
 		Log:     &config.LogConfig{Level: "debug", Format: "human"},
 		Tracing: &config.TracingConfig{Enabled: false},
@@ -47,12 +54,9 @@ func (suite *LockDistributorTestSuite) SetupSuite() {
 			LeaseDuration:      config.DefaultLockDistributorLeaseDuration,
 			HeartbeatFrequency: config.DefaultLockDistributionHeartbeatFrequency,
 		},
-		Database: &config.DatabaseConfig{
-			Driver: config.DefaultDatabaseDriver,
-			ConnectionURL: &config.CredentialConfig{
-				Value: "host=localhost port=5432 user=postgres dbname=postgres-integration password=postgres sslmode=disable",
-			},
-		},
+		Database: dbCfg,
+		// Second connection on same database to simulate another instance
+		Databases: map[string]*config.DatabaseConfig{"secondary": dbCfg},
 	}
 
 	ctrl := gomock.NewController(suite.T())
@@ -11,7 +11,9 @@ import (
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/log"
 )
 
-const acquireTimeoutDuration = 30 * time.Second
+// Acquire timeout duration.
+// This is a variable to be overridden in tests.
+var acquireTimeoutDuration = 30 * time.Second
 
 // ErrLockNotAcquired is returned when a lock cannot be acquired.
 var ErrLockNotAcquired = errors.New("lock not acquired")
@@ -3,14 +3,11 @@ package sqllockdistributor
 import (
 	"context"
 
-	"cirello.io/pglock"
-	"emperror.dev/errors"
-
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/tracing"
 )
 
 type lock struct {
-	pl    *pglock.Lock
+	el    engineLock
 	s     *service
 	trace tracing.Trace
 	ctx   context.Context //nolint:containedctx // Keep the first context
@@ -18,20 +15,7 @@ type lock struct {
 }
 
 func (l *lock) IsAlreadyTaken() (bool, error) {
-	// Get lock
-	lo, err := l.s.cl.Get(l.name)
-	// Check error
-	if err != nil {
-		// Check if error is a not found error
-		if errors.Is(err, pglock.ErrLockNotFound) {
-			return false, nil
-		}
-
-		return false, errors.WithStack(err)
-	}
-
-	// Check if lock exists or not
-	return lo != nil, nil
+	return l.s.eng.isAlreadyTaken(context.TODO(), l.name)
 }
 
 func (l *lock) AcquireWithContext(ctx context.Context) (err error) {
@@ -45,7 +29,7 @@ func (l *lock) AcquireWithContext(ctx context.Context) (err error) {
 	ctx, ct := trace.GetChildTrace(ctx, "lockdistributor.Acquiring")
 	// Add tags
 	ct.SetTag("lock.name", l.name)
-	ct.SetTag("lock.engine", "postgresql")
+	ct.SetTag("lock.engine", l.s.eng.getName())
 	// Defer end
 	defer func() {
 		// Check error
@@ -64,28 +48,16 @@ func (l *lock) AcquireWithContext(ctx context.Context) (err error) {
 	// Defer the cancel in case it is finishing earlier
 	defer cancelTimeout()
 
-	// Building error chan
-	errChan := make(chan error)
+	// Building result chan
+	// Chan is buffered to never block the routine when the result isn't awaited anymore
+	resChan := make(chan *acquireResult, 1)
 
 	// Start acquire in routine to manage timeout
 	go func() {
 		// Acquire lock
-		ll, err2 := l.s.cl.AcquireContext(cancelCtx, l.name)
-		// Check error
-		if err2 != nil {
-			// Check if it is a not acquired error to wrap it
-			if errors.Is(err2, pglock.ErrNotAcquired) {
-				errChan <- ErrLockNotAcquired
-
-				return
-			}
-
-			errChan <- errors.WithStack(err2)
-		}
-		// Save lock
-		l.pl = ll
-		// Inform
-		errChan <- nil
+		el, err2 := l.s.eng.acquire(cancelCtx, l.name)
+		// Send result
+		resChan <- &acquireResult{el: el, err: err2}
 	}()
 
 	// Wait for timeout or result
@@ -93,10 +65,36 @@ func (l *lock) AcquireWithContext(ctx context.Context) (err error) {
 	case <-timeoutCtx.Done():
 		// Timeout raised => Need to cancel context
 		cancel()
+		// Release lock if it is acquired after the timeout
+		go releaseLateLock(resChan)
 
 		return timeoutCtx.Err()
-	case err = <-errChan:
-		return err //nolint:govet
+	case res := <-resChan:
+		// Check error
+		if res.err != nil {
+			return res.err //nolint:govet
+		}
+
+		// Save lock
+		l.el = res.el
+
+		return nil
+	}
+}
+
+type acquireResult struct {
+	el  engineLock
+	err error
+}
+
+// releaseLateLock will wait for the acquire result and will release the lock if it has been acquired.
+// Otherwise, it would be kept until the end of the process whereas nobody is using it.
+func releaseLateLock(resChan <-chan *acquireResult) {
+	// Wait result
+	res := <-resChan
+	// Check if lock has been acquired
+	if res.err == nil && res.el != nil {
+		_ = res.el.release()
 	}
 }
 
@@ -105,7 +103,7 @@ func (l *lock) Acquire() error {
 }
 
 func (l *lock) IsReleased() (bool, error) {
-	return l.pl.IsReleased(), nil
+	return l.el.isReleased()
 }
 
 func (l *lock) Release() (err error) {
@@ -113,7 +111,7 @@ func (l *lock) Release() (err error) {
 	_, ct := l.trace.GetChildTrace(l.ctx, "lockdistributor.Release")
 	// Add tags
 	ct.SetTag("lock.name", l.name)
-	ct.SetTag("lock.engine", "postgresql")
+	ct.SetTag("lock.engine", l.s.eng.getName())
 	// Defer
 	defer func() {
 		// Check error
@@ -125,12 +123,6 @@ func (l *lock) Release() (err error) {
 		ct.Finish()
 	}()
 
-	// Close
-	err = l.pl.Close()
-	// Check error
-	if err != nil && !errors.Is(err, pglock.ErrLockAlreadyReleased) {
-		return errors.WithStack(err)
-	}
-	// Default
-	return nil
+	// Release
+	return l.el.release()
 }
@@ -0,0 +1,105 @@
+//go:build unit
+
+package sqllockdistributor
+
+import (
+	"context"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeEngine struct {
+	released atomic.Int64
+	// Delay before acquiring lock, context is ignored when set
+	lateDelay time.Duration
+}
+
+type fakeEngineLock struct {
+	eng *fakeEngine
+}
+
+func (*fakeEngine) getName() string {
+	return "fake"
+}
+
+func (*fakeEngine) isAlreadyTaken(_ context.Context, _ string) (bool, error) {
+	return true, nil
+}
+
+func (e *fakeEngine) acquire(ctx context.Context, _ string) (engineLock, error) {
+	// Check if lock must be acquired late
+	if e.lateDelay != 0 {
+		time.Sleep(e.lateDelay)
+
+		return &fakeEngineLock{eng: e}, nil
+	}
+
+	// Lock is held by someone else, wait until cancellation
+	<-ctx.Done()
+
+	return nil, ctx.Err()
+}
+
+func (*fakeEngineLock) isReleased() (bool, error) {
+	return false, nil
+}
+
+func (l *fakeEngineLock) release() error {
+	l.eng.released.Add(1)
+
+	return nil
+}
+
+func setAcquireTimeout(t *testing.T, d time.Duration) {
+	t.Helper()
+
+	old := acquireTimeoutDuration
+	acquireTimeoutDuration = d
+
+	t.Cleanup(func() { acquireTimeoutDuration = old })
+}
+
+func Test_lock_AcquireWithContext_HeldLock(t *testing.T) {
+	setAcquireTimeout(t, 5*time.Millisecond)
+
+	s := &service{eng: &fakeEngine{}}
+	baseGoroutines := runtime.NumGoroutine()
+
+	// Loop like standby replicas waiting for a held lock
+	for range 50 {
+		err := s.GetLock("lock1").AcquireWithContext(context.TODO())
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	}
+
+	// Acquire routines must be stopped
+	// Eventually isn't used as it is running its own routines
+	for range 100 {
+		if runtime.NumGoroutine() <= baseGoroutines {
+			break
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	assert.LessOrEqual(t, runtime.NumGoroutine(), baseGoroutines)
+}
+
+func Test_lock_AcquireWithContext_LateLock(t *testing.T) {
+	setAcquireTimeout(t, 5*time.Millisecond)
+
+	eng := &fakeEngine{lateDelay: 20 * time.Millisecond}
+	s := &service{eng: eng}
+
+	err := s.GetLock("lock1").AcquireWithContext(context.TODO())
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Lock acquired after timeout must be released
+	assert.Eventually(t, func() bool {
+		return eng.released.Load() == 1
+	}, time.Second, 5*time.Millisecond)
+}
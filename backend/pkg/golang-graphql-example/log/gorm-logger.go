@@ -6,11 +6,13 @@ import (
 
 	"emperror.dev/errors"
 
+	gqlgraphql "github.com/99designs/gqlgen/graphql"
 	gormlogger "gorm.io/gorm/logger"
 )
 
 type gormLogger struct {
 	logger Logger
+	opts   *GormLoggerOptions
 }
 
 func (gl *gormLogger) LogMode(gormlogger.LogLevel) gormlogger.Interface {
@@ -55,9 +57,41 @@ func (gl *gormLogger) Trace(
 ) {
 	sql, rows := fc()
 	elapsed := time.Since(begin)
-	gl.getCtxLoggerOrDefault(ctx).
+	logger := gl.getCtxLoggerOrDefault(ctx).
 		WithField("sql_duration_ms", elapsed.Milliseconds()).
 		WithField("rows", rows).
-		WithError(err).
-		Debug(sql)
+		WithError(err)
+
+	// Check if it is a slow query
+	if gl.opts.SlowQueryThreshold > 0 && elapsed > gl.opts.SlowQueryThreshold {
+		// Check if there is a callback
+		if gl.opts.OnSlowQuery != nil {
+			gl.opts.OnSlowQuery()
+		}
+
+		logger.
+			WithField("sql", sql).
+			WithField("graphql_operation_name", getGraphqlOperationName(ctx)).
+			Warn("Slow sql query detected")
+
+		return
+	}
+
+	logger.Debug(sql)
+}
+
+func getGraphqlOperationName(ctx context.Context) string {
+	// Check if context is coming from a graphql request
+	if !gqlgraphql.HasOperationContext(ctx) {
+		return "no-operation-context"
+	}
+
+	// Get operation name
+	opName := gqlgraphql.GetOperationContext(ctx).OperationName
+	// Check if it is an anonymous operation
+	if opName == "" {
+		return "Anonymous"
+	}
+
+	return opName
 }
@@ -0,0 +1,62 @@
+//go:build unit
+
+package log
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_gormLogger_Trace_SlowQuery(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold time.Duration
+		elapsed   time.Duration
+		wantSlow  bool
+	}{
+		{
+			name:      "disabled threshold",
+			threshold: 0,
+			elapsed:   time.Hour,
+		},
+		{
+			name:      "fast query",
+			threshold: time.Second,
+			elapsed:   time.Millisecond,
+		},
+		{
+			name:      "slow query",
+			threshold: time.Second,
+			elapsed:   2 * time.Second,
+			wantSlow:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slowQueries := 0
+
+			gl := NewLogger().GetGormLogger(&GormLoggerOptions{
+				SlowQueryThreshold: tt.threshold,
+				OnSlowQuery:        func() { slowQueries++ },
+			})
+
+			gl.Trace(context.TODO(), time.Now().Add(-tt.elapsed), func() (string, int64) { return "SELECT 1", 1 }, nil)
+
+			assert.Equal(t, tt.wantSlow, slowQueries == 1)
+		})
+	}
+}
+
+func Test_getGraphqlOperationName(t *testing.T) {
+	assert.Equal(t, "no-operation-context", getGraphqlOperationName(context.TODO()))
+	assert.Equal(t, "Anonymous", getGraphqlOperationName(
+		graphql.WithOperationContext(context.TODO(), &graphql.OperationContext{}),
+	))
+	assert.Equal(t, "todos", getGraphqlOperationName(
+		graphql.WithOperationContext(context.TODO(), &graphql.OperationContext{OperationName: "todos"}),
+	))
+}
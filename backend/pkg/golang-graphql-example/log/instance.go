@@ -48,9 +48,15 @@ type loggerIns struct {
 	*zap.SugaredLogger
 }
 
-func (ll *loggerIns) GetGormLogger() gormlogger.Interface {
+func (ll *loggerIns) GetGormLogger(opts *GormLoggerOptions) gormlogger.Interface {
+	// Check options
+	if opts == nil {
+		opts = &GormLoggerOptions{}
+	}
+
 	return &gormLogger{
 		logger: ll,
+		opts:   opts,
 	}
 }
 
@@ -32,7 +32,7 @@ type Logger interface {
 	Panic(args ...any)
 
 	GetLockDistributorLogger() LockDistributorLogger
-	GetGormLogger() gormlogger.Interface
+	GetGormLogger(opts *GormLoggerOptions) gormlogger.Interface
 	GetSlogInstance() *slog.Logger
 }
 
@@ -40,6 +40,14 @@ type LockDistributorLogger interface {
 	Println(args ...any)
 }
 
+// GormLoggerOptions are the gorm logger options.
+type GormLoggerOptions struct {
+	// OnSlowQuery is called for each slow query
+	OnSlowQuery func()
+	// SlowQueryThreshold is the duration above which queries are logged in warning level, disabled when 0
+	SlowQueryThreshold time.Duration
+}
+
 type GormLogger interface {
 	LogMode(lvl gormlogger.LogLevel) GormLogger
 	Info(ctx context.Context, msg string, args ...any)
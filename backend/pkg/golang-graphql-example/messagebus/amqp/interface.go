@@ -22,6 +22,9 @@ var (
 	tracingConsumeOperation         = "amqp:consume"
 )
 
+// TenantHeaderName is the message header used to propagate the tenant on publish.
+const TenantHeaderName = "x-tenant-id"
+
 // ErrPublishTimeoutReached is the error thrown when the publish timeout is over.
 var ErrPublishTimeoutReached = errors.New("timeout reached")
 
@@ -61,6 +64,10 @@ type ConsumeConfigInput struct {
 	// nack response when the message consume is in error.
 	// The default value is true is no function is set.
 	RequeueOnNackFn func(d *amqp091.Delivery, err error) bool
+	// TenantFn is a function that returns the tenant to run the callback as.
+	// Use GetTenantFromDelivery to use the tenant propagated on publish.
+	// No tenant is set in callback context if no function is set or if it returns an empty string.
+	TenantFn func(d *amqp091.Delivery) string
 	// QueueName is the queue name for consume.
 	QueueName string
 	// ConsumerPrefix is the prefix used for the consumer tag in AMQP consumer.
@@ -41,3 +41,11 @@ func ParseJSONMessage(res any, input *amqp091.Delivery) error {
 	// Default
 	return nil
 }
+
+// GetTenantFromDelivery will return the tenant propagated in message headers on publish.
+func GetTenantFromDelivery(d *amqp091.Delivery) string {
+	// Get header
+	res, _ := d.Headers[TenantHeaderName].(string)
+
+	return res
+}
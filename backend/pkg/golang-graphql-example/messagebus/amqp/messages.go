@@ -10,6 +10,7 @@ import (
 	"github.com/rabbitmq/amqp091-go"
 
 	correlationid "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/common/correlation-id"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/common/tenant"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/log"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/tracing"
 )
@@ -100,6 +101,12 @@ func (as *amqpService) Publish(
 	}
 	// Create headers
 	as.injectTracedHeaders(trace, message.Headers)
+	// Get tenant from context
+	tenantID := tenant.GetFromContext(ctx)
+	// Check if tenant is set and not already in headers
+	if _, ok := message.Headers[TenantHeaderName]; !ok && tenantID != "" {
+		message.Headers[TenantHeaderName] = tenantID
+	}
 
 	// Initialize retry send delay
 	sendDelayDur := defaultRetryDelay
@@ -397,6 +404,17 @@ func (as *amqpService) Consume(
 					// Set correlation id in context
 					cbCtx = correlationid.SetInContext(cbCtx, d.CorrelationId)
 
+					// Check if tenant function is set
+					if consumeCfg.TenantFn != nil {
+						// Get tenant
+						tenantID := consumeCfg.TenantFn(&d)
+						// Check if tenant is set
+						if tenantID != "" {
+							// Run as tenant
+							cbCtx = tenant.SetInContext(cbCtx, tenantID)
+						}
+					}
+
 					// Log
 					childLogger.Debug("start consuming message")
 
@@ -0,0 +1,4 @@
+package amqpoutbox
+
+// This package will manage the AMQP transactional outbox.
+// Messages are stored in database within the caller transaction and are published later by a relay.
@@ -0,0 +1,66 @@
+package amqpoutbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/rabbitmq/amqp091-go"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/config"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
+	lockdistributor "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/lockdistributor/sql"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/log"
+	amqpbusmessage "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/messagebus/amqp"
+)
+
+// TableName is the outbox table name.
+const TableName = "amqp_outbox_messages"
+
+// RelayLockName is the lock distributor lock name used to elect the relay.
+const RelayLockName = "amqp-outbox-relay"
+
+var (
+	defaultPollInterval = time.Second
+	defaultBatchSize    = 100
+	defaultMaxAttempts  = 10
+	// Maximum delay between 2 attempts of a failed message
+	maxRetryBackoff = time.Hour
+)
+
+// Service represents the AMQP outbox.
+//
+//go:generate mockgen -destination=./mocks/mock_Service.go -package=mocks github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/messagebus/amqp/outbox Service
+type Service interface {
+	// Enqueue will store the message in the outbox table.
+	// The transaction present in context is used, so the message is only
+	// stored if the transaction is committed.
+	Enqueue(
+		ctx context.Context,
+		message *amqp091.Publishing,
+		publishCfg *amqpbusmessage.PublishConfigInput,
+	) error
+	// RelayPending will publish one batch of pending messages.
+	// A failed message is retried later with a backoff and doesn't block the next ones.
+	// It is dead lettered when max attempts is reached.
+	// It returns the number of relayed messages and the publish errors.
+	RelayPending(ctx context.Context) (int, error)
+	// Run will relay pending messages until the context is done.
+	// Only the replica owning the relay lock will publish messages.
+	Run(ctx context.Context) error
+}
+
+func NewService(
+	logger log.Logger,
+	cfgManager config.Manager,
+	db database.DB,
+	amqpSvc amqpbusmessage.Service,
+	ldSvc lockdistributor.Service,
+) Service {
+	return &service{
+		logger:     logger,
+		cfgManager: cfgManager,
+		db:         db,
+		amqpSvc:    amqpSvc,
+		ldSvc:      ldSvc,
+	}
+}
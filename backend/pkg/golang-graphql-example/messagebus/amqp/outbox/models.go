@@ -0,0 +1,31 @@
+package amqpoutbox
+
+import (
+	"time"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
+)
+
+// Message is an outbox message waiting to be published.
+type Message struct {
+	DeliveredAt *time.Time `gorm:"index"`
+	// Failed messages are retried with a backoff after this date
+	NextAttemptAt *time.Time `gorm:"index"`
+	// Messages reaching max attempts aren't relayed anymore
+	DeadLetteredAt *time.Time `gorm:"index"`
+	database.Base
+	Exchange   string
+	RoutingKey string
+	LastError  string
+	// Publishing is the JSON serialized amqp091.Publishing.
+	Publishing []byte `gorm:"not null"`
+	Timeout    time.Duration
+	RetryDelay time.Duration
+	Attempts   int `gorm:"not null;default:0"`
+	Mandatory  bool
+	Immediate  bool
+}
+
+func (*Message) TableName() string {
+	return TableName
+}
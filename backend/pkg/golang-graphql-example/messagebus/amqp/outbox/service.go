@@ -0,0 +1,363 @@
+package amqpoutbox
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/rabbitmq/amqp091-go"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/common/tenant"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/config"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
+	lockdistributor "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/lockdistributor/sql"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/log"
+	amqpbusmessage "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/messagebus/amqp"
+)
+
+type service struct {
+	logger     log.Logger
+	cfgManager config.Manager
+	db         database.DB
+	amqpSvc    amqpbusmessage.Service
+	ldSvc      lockdistributor.Service
+}
+
+type relayConfig struct {
+	pollInterval   time.Duration
+	batchSize      int
+	maxAttempts    int
+	purgeDelivered bool
+}
+
+func (s *service) Enqueue(
+	ctx context.Context,
+	message *amqp091.Publishing,
+	publishCfg *amqpbusmessage.PublishConfigInput,
+) error {
+	// Get tenant from context
+	// Relay isn't running as a tenant, so it must be kept in message headers
+	tenantID := tenant.GetFromContext(ctx)
+	// Check if tenant is set and not already in headers
+	if _, ok := message.Headers[amqpbusmessage.TenantHeaderName]; !ok && tenantID != "" {
+		// Copy message and headers to avoid modifying caller ones
+		msgCopy := *message
+		msgCopy.Headers = make(amqp091.Table, len(message.Headers)+1)
+		maps.Copy(msgCopy.Headers, message.Headers)
+		// Save tenant
+		msgCopy.Headers[amqpbusmessage.TenantHeaderName] = tenantID
+		message = &msgCopy
+	}
+
+	// Serialize publishing
+	b, err := json.Marshal(message)
+	// Check error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Create message
+	m := &Message{
+		Exchange:   publishCfg.Exchange,
+		RoutingKey: publishCfg.RoutingKey,
+		Mandatory:  publishCfg.Mandatory,
+		Immediate:  publishCfg.Immediate,
+		Timeout:    publishCfg.Timeout,
+		RetryDelay: publishCfg.RetryDelay,
+		Publishing: b,
+	}
+
+	// Save it using transaction from context if it exists
+	res := s.db.GetTransactionalOrDefaultGormDB(ctx).Create(m)
+	// Check error
+	if res.Error != nil {
+		return errors.WithStack(res.Error)
+	}
+
+	return nil
+}
+
+func (s *service) RelayPending(ctx context.Context) (int, error) {
+	// Get configuration
+	rCfg, err := s.getRelayConfig()
+	// Check error
+	if err != nil {
+		return 0, err
+	}
+
+	// Get pending messages, oldest first to keep ordering
+	var list []*Message
+
+	res := s.db.GetGormDB().
+		WithContext(ctx).
+		Where("delivered_at IS NULL AND dead_lettered_at IS NULL").
+		Where("next_attempt_at IS NULL OR next_attempt_at <= ?", time.Now()).
+		Order("created_at ASC").
+		Limit(rCfg.batchSize).
+		Find(&list)
+	// Check error
+	if res.Error != nil {
+		return 0, errors.WithStack(res.Error)
+	}
+
+	// Initialize relayed count and errors
+	count := 0
+
+	var errs []error
+	// Loop over messages
+	for _, m := range list {
+		// Relay
+		err = s.relayMessage(ctx, m, rCfg)
+		// Check error
+		if err != nil {
+			// Continue with next messages to avoid blocking the queue with a failing message
+			errs = append(errs, errors.WithMessagef(err, "cannot relay outbox message %s", m.ID))
+
+			continue
+		}
+
+		count++
+	}
+
+	return count, errors.Combine(errs...)
+}
+
+func (s *service) relayMessage(ctx context.Context, m *Message, rCfg *relayConfig) error {
+	// Deserialize publishing
+	var pub amqp091.Publishing
+	// Unmarshal
+	err := json.Unmarshal(m.Publishing, &pub)
+	// Check error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Publish and wait for confirmation
+	err = s.amqpSvc.Publish(ctx, &pub, &amqpbusmessage.PublishConfigInput{
+		Exchange:   m.Exchange,
+		RoutingKey: m.RoutingKey,
+		Mandatory:  m.Mandatory,
+		Immediate:  m.Immediate,
+		Timeout:    m.Timeout,
+		RetryDelay: m.RetryDelay,
+	})
+	// Check error
+	if err != nil {
+		// Save failure
+		err2 := s.saveFailure(ctx, m, err, rCfg)
+		// Check error
+		if err2 != nil {
+			return err2
+		}
+
+		return err
+	}
+
+	// Check if delivered messages must be purged
+	if rCfg.purgeDelivered {
+		res := s.db.GetGormDB().WithContext(ctx).Unscoped().Delete(m)
+		// Check error
+		if res.Error != nil {
+			return errors.WithStack(res.Error)
+		}
+
+		return nil
+	}
+
+	// Mark as delivered
+	res := s.db.GetGormDB().WithContext(ctx).Model(m).Updates(map[string]any{
+		"attempts":     m.Attempts + 1,
+		"last_error":   "",
+		"delivered_at": time.Now(),
+	})
+	// Check error
+	if res.Error != nil {
+		return errors.WithStack(res.Error)
+	}
+
+	return nil
+}
+
+// saveFailure will increment attempts and compute next attempt date or dead letter the message.
+func (s *service) saveFailure(ctx context.Context, m *Message, publishErr error, rCfg *relayConfig) error {
+	// Compute attempts
+	attempts := m.Attempts + 1
+	now := time.Now()
+
+	// Create update
+	upd := map[string]any{
+		"attempts":   attempts,
+		"last_error": publishErr.Error(),
+	}
+
+	// Check if max attempts is reached
+	if attempts >= rCfg.maxAttempts {
+		upd["dead_lettered_at"] = now
+
+		s.logger.Errorf("outbox message %s reached max attempts and is dead lettered: %s", m.ID, publishErr.Error())
+	} else {
+		upd["next_attempt_at"] = now.Add(getRetryBackoff(rCfg.pollInterval, attempts))
+	}
+
+	// Save
+	res := s.db.GetGormDB().WithContext(ctx).Model(m).Updates(upd)
+	// Check error
+	if res.Error != nil {
+		return errors.WithStack(res.Error)
+	}
+
+	return nil
+}
+
+// getRetryBackoff will return the exponential delay before next attempt.
+func getRetryBackoff(base time.Duration, attempts int) time.Duration {
+	// Initialize
+	res := base
+	// Double for each previous attempt
+	for i := 1; i < attempts && res < maxRetryBackoff; i++ {
+		res *= 2
+	}
+
+	return min(res, maxRetryBackoff)
+}
+
+func (s *service) Run(ctx context.Context) error {
+	// Add logger in context as publish is using it
+	ctx = log.SetLoggerToContext(ctx, s.logger)
+
+	// Loop until context is done
+	for ctx.Err() == nil {
+		// Get configuration
+		rCfg, err := s.getRelayConfig()
+		// Check error
+		if err != nil {
+			return err
+		}
+
+		// Get lock
+		lo := s.ldSvc.GetLock(RelayLockName)
+		// Acquire it, this will wait until the current owner releases it
+		err = lo.AcquireWithContext(ctx)
+		// Check error
+		if err != nil {
+			// Check if context is done
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			s.logger.Debugf("outbox relay lock not acquired, retrying: %s", err.Error())
+			// Wait
+			wait(ctx, rCfg.pollInterval)
+
+			continue
+		}
+
+		s.logger.Info("outbox relay lock acquired, starting to relay messages")
+		// Relay until the lock is lost or the context is done
+		s.relayWhileOwner(ctx, lo)
+
+		// Release lock
+		err = lo.Release()
+		// Check error
+		if err != nil {
+			s.logger.Error(err)
+		}
+	}
+
+	return nil
+}
+
+func (s *service) relayWhileOwner(ctx context.Context, lo lockdistributor.Lock) {
+	// Loop until context is done
+	for ctx.Err() == nil {
+		// Check if lock is still owned
+		released, err := lo.IsReleased()
+		// Check error
+		if err != nil {
+			s.logger.Error(err)
+
+			return
+		}
+		// Check if lock have been lost
+		if released {
+			s.logger.Warn("outbox relay lock lost, stopping to relay messages")
+
+			return
+		}
+
+		// Get configuration
+		rCfg, err := s.getRelayConfig()
+		// Check error
+		if err != nil {
+			s.logger.Error(err)
+
+			return
+		}
+
+		// Relay one batch
+		count, err := s.RelayPending(ctx)
+		// Check error
+		if err != nil {
+			s.logger.Error(errors.Wrap(err, "outbox relay failed, retrying after delay"))
+		}
+
+		// Check if a full batch have been relayed to continue without waiting
+		if err == nil && count == rCfg.batchSize {
+			continue
+		}
+
+		// Wait
+		wait(ctx, rCfg.pollInterval)
+	}
+}
+
+func (s *service) getRelayConfig() (*relayConfig, error) {
+	// Initialize with default values
+	res := &relayConfig{
+		pollInterval: defaultPollInterval,
+		batchSize:    defaultBatchSize,
+		maxAttempts:  defaultMaxAttempts,
+	}
+
+	// Get configuration
+	cfg := s.cfgManager.GetConfig()
+	// Check if outbox configuration is set
+	if cfg.AMQP == nil || cfg.AMQP.Outbox == nil {
+		return res, nil
+	}
+
+	// Check poll interval
+	if cfg.AMQP.Outbox.PollInterval != "" {
+		// Parse
+		d, err := time.ParseDuration(cfg.AMQP.Outbox.PollInterval)
+		// Check error
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		res.pollInterval = d
+	}
+
+	// Check batch size
+	if cfg.AMQP.Outbox.BatchSize != 0 {
+		res.batchSize = cfg.AMQP.Outbox.BatchSize
+	}
+
+	// Check max attempts
+	if cfg.AMQP.Outbox.MaxAttempts != 0 {
+		res.maxAttempts = cfg.AMQP.Outbox.MaxAttempts
+	}
+
+	res.purgeDelivered = cfg.AMQP.Outbox.PurgeDelivered
+
+	return res, nil
+}
+
+func wait(ctx context.Context, d time.Duration) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(d):
+	}
+}
@@ -0,0 +1,261 @@
+//go:build unit
+
+package amqpoutbox
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/common/tenant"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/config"
+	cmocks "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/config/mocks"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/dbtest"
+	dmocks "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/mocks"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/log"
+	amqpbusmessage "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/messagebus/amqp"
+	amocks "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/messagebus/amqp/mocks"
+)
+
+func newTestService(
+	t *testing.T,
+	gdb *gorm.DB,
+	outboxCfg *config.AMQPOutboxConfig,
+) (*service, *amocks.MockService) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+
+	cfgManagerMock := cmocks.NewMockManager(ctrl)
+	cfgManagerMock.EXPECT().GetConfig().AnyTimes().Return(&config.Config{
+		AMQP: &config.AMQPConfig{Outbox: outboxCfg},
+	})
+
+	dbMock := dmocks.NewMockDB(ctrl)
+	dbMock.EXPECT().GetGormDB().AnyTimes().Return(gdb)
+	dbMock.EXPECT().GetTransactionalOrDefaultGormDB(gomock.Any()).AnyTimes().Return(gdb)
+
+	amqpMock := amocks.NewMockService(ctrl)
+
+	return &service{
+		logger:     log.NewLogger(),
+		cfgManager: cfgManagerMock,
+		db:         dbMock,
+		amqpSvc:    amqpMock,
+	}, amqpMock
+}
+
+func Test_service_Enqueue(t *testing.T) {
+	gdb := dbtest.NewSQLiteDB(t, &Message{})
+	s, _ := newTestService(t, gdb, nil)
+
+	err := s.Enqueue(context.TODO(), &amqp091.Publishing{
+		ContentType: "application/json",
+		Body:        []byte(`{"fake":true}`),
+	}, &amqpbusmessage.PublishConfigInput{
+		Exchange:   "exchange",
+		RoutingKey: "key",
+		Mandatory:  true,
+		Timeout:    5 * time.Second,
+	})
+	require.NoError(t, err)
+
+	var list []*Message
+	require.NoError(t, gdb.Find(&list).Error)
+	require.Len(t, list, 1)
+
+	assert.NotEmpty(t, list[0].ID)
+	assert.Equal(t, "exchange", list[0].Exchange)
+	assert.Equal(t, "key", list[0].RoutingKey)
+	assert.True(t, list[0].Mandatory)
+	assert.Equal(t, 5*time.Second, list[0].Timeout)
+	assert.Nil(t, list[0].DeliveredAt)
+	assert.Equal(t, 0, list[0].Attempts)
+}
+
+func Test_service_Enqueue_Tenant(t *testing.T) {
+	gdb := dbtest.NewSQLiteDB(t, &Message{})
+	s, _ := newTestService(t, gdb, nil)
+
+	message := &amqp091.Publishing{
+		Headers: amqp091.Table{"key": "value"},
+		Body:    []byte(`{"fake":true}`),
+	}
+
+	err := s.Enqueue(tenant.SetInContext(context.TODO(), "tenant1"), message, &amqpbusmessage.PublishConfigInput{
+		Exchange: "exchange",
+	})
+	require.NoError(t, err)
+
+	// Caller headers mustn't be modified
+	assert.Equal(t, amqp091.Table{"key": "value"}, message.Headers)
+
+	var list []*Message
+	require.NoError(t, gdb.Find(&list).Error)
+	require.Len(t, list, 1)
+
+	var saved amqp091.Publishing
+	require.NoError(t, json.Unmarshal(list[0].Publishing, &saved))
+	assert.Equal(t, amqp091.Table{"key": "value", amqpbusmessage.TenantHeaderName: "tenant1"}, saved.Headers)
+}
+
+func Test_service_RelayPending(t *testing.T) {
+	tests := []struct {
+		name           string
+		outboxCfg      *config.AMQPOutboxConfig
+		publishErr     error
+		wantCount      int
+		wantErr        bool
+		wantRows       int
+		wantDelivered  bool
+		wantAttempts   int
+		wantLastErrMsg string
+	}{
+		{
+			name:          "mark as delivered",
+			wantCount:     1,
+			wantRows:      1,
+			wantDelivered: true,
+			wantAttempts:  1,
+		},
+		{
+			name:      "purge delivered",
+			outboxCfg: &config.AMQPOutboxConfig{PurgeDelivered: true},
+			wantCount: 1,
+			wantRows:  0,
+		},
+		{
+			name:           "publish error",
+			publishErr:     errors.New("fake"),
+			wantCount:      0,
+			wantErr:        true,
+			wantRows:       1,
+			wantAttempts:   1,
+			wantLastErrMsg: "fake",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb := dbtest.NewSQLiteDB(t, &Message{})
+			s, amqpMock := newTestService(t, gdb, tt.outboxCfg)
+
+			require.NoError(t, s.Enqueue(context.TODO(), &amqp091.Publishing{
+				Body: []byte("body"),
+			}, &amqpbusmessage.PublishConfigInput{
+				Exchange:   "exchange",
+				RoutingKey: "key",
+			}))
+
+			amqpMock.EXPECT().
+				Publish(gomock.Any(), &amqp091.Publishing{Body: []byte("body")}, &amqpbusmessage.PublishConfigInput{
+					Exchange:   "exchange",
+					RoutingKey: "key",
+				}).
+				Return(tt.publishErr)
+
+			got, err := s.RelayPending(context.TODO())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("service.RelayPending() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			assert.Equal(t, tt.wantCount, got)
+
+			var list []*Message
+			require.NoError(t, gdb.Unscoped().Find(&list).Error)
+			require.Len(t, list, tt.wantRows)
+
+			if tt.wantRows == 0 {
+				return
+			}
+
+			assert.Equal(t, tt.wantDelivered, list[0].DeliveredAt != nil)
+			assert.Equal(t, tt.wantAttempts, list[0].Attempts)
+			assert.Equal(t, tt.wantLastErrMsg, list[0].LastError)
+
+			// Delivered messages mustn't be relayed again
+			if tt.wantDelivered {
+				got, err = s.RelayPending(context.TODO())
+				require.NoError(t, err)
+				assert.Equal(t, 0, got)
+			}
+		})
+	}
+}
+
+func Test_service_RelayPending_FailingMessage(t *testing.T) {
+	gdb := dbtest.NewSQLiteDB(t, &Message{})
+	s, amqpMock := newTestService(t, gdb, &config.AMQPOutboxConfig{MaxAttempts: 2})
+
+	for _, body := range []string{"poison", "valid"} {
+		require.NoError(t, s.Enqueue(context.TODO(), &amqp091.Publishing{
+			Body: []byte(body),
+		}, &amqpbusmessage.PublishConfigInput{Exchange: "exchange"}))
+		// Ensure ordering on creation date
+		time.Sleep(time.Millisecond)
+	}
+
+	amqpMock.EXPECT().
+		Publish(gomock.Any(), &amqp091.Publishing{Body: []byte("poison")}, gomock.Any()).
+		Return(errors.New("fake"))
+	amqpMock.EXPECT().
+		Publish(gomock.Any(), &amqp091.Publishing{Body: []byte("valid")}, gomock.Any()).
+		Return(nil)
+
+	// Failing message mustn't block next ones
+	got, err := s.RelayPending(context.TODO())
+	assert.Error(t, err)
+	assert.Equal(t, 1, got)
+
+	var poison Message
+	require.NoError(t, gdb.Where("delivered_at IS NULL").First(&poison).Error)
+	assert.Equal(t, 1, poison.Attempts)
+	assert.Equal(t, "fake", poison.LastError)
+	assert.Nil(t, poison.DeadLetteredAt)
+	require.NotNil(t, poison.NextAttemptAt)
+	assert.True(t, poison.NextAttemptAt.After(time.Now()))
+
+	// Message in backoff mustn't be relayed
+	got, err = s.RelayPending(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, 0, got)
+
+	// Make next attempt available
+	require.NoError(t, gdb.Model(&poison).Update("next_attempt_at", time.Now().Add(-time.Second)).Error)
+
+	amqpMock.EXPECT().
+		Publish(gomock.Any(), &amqp091.Publishing{Body: []byte("poison")}, gomock.Any()).
+		Return(errors.New("fake"))
+
+	// Max attempts is reached
+	got, err = s.RelayPending(context.TODO())
+	assert.Error(t, err)
+	assert.Equal(t, 0, got)
+
+	require.NoError(t, gdb.First(&poison, "id = ?", poison.ID).Error)
+	assert.Equal(t, 2, poison.Attempts)
+	assert.NotNil(t, poison.DeadLetteredAt)
+
+	// Dead lettered message mustn't be relayed anymore
+	require.NoError(t, gdb.Model(&poison).Update("next_attempt_at", nil).Error)
+
+	got, err = s.RelayPending(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, 0, got)
+}
+
+func Test_getRetryBackoff(t *testing.T) {
+	assert.Equal(t, time.Second, getRetryBackoff(time.Second, 1))
+	assert.Equal(t, 2*time.Second, getRetryBackoff(time.Second, 2))
+	assert.Equal(t, 8*time.Second, getRetryBackoff(time.Second, 4))
+	assert.Equal(t, time.Hour, getRetryBackoff(time.Second, 100))
+}
@@ -32,6 +32,10 @@ type Service interface {
 	IncreaseSuccessfullyAMQPPublishedMessage(exchange, routingKey string)
 	// IncreaseFailedAMQPPublishedMessage will increase counter of failed AMQP published message.
 	IncreaseFailedAMQPPublishedMessage(exchange, routingKey string)
+	// IncreaseDatabaseTransactionAttempt will increase counter of database transaction attempts by status (success, retry or error).
+	IncreaseDatabaseTransactionAttempt(connectionName, status string)
+	// IncreaseDatabaseSlowQuery will increase counter of database slow queries.
+	IncreaseDatabaseSlowQuery(connectionName string)
 	// UpFailedConfigReload will raise the failed configuration reload gauge.
 	UpFailedConfigReload()
 	// DownFailedConfigReload will down the failed configuration reload gauge.
@@ -83,6 +83,30 @@ func (mr *MockServiceMockRecorder) GraphqlMiddleware() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GraphqlMiddleware", reflect.TypeOf((*MockService)(nil).GraphqlMiddleware))
 }
 
+// IncreaseDatabaseSlowQuery mocks base method.
+func (m *MockService) IncreaseDatabaseSlowQuery(connectionName string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "IncreaseDatabaseSlowQuery", connectionName)
+}
+
+// IncreaseDatabaseSlowQuery indicates an expected call of IncreaseDatabaseSlowQuery.
+func (mr *MockServiceMockRecorder) IncreaseDatabaseSlowQuery(connectionName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncreaseDatabaseSlowQuery", reflect.TypeOf((*MockService)(nil).IncreaseDatabaseSlowQuery), connectionName)
+}
+
+// IncreaseDatabaseTransactionAttempt mocks base method.
+func (m *MockService) IncreaseDatabaseTransactionAttempt(connectionName, status string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "IncreaseDatabaseTransactionAttempt", connectionName, status)
+}
+
+// IncreaseDatabaseTransactionAttempt indicates an expected call of IncreaseDatabaseTransactionAttempt.
+func (mr *MockServiceMockRecorder) IncreaseDatabaseTransactionAttempt(connectionName, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncreaseDatabaseTransactionAttempt", reflect.TypeOf((*MockService)(nil).IncreaseDatabaseTransactionAttempt), connectionName, status)
+}
+
 // IncreaseFailedAMQPConsumedMessage mocks base method.
 func (m *MockService) IncreaseFailedAMQPConsumedMessage(queue, consumerTag, routingKey string) {
 	m.ctrl.T.Helper()
@@ -25,6 +25,8 @@ type prometheusMetrics struct {
 	gormPrometheus        map[string]gorm.Plugin
 	amqpConsumedMessages  *prometheus.CounterVec
 	amqpPublishedMessages *prometheus.CounterVec
+	dbTransactionAttempts *prometheus.CounterVec
+	dbSlowQueries         *prometheus.CounterVec
 }
 
 func (*prometheusMetrics) GraphqlMiddleware() gqlgraphql.HandlerExtension {
@@ -65,6 +67,14 @@ func (impl *prometheusMetrics) IncreaseFailedAMQPPublishedMessage(exchange, rout
 	impl.amqpPublishedMessages.WithLabelValues(exchange, routingKey, "error").Inc()
 }
 
+func (impl *prometheusMetrics) IncreaseDatabaseTransactionAttempt(connectionName, status string) {
+	impl.dbTransactionAttempts.WithLabelValues(connectionName, status).Inc()
+}
+
+func (impl *prometheusMetrics) IncreaseDatabaseSlowQuery(connectionName string) {
+	impl.dbSlowQueries.WithLabelValues(connectionName).Inc()
+}
+
 // The gorm prometheus plugin cannot be instantiated twice because there is a loop inside that cannot be modified or stopped.
 // This loop get all data from database and the loop cannot be modified in terms of the duration.
 // Labels and all other options cannot be modified.
@@ -212,6 +222,24 @@ func (impl *prometheusMetrics) register() {
 	)
 	prometheus.MustRegister(impl.amqpPublishedMessages)
 
+	impl.dbTransactionAttempts = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "database_transaction_attempts_total",
+			Help: "How many database transaction attempts have been executed by connection name and status",
+		},
+		[]string{"connection_name", "status"},
+	)
+	prometheus.MustRegister(impl.dbTransactionAttempts)
+
+	impl.dbSlowQueries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "database_slow_queries_total",
+			Help: "How many database queries have exceeded the slow query threshold by connection name",
+		},
+		[]string{"connection_name"},
+	)
+	prometheus.MustRegister(impl.dbSlowQueries)
+
 	// Register gqlgen graphql prometheus metrics
 	gqlprometheus.Register()
 }
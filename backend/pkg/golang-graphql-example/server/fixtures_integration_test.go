@@ -0,0 +1,29 @@
+//go:build integration
+
+package server
+
+import (
+	"context"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/todos/models"
+)
+
+func (suite *GraphQLTestSuite) TestSeedDB() {
+	// Seed twice to check that fixtures are upserted
+	res, err := suite.busiServices.SeedDB(context.TODO(), "development")
+	suite.Require().NoError(err)
+	_, err = suite.busiServices.SeedDB(context.TODO(), "development")
+	suite.Require().NoError(err)
+
+	var list []*models.Todo
+
+	suite.Require().NoError(suite.db.GetGormDB().Order("text").Find(&list).Error)
+	suite.Require().Len(list, 2)
+
+	suite.Equal(res.GetID("todo1"), list[0].ID)
+	suite.Equal("Install the application", list[0].Text)
+	suite.True(list[0].Done)
+	suite.Equal("organization1", list[0].TenantID)
+	suite.Equal("Read the documentation", list[1].Text)
+	suite.False(list[1].Done)
+}
@@ -26,6 +26,8 @@ import (
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/authx/authentication"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/authx/authorization"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business"
+	auditmodels "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/audits/models"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/fixtures"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/todos/models"
 	cmocks "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/config/mocks"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
@@ -80,7 +82,7 @@ func (suite *GraphQLTestSuite) SetupSuite() {
 	// Create authorization service
 	authoCl := authorization.NewService(cfgManagerMock)
 	// Create services
-	bSvc := business.NewServices(logger, db, authoCl)
+	bSvc := business.NewServices(logger, db, authoCl, ld)
 	// Migrate
 	err = bSvc.MigrateDB(context.TODO())
 	suite.NoError(err)
@@ -171,6 +173,7 @@ func (suite *GraphQLTestSuite) AfterTest(suiteName, testName string) {
 func (suite *GraphQLTestSuite) cleanDB() {
 	modelList := []any{
 		&models.Todo{},
+		&auditmodels.AuditEvent{},
 	}
 
 	for _, item := range modelList {
@@ -188,6 +191,14 @@ func (suite *GraphQLTestSuite) setupGenericDataset(dataset []any) {
 	}
 }
 
+// setupFixtures will load a fixture set of the testdata/fixtures folder.
+func (suite *GraphQLTestSuite) setupFixtures(set string) *fixtures.Result {
+	res, err := suite.busiServices.LoadDBFixtures(context.TODO(), os.DirFS("testdata/fixtures"), set)
+	suite.NoError(err)
+
+	return res
+}
+
 func TestGraphQLTestSuite(t *testing.T) {
 	// Verify there isn't any go routine leak
 	defer goleak.VerifyNone(
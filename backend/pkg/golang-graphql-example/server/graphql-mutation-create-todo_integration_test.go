@@ -40,6 +40,7 @@ func (suite *GraphQLTestSuite) TestMutationCreateTodo() {
 	suite.NoError(dbRes.Error)
 	suite.Equal(res.ID, uuid)
 	suite.Equal(res.Text, m.Todo.Text)
+	suite.Equal("organization1", res.TenantID)
 	suite.NotEmpty(res.CreatedAt)
 	suite.NotEmpty(res.UpdatedAt)
 }
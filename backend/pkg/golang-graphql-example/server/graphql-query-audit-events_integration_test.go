@@ -0,0 +1,78 @@
+//go:build integration
+
+package server
+
+import (
+	"context"
+
+	"github.com/hasura/go-graphql-client"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/todos"
+	graphqlutils "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/common/graphqlutils"
+)
+
+func (suite *GraphQLTestSuite) TestQueryAuditEvents() {
+	var m struct {
+		Todo struct {
+			ID string
+		} `graphql:"createTodo(input: $input)"`
+	}
+	type NewTodo struct {
+		Text string `json:"text"`
+	}
+
+	err := suite.graphqlClient.Mutate(context.TODO(), &m, map[string]any{
+		"input": NewTodo{Text: "Audited"},
+	})
+	suite.NoError(err)
+
+	var q struct {
+		AuditEvents struct {
+			Edges []struct {
+				Node struct {
+					EntityID       string
+					ModelName      string
+					Action         string
+					UserIdentifier string
+				}
+			}
+		} `graphql:"auditEvents(entityId: $entityId, first: 10)"`
+	}
+
+	err = suite.graphqlClient.Query(context.TODO(), &q, map[string]any{
+		"entityId": graphql.ID(m.Todo.ID),
+	})
+
+	suite.NoError(err)
+	suite.Len(q.AuditEvents.Edges, 1)
+
+	uuid, err := graphqlutils.FromRelayID(todos.TodoIDPrefix, m.Todo.ID)
+	suite.NoError(err)
+
+	ev := q.AuditEvents.Edges[0].Node
+	suite.Equal(uuid, ev.EntityID)
+	suite.Equal(todos.TodoModelName, ev.ModelName)
+	suite.Equal("CREATE", ev.Action)
+	suite.NotEmpty(ev.UserIdentifier)
+}
+
+func (suite *GraphQLTestSuite) TestQueryAuditEventsOtherTenant() {
+	res := suite.setupFixtures("todos-other-tenant")
+
+	var q struct {
+		AuditEvents struct {
+			Edges []struct {
+				Node struct {
+					ID string
+				}
+			}
+		} `graphql:"auditEvents(entityId: $entityId, first: 10)"`
+	}
+
+	err := suite.graphqlClient.Query(context.TODO(), &q, map[string]any{
+		"entityId": graphql.ID(graphqlutils.ToRelayID(todos.TodoIDPrefix, res.GetID("otherTodo1"))),
+	})
+
+	// Entity isn't readable by current user
+	suite.Error(err)
+}
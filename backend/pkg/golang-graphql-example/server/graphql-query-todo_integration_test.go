@@ -0,0 +1,56 @@
+//go:build integration
+
+package server
+
+import (
+	"context"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/todos"
+	graphqlutils "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/common/graphqlutils"
+)
+
+func (suite *GraphQLTestSuite) TestQueryTodo() {
+	res := suite.setupFixtures("todos")
+
+	var q struct {
+		Todo struct {
+			ID   string
+			Text string
+			Done bool
+		} `graphql:"todo(id: $id)"`
+	}
+	variables := map[string]any{
+		"id": graphqlutils.ToRelayID(todos.TodoIDPrefix, res.GetID("todo1")),
+	}
+
+	err := suite.graphqlClient.Query(context.TODO(), &q, variables)
+
+	suite.NoError(err)
+	suite.Equal("First todo", q.Todo.Text)
+	suite.True(q.Todo.Done)
+	suite.Equal(variables["id"], q.Todo.ID)
+}
+
+func (suite *GraphQLTestSuite) TestQueryTodoFieldValues() {
+	suite.setupFixtures("todos")
+
+	var q struct {
+		TodoFieldValues struct {
+			Edges []struct {
+				Node struct {
+					Value string
+				}
+			}
+			PageInfo struct {
+				HasNextPage bool
+			}
+		} `graphql:"todoFieldValues(field: TEXT, search: \"sec\", first: 10)"`
+	}
+
+	err := suite.graphqlClient.Query(context.TODO(), &q, nil)
+
+	suite.NoError(err)
+	suite.Len(q.TodoFieldValues.Edges, 1)
+	suite.Equal("Second todo", q.TodoFieldValues.Edges[0].Node.Value)
+	suite.False(q.TodoFieldValues.PageInfo.HasNextPage)
+}
@@ -0,0 +1,83 @@
+//go:build integration
+
+package server
+
+import (
+	"context"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/todos"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/todos/models"
+	graphqlutils "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/common/graphqlutils"
+)
+
+func (suite *GraphQLTestSuite) TestQueryTodosOtherTenant() {
+	suite.setupFixtures("todos")
+	suite.setupFixtures("todos-other-tenant")
+
+	var q struct {
+		Todos struct {
+			Edges []struct {
+				Node struct {
+					Text string
+				}
+			}
+		} `graphql:"todos(first: 10)"`
+	}
+
+	err := suite.graphqlClient.Query(context.TODO(), &q, nil)
+
+	suite.NoError(err)
+	suite.Len(q.Todos.Edges, 2)
+
+	for _, e := range q.Todos.Edges {
+		suite.NotEqual("Other tenant todo", e.Node.Text)
+	}
+}
+
+func (suite *GraphQLTestSuite) TestQueryTodoOtherTenant() {
+	res := suite.setupFixtures("todos-other-tenant")
+
+	var q struct {
+		Todo *struct {
+			ID string
+		} `graphql:"todo(id: $id)"`
+	}
+	variables := map[string]any{
+		"id": graphqlutils.ToRelayID(todos.TodoIDPrefix, res.GetID("otherTodo1")),
+	}
+
+	err := suite.graphqlClient.Query(context.TODO(), &q, variables)
+
+	suite.NoError(err)
+	suite.Nil(q.Todo)
+}
+
+func (suite *GraphQLTestSuite) TestMutationUpdateTodoOtherTenant() {
+	res := suite.setupFixtures("todos-other-tenant")
+
+	var m struct {
+		Todo struct {
+			ID string
+		} `graphql:"updateTodo(input: $input)"`
+	}
+	type UpdateTodo struct {
+		ID   string `json:"id"`
+		Text string `json:"text"`
+	}
+	variables := map[string]any{
+		"input": UpdateTodo{
+			ID:   graphqlutils.ToRelayID(todos.TodoIDPrefix, res.GetID("otherTodo1")),
+			Text: "Updated",
+		},
+	}
+
+	err := suite.graphqlClient.Mutate(context.TODO(), &m, variables)
+
+	suite.Error(err)
+
+	var tt models.Todo
+	dbRes := suite.db.GetGormDB().Where("id", res.GetID("otherTodo1")).First(&tt)
+	suite.NoError(dbRes.Error)
+	suite.Equal("Other tenant todo", tt.Text)
+	suite.Equal("organization2", tt.TenantID)
+}
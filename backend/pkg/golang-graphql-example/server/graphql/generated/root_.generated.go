@@ -9,7 +9,8 @@ import (
 	"sync/atomic"
 
 	"github.com/99designs/gqlgen/graphql"
-	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/todos/models"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/audits/models"
+	models1 "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/todos/models"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/server/graphql/model"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/server/graphql/utils"
 	gqlparser "github.com/vektah/gqlparser/v2"
@@ -24,9 +25,12 @@ func NewExecutableSchema(cfg Config) graphql.ExecutableSchema {
 type Config = graphql.Config[ResolverRoot, DirectiveRoot, ComplexityRoot]
 
 type ResolverRoot interface {
+	AuditEvent() AuditEventResolver
+	FieldValue() FieldValueResolver
 	Mutation() MutationResolver
 	Query() QueryResolver
 	Todo() TodoResolver
+	TodoAggregateBucket() TodoAggregateBucketResolver
 	BooleanFilter() BooleanFilterResolver
 	DateFilter() DateFilterResolver
 	IntFilter() IntFilterResolver
@@ -37,10 +41,48 @@ type DirectiveRoot struct {
 }
 
 type ComplexityRoot struct {
+	AuditEvent struct {
+		Action         func(childComplexity int) int
+		After          func(childComplexity int) int
+		Before         func(childComplexity int) int
+		CorrelationID  func(childComplexity int) int
+		CreatedAt      func(childComplexity int, format *utils.DateFormat) int
+		EntityID       func(childComplexity int) int
+		ID             func(childComplexity int) int
+		ModelName      func(childComplexity int) int
+		UserIdentifier func(childComplexity int) int
+	}
+
+	AuditEventConnection struct {
+		Edges    func(childComplexity int) int
+		PageInfo func(childComplexity int) int
+	}
+
+	AuditEventEdge struct {
+		Cursor func(childComplexity int) int
+		Node   func(childComplexity int) int
+	}
+
+	FieldValue struct {
+		Value func(childComplexity int, format *utils.DateFormat) int
+	}
+
+	FieldValueConnection struct {
+		Edges    func(childComplexity int) int
+		PageInfo func(childComplexity int) int
+	}
+
+	FieldValueEdge struct {
+		Cursor func(childComplexity int) int
+		Node   func(childComplexity int) int
+	}
+
 	Mutation struct {
-		CloseTodo  func(childComplexity int, todoID string) int
-		CreateTodo func(childComplexity int, input model.NewTodo) int
-		UpdateTodo func(childComplexity int, input *model.UpdateTodo) int
+		CloseTodo   func(childComplexity int, todoID string) int
+		CreateTodo  func(childComplexity int, input model.NewTodo) int
+		DeleteTodo  func(childComplexity int, todoID string) int
+		RestoreTodo func(childComplexity int, todoID string) int
+		UpdateTodo  func(childComplexity int, input *model.UpdateTodo) int
 	}
 
 	PageInfo struct {
@@ -51,8 +93,11 @@ type ComplexityRoot struct {
 	}
 
 	Query struct {
-		Todo  func(childComplexity int, id string) int
-		Todos func(childComplexity int, after *string, before *string, first *int, last *int, sort *models.SortOrder, sorts []*models.SortOrder, filter *models.Filter) int
+		AuditEvents     func(childComplexity int, entityID string, after *string, before *string, first *int, last *int, sorts []*models.SortOrder, filter *models.Filter) int
+		Todo            func(childComplexity int, id string) int
+		TodoFieldValues func(childComplexity int, field models1.Field, filter *models1.Filter, search *string, after *string, first *int) int
+		Todos           func(childComplexity int, after *string, before *string, first *int, last *int, sort *models1.SortOrder, sorts []*models1.SortOrder, filter *models1.Filter) int
+		TodosAggregate  func(childComplexity int, filter *models1.Filter, groupBy models1.GroupBy) int
 	}
 
 	Todo struct {
@@ -61,11 +106,20 @@ type ComplexityRoot struct {
 		ID        func(childComplexity int) int
 		Text      func(childComplexity int) int
 		UpdatedAt func(childComplexity int, format *utils.DateFormat) int
+		Version   func(childComplexity int) int
+	}
+
+	TodoAggregateBucket struct {
+		Count     func(childComplexity int) int
+		CreatedAt func(childComplexity int, format *utils.DateFormat) int
+		Done      func(childComplexity int) int
+		UpdatedAt func(childComplexity int, format *utils.DateFormat) int
 	}
 
 	TodoConnection struct {
-		Edges    func(childComplexity int) int
-		PageInfo func(childComplexity int) int
+		Edges      func(childComplexity int) int
+		PageInfo   func(childComplexity int) int
+		TotalCount func(childComplexity int) int
 	}
 
 	TodoEdge struct {
@@ -88,6 +142,130 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 	_ = ec
 	switch typeName + "." + field {
 
+	case "AuditEvent.action":
+		if e.ComplexityRoot.AuditEvent.Action == nil {
+			break
+		}
+
+		return e.ComplexityRoot.AuditEvent.Action(childComplexity), true
+	case "AuditEvent.after":
+		if e.ComplexityRoot.AuditEvent.After == nil {
+			break
+		}
+
+		return e.ComplexityRoot.AuditEvent.After(childComplexity), true
+	case "AuditEvent.before":
+		if e.ComplexityRoot.AuditEvent.Before == nil {
+			break
+		}
+
+		return e.ComplexityRoot.AuditEvent.Before(childComplexity), true
+	case "AuditEvent.correlationId":
+		if e.ComplexityRoot.AuditEvent.CorrelationID == nil {
+			break
+		}
+
+		return e.ComplexityRoot.AuditEvent.CorrelationID(childComplexity), true
+	case "AuditEvent.createdAt":
+		if e.ComplexityRoot.AuditEvent.CreatedAt == nil {
+			break
+		}
+
+		args, err := ec.field_AuditEvent_createdAt_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.ComplexityRoot.AuditEvent.CreatedAt(childComplexity, args["format"].(*utils.DateFormat)), true
+	case "AuditEvent.entityId":
+		if e.ComplexityRoot.AuditEvent.EntityID == nil {
+			break
+		}
+
+		return e.ComplexityRoot.AuditEvent.EntityID(childComplexity), true
+	case "AuditEvent.id":
+		if e.ComplexityRoot.AuditEvent.ID == nil {
+			break
+		}
+
+		return e.ComplexityRoot.AuditEvent.ID(childComplexity), true
+	case "AuditEvent.modelName":
+		if e.ComplexityRoot.AuditEvent.ModelName == nil {
+			break
+		}
+
+		return e.ComplexityRoot.AuditEvent.ModelName(childComplexity), true
+	case "AuditEvent.userIdentifier":
+		if e.ComplexityRoot.AuditEvent.UserIdentifier == nil {
+			break
+		}
+
+		return e.ComplexityRoot.AuditEvent.UserIdentifier(childComplexity), true
+
+	case "AuditEventConnection.edges":
+		if e.ComplexityRoot.AuditEventConnection.Edges == nil {
+			break
+		}
+
+		return e.ComplexityRoot.AuditEventConnection.Edges(childComplexity), true
+	case "AuditEventConnection.pageInfo":
+		if e.ComplexityRoot.AuditEventConnection.PageInfo == nil {
+			break
+		}
+
+		return e.ComplexityRoot.AuditEventConnection.PageInfo(childComplexity), true
+
+	case "AuditEventEdge.cursor":
+		if e.ComplexityRoot.AuditEventEdge.Cursor == nil {
+			break
+		}
+
+		return e.ComplexityRoot.AuditEventEdge.Cursor(childComplexity), true
+	case "AuditEventEdge.node":
+		if e.ComplexityRoot.AuditEventEdge.Node == nil {
+			break
+		}
+
+		return e.ComplexityRoot.AuditEventEdge.Node(childComplexity), true
+
+	case "FieldValue.value":
+		if e.ComplexityRoot.FieldValue.Value == nil {
+			break
+		}
+
+		args, err := ec.field_FieldValue_value_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.ComplexityRoot.FieldValue.Value(childComplexity, args["format"].(*utils.DateFormat)), true
+
+	case "FieldValueConnection.edges":
+		if e.ComplexityRoot.FieldValueConnection.Edges == nil {
+			break
+		}
+
+		return e.ComplexityRoot.FieldValueConnection.Edges(childComplexity), true
+	case "FieldValueConnection.pageInfo":
+		if e.ComplexityRoot.FieldValueConnection.PageInfo == nil {
+			break
+		}
+
+		return e.ComplexityRoot.FieldValueConnection.PageInfo(childComplexity), true
+
+	case "FieldValueEdge.cursor":
+		if e.ComplexityRoot.FieldValueEdge.Cursor == nil {
+			break
+		}
+
+		return e.ComplexityRoot.FieldValueEdge.Cursor(childComplexity), true
+	case "FieldValueEdge.node":
+		if e.ComplexityRoot.FieldValueEdge.Node == nil {
+			break
+		}
+
+		return e.ComplexityRoot.FieldValueEdge.Node(childComplexity), true
+
 	case "Mutation.closeTodo":
 		if e.ComplexityRoot.Mutation.CloseTodo == nil {
 			break
@@ -110,6 +288,28 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.ComplexityRoot.Mutation.CreateTodo(childComplexity, args["input"].(model.NewTodo)), true
+	case "Mutation.deleteTodo":
+		if e.ComplexityRoot.Mutation.DeleteTodo == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_deleteTodo_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.ComplexityRoot.Mutation.DeleteTodo(childComplexity, args["todoId"].(string)), true
+	case "Mutation.restoreTodo":
+		if e.ComplexityRoot.Mutation.RestoreTodo == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_restoreTodo_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.ComplexityRoot.Mutation.RestoreTodo(childComplexity, args["todoId"].(string)), true
 	case "Mutation.updateTodo":
 		if e.ComplexityRoot.Mutation.UpdateTodo == nil {
 			break
@@ -147,6 +347,18 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.ComplexityRoot.PageInfo.StartCursor(childComplexity), true
 
+	case "Query.auditEvents":
+		if e.ComplexityRoot.Query.AuditEvents == nil {
+			break
+		}
+
+		args, err := ec.field_Query_auditEvents_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.ComplexityRoot.Query.AuditEvents(childComplexity, args["entityId"].(string), args["after"].(*string), args["before"].(*string), args["first"].(*int), args["last"].(*int), args["sorts"].([]*models.SortOrder), args["filter"].(*models.Filter)), true
+
 	case "Query.todo":
 		if e.ComplexityRoot.Query.Todo == nil {
 			break
@@ -158,6 +370,17 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.ComplexityRoot.Query.Todo(childComplexity, args["id"].(string)), true
+	case "Query.todoFieldValues":
+		if e.ComplexityRoot.Query.TodoFieldValues == nil {
+			break
+		}
+
+		args, err := ec.field_Query_todoFieldValues_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.ComplexityRoot.Query.TodoFieldValues(childComplexity, args["field"].(models1.Field), args["filter"].(*models1.Filter), args["search"].(*string), args["after"].(*string), args["first"].(*int)), true
 	case "Query.todos":
 		if e.ComplexityRoot.Query.Todos == nil {
 			break
@@ -168,7 +391,18 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 			return 0, false
 		}
 
-		return e.ComplexityRoot.Query.Todos(childComplexity, args["after"].(*string), args["before"].(*string), args["first"].(*int), args["last"].(*int), args["sort"].(*models.SortOrder), args["sorts"].([]*models.SortOrder), args["filter"].(*models.Filter)), true
+		return e.ComplexityRoot.Query.Todos(childComplexity, args["after"].(*string), args["before"].(*string), args["first"].(*int), args["last"].(*int), args["sort"].(*models1.SortOrder), args["sorts"].([]*models1.SortOrder), args["filter"].(*models1.Filter)), true
+	case "Query.todosAggregate":
+		if e.ComplexityRoot.Query.TodosAggregate == nil {
+			break
+		}
+
+		args, err := ec.field_Query_todosAggregate_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.ComplexityRoot.Query.TodosAggregate(childComplexity, args["filter"].(*models1.Filter), args["groupBy"].(models1.GroupBy)), true
 
 	case "Todo.createdAt":
 		if e.ComplexityRoot.Todo.CreatedAt == nil {
@@ -210,6 +444,47 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.ComplexityRoot.Todo.UpdatedAt(childComplexity, args["format"].(*utils.DateFormat)), true
+	case "Todo.version":
+		if e.ComplexityRoot.Todo.Version == nil {
+			break
+		}
+
+		return e.ComplexityRoot.Todo.Version(childComplexity), true
+
+	case "TodoAggregateBucket.count":
+		if e.ComplexityRoot.TodoAggregateBucket.Count == nil {
+			break
+		}
+
+		return e.ComplexityRoot.TodoAggregateBucket.Count(childComplexity), true
+	case "TodoAggregateBucket.createdAt":
+		if e.ComplexityRoot.TodoAggregateBucket.CreatedAt == nil {
+			break
+		}
+
+		args, err := ec.field_TodoAggregateBucket_createdAt_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.ComplexityRoot.TodoAggregateBucket.CreatedAt(childComplexity, args["format"].(*utils.DateFormat)), true
+	case "TodoAggregateBucket.done":
+		if e.ComplexityRoot.TodoAggregateBucket.Done == nil {
+			break
+		}
+
+		return e.ComplexityRoot.TodoAggregateBucket.Done(childComplexity), true
+	case "TodoAggregateBucket.updatedAt":
+		if e.ComplexityRoot.TodoAggregateBucket.UpdatedAt == nil {
+			break
+		}
+
+		args, err := ec.field_TodoAggregateBucket_updatedAt_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.ComplexityRoot.TodoAggregateBucket.UpdatedAt(childComplexity, args["format"].(*utils.DateFormat)), true
 
 	case "TodoConnection.edges":
 		if e.ComplexityRoot.TodoConnection.Edges == nil {
@@ -223,6 +498,12 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.ComplexityRoot.TodoConnection.PageInfo(childComplexity), true
+	case "TodoConnection.totalCount":
+		if e.ComplexityRoot.TodoConnection.TotalCount == nil {
+			break
+		}
+
+		return e.ComplexityRoot.TodoConnection.TotalCount(childComplexity), true
 
 	case "TodoEdge.cursor":
 		if e.ComplexityRoot.TodoEdge.Cursor == nil {
@@ -245,12 +526,15 @@ func (e *executableSchema) Exec(ctx context.Context) graphql.ResponseHandler {
 	opCtx := graphql.GetOperationContext(ctx)
 	ec := newExecutionContext(opCtx, e, make(chan graphql.DeferredResult))
 	inputUnmarshalMap := graphql.BuildUnmarshalerMap(
+		ec.unmarshalInputAuditEventFilter,
+		ec.unmarshalInputAuditEventSortOrder,
 		ec.unmarshalInputBooleanFilter,
 		ec.unmarshalInputDateFilter,
 		ec.unmarshalInputIntFilter,
 		ec.unmarshalInputNewTodo,
 		ec.unmarshalInputStringFilter,
 		ec.unmarshalInputTodoFilter,
+		ec.unmarshalInputTodoGroupBy,
 		ec.unmarshalInputTodoSortOrder,
 		ec.unmarshalInputUpdateTodo,
 	)
@@ -328,6 +612,68 @@ func newExecutionContext(
 }
 
 var sources = []*ast.Source{
+	{Name: "../../../../../graphql/audit.graphql", Input: `"""
+This represents an audit event recorded on an entity change
+"""
+type AuditEvent {
+  id: ID!
+  createdAt(format: DateFormat): String!
+  """
+  Changed entity identifier
+  """
+  entityId: String!
+  """
+  Changed entity model name
+  """
+  modelName: String!
+  """
+  Action done: CREATE, UPDATE or DELETE
+  """
+  action: String!
+  """
+  Identifier of the user who made the change
+  """
+  userIdentifier: String!
+  """
+  Correlation id of the request that made the change
+  """
+  correlationId: String!
+  """
+  JSON object of changed fields values before change
+  """
+  before: String!
+  """
+  JSON object of changed fields values after change
+  """
+  after: String!
+}
+
+type AuditEventConnection {
+  edges: [AuditEventEdge]
+  pageInfo: PageInfo!
+}
+
+type AuditEventEdge {
+  cursor: String!
+  node: AuditEvent
+}
+
+input AuditEventSortOrder {
+  createdAt: SortOrderEnum
+  modelName: SortOrderEnum
+  action: SortOrderEnum
+}
+
+input AuditEventFilter {
+  AND: [AuditEventFilter!]
+  OR: [AuditEventFilter!]
+  createdAt: DateFilter
+  modelName: StringFilter
+  action: StringFilter
+  userIdentifier: StringFilter
+  correlationId: StringFilter
+}
+`, BuiltIn: false},
 	{Name: "../../../../../graphql/schema.graphql", Input: `# GraphQL schema example
 #
 # https://gqlgen.com/getting-started/
@@ -364,6 +710,10 @@ type Query {
     sort: TodoSortOrder @deprecated(reason: "Use sort list instead")
     """
     Sort list
+
+    Keyset cursors are returned by default. Offset cursors are returned when a sort
+    cannot be used with keyset pagination (relevance, case insensitive, computed or nulls ordered sorts).
+    Cursors must be used with the same sort list.
     """
     sorts: [TodoSortOrder]
     """
@@ -371,12 +721,102 @@ type Query {
     """
     filter: TodoFilter
   ): TodoConnection
+  """
+  Aggregate todos matching filter in buckets
+  """
+  todosAggregate(
+    """
+    Filter
+    """
+    filter: TodoFilter
+    """
+    Group by
+    """
+    groupBy: TodoGroupBy!
+  ): [TodoAggregateBucket!]!
+  """
+  Distinct values of a todo field for todos matching filter, ordered by value.
+  This is used to suggest filter values.
+  """
+  todoFieldValues(
+    """
+    Field
+    """
+    field: TodoField!
+    """
+    Filter
+    """
+    filter: TodoFilter
+    """
+    Case insensitive prefix of values.
+    Only supported on text field.
+    """
+    search: String
+    """
+    Cursor delimiter after you want data (used with first only)
+
+    See here: https://relay.dev/graphql/connections.htm#sec-Forward-pagination-arguments
+    """
+    after: String
+    """
+    First elements
+
+    See here: https://relay.dev/graphql/connections.htm#sec-Forward-pagination-arguments
+    """
+    first: Int
+  ): FieldValueConnection
   todo(id: String!): Todo
+  auditEvents(
+    """
+    Audited entity id (relay id). Entity must be readable by current user.
+    """
+    entityId: ID!
+    """
+    Cursor delimiter after you want data (used with first only)
+
+    See here: https://relay.dev/graphql/connections.htm#sec-Forward-pagination-arguments
+    """
+    after: String
+    """
+    Cursor delimiter before you want data (used with after only)
+
+    See here: https://relay.dev/graphql/connections.htm#sec-Backward-pagination-arguments
+    """
+    before: String
+    """
+    First elements
+
+    See here: https://relay.dev/graphql/connections.htm#sec-Forward-pagination-arguments
+    """
+    first: Int
+    """
+    Last elements (used only with before)
+
+    See here: https://relay.dev/graphql/connections.htm#sec-Backward-pagination-arguments
+    """
+    last: Int
+    """
+    Sort list
+    """
+    sorts: [AuditEventSortOrder]
+    """
+    Filter
+    """
+    filter: AuditEventFilter
+  ): AuditEventConnection
 }
 
 type Mutation {
   createTodo(input: NewTodo!): Todo!
   closeTodo(todoId: ID!): Todo!
+  """
+  Soft delete a todo. It can be restored later.
+  """
+  deleteTodo(todoId: ID!): Todo!
+  """
+  Restore a soft deleted todo.
+  """
+  restoreTodo(todoId: ID!): Todo!
   updateTodo(input: UpdateTodo): Todo!
 }
 `, BuiltIn: false},
@@ -389,6 +829,11 @@ type Todo {
   updatedAt(format: DateFormat): String!
   text: String!
   done: Boolean!
+  """
+  Version used for optimistic concurrency control.
+  It is incremented on each update.
+  """
+  version: Int!
 }
 
 input NewTodo {
@@ -398,11 +843,21 @@ input NewTodo {
 input UpdateTodo {
   id: ID!
   text: String!
+  """
+  Expected todo version.
+  A conflict error is returned if todo was modified in the meantime.
+  """
+  version: Int
 }
 
 type TodoConnection {
   edges: [TodoEdge]
   pageInfo: PageInfo!
+  """
+  Total count of todos matching filter.
+  Only computed when asked.
+  """
+  totalCount: Int
 }
 
 type TodoEdge {
@@ -414,7 +869,31 @@ input TodoSortOrder {
   createdAt: SortOrderEnum
   updatedAt: SortOrderEnum
   text: SortOrderEnum
+  """
+  Sort on lowercased text.
+  This will switch to offset pagination.
+  """
+  textCaseInsensitive: SortOrderEnum
+  """
+  Sort on text length.
+  This will switch to offset pagination.
+  """
+  textLength: SortOrderEnum
   done: SortOrderEnum
+  """
+  Sort on text search relevance.
+  This is only applied when a text search is set and it will switch to offset pagination.
+  Cursors returned with keyset pagination cannot be used with this sort, pagination must restart without cursor.
+  """
+  relevance: SortOrderEnum
+}
+
+"""
+Todo fields on which distinct values can be listed
+"""
+enum TodoField {
+  TEXT
+  DONE
 }
 
 input TodoFilter {
@@ -425,6 +904,27 @@ input TodoFilter {
   text: StringFilter
   done: BooleanFilter
 }
+
+"""
+Todo aggregation group by.
+Dates are grouped on truncated value and done is grouped on value when set to true.
+"""
+input TodoGroupBy {
+  createdAt: DateTruncEnum
+  updatedAt: DateTruncEnum
+  done: Boolean
+}
+
+"""
+Todo aggregation bucket.
+Only grouped fields are set.
+"""
+type TodoAggregateBucket {
+  createdAt(format: DateFormat): String
+  updatedAt(format: DateFormat): String
+  done: Boolean
+  count: Int!
+}
 `, BuiltIn: false},
 	{Name: "../../../../../graphql/utils.graphql", Input: `"""
 Pagination information
@@ -448,12 +948,64 @@ type PageInfo {
   endCursor: String
 }
 
+"""
+Distinct value of a field
+"""
+type FieldValue {
+  """
+  Value rendered as a string.
+  Dates are formatted and booleans are "true" or "false".
+  """
+  value(format: DateFormat): String!
+}
+
+type FieldValueConnection {
+  edges: [FieldValueEdge]
+  pageInfo: PageInfo!
+}
+
+type FieldValueEdge {
+  cursor: String!
+  node: FieldValue
+}
+
 """
 Sort for enumeration
 """
 enum SortOrderEnum {
   ASC
   DESC
+  """
+  Ascending with null values first.
+  This will switch to offset pagination.
+  """
+  ASC_NULLS_FIRST
+  """
+  Ascending with null values last.
+  This will switch to offset pagination.
+  """
+  ASC_NULLS_LAST
+  """
+  Descending with null values first.
+  This will switch to offset pagination.
+  """
+  DESC_NULLS_FIRST
+  """
+  Descending with null values last.
+  This will switch to offset pagination.
+  """
+  DESC_NULLS_LAST
+}
+
+"""
+Date truncation used to group dates in buckets
+"""
+enum DateTruncEnum {
+  HOUR
+  DAY
+  WEEK
+  MONTH
+  YEAR
 }
 
 """
@@ -501,6 +1053,11 @@ input StringFilter {
   """
   notIn: [String]
   """
+  Allow to run a full text search on value.
+  All words must be found. An empty search is ignored.
+  """
+  search: String
+  """
   Allow to test if value is null
   """
   isNull: Boolean
@@ -567,6 +1124,16 @@ input IntFilter {
   """
   notIn: [Int]
   """
+  Allow to test if value is between a start and an end values (included).
+  Array must contain 2 values.
+  """
+  between: [Int!]
+  """
+  Allow to test if value isn't between a start and an end values (included).
+  Array must contain 2 values.
+  """
+  notBetween: [Int!]
+  """
   Allow to test if value is null
   """
   isNull: Boolean
@@ -591,7 +1158,14 @@ input BooleanFilter {
 }
 
 """
-Date filter structure
+Date filter structure.
+
+Dates are RFC3339 dates or relative dates evaluated at query time.
+Relative dates start with "now", "startOf(unit)" or "endOf(unit)" followed by operations:
+"+1d" or "-1d" to add or subtract and "/d" to round down to the start of unit.
+Units are s, m, h, d, w, M and y (second to year), full names are used in startOf and endOf.
+Weeks start on monday and "endOf(unit)" is the last microsecond of the unit.
+Examples: "now-7d", "now/d", "startOf(week)", "endOf(month)-1M".
 """
 input DateFilter {
   """
@@ -643,6 +1217,21 @@ input DateFilter {
   """
   notIn: [String]
   """
+  Allow to test if value is between a start and an end dates (included).
+  Array must contain 2 dates.
+  """
+  between: [String!]
+  """
+  Allow to test if value isn't between a start and an end dates (included).
+  Array must contain 2 dates.
+  """
+  notBetween: [String!]
+  """
+  IANA timezone (like Europe/Paris) used to evaluate relative dates.
+  UTC is used by default.
+  """
+  timezone: String
+  """
   Allow to test if value is null
   """
   isNull: Boolean
@@ -673,6 +1262,78 @@ var parsedSchema = gqlparser.MustLoadSchema(sources...)
 // Each function is generated once per unique object type, deduplicating the
 // switch statements that were previously inlined in every fieldContext_* function.
 
+func (ec *executionContext) childFields_AuditEvent(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+	switch field.Name {
+	case "id":
+		return ec.fieldContext_AuditEvent_id(ctx, field)
+	case "createdAt":
+		return ec.fieldContext_AuditEvent_createdAt(ctx, field)
+	case "entityId":
+		return ec.fieldContext_AuditEvent_entityId(ctx, field)
+	case "modelName":
+		return ec.fieldContext_AuditEvent_modelName(ctx, field)
+	case "action":
+		return ec.fieldContext_AuditEvent_action(ctx, field)
+	case "userIdentifier":
+		return ec.fieldContext_AuditEvent_userIdentifier(ctx, field)
+	case "correlationId":
+		return ec.fieldContext_AuditEvent_correlationId(ctx, field)
+	case "before":
+		return ec.fieldContext_AuditEvent_before(ctx, field)
+	case "after":
+		return ec.fieldContext_AuditEvent_after(ctx, field)
+	}
+	return nil, fmt.Errorf("no field named %q was found under type AuditEvent", field.Name)
+}
+
+func (ec *executionContext) childFields_AuditEventConnection(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+	switch field.Name {
+	case "edges":
+		return ec.fieldContext_AuditEventConnection_edges(ctx, field)
+	case "pageInfo":
+		return ec.fieldContext_AuditEventConnection_pageInfo(ctx, field)
+	}
+	return nil, fmt.Errorf("no field named %q was found under type AuditEventConnection", field.Name)
+}
+
+func (ec *executionContext) childFields_AuditEventEdge(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+	switch field.Name {
+	case "cursor":
+		return ec.fieldContext_AuditEventEdge_cursor(ctx, field)
+	case "node":
+		return ec.fieldContext_AuditEventEdge_node(ctx, field)
+	}
+	return nil, fmt.Errorf("no field named %q was found under type AuditEventEdge", field.Name)
+}
+
+func (ec *executionContext) childFields_FieldValue(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+	switch field.Name {
+	case "value":
+		return ec.fieldContext_FieldValue_value(ctx, field)
+	}
+	return nil, fmt.Errorf("no field named %q was found under type FieldValue", field.Name)
+}
+
+func (ec *executionContext) childFields_FieldValueConnection(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+	switch field.Name {
+	case "edges":
+		return ec.fieldContext_FieldValueConnection_edges(ctx, field)
+	case "pageInfo":
+		return ec.fieldContext_FieldValueConnection_pageInfo(ctx, field)
+	}
+	return nil, fmt.Errorf("no field named %q was found under type FieldValueConnection", field.Name)
+}
+
+func (ec *executionContext) childFields_FieldValueEdge(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+	switch field.Name {
+	case "cursor":
+		return ec.fieldContext_FieldValueEdge_cursor(ctx, field)
+	case "node":
+		return ec.fieldContext_FieldValueEdge_node(ctx, field)
+	}
+	return nil, fmt.Errorf("no field named %q was found under type FieldValueEdge", field.Name)
+}
+
 func (ec *executionContext) childFields_PageInfo(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 	switch field.Name {
 	case "hasNextPage":
@@ -699,16 +1360,34 @@ func (ec *executionContext) childFields_Todo(ctx context.Context, field graphql.
 		return ec.fieldContext_Todo_text(ctx, field)
 	case "done":
 		return ec.fieldContext_Todo_done(ctx, field)
+	case "version":
+		return ec.fieldContext_Todo_version(ctx, field)
 	}
 	return nil, fmt.Errorf("no field named %q was found under type Todo", field.Name)
 }
 
+func (ec *executionContext) childFields_TodoAggregateBucket(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+	switch field.Name {
+	case "createdAt":
+		return ec.fieldContext_TodoAggregateBucket_createdAt(ctx, field)
+	case "updatedAt":
+		return ec.fieldContext_TodoAggregateBucket_updatedAt(ctx, field)
+	case "done":
+		return ec.fieldContext_TodoAggregateBucket_done(ctx, field)
+	case "count":
+		return ec.fieldContext_TodoAggregateBucket_count(ctx, field)
+	}
+	return nil, fmt.Errorf("no field named %q was found under type TodoAggregateBucket", field.Name)
+}
+
 func (ec *executionContext) childFields_TodoConnection(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 	switch field.Name {
 	case "edges":
 		return ec.fieldContext_TodoConnection_edges(ctx, field)
 	case "pageInfo":
 		return ec.fieldContext_TodoConnection_pageInfo(ctx, field)
+	case "totalCount":
+		return ec.fieldContext_TodoConnection_totalCount(ctx, field)
 	}
 	return nil, fmt.Errorf("no field named %q was found under type TodoConnection", field.Name)
 }
@@ -2,8 +2,10 @@
 package graphqlgenerated
 
 import (
+	models1 "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/audits/models"
 	models0 "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/todos/models"
 	graphqlutils "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/common/graphqlutils"
+	models2 "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/common"
 	pagination "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/pagination"
 	model "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/server/graphql/model"
 )
@@ -16,7 +18,10 @@ func MapTodoConnection(list []*models0.Todo, pageOut *pagination.PageOutput) (*m
 	last := len(list) - 1
 
 	for i, v := range list {
-		cursor := graphqlutils.GetPaginateCursor(i, pageOut.Skip)
+		cursor, err := graphqlutils.GetConnectionCursor(i, pageOut)
+		if err != nil {
+			return nil, err
+		}
 
 		if i == 0 {
 			startCursor = &cursor
@@ -40,6 +45,85 @@ func MapTodoConnection(list []*models0.Todo, pageOut *pagination.PageOutput) (*m
 			HasPreviousPage: pageOut.HasPrevious,
 			StartCursor:     startCursor,
 		},
+		TotalCount: graphqlutils.GetTotalCount(pageOut),
+	}
+
+	return res, nil
+}
+func MapAuditEventConnection(list []*models1.AuditEvent, pageOut *pagination.PageOutput) (*model.AuditEventConnection, error) {
+	edges := make([]*model.AuditEventEdge, len(list))
+
+	var startCursor, endCursor *string
+
+	last := len(list) - 1
+
+	for i, v := range list {
+		cursor, err := graphqlutils.GetConnectionCursor(i, pageOut)
+		if err != nil {
+			return nil, err
+		}
+
+		if i == 0 {
+			startCursor = &cursor
+		}
+
+		if i == last {
+			endCursor = &cursor
+		}
+
+		edges[i] = &model.AuditEventEdge{
+			Cursor: cursor,
+			Node:   v,
+		}
+	}
+
+	res := &model.AuditEventConnection{
+		Edges: edges,
+		PageInfo: &graphqlutils.PageInfo{
+			EndCursor:       endCursor,
+			HasNextPage:     pageOut.HasNext,
+			HasPreviousPage: pageOut.HasPrevious,
+			StartCursor:     startCursor,
+		},
+	}
+
+	return res, nil
+}
+func MapFieldValueConnection(list []*models2.FieldValue, pageOut *pagination.PageOutput) (*model.FieldValueConnection, error) {
+	edges := make([]*model.FieldValueEdge, len(list))
+
+	var startCursor, endCursor *string
+
+	last := len(list) - 1
+
+	for i, v := range list {
+		cursor, err := graphqlutils.GetConnectionCursor(i, pageOut)
+		if err != nil {
+			return nil, err
+		}
+
+		if i == 0 {
+			startCursor = &cursor
+		}
+
+		if i == last {
+			endCursor = &cursor
+		}
+
+		edges[i] = &model.FieldValueEdge{
+			Cursor: cursor,
+			Node:   v,
+		}
+	}
+
+	res := &model.FieldValueConnection{
+		Edges: edges,
+		PageInfo: &graphqlutils.PageInfo{
+			EndCursor:       endCursor,
+			HasNextPage:     pageOut.HasNext,
+			HasPreviousPage: pageOut.HasPrevious,
+			StartCursor:     startCursor,
+		},
 	}
 
 	return res, nil
@@ -3,10 +3,32 @@
 package model
 
 import (
-	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/todos/models"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/audits/models"
+	models1 "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/todos/models"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/common/graphqlutils"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/common"
 )
 
+type AuditEventConnection struct {
+	Edges    []*AuditEventEdge      `json:"edges,omitempty"`
+	PageInfo *graphqlutils.PageInfo `json:"pageInfo"`
+}
+
+type AuditEventEdge struct {
+	Cursor string             `json:"cursor"`
+	Node   *models.AuditEvent `json:"node,omitempty"`
+}
+
+type FieldValueConnection struct {
+	Edges    []*FieldValueEdge      `json:"edges,omitempty"`
+	PageInfo *graphqlutils.PageInfo `json:"pageInfo"`
+}
+
+type FieldValueEdge struct {
+	Cursor string             `json:"cursor"`
+	Node   *common.FieldValue `json:"node,omitempty"`
+}
+
 type Mutation struct {
 }
 
@@ -20,14 +42,20 @@ type Query struct {
 type TodoConnection struct {
 	Edges    []*TodoEdge            `json:"edges,omitempty"`
 	PageInfo *graphqlutils.PageInfo `json:"pageInfo"`
+	// Total count of todos matching filter.
+	// Only computed when asked.
+	TotalCount *int `json:"totalCount,omitempty"`
 }
 
 type TodoEdge struct {
-	Cursor string       `json:"cursor"`
-	Node   *models.Todo `json:"node,omitempty"`
+	Cursor string        `json:"cursor"`
+	Node   *models1.Todo `json:"node,omitempty"`
 }
 
 type UpdateTodo struct {
 	ID   string `json:"id"`
 	Text string `json:"text"`
+	// Expected todo version.
+	// A conflict error is returned if todo was modified in the meantime.
+	Version *int `json:"version,omitempty"`
 }
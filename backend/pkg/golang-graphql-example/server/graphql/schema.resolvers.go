@@ -8,9 +8,12 @@ package graphql
 import (
 	"context"
 
+	models1 "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/audits/models"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/todos"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/todos/models"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/common/graphqlutils"
+	common1 "github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/common"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/pagination"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/server/graphql/dataloaders"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/server/graphql/dataloaders/common"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/server/graphql/generated"
@@ -56,6 +59,56 @@ func (r *mutationResolver) CloseTodo(ctx context.Context, todoID string) (*model
 	return res, nil
 }
 
+// DeleteTodo is the resolver for the deleteTodo field.
+func (r *mutationResolver) DeleteTodo(ctx context.Context, todoID string) (*models.Todo, error) {
+	// Manage relay id
+	bid, err := graphqlutils.FromRelayID(todos.TodoIDPrefix, todoID)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	// Get projection
+	proj := &models.Projection{}
+	err = utils.ManageSimpleProjection(ctx, proj)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := r.BusiServices.TodoSvc.Delete(ctx, bid, proj)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// RestoreTodo is the resolver for the restoreTodo field.
+func (r *mutationResolver) RestoreTodo(ctx context.Context, todoID string) (*models.Todo, error) {
+	// Manage relay id
+	bid, err := graphqlutils.FromRelayID(todos.TodoIDPrefix, todoID)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	// Get projection
+	proj := &models.Projection{}
+	err = utils.ManageSimpleProjection(ctx, proj)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := r.BusiServices.TodoSvc.Restore(ctx, bid, proj)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 // UpdateTodo is the resolver for the updateTodo field.
 func (r *mutationResolver) UpdateTodo(ctx context.Context, input *model.UpdateTodo) (*models.Todo, error) {
 	// Manage relay id
@@ -65,7 +118,7 @@ func (r *mutationResolver) UpdateTodo(ctx context.Context, input *model.UpdateTo
 		return nil, err
 	}
 
-	inp := &todos.InputUpdateTodo{ID: bid, Text: input.Text}
+	inp := &todos.InputUpdateTodo{ID: bid, Text: input.Text, Version: input.Version}
 	tt, err := r.BusiServices.TodoSvc.Update(ctx, inp)
 	// Check error
 	if err != nil {
@@ -77,13 +130,31 @@ func (r *mutationResolver) UpdateTodo(ctx context.Context, input *model.UpdateTo
 
 // Todos is the resolver for the todos field.
 func (r *queryResolver) Todos(ctx context.Context, after *string, before *string, first *int, last *int, sort *models.SortOrder, sorts []*models.SortOrder, filter *models.Filter) (*model.TodoConnection, error) {
+	// Manage deprecated sort
+	if len(sorts) == 0 && sort != nil {
+		sorts = []*models.SortOrder{sort}
+	}
+
+	// Create keyset pagination input by default
+	pageInputFn := graphqlutils.GetKeysetPageInput
+	// Relevance, case insensitive, computed and nulls ordered sorts cannot be used with keyset pagination,
+	// so switch to offset pagination
+	if !common1.SupportsKeysetPagination(sorts) {
+		pageInputFn = graphqlutils.GetPageInput
+	}
+
 	// Create pagination input
-	pageInput, err := graphqlutils.GetPageInput(after, before, first, last)
+	pageInput, err := pageInputFn(after, before, first, last)
 	// Check error
 	if err != nil {
 		return nil, err
 	}
 
+	// Compute total count only when asked
+	if !utils.IsFieldSelected(ctx, nil, "totalCount") {
+		pageInput.CountMode = pagination.CountModeNone
+	}
+
 	// Build projection from graphql fields
 	projection := &models.Projection{}
 	err = utils.ManageConnectionNodeProjection(ctx, projection)
@@ -92,11 +163,6 @@ func (r *queryResolver) Todos(ctx context.Context, after *string, before *string
 		return nil, err
 	}
 
-	// Manage deprecated sort
-	if len(sorts) == 0 && sort != nil {
-		sorts = []*models.SortOrder{sort}
-	}
-
 	// Call business
 	allTodos, pageOut, err := r.BusiServices.TodoSvc.GetAllPaginated(ctx, pageInput, sorts, filter, projection)
 	// Check error
@@ -107,6 +173,36 @@ func (r *queryResolver) Todos(ctx context.Context, after *string, before *string
 	return graphqlgenerated.MapTodoConnection(allTodos, pageOut)
 }
 
+// TodosAggregate is the resolver for the todosAggregate field.
+func (r *queryResolver) TodosAggregate(ctx context.Context, filter *models.Filter, groupBy models.GroupBy) ([]*common1.AggregationBucket, error) {
+	return r.BusiServices.TodoSvc.Aggregate(ctx, filter, &groupBy)
+}
+
+// TodoFieldValues is the resolver for the todoFieldValues field.
+func (r *queryResolver) TodoFieldValues(ctx context.Context, field models.Field, filter *models.Filter, search *string, after *string, first *int) (*model.FieldValueConnection, error) {
+	// Create pagination input
+	pageInput, err := graphqlutils.GetPageInput(after, nil, first, nil)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	// Get search
+	s := ""
+	if search != nil {
+		s = *search
+	}
+
+	// Call business
+	values, pageOut, err := r.BusiServices.TodoSvc.FieldValues(ctx, pageInput, field, filter, s)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	return graphqlgenerated.MapFieldValueConnection(values, pageOut)
+}
+
 // Todo is the resolver for the todo field.
 func (r *queryResolver) Todo(ctx context.Context, id string) (*models.Todo, error) {
 	// Get projection
@@ -133,6 +229,48 @@ func (r *queryResolver) Todo(ctx context.Context, id string) (*models.Todo, erro
 	return res, err
 }
 
+// AuditEvents is the resolver for the auditEvents field.
+func (r *queryResolver) AuditEvents(ctx context.Context, entityID string, after *string, before *string, first *int, last *int, sorts []*models1.SortOrder, filter *models1.Filter) (*model.AuditEventConnection, error) {
+	// Manage relay id
+	// Prefix is validated by business against audited entity types
+	prefix, bid, err := graphqlutils.GetPrefixAndIDFromRelayID(entityID)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	// Create keyset pagination input by default
+	pageInputFn := graphqlutils.GetKeysetPageInput
+	// Nulls ordered sorts cannot be used with keyset pagination, so switch to offset pagination
+	if !common1.SupportsKeysetPagination(sorts) {
+		pageInputFn = graphqlutils.GetPageInput
+	}
+
+	// Create pagination input
+	pageInput, err := pageInputFn(after, before, first, last)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	// Build projection from graphql fields
+	projection := &models1.Projection{}
+	err = utils.ManageConnectionNodeProjection(ctx, projection)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	// Call business
+	allEvents, pageOut, err := r.BusiServices.AuditSvc.GetAllPaginated(ctx, pageInput, prefix, bid, sorts, filter, projection)
+	// Check error
+	if err != nil {
+		return nil, err
+	}
+
+	return graphqlgenerated.MapAuditEventConnection(allEvents, pageOut)
+}
+
 // Mutation returns generated.MutationResolver implementation.
 func (r *Resolver) Mutation() generated.MutationResolver { return &mutationResolver{r} }
 
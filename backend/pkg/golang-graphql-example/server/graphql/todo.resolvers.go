@@ -11,6 +11,7 @@ import (
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/todos"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/todos/models"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/common/graphqlutils"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/common"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/server/graphql/generated"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/server/graphql/utils"
 )
@@ -30,7 +31,36 @@ func (r *todoResolver) UpdatedAt(ctx context.Context, obj *models.Todo, format *
 	return utils.FormatTime(format, obj.UpdatedAt), nil
 }
 
+// CreatedAt is the resolver for the createdAt field.
+func (r *todoAggregateBucketResolver) CreatedAt(ctx context.Context, obj *common.AggregationBucket, format *utils.DateFormat) (*string, error) {
+	return utils.FormatOptionalTime(format, obj.Keys[models.TodoCreatedAtGormColumnName]), nil
+}
+
+// UpdatedAt is the resolver for the updatedAt field.
+func (r *todoAggregateBucketResolver) UpdatedAt(ctx context.Context, obj *common.AggregationBucket, format *utils.DateFormat) (*string, error) {
+	return utils.FormatOptionalTime(format, obj.Keys[models.TodoUpdatedAtGormColumnName]), nil
+}
+
+// Done is the resolver for the done field.
+func (r *todoAggregateBucketResolver) Done(ctx context.Context, obj *common.AggregationBucket) (*bool, error) {
+	// Check if done is grouped
+	v, ok := obj.Keys[models.TodoDoneGormColumnName].(bool)
+	if !ok {
+		return nil, nil
+	}
+
+	return &v, nil
+}
+
 // Todo returns generated.TodoResolver implementation.
 func (r *Resolver) Todo() generated.TodoResolver { return &todoResolver{r} }
 
-type todoResolver struct{ *Resolver }
+// TodoAggregateBucket returns generated.TodoAggregateBucketResolver implementation.
+func (r *Resolver) TodoAggregateBucket() generated.TodoAggregateBucketResolver {
+	return &todoAggregateBucketResolver{r}
+}
+
+type (
+	todoResolver                struct{ *Resolver }
+	todoAggregateBucketResolver struct{ *Resolver }
+)
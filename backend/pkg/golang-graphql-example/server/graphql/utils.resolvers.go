@@ -7,11 +7,24 @@ package graphql
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database/common"
 	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/server/graphql/generated"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/server/graphql/utils"
 )
 
+// Value is the resolver for the value field.
+func (r *fieldValueResolver) Value(ctx context.Context, obj *common.FieldValue, format *utils.DateFormat) (string, error) {
+	// Check if it is a time
+	if ti, ok := obj.Value.(time.Time); ok {
+		return utils.FormatTime(format, ti), nil
+	}
+
+	return fmt.Sprint(obj.Value), nil
+}
+
 // Eq is the resolver for the eq field.
 func (r *booleanFilterResolver) Eq(ctx context.Context, obj *common.GenericFilter, data *bool) error {
 	obj.Eq = data
@@ -110,6 +123,20 @@ func (r *dateFilterResolver) NotIn(ctx context.Context, obj *common.DateFilter,
 	return nil
 }
 
+// Between is the resolver for the between field.
+func (r *dateFilterResolver) Between(ctx context.Context, obj *common.DateFilter, data []string) error {
+	obj.Between = data
+
+	return nil
+}
+
+// NotBetween is the resolver for the notBetween field.
+func (r *dateFilterResolver) NotBetween(ctx context.Context, obj *common.DateFilter, data []string) error {
+	obj.NotBetween = data
+
+	return nil
+}
+
 // Eq is the resolver for the eq field.
 func (r *intFilterResolver) Eq(ctx context.Context, obj *common.GenericFilter, data *int) error {
 	obj.Eq = data
@@ -194,6 +221,20 @@ func (r *intFilterResolver) NotIn(ctx context.Context, obj *common.GenericFilter
 	return nil
 }
 
+// Between is the resolver for the between field.
+func (r *intFilterResolver) Between(ctx context.Context, obj *common.GenericFilter, data []int) error {
+	obj.Between = data
+
+	return nil
+}
+
+// NotBetween is the resolver for the notBetween field.
+func (r *intFilterResolver) NotBetween(ctx context.Context, obj *common.GenericFilter, data []int) error {
+	obj.NotBetween = data
+
+	return nil
+}
+
 // Eq is the resolver for the eq field.
 func (r *stringFilterResolver) Eq(ctx context.Context, obj *common.GenericFilter, data *string) error {
 	obj.Eq = data
@@ -264,6 +305,16 @@ func (r *stringFilterResolver) NotIn(ctx context.Context, obj *common.GenericFil
 	return nil
 }
 
+// Search is the resolver for the search field.
+func (r *stringFilterResolver) Search(ctx context.Context, obj *common.GenericFilter, data *string) error {
+	obj.Search = data
+
+	return nil
+}
+
+// FieldValue returns generated.FieldValueResolver implementation.
+func (r *Resolver) FieldValue() generated.FieldValueResolver { return &fieldValueResolver{r} }
+
 // BooleanFilter returns generated.BooleanFilterResolver implementation.
 func (r *Resolver) BooleanFilter() generated.BooleanFilterResolver { return &booleanFilterResolver{r} }
 
@@ -277,6 +328,7 @@ func (r *Resolver) IntFilter() generated.IntFilterResolver { return &intFilterRe
 func (r *Resolver) StringFilter() generated.StringFilterResolver { return &stringFilterResolver{r} }
 
 type (
+	fieldValueResolver    struct{ *Resolver }
 	booleanFilterResolver struct{ *Resolver }
 	dateFilterResolver    struct{ *Resolver }
 	intFilterResolver     struct{ *Resolver }
@@ -69,3 +69,16 @@ func FormatTime(format *DateFormat, ti time.Time) string {
 		return ti.UTC().Format(time.RFC3339)
 	}
 }
+
+// FormatOptionalTime will format value if it is a time and return nil otherwise.
+func FormatOptionalTime(format *DateFormat, v any) *string {
+	// Check if it is a time
+	ti, ok := v.(time.Time)
+	if !ok {
+		return nil
+	}
+
+	res := FormatTime(format, ti)
+
+	return &res
+}
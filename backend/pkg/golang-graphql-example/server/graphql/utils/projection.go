@@ -198,3 +198,23 @@ func manageFieldProjection(
 	// Field is found but type isn't supported
 	return errors.NewInvalidInputError(fmt.Sprintf("field %s must be a boolean", fieldType.Name))
 }
+
+// IsFieldSelected will check if a field is selected in the graphql query under the field chain.
+func IsFieldSelected(
+	ctx context.Context,
+	fieldChain []string,
+	field string,
+) bool {
+	// Get operation context
+	octx := graphql.GetOperationContext(ctx)
+	// Get graphql fields
+	fields := graphql.CollectFieldsCtx(ctx, nil)
+
+	// Dive to get collected fields under chain
+	collectedFields := diveToGraphqlCollectedField(octx, fields, fieldChain)
+
+	// Search field
+	return lo.ContainsBy(collectedFields, func(fieldC graphql.CollectedField) bool {
+		return fieldC.Name == field
+	})
+}
@@ -529,3 +529,75 @@ func TestManageConnectionNodeProjection(t *testing.T) {
 		})
 	}
 }
+
+func TestIsFieldSelected(t *testing.T) {
+	type args struct {
+		fctx       *graphql.FieldContext
+		fieldChain []string
+		field      string
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "not selected",
+			args: args{
+				fctx: &graphql.FieldContext{
+					Field: graphql.CollectedField{
+						Selections: ast.SelectionSet{
+							&ast.Field{Name: "edges"},
+						},
+					},
+				},
+				field: "totalCount",
+			},
+			want: false,
+		},
+		{
+			name: "selected",
+			args: args{
+				fctx: &graphql.FieldContext{
+					Field: graphql.CollectedField{
+						Selections: ast.SelectionSet{
+							&ast.Field{Name: "edges"},
+							&ast.Field{Name: "totalCount"},
+						},
+					},
+				},
+				field: "totalCount",
+			},
+			want: true,
+		},
+		{
+			name: "selected under chain",
+			args: args{
+				fctx: &graphql.FieldContext{
+					Field: graphql.CollectedField{
+						Selections: ast.SelectionSet{
+							&ast.Field{
+								Name: "pageInfo",
+								SelectionSet: ast.SelectionSet{
+									&ast.Field{Name: "hasNextPage"},
+								},
+							},
+						},
+					},
+				},
+				fieldChain: []string{"pageInfo"},
+				field:      "hasNextPage",
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create context
+			ctx := context.TODO()
+			ctx = graphql.WithOperationContext(ctx, &graphql.OperationContext{})
+			ctx = graphql.WithFieldContext(ctx, tt.args.fctx)
+			assert.Equal(t, tt.want, IsFieldSelected(ctx, tt.args.fieldChain, tt.args.field))
+		})
+	}
+}
@@ -39,6 +39,41 @@ type CheckerInput struct {
 	Interval     time.Duration
 	Timeout      time.Duration
 	InitialDelay time.Duration
+	// Health only checkers are reported in health endpoint but don't impact readiness
+	HealthOnly bool
+}
+
+// readinessHealth will ignore results of health only checkers.
+type readinessHealth struct {
+	gosundheit.Health
+
+	ignored map[string]bool
+}
+
+func (h *readinessHealth) Results() (map[string]gosundheit.Result, bool) {
+	// Get all results
+	results, _ := h.Health.Results()
+
+	healthy := true
+	// Loop over results
+	for name, res := range results {
+		// Check if it must be ignored
+		if h.ignored[name] {
+			delete(results, name)
+
+			continue
+		}
+
+		healthy = healthy && res.IsHealthy()
+	}
+
+	return results, healthy
+}
+
+func (h *readinessHealth) IsHealthy() bool {
+	_, healthy := h.Results()
+
+	return healthy
 }
 
 // Configuration endpoint response object.
@@ -90,8 +125,15 @@ func (svr *InternalServer) generateInternalRouter() (http.Handler, error) {
 
 	// create a new health instance
 	h2 := gosundheit.New()
+	// Create readiness view without health only checkers
+	readiness := &readinessHealth{Health: h2, ignored: map[string]bool{}}
 
 	for _, it := range svr.checkers {
+		// Check if it must be ignored in readiness
+		if it.HealthOnly {
+			readiness.ignored[it.Name] = true
+		}
+
 		// Create logger
 		logger := svr.logger.WithField("health-check-target", it.Name)
 
@@ -140,8 +182,8 @@ func (svr *InternalServer) generateInternalRouter() (http.Handler, error) {
 			return
 		}
 
-		// Otherwise, send health check result
-		gin.WrapH(healthhttp.HandleHealthJSON(h2))(c)
+		// Otherwise, send readiness check result
+		gin.WrapH(healthhttp.HandleHealthJSON(readiness))(c)
 	})
 	router.GET("/config", func(c *gin.Context) {
 		// Get configuration
@@ -162,3 +162,45 @@ func TestInternal_Server_Listen(t *testing.T) {
 	// Wait a bit
 	time.Sleep(200 * time.Millisecond)
 }
+
+func TestInternalServer_HealthOnlyChecker(t *testing.T) {
+	// Create go mock controller
+	ctrl := gomock.NewController(t)
+	cfgManagerMock := cmocks.NewMockManager(ctrl)
+	signalHandlerMock := smocks.NewMockService(ctrl)
+
+	cfgManagerMock.EXPECT().GetConfig().Return(&config.Config{
+		InternalServer: &config.ServerConfig{},
+	})
+	signalHandlerMock.EXPECT().IsStoppingSystem().AnyTimes().Return(false)
+
+	svr := NewInternalServer(log.NewLogger(), cfgManagerMock, metricsCtx, signalHandlerMock)
+	// Add failing health only checker
+	svr.AddChecker(&CheckerInput{
+		Name:       "health-only",
+		CheckFn:    func() error { return errors.New("fake") },
+		Interval:   time.Second,
+		HealthOnly: true,
+	})
+
+	got, err := svr.generateInternalRouter()
+	assert.NoError(t, err)
+
+	call := func(url string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", url, nil)
+		assert.NoError(t, err)
+		got.ServeHTTP(w, req)
+
+		return w
+	}
+
+	// Wait first check execution
+	assert.Eventually(t, func() bool {
+		return call("http://localhost/health").Code == http.StatusServiceUnavailable
+	}, 2*time.Second, 10*time.Millisecond)
+
+	w := call("http://localhost/ready")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "{}\n", w.Body.String())
+}
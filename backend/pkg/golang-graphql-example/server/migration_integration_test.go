@@ -0,0 +1,40 @@
+//go:build integration
+
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/migration"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/business/todos/models"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/common/tenant"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/config"
+)
+
+func (suite *GraphQLTestSuite) TestMigrateDBWithExistingTodos() {
+	// Go back before todos multi-tenancy
+	_, err := suite.busiServices.RunDBMigration(context.TODO(), &migration.RunInput{
+		Mode:     migration.RollbackToMode,
+		TargetID: "202610201100",
+	})
+	suite.Require().NoError(err)
+
+	// Create todo like an old version
+	suite.Require().NoError(suite.db.GetGormDB().Exec(
+		"INSERT INTO todos (id, created_at, updated_at, text, done) VALUES (?, ?, ?, ?, ?)",
+		"todo1", time.Now(), time.Now(), "text", false,
+	).Error)
+
+	// Upgrade as default tenant
+	err = suite.busiServices.MigrateDB(tenant.SetInContext(context.TODO(), config.DefaultTenant))
+	suite.Require().NoError(err)
+
+	var list []*models.Todo
+
+	suite.Require().NoError(suite.db.GetGormDB().Find(&list).Error)
+	suite.Require().Len(list, 1)
+
+	suite.Equal("todo1", list[0].ID)
+	suite.Equal(config.DefaultTenant, list[0].TenantID)
+}
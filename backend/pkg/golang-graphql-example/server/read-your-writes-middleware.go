@@ -0,0 +1,29 @@
+package server
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/authx/authentication"
+	"github.com/oxyno-zeta/golang-graphql-example/pkg/golang-graphql-example/database"
+)
+
+// readYourWritesMiddleware will track database writes of the request in order to read them on primary.
+// Authenticated user is used as session to read its own writes in following requests.
+func readYourWritesMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Initialize session key
+		sessionKey := ""
+		// Get authenticated user
+		user := authentication.GetAuthenticatedUserFromGin(c)
+		// Check if user exists
+		if user != nil {
+			sessionKey = user.Tenant + "/" + user.PreferredUsername
+		}
+
+		// Update request with new context
+		c.Request = c.Request.WithContext(database.NewReadYourWritesContext(c.Request.Context(), sessionKey))
+
+		// Next
+		c.Next()
+	}
+}
@@ -197,6 +197,8 @@ func (svr *Server) generateRouter() (http.Handler, error) {
 		router.Use(svr.authenticationSvc.Middleware([]*regexp.Regexp{apiReg}))
 	}
 
+	// Track database writes to read them on primary
+	router.Use(readYourWritesMiddleware())
 	// Integrate graphql dataloaders
 	router.Use(dataloaders.Middleware(svr.busiServices))
 	// Add graphql endpoints
@@ -244,9 +246,11 @@ func (svr *Server) graphqlHandler() gin.HandlerFunc {
 		},
 		Complexity: generated.ComplexityRoot{
 			Mutation: struct {
-				CloseTodo  func(childComplexity int, todoID string) int
-				CreateTodo func(childComplexity int, input model.NewTodo) int
-				UpdateTodo func(childComplexity int, input *model.UpdateTodo) int
+				CloseTodo   func(childComplexity int, todoID string) int
+				CreateTodo  func(childComplexity int, input model.NewTodo) int
+				DeleteTodo  func(childComplexity int, todoID string) int
+				RestoreTodo func(childComplexity int, todoID string) int
+				UpdateTodo  func(childComplexity int, input *model.UpdateTodo) int
 			}{
 				CloseTodo: func(childComplexity int, _ string) int {
 					return gutils.CalculateMutationComplexity(childComplexity)
@@ -254,6 +258,12 @@ func (svr *Server) graphqlHandler() gin.HandlerFunc {
 				CreateTodo: func(childComplexity int, _ model.NewTodo) int {
 					return gutils.CalculateMutationComplexity(childComplexity)
 				},
+				DeleteTodo: func(childComplexity int, _ string) int {
+					return gutils.CalculateMutationComplexity(childComplexity)
+				},
+				RestoreTodo: func(childComplexity int, _ string) int {
+					return gutils.CalculateMutationComplexity(childComplexity)
+				},
 				UpdateTodo: func(childComplexity int, _ *model.UpdateTodo) int {
 					return gutils.CalculateMutationComplexity(childComplexity)
 				},
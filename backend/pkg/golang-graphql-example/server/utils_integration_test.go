@@ -21,6 +21,7 @@ var integrationTestsCfg *config.Config = &config.Config{
 		RedirectURL:       "http://localhost:8080/",
 		LogoutRedirectURL: "http://localhost:8080/",
 		EmailVerified:     true,
+		TenantClaim:       "organization",
 		Scopes:            config.DefaultOIDCScopes,
 		CookieName:        config.DefaultCookieName,
 	},
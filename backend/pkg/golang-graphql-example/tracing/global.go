@@ -116,3 +116,15 @@ func manageGenericAttribute(key string, value any) *attribute.KeyValue {
 
 	return &res
 }
+
+// WithEventAttributes will create a trace event option containing given attributes.
+func WithEventAttributes(attributes map[string]any) TraceEventOption {
+	// Create attributes list
+	list := make([]attribute.KeyValue, 0, len(attributes))
+	// Loop over attributes
+	for k, v := range attributes {
+		list = append(list, *manageGenericAttribute(k, v))
+	}
+
+	return oteltrace.WithAttributes(list...)
+}
@@ -18,13 +18,16 @@ type NeededPackagesCfg struct {
 	DB         string `validate:"required" yaml:"db"`
 	Pagination string `validate:"required" yaml:"pagination"`
 	Helpers    string `validate:"required" yaml:"helpers"`
+	Common     string `validate:"required" yaml:"common"`
 }
 
 type DaoCfg struct {
-	Path          string         `validate:"required" yaml:"path"`
-	PackageName   string         `validate:"required" yaml:"packageName"`
-	InterfaceName string         `                    yaml:"interfaceName"`
-	Models        []*DaoModelCfg `                    yaml:"models"        validated:"required"`
+	Path          string `validate:"required" yaml:"path"`
+	PackageName   string `validate:"required" yaml:"packageName"`
+	InterfaceName string `                    yaml:"interfaceName"`
+	// Database connection name bound to dao, a constructor from database registry is generated when set
+	ConnectionName string         `                    yaml:"connectionName"`
+	Models         []*DaoModelCfg `                    yaml:"models"         validated:"required"`
 }
 
 type DaoModelCfg struct {
@@ -34,6 +37,7 @@ type DaoModelCfg struct {
 	ProjectionStructureName string                      `yaml:"projectionStructureName"`
 	SortOrderStructureName  string                      `yaml:"sortOrderStructureName"`
 	FilterStructureName     string                      `yaml:"filterStructureName"`
+	GroupByStructureName    string                      `yaml:"groupByStructureName"`
 }
 
 type DaoModelDisabledMethodsCfg struct {
@@ -42,12 +46,19 @@ type DaoModelDisabledMethodsCfg struct {
 	FindWithPagination      bool `yaml:"findWithPagination"`
 	FindPaginated           bool `yaml:"findPaginated"`
 	FindAll                 bool `yaml:"findAll"`
+	Iterate                 bool `yaml:"iterate"`
 	CountPaginated          bool `yaml:"countPaginated"`
 	Count                   bool `yaml:"count"`
+	Aggregate               bool `yaml:"aggregate"`
+	Distinct                bool `yaml:"distinct"`
 	CreateOrUpdate          bool `yaml:"createOrUpdate"`
+	BulkCreate              bool `yaml:"bulkCreate"`
+	Upsert                  bool `yaml:"upsert"`
 	PermanentDelete         bool `yaml:"permanentDelete"`
 	PermanentDeleteByID     bool `yaml:"permanentDeleteById"`
 	PermanentDeleteFiltered bool `yaml:"permanentDeleteFiltered"`
+	SoftDeleteByID          bool `yaml:"softDeleteById"`
+	RestoreByID             bool `yaml:"restoreById"`
 	PatchUpdate             bool `yaml:"patchUpdate"`
 	PatchUpdateByID         bool `yaml:"patchUpdateById"`
 	PatchUpdateFiltered     bool `yaml:"patchUpdateFiltered"`
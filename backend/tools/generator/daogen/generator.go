@@ -47,6 +47,11 @@ func generate(cfg *Config) error {
 			),
 		)
 
+		// Check if dao is bound to a database connection
+		if v.ConnectionName != "" {
+			generateConnectionBinding(f, v, cfg.NeededPackages)
+		}
+
 		f.Line()
 		f.Comment("/* Structure */")
 		f.Line()
@@ -76,6 +81,28 @@ func generate(cfg *Config) error {
 	return nil
 }
 
+func generateConnectionBinding(f *jen.File, v *DaoCfg, neededPackages *NeededPackagesCfg) {
+	// Get connection name constant
+	constName := getConnectionNameConstName(v)
+
+	f.Line()
+	f.Commentf("%s is the database connection name bound to %s.", constName, getInterfaceName(v))
+	f.Const().Id(constName).Op("=").Lit(v.ConnectionName)
+
+	f.Line()
+	f.Commentf("New%sFromRegistry will create a %s using its bound database connection.", getInterfaceName(v), getInterfaceName(v))
+	f.Func().Id("New"+getInterfaceName(v)+"FromRegistry").Params(
+		jen.Id("registry").Qual(neededPackages.DB, "Registry"),
+	).Params(jen.Id(getInterfaceName(v)), jen.Error()).Block(
+		jen.List(jen.Id("db"), jen.Err()).Op(":=").Id("registry").Dot("Get").Call(jen.Id(constName)),
+		jen.If(jen.Err().Op("!=").Nil()).Block(
+			jen.Return(jen.Nil(), jen.Err()),
+		),
+		jen.Line(),
+		jen.Return(jen.Id("New"+getInterfaceName(v)).Call(jen.Id("db")), jen.Nil()),
+	)
+}
+
 func addImports(f *jen.File, list []*DaoModelCfg) {
 	f.ImportName("context", "context")
 
@@ -181,6 +208,22 @@ func generateStructureMethods(f *jen.File, v *DaoCfg, neededPackages *NeededPack
 			)).Line()
 		}
 
+		if m.DisabledMethods == nil || !m.DisabledMethods.Iterate {
+			f.Func().Params(jen.Id("d").Op("*").Id(getDaoStructureName(v))).
+				Id("Iterate" + m.StructureName).
+				Add(iterateParamsAndReturns(m, neededPackages)).Block(jen.Return(
+				jen.Qual(neededPackages.Helpers, "Iterate").Types(jen.Op("*").Qual(m.Package, m.StructureName)).Params(
+					jen.Id("ctx"),
+					jen.Id("d.db"),
+					jen.Id("batchSize"),
+					jen.Id("sorts"),
+					jen.Id("filter"),
+					jen.Id("projection"),
+					jen.Id("opts").Op("..."),
+				),
+			)).Line()
+		}
+
 		if m.DisabledMethods == nil || !m.DisabledMethods.CountPaginated {
 			f.Func().Params(jen.Id("d").Op("*").Id(getDaoStructureName(v))).
 				Id("Count" + m.StructureName + "Paginated").
@@ -210,6 +253,39 @@ func generateStructureMethods(f *jen.File, v *DaoCfg, neededPackages *NeededPack
 			)).Line()
 		}
 
+		if m.DisabledMethods == nil || !m.DisabledMethods.Aggregate {
+			f.Func().Params(jen.Id("d").Op("*").Id(getDaoStructureName(v))).
+				Id("Aggregate" + m.StructureName).
+				Add(aggregateParamsAndReturns(m, neededPackages)).Block(jen.Return(
+				jen.Qual(neededPackages.Helpers, "Aggregate").Params(
+					jen.Id("ctx"),
+					jen.Id("d.db"),
+					jen.Op("&").Qual(m.Package, m.StructureName).Values(),
+					jen.Id("filter"),
+					jen.Id("groupBy"),
+					jen.Id("metrics"),
+					jen.Id("opts").Op("..."),
+				),
+			)).Line()
+		}
+
+		if m.DisabledMethods == nil || !m.DisabledMethods.Distinct {
+			f.Func().Params(jen.Id("d").Op("*").Id(getDaoStructureName(v))).
+				Id("Distinct" + m.StructureName).
+				Add(distinctParamsAndReturns(m, neededPackages)).Block(jen.Return(
+				jen.Qual(neededPackages.Helpers, "Distinct").Params(
+					jen.Id("ctx"),
+					jen.Id("d.db"),
+					jen.Op("&").Qual(m.Package, m.StructureName).Values(),
+					jen.Id("page"),
+					jen.Id("field"),
+					jen.Id("filter"),
+					jen.Id("search"),
+					jen.Id("opts").Op("..."),
+				),
+			)).Line()
+		}
+
 		if m.DisabledMethods == nil || !m.DisabledMethods.CreateOrUpdate {
 			f.Func().Params(jen.Id("d").Op("*").Id(getDaoStructureName(v))).
 				Id("CreateOrUpdate" + m.StructureName).
@@ -223,6 +299,36 @@ func generateStructureMethods(f *jen.File, v *DaoCfg, neededPackages *NeededPack
 			)).Line()
 		}
 
+		if m.DisabledMethods == nil || !m.DisabledMethods.BulkCreate {
+			f.Func().Params(jen.Id("d").Op("*").Id(getDaoStructureName(v))).
+				Id("BulkCreate" + m.StructureName).
+				Add(bulkCreateParamsAndReturns(m, neededPackages)).Block(jen.Return(
+				jen.Qual(neededPackages.Helpers, "CreateInBatches").Params(
+					jen.Id("ctx"),
+					jen.Id("input"),
+					jen.Id("batchSize"),
+					jen.Id("d.db"),
+					jen.Id("opts").Op("..."),
+				),
+			)).Line()
+		}
+
+		if m.DisabledMethods == nil || !m.DisabledMethods.Upsert {
+			f.Func().Params(jen.Id("d").Op("*").Id(getDaoStructureName(v))).
+				Id("Upsert" + m.StructureName).
+				Add(upsertParamsAndReturns(m, neededPackages)).Block(jen.Return(
+				jen.Qual(neededPackages.Helpers, "Upsert").Params(
+					jen.Id("ctx"),
+					jen.Id("input"),
+					jen.Id("batchSize"),
+					jen.Id("conflictColumns"),
+					jen.Id("updateColumns"),
+					jen.Id("d.db"),
+					jen.Id("opts").Op("..."),
+				),
+			)).Line()
+		}
+
 		if m.DisabledMethods == nil || !m.DisabledMethods.PermanentDelete {
 			f.Func().Params(jen.Id("d").Op("*").Id(getDaoStructureName(v))).
 				Id("PermanentDelete" + m.StructureName).
@@ -255,6 +361,44 @@ func generateStructureMethods(f *jen.File, v *DaoCfg, neededPackages *NeededPack
 			).Line()
 		}
 
+		if m.DisabledMethods == nil || !m.DisabledMethods.SoftDeleteByID {
+			f.Func().Params(jen.Id("d").Op("*").Id(getDaoStructureName(v))).
+				Id("SoftDelete"+m.StructureName+"ByID").
+				Add(softDeleteByIDParamsAndReturns(m, neededPackages)).Block(
+				// This is make list this to avoid any choice between Base and BaseWithoutIndexes structures
+				jen.Id("input").Op(":=").Op("&").Qual(m.Package, m.StructureName).Values(),
+				jen.Id("input").Op(".").Id("ID").Op("=").Id("id"),
+				jen.Line(),
+				jen.Return(
+					jen.Qual(neededPackages.Helpers, "SoftDelete").Params(
+						jen.Id("ctx"),
+						jen.Id("input"),
+						jen.Id("d.db"),
+						jen.Id("opts").Op("..."),
+					),
+				),
+			).Line()
+		}
+
+		if m.DisabledMethods == nil || !m.DisabledMethods.RestoreByID {
+			f.Func().Params(jen.Id("d").Op("*").Id(getDaoStructureName(v))).
+				Id("Restore"+m.StructureName+"ByID").
+				Add(restoreByIDParamsAndReturns(m, neededPackages)).Block(
+				// This is make list this to avoid any choice between Base and BaseWithoutIndexes structures
+				jen.Id("input").Op(":=").Op("&").Qual(m.Package, m.StructureName).Values(),
+				jen.Id("input").Op(".").Id("ID").Op("=").Id("id"),
+				jen.Line(),
+				jen.Return(
+					jen.Qual(neededPackages.Helpers, "Restore").Params(
+						jen.Id("ctx"),
+						jen.Id("input"),
+						jen.Id("d.db"),
+						jen.Id("opts").Op("..."),
+					),
+				),
+			).Line()
+		}
+
 		if m.DisabledMethods == nil || !m.DisabledMethods.PermanentDeleteFiltered {
 			f.Func().Params(jen.Id("d").Op("*").Id(getDaoStructureName(v))).
 				Id("PermanentDelete" + m.StructureName + "Filtered").
@@ -349,6 +493,10 @@ func generateInterface(f *jen.File, v *DaoCfg, neededPackages *NeededPackagesCfg
 			res = append(res, jen.Id("FindAll"+m.StructureName).Add(findAllParamsAndReturns(m, neededPackages)))
 		}
 
+		if m.DisabledMethods == nil || !m.DisabledMethods.Iterate {
+			res = append(res, jen.Id("Iterate"+m.StructureName).Add(iterateParamsAndReturns(m, neededPackages)))
+		}
+
 		if m.DisabledMethods == nil || !m.DisabledMethods.CountPaginated {
 			res = append(res, jen.Id("Count"+m.StructureName+"Paginated").Add(countPaginatedParamsAndReturns(m, neededPackages)))
 		}
@@ -357,10 +505,26 @@ func generateInterface(f *jen.File, v *DaoCfg, neededPackages *NeededPackagesCfg
 			res = append(res, jen.Id("Count"+m.StructureName).Add(countParamsAndReturns(m, neededPackages)))
 		}
 
+		if m.DisabledMethods == nil || !m.DisabledMethods.Aggregate {
+			res = append(res, jen.Id("Aggregate"+m.StructureName).Add(aggregateParamsAndReturns(m, neededPackages)))
+		}
+
+		if m.DisabledMethods == nil || !m.DisabledMethods.Distinct {
+			res = append(res, jen.Id("Distinct"+m.StructureName).Add(distinctParamsAndReturns(m, neededPackages)))
+		}
+
 		if m.DisabledMethods == nil || !m.DisabledMethods.CreateOrUpdate {
 			res = append(res, jen.Id("CreateOrUpdate"+m.StructureName).Add(createOrUpdateParamsAndReturns(m, neededPackages)))
 		}
 
+		if m.DisabledMethods == nil || !m.DisabledMethods.BulkCreate {
+			res = append(res, jen.Id("BulkCreate"+m.StructureName).Add(bulkCreateParamsAndReturns(m, neededPackages)))
+		}
+
+		if m.DisabledMethods == nil || !m.DisabledMethods.Upsert {
+			res = append(res, jen.Id("Upsert"+m.StructureName).Add(upsertParamsAndReturns(m, neededPackages)))
+		}
+
 		if m.DisabledMethods == nil || !m.DisabledMethods.CreateOrUpdate {
 			res = append(res, jen.Id("PermanentDelete"+m.StructureName).Add(permanentDeleteParamsAndReturns(m, neededPackages)))
 		}
@@ -373,6 +537,14 @@ func generateInterface(f *jen.File, v *DaoCfg, neededPackages *NeededPackagesCfg
 			res = append(res, jen.Id("PermanentDelete"+m.StructureName+"Filtered").Add(permanentDeleteFilteredParamsAndReturns(m, neededPackages)))
 		}
 
+		if m.DisabledMethods == nil || !m.DisabledMethods.SoftDeleteByID {
+			res = append(res, jen.Id("SoftDelete"+m.StructureName+"ByID").Add(softDeleteByIDParamsAndReturns(m, neededPackages)))
+		}
+
+		if m.DisabledMethods == nil || !m.DisabledMethods.RestoreByID {
+			res = append(res, jen.Id("Restore"+m.StructureName+"ByID").Add(restoreByIDParamsAndReturns(m, neededPackages)))
+		}
+
 		if m.DisabledMethods == nil || !m.DisabledMethods.PatchUpdate {
 			res = append(res, jen.Id("PatchUpdate"+m.StructureName).Add(patchUpdateParamsAndReturns(m, neededPackages)))
 		}
@@ -446,6 +618,32 @@ func createOrUpdateParamsAndReturns(m *DaoModelCfg, neededPackages *NeededPackag
 	))
 }
 
+func bulkCreateParamsAndReturns(m *DaoModelCfg, neededPackages *NeededPackagesCfg) jen.Code {
+	return jen.Params(
+		jen.Id("ctx").Qual("context", "Context"),
+		jen.Id("input").Index().Op("*").Qual(m.Package, m.StructureName),
+		jen.Id("batchSize").Int(),
+		jen.Id("opts").Op("...").Qual(neededPackages.Helpers, "GormOpt"),
+	).Parens(jen.List(
+		jen.Index().Op("*").Qual(m.Package, m.StructureName),
+		jen.Error(),
+	))
+}
+
+func upsertParamsAndReturns(m *DaoModelCfg, neededPackages *NeededPackagesCfg) jen.Code {
+	return jen.Params(
+		jen.Id("ctx").Qual("context", "Context"),
+		jen.Id("input").Index().Op("*").Qual(m.Package, m.StructureName),
+		jen.Id("batchSize").Int(),
+		jen.Id("conflictColumns").Index().String(),
+		jen.Id("updateColumns").Index().String(),
+		jen.Id("opts").Op("...").Qual(neededPackages.Helpers, "GormOpt"),
+	).Parens(jen.List(
+		jen.Index().Op("*").Qual(m.Package, m.StructureName),
+		jen.Error(),
+	))
+}
+
 func permanentDeleteParamsAndReturns(m *DaoModelCfg, neededPackages *NeededPackagesCfg) jen.Code {
 	return jen.Params(
 		jen.Id("ctx").Qual("context", "Context"),
@@ -468,6 +666,28 @@ func permanentDeleteByIDParamsAndReturns(m *DaoModelCfg, neededPackages *NeededP
 	))
 }
 
+func softDeleteByIDParamsAndReturns(m *DaoModelCfg, neededPackages *NeededPackagesCfg) jen.Code {
+	return jen.Params(
+		jen.Id("ctx").Qual("context", "Context"),
+		jen.Id("id").String(),
+		jen.Id("opts").Op("...").Qual(neededPackages.Helpers, "GormOpt"),
+	).Parens(jen.List(
+		jen.Op("*").Qual(m.Package, m.StructureName),
+		jen.Error(),
+	))
+}
+
+func restoreByIDParamsAndReturns(m *DaoModelCfg, neededPackages *NeededPackagesCfg) jen.Code {
+	return jen.Params(
+		jen.Id("ctx").Qual("context", "Context"),
+		jen.Id("id").String(),
+		jen.Id("opts").Op("...").Qual(neededPackages.Helpers, "GormOpt"),
+	).Parens(jen.List(
+		jen.Op("*").Qual(m.Package, m.StructureName),
+		jen.Error(),
+	))
+}
+
 func permanentDeleteFilteredParamsAndReturns(m *DaoModelCfg, neededPackages *NeededPackagesCfg) jen.Code {
 	return jen.Params(
 		jen.Id("ctx").Qual("context", "Context"),
@@ -491,6 +711,20 @@ func findAllPaginatedParamsAndReturns(m *DaoModelCfg, neededPackages *NeededPack
 	))
 }
 
+func iterateParamsAndReturns(m *DaoModelCfg, neededPackages *NeededPackagesCfg) jen.Code {
+	return jen.Params(
+		jen.Id("ctx").Qual("context", "Context"),
+		jen.Id("batchSize").Int(),
+		jen.Id("sorts").Index().Op("*").Qual(m.Package, getSortOrderStructureName(m)),
+		jen.Id("filter").Op("*").Qual(m.Package, getFilterStructureName(m)),
+		jen.Id("projection").Op("*").Qual(m.Package, getProjectionStructureName(m)),
+		jen.Id("opts").Op("...").Qual(neededPackages.DB, "TransactionOption"),
+	).Qual("iter", "Seq2").Types(
+		jen.Op("*").Qual(m.Package, m.StructureName),
+		jen.Error(),
+	)
+}
+
 func findWithPaginationParamsAndReturns(m *DaoModelCfg, neededPackages *NeededPackagesCfg) jen.Code {
 	return jen.Params(
 		jen.Id("ctx").Qual("context", "Context"),
@@ -528,6 +762,34 @@ func countParamsAndReturns(m *DaoModelCfg, neededPackages *NeededPackagesCfg) je
 	))
 }
 
+func aggregateParamsAndReturns(m *DaoModelCfg, neededPackages *NeededPackagesCfg) jen.Code {
+	return jen.Params(
+		jen.Id("ctx").Qual("context", "Context"),
+		jen.Id("filter").Op("*").Qual(m.Package, getFilterStructureName(m)),
+		jen.Id("groupBy").Op("*").Qual(m.Package, getGroupByStructureName(m)),
+		jen.Id("metrics").Op("*").Qual(neededPackages.Common, "AggregationMetrics"),
+		jen.Id("opts").Op("...").Qual(neededPackages.Helpers, "GormOpt"),
+	).Parens(jen.List(
+		jen.Index().Op("*").Qual(neededPackages.Common, "AggregationBucket"),
+		jen.Error(),
+	))
+}
+
+func distinctParamsAndReturns(m *DaoModelCfg, neededPackages *NeededPackagesCfg) jen.Code {
+	return jen.Params(
+		jen.Id("ctx").Qual("context", "Context"),
+		jen.Id("page").Op("*").Qual(neededPackages.Pagination, "PageInput"),
+		jen.Id("field").String(),
+		jen.Id("filter").Op("*").Qual(m.Package, getFilterStructureName(m)),
+		jen.Id("search").String(),
+		jen.Id("opts").Op("...").Qual(neededPackages.Helpers, "GormOpt"),
+	).Parens(jen.List(
+		jen.Index().Op("*").Qual(neededPackages.Common, "FieldValue"),
+		jen.Op("*").Qual(neededPackages.Pagination, "PageOutput"),
+		jen.Error(),
+	))
+}
+
 func findByIdParamsAndReturns(m *DaoModelCfg, neededPackages *NeededPackagesCfg) jen.Code {
 	return jen.Params(
 		jen.Id("ctx").Qual("context", "Context"),
@@ -565,6 +827,10 @@ func getStructureInterfaceName(structureName string, input *DaoCfg) string {
 	return structureName + "Structure" + getInterfaceName(input)
 }
 
+func getConnectionNameConstName(input *DaoCfg) string {
+	return getInterfaceName(input) + "ConnectionName"
+}
+
 func getInterfaceName(input *DaoCfg) string {
 	if input.InterfaceName != "" {
 		return input.InterfaceName
@@ -596,3 +862,11 @@ func getSortOrderStructureName(input *DaoModelCfg) string {
 
 	return input.StructureName + "SortOrder"
 }
+
+func getGroupByStructureName(input *DaoModelCfg) string {
+	if input.GroupByStructureName != "" {
+		return input.GroupByStructureName
+	}
+
+	return input.StructureName + "GroupBy"
+}
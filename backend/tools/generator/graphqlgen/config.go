@@ -26,6 +26,7 @@ type ConnectionCfg struct {
 	Package              string `validate:"required" yaml:"package"`
 	StructureName        string `validate:"required" yaml:"structureName"`
 	GraphQLStructureName string `                    yaml:"graphqlStructureName"`
+	TotalCount           bool   `                    yaml:"totalCount"`
 }
 
 func loadConfig() (*Config, error) {
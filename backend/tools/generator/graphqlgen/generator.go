@@ -19,8 +19,8 @@ const (
 	PageInfoUtilsHasPreviousPageKeyName    = "HasPreviousPage"
 	PageInfoUtilsStartCursorKeyName        = "StartCursor"
 	PageInfoUtilsEndCursorKeyName          = "EndCursor"
+	TotalCountStructureKeyName             = "TotalCount"
 	PaginationPageOutputStructureName      = "PageOutput"
-	PaginationPageOutSkipKeyName           = "Skip"
 	PaginationPageOutputHasPreviousKeyName = "HasPrevious"
 	PaginationPageOutputHasNextKeyName     = "HasNext"
 )
@@ -82,6 +82,21 @@ func addImports(f *jen.File, list []*ConnectionCfg, gqlgenModelPackage string) {
 func generateConnectionTransform(f *jen.File, conn *ConnectionCfg, neededPackages *NeededPackagesCfg) {
 	const pageOutParamName = "pageOut"
 
+	// Create connection values
+	connDict := jen.Dict{
+		jen.Id(EdgesStructureKeyName): jen.Id("edges"),
+		jen.Id(PageInfoStructureKeyName): jen.Op("&").Qual(neededPackages.GraphqlUtils, PageInfoUtilsStructureName).Values(jen.Dict{
+			jen.Id(PageInfoUtilsHasNextPageKeyName):     jen.Id(pageOutParamName).Op(".").Id(PaginationPageOutputHasNextKeyName),
+			jen.Id(PageInfoUtilsHasPreviousPageKeyName): jen.Id(pageOutParamName).Op(".").Id(PaginationPageOutputHasPreviousKeyName),
+			jen.Id(PageInfoUtilsStartCursorKeyName):     jen.Id("startCursor"),
+			jen.Id(PageInfoUtilsEndCursorKeyName):       jen.Id("endCursor"),
+		}),
+	}
+	// Check if total count is enabled
+	if conn.TotalCount {
+		connDict[jen.Id(TotalCountStructureKeyName)] = jen.Qual(neededPackages.GraphqlUtils, "GetTotalCount").Call(jen.Id(pageOutParamName))
+	}
+
 	f.Func().Id("Map"+conn.GraphQLStructureName+"Connection").Params(
 		jen.Id("list").Index().Op("*").Qual(conn.Package, conn.StructureName),
 		jen.Id(pageOutParamName).Op("*").Qual(neededPackages.Pagination, PaginationPageOutputStructureName),
@@ -102,10 +117,13 @@ func generateConnectionTransform(f *jen.File, conn *ConnectionCfg, neededPackage
 		jen.Line(),
 
 		jen.For(jen.Id("i").Op(",").Id("v").Op(":=").Range().Id("list")).Block(
-			jen.Id("cursor").Op(":=").Qual(neededPackages.GraphqlUtils, "GetPaginateCursor").Parens(jen.List(
+			jen.List(jen.Id("cursor"), jen.Err()).Op(":=").Qual(neededPackages.GraphqlUtils, "GetConnectionCursor").Parens(jen.List(
 				jen.Id("i"),
-				jen.Id(pageOutParamName).Op(".").Id(PaginationPageOutSkipKeyName),
+				jen.Id(pageOutParamName),
 			)),
+			jen.If(jen.Err().Op("!=").Nil()).Block(
+				jen.Return(jen.Nil(), jen.Err()),
+			),
 			jen.Line(),
 
 			jen.If(jen.Id("i").Op("==").Lit(0)).Block(
@@ -127,15 +145,7 @@ func generateConnectionTransform(f *jen.File, conn *ConnectionCfg, neededPackage
 		),
 		jen.Line(),
 
-		jen.Id("res").Op(":=").Op("&").Qual(neededPackages.GqlgenModelPackage, conn.GraphQLStructureName+"Connection").Values(jen.Dict{
-			jen.Id(EdgesStructureKeyName): jen.Id("edges"),
-			jen.Id(PageInfoStructureKeyName): jen.Op("&").Qual(neededPackages.GraphqlUtils, PageInfoUtilsStructureName).Values(jen.Dict{
-				jen.Id(PageInfoUtilsHasNextPageKeyName):     jen.Id(pageOutParamName).Op(".").Id(PaginationPageOutputHasNextKeyName),
-				jen.Id(PageInfoUtilsHasPreviousPageKeyName): jen.Id(pageOutParamName).Op(".").Id(PaginationPageOutputHasPreviousKeyName),
-				jen.Id(PageInfoUtilsStartCursorKeyName):     jen.Id("startCursor"),
-				jen.Id(PageInfoUtilsEndCursorKeyName):       jen.Id("endCursor"),
-			}),
-		}),
+		jen.Id("res").Op(":=").Op("&").Qual(neededPackages.GqlgenModelPackage, conn.GraphQLStructureName+"Connection").Values(connDict),
 		jen.Line(),
 
 		jen.Return(jen.Id("res"), jen.Nil()),
@@ -16,6 +16,7 @@ import (
 const (
 	errorPkg                         = "emperror.dev/errors"
 	gormVariableNameSuffix           = "GormColumnName"
+	gormAssociationNameSuffix        = "GormAssociationName"
 	jsonKeyNameSuffix                = "JSONKeyName"
 	structKeyNameSuffix              = "StructKeyName"
 	unsupportedGormColumnErrTemplate = "Err%sUnsupportedGormColumn"
@@ -25,6 +26,7 @@ const (
 
 type MainPkg struct {
 	GormMap           map[string]string
+	GormAssociations  []string
 	JSONMap           map[string]string
 	StructKeyNamesMap map[string]string
 	ObjectName        string
@@ -70,6 +72,8 @@ func saveJSONKeys(rType reflect.Type, jsonMap map[string]string) {
 func getPackageData(pkgName string, obj any, disableJSON, disableGorm, disableStructKeyName bool) (*MainPkg, error) {
 	// Init gorm map
 	gormMap := make(map[string]string)
+	// Init gorm associations
+	gormAssociations := []string{}
 
 	if !disableGorm {
 		// Manage Gorm
@@ -88,6 +92,11 @@ func getPackageData(pkgName string, obj any, disableJSON, disableGorm, disableSt
 				gormMap[field.Name] = dbName
 			}
 		}
+
+		// Save associations names
+		// Those are used by association filters
+		gormAssociations = lo.Keys(s.Relationships.Relations)
+		sort.Strings(gormAssociations)
 	}
 
 	// Get reflect type of object
@@ -110,6 +119,7 @@ func getPackageData(pkgName string, obj any, disableJSON, disableGorm, disableSt
 	return &MainPkg{
 		ObjectName:        rType.Name(),
 		GormMap:           gormMap,
+		GormAssociations:  gormAssociations,
 		JSONMap:           jsonMap,
 		StructKeyNamesMap: structKeyNamesMap,
 	}, nil
@@ -156,6 +166,7 @@ func Generate(pkgName string, obj any, disableJSON, disableGorm, disableStructKe
 
 	if !disableGorm {
 		generateGormVariables(f, mainPkg)
+		generateGormAssociationVariables(f, mainPkg)
 	}
 
 	if !disableJSON {
@@ -229,6 +240,27 @@ func generateGormVariables(f *jen.File, mainPkg *MainPkg) {
 	f.Var().Id(mainPkg.ObjectName + gormVariableNameSuffix + "List").Op("=").Index().String().Values(list...)
 }
 
+func generateGormAssociationVariables(f *jen.File, mainPkg *MainPkg) {
+	// Ignore objects without associations
+	if len(mainPkg.GormAssociations) == 0 {
+		return
+	}
+
+	f.Comment("/* Gorm associations Names */")
+
+	list := []jen.Code{}
+	for _, k := range mainPkg.GormAssociations {
+		vName := mainPkg.ObjectName + k + gormAssociationNameSuffix
+
+		f.Commentf("%s %s Gorm Association Name", mainPkg.ObjectName, k)
+		f.Const().Id(vName).Op("=").Lit(k)
+
+		list = append(list, jen.Id(vName))
+	}
+
+	f.Var().Id(mainPkg.ObjectName + gormAssociationNameSuffix + "List").Op("=").Index().String().Values(list...)
+}
+
 func generateJSONVariables(f *jen.File, mainPkg *MainPkg) {
 	f.Comment("/* JSON Key Names */")
 
@@ -86,6 +86,10 @@ export interface DateFilterModel {
   notGt?: string;
   lt?: string;
   notLt?: string;
+  between?: string[];
+  notBetween?: string[];
+  // IANA timezone used to evaluate relative dates (like "now/d")
+  timezone?: string;
   isNull?: boolean;
   isNotNull?: boolean;
   // For the moment, "In" and "NotIn" aren't supported